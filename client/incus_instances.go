@@ -2246,6 +2246,38 @@ func (r *ProtocolIncus) GetInstanceAccess(name string) (api.Access, error) {
 	return access, nil
 }
 
+// GetInstanceReplication returns the replication state of the instance.
+func (r *ProtocolIncus) GetInstanceReplication(name string) (*api.Replication, error) {
+	if !r.HasExtension("replication") {
+		return nil, errors.New("The server is missing the required \"replication\" API extension")
+	}
+
+	replication := api.Replication{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/replication", url.PathEscape(name)), nil, "", &replication)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replication, nil
+}
+
+// ReplicateInstance requests that Incus replicates the instance to its replication target or fails it over.
+func (r *ProtocolIncus) ReplicateInstance(name string, req api.ReplicationPost) (Operation, error) {
+	if !r.HasExtension("replication") {
+		return nil, errors.New("The server is missing the required \"replication\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/instances/%s/replication", url.PathEscape(name)), req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // GetInstanceLogfiles returns a list of logfiles for the instance.
 func (r *ProtocolIncus) GetInstanceLogfiles(name string) ([]string, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -2881,6 +2913,20 @@ func (r *ProtocolIncus) CreateInstanceBackup(instanceName string, backup api.Ins
 		return nil, errors.New("The server is missing the required \"container_backup\" API extension")
 	}
 
+	if backup.Target != nil {
+		if !r.HasExtension("backup_s3_upload") {
+			return nil, errors.New("The server is missing the required \"backup_s3_upload\" API extension")
+		}
+
+		if backup.Target.Protocol != "s3" && !r.HasExtension("backup_upload_targets") {
+			return nil, errors.New("The server is missing the required \"backup_upload_targets\" API extension")
+		}
+	}
+
+	if backup.Parent != "" && !r.HasExtension("backup_incremental") {
+		return nil, errors.New("The server is missing the required \"backup_incremental\" API extension")
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/backups", path, url.PathEscape(instanceName)), backup, "")
 	if err != nil {
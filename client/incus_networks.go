@@ -196,6 +196,21 @@ func (r *ProtocolIncus) RenameNetwork(name string, network api.NetworkPost) erro
 	return nil
 }
 
+// RotateNetworkKeys replaces the keys used by the network with newly generated ones.
+func (r *ProtocolIncus) RotateNetworkKeys(name string) error {
+	if !r.HasExtension("network_type_wireguard") {
+		return errors.New("The server is missing the required \"network_type_wireguard\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("POST", fmt.Sprintf("/networks/%s/rotate-keys", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // DeleteNetwork deletes an existing network.
 func (r *ProtocolIncus) DeleteNetwork(name string) error {
 	if !r.HasExtension("network") {
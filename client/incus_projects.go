@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -99,6 +100,37 @@ func (r *ProtocolIncus) GetProjectState(name string) (*api.ProjectState, error)
 	return &projectState, nil
 }
 
+// GetProjectUsage returns the historical resource usage of the project over the given range.
+// The granularity is either "hourly" or "daily", using the server default when empty.
+func (r *ProtocolIncus) GetProjectUsage(name string, from time.Time, to time.Time, granularity string) (*api.ProjectUsage, error) {
+	if !r.HasExtension("projects_usage") {
+		return nil, errors.New("The server is missing the required \"projects_usage\" API extension")
+	}
+
+	v := url.Values{}
+	if !from.IsZero() {
+		v.Set("from", from.UTC().Format(time.RFC3339))
+	}
+
+	if !to.IsZero() {
+		v.Set("to", to.UTC().Format(time.RFC3339))
+	}
+
+	if granularity != "" {
+		v.Set("granularity", granularity)
+	}
+
+	projectUsage := api.ProjectUsage{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/projects/%s/usage?%s", url.PathEscape(name), v.Encode()), nil, "", &projectUsage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &projectUsage, nil
+}
+
 // GetProjectAccess returns an Access entry for the specified project.
 func (r *ProtocolIncus) GetProjectAccess(name string) (api.Access, error) {
 	access := api.Access{}
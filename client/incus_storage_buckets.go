@@ -317,6 +317,13 @@ func (r *ProtocolIncus) CreateStoragePoolBucketBackup(poolName string, bucketNam
 		return nil, err
 	}
 
+	if backup.Target != nil {
+		err := r.CheckExtension("backup_upload_targets")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/buckets/%s/backups", url.PathEscape(poolName), url.PathEscape(bucketName)), backup, "")
 	if err != nil {
 		return nil, err
@@ -305,6 +305,35 @@ func (r *ProtocolIncus) GetStoragePoolVolumeSnapshot(pool string, volumeType str
 	return &snapshot, etag, nil
 }
 
+// GetStoragePoolVolumeSnapshotDiff returns the paths which differ between a storage volume snapshot and
+// another snapshot of the volume (or the volume itself if against is empty).
+func (r *ProtocolIncus) GetStoragePoolVolumeSnapshotDiff(pool string, volumeType string, volumeName string, snapshotName string, against string) ([]api.StorageVolumeSnapshotDiff, error) {
+	if !r.HasExtension("storage_volume_snapshot_diff") {
+		return nil, errors.New("The server is missing the required \"storage_volume_snapshot_diff\" API extension")
+	}
+
+	diff := []api.StorageVolumeSnapshotDiff{}
+
+	path := fmt.Sprintf("/storage-pools/%s/volumes/%s/%s/snapshots/%s/diff",
+		url.PathEscape(pool),
+		url.PathEscape(volumeType),
+		url.PathEscape(volumeName),
+		url.PathEscape(snapshotName))
+
+	if against != "" {
+		v := url.Values{}
+		v.Set("against", against)
+		path += "?" + v.Encode()
+	}
+
+	_, err := r.queryStruct("GET", path, nil, "", &diff)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
 // RenameStoragePoolVolumeSnapshot renames a storage volume snapshot.
 func (r *ProtocolIncus) RenameStoragePoolVolumeSnapshot(pool string, volumeType string, volumeName string, snapshotName string, snapshot api.StorageVolumeSnapshotPost) (Operation, error) {
 	if !r.HasExtension("storage_api_volume_snapshots") {
@@ -890,6 +919,20 @@ func (r *ProtocolIncus) CreateStorageVolumeBackup(pool string, volName string, b
 		return nil, errors.New("The server is missing the required \"custom_volume_backup\" API extension")
 	}
 
+	if backup.Target != nil {
+		if !r.HasExtension("backup_s3_upload") {
+			return nil, errors.New("The server is missing the required \"backup_s3_upload\" API extension")
+		}
+
+		if backup.Target.Protocol != "s3" && !r.HasExtension("backup_upload_targets") {
+			return nil, errors.New("The server is missing the required \"backup_upload_targets\" API extension")
+		}
+	}
+
+	if backup.Parent != "" && !r.HasExtension("backup_incremental") {
+		return nil, errors.New("The server is missing the required \"backup_incremental\" API extension")
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/volumes/custom/%s/backups", url.PathEscape(pool), url.PathEscape(volName)), backup, "")
 	if err != nil {
@@ -1008,6 +1051,40 @@ func (r *ProtocolIncus) CreateStoragePoolVolumeFromMigration(pool string, volume
 	return op, nil
 }
 
+// GetStoragePoolVolumeReplication returns the replication state of a custom volume.
+func (r *ProtocolIncus) GetStoragePoolVolumeReplication(pool string, volName string) (*api.Replication, error) {
+	if !r.HasExtension("replication") {
+		return nil, errors.New("The server is missing the required \"replication\" API extension")
+	}
+
+	replication := api.Replication{}
+
+	// Fetch the raw value
+	path := fmt.Sprintf("/storage-pools/%s/volumes/custom/%s/replication", url.PathEscape(pool), url.PathEscape(volName))
+	_, err := r.queryStruct("GET", path, nil, "", &replication)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replication, nil
+}
+
+// ReplicateStoragePoolVolume requests that Incus replicates a custom volume to its replication target or fails it over.
+func (r *ProtocolIncus) ReplicateStoragePoolVolume(pool string, volName string, req api.ReplicationPost) (Operation, error) {
+	if !r.HasExtension("replication") {
+		return nil, errors.New("The server is missing the required \"replication\" API extension")
+	}
+
+	// Send the request
+	path := fmt.Sprintf("/storage-pools/%s/volumes/custom/%s/replication", url.PathEscape(pool), url.PathEscape(volName))
+	op, _, err := r.queryOperation("POST", path, req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // CreateStoragePoolVolumeFromISO creates a custom volume from an ISO file.
 func (r *ProtocolIncus) CreateStoragePoolVolumeFromISO(pool string, args StorageVolumeBackupArgs) (Operation, error) {
 	err := r.CheckExtension("custom_volume_iso")
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
@@ -24,6 +25,8 @@ type cmdExport struct {
 	flagInstanceOnly         bool
 	flagOptimizedStorage     bool
 	flagCompressionAlgorithm string
+
+	upload backupUploadFlags
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -38,7 +41,10 @@ func (c *cmdExport) Command() *cobra.Command {
 	Download a backup tarball of the u1 instance.
 
 incus export u1 -
-	Download a backup tarball with it written to the standard output.`))
+	Download a backup tarball with it written to the standard output.
+
+incus export u1 --upload-protocol=sftp --upload-url=sftp://backup.example.net --upload-path=/backups/u1.tar.gz --upload-username=incus --upload-private-key=id_ed25519 --upload-host-key="ssh-ed25519 AAAA..."
+	Upload a backup tarball of the u1 instance to an SFTP server.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagInstanceOnly, "instance-only", false,
@@ -46,6 +52,7 @@ incus export u1 -
 	cmd.Flags().BoolVar(&c.flagOptimizedStorage, "optimized-storage", false,
 		i18n.G("Use storage driver optimized format (can only be restored on a similar pool)"))
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Compression algorithm to use (none for uncompressed)")+"``")
+	c.upload.register(cmd)
 
 	return cmd
 }
@@ -71,6 +78,15 @@ func (c *cmdExport) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	uploadTarget, err := c.upload.target()
+	if err != nil {
+		return err
+	}
+
+	if uploadTarget != nil && len(args) > 1 {
+		return errors.New(i18n.G("A target path can't be used when uploading the backup"))
+	}
+
 	var targetName string
 	if len(args) > 1 {
 		targetName = args[1]
@@ -79,7 +95,7 @@ func (c *cmdExport) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if the target path already exists.
-	if util.PathExists(targetName) {
+	if uploadTarget == nil && util.PathExists(targetName) {
 		return fmt.Errorf(i18n.G("Target path %q already exists"), targetName)
 	}
 
@@ -96,6 +112,7 @@ func (c *cmdExport) Run(cmd *cobra.Command, args []string) error {
 		InstanceOnly:         instanceOnly,
 		OptimizedStorage:     c.flagOptimizedStorage,
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		Target:               uploadTarget,
 	}
 
 	op, err := d.CreateInstanceBackup(name, req)
@@ -129,6 +146,15 @@ func (c *cmdExport) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// The server deletes the backup once it has been uploaded.
+	if uploadTarget != nil {
+		if !c.global.flagQuiet {
+			fmt.Println(i18n.G("Backup uploaded successfully!"))
+		}
+
+		return nil
+	}
+
 	// Get name of backup
 	uStr := op.Get().Resources["backups"][0]
 	u, err := url.Parse(uStr)
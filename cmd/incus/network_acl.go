@@ -17,6 +17,7 @@ import (
 	"github.com/lxc/incus/v6/shared/api"
 	cli "github.com/lxc/incus/v6/shared/cmd"
 	"github.com/lxc/incus/v6/shared/termios"
+	"github.com/lxc/incus/v6/shared/units"
 )
 
 type cmdNetworkACL struct {
@@ -42,6 +43,10 @@ func (c *cmdNetworkACL) Command() *cobra.Command {
 	networkACLShowLogCmd := cmdNetworkACLShowLog{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLShowLogCmd.Command())
 
+	// Info.
+	networkACLInfoCmd := cmdNetworkACLInfo{global: c.global, networkACL: c}
+	cmd.AddCommand(networkACLInfoCmd.Command())
+
 	// Get.
 	networkACLGetCmd := cmdNetworkACLGet{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLGetCmd.Command())
@@ -305,6 +310,85 @@ func (c *cmdNetworkACLShowLog) Run(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// Info.
+type cmdNetworkACLInfo struct {
+	global     *cmdGlobal
+	networkACL *cmdNetworkACL
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdNetworkACLInfo) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.Usage("info", i18n.G("[<remote>:]<ACL>"))
+	cmd.Short = i18n.G("Get runtime information on network ACLs")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Get runtime information on network ACLs
+
+The hit counters of each rule are aggregated across all cluster members.`))
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworkACLs(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdNetworkACLInfo) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing network ACL name"))
+	}
+
+	netACL, _, err := resource.server.GetNetworkACL(resource.name)
+	if err != nil {
+		return err
+	}
+
+	state, err := resource.server.GetNetworkACLState(resource.name)
+	if err != nil {
+		return err
+	}
+
+	printRules := func(title string, rules []api.NetworkACLRule, counters []api.NetworkACLRuleCounters) {
+		if len(rules) == 0 {
+			return
+		}
+
+		fmt.Println(title)
+		for i, rule := range rules {
+			var counter api.NetworkACLRuleCounters
+			if i < len(counters) {
+				counter = counters[i]
+			}
+
+			fmt.Printf("  %d (%s): %s: %d, %s: %s\n", i, rule.Action, i18n.G("Packets"), counter.Packets, i18n.G("Bytes"), units.GetByteSizeString(counter.Bytes, 2))
+		}
+	}
+
+	fmt.Printf(i18n.G("Name: %s")+"\n", netACL.Name)
+	printRules(i18n.G("Ingress rules:"), netACL.Ingress, state.Ingress)
+	printRules(i18n.G("Egress rules:"), netACL.Egress, state.Egress)
+
+	return nil
+}
+
 // Get.
 type cmdNetworkACLGet struct {
 	global     *cmdGlobal
@@ -90,7 +90,13 @@ func (c *cmdNetworkIntegrationCreate) Command() *cobra.Command {
 	cmd.Example = cli.FormatSection("", i18n.G(`incus network integration create o1 ovn
 
 incus network integration create o1 ovn < config.yaml
-    Create network integration o1 of type ovn with configuration from config.yaml`))
+    Create network integration o1 of type ovn with configuration from config.yaml
+
+incus network integration create r1 bgp -c bgp.peer.address=192.0.2.1 -c bgp.peer.asn=65000
+    Create network integration r1 of type bgp peering with the BGP router at 192.0.2.1
+
+incus network integration create g1 vlan -c vlan.id=100 -c vlan.ipv4.gateway=198.51.100.1/24
+    Create network integration g1 of type vlan using the gateway 198.51.100.1 on VLAN 100 of the uplink`))
 
 	cmd.Flags().StringArrayVarP(&c.flagConfig, "config", "c", nil, i18n.G("Config key/value to apply to the new network integration")+"``")
 
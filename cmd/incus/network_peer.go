@@ -340,7 +340,10 @@ incus network peer create default peer2 ovn-ic --type=remote
 
 incus network peer create default peer3 web/default < config.yaml
 	Create a new peering between network default in the current project and network default in the web project using the configuration
-	in the file config.yaml`))
+	in the file config.yaml
+
+incus network peer create default peer4 dc-gateway vlan.ipv4.address=198.51.100.10 --type=remote
+    Create a new peering between network "default" in the current project and the "dc-gateway" VLAN gateway integration`))
 
 	cmd.RunE = c.Run
 
@@ -11,11 +11,13 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
 	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/shared/api"
 	cli "github.com/lxc/incus/v6/shared/cmd"
 	"github.com/lxc/incus/v6/shared/termios"
@@ -80,6 +82,10 @@ func (c *cmdProject) Command() *cobra.Command {
 	projectGetInfo := cmdProjectInfo{global: c.global, project: c}
 	cmd.AddCommand(projectGetInfo.Command())
 
+	// Usage
+	projectUsageCmd := cmdProjectUsage{global: c.global, project: c}
+	cmd.AddCommand(projectUsageCmd.Command())
+
 	// Set default
 	projectSwitchCmd := cmdProjectSwitch{global: c.global, project: c}
 	cmd.AddCommand(projectSwitchCmd.Command())
@@ -1197,6 +1203,155 @@ func (c *cmdProjectInfo) Run(cmd *cobra.Command, args []string) error {
 	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, projectState)
 }
 
+// Usage.
+type cmdProjectUsage struct {
+	global  *cmdGlobal
+	project *cmdProject
+
+	flagFrom        string
+	flagTo          string
+	flagGranularity string
+	flagFormat      string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdProjectUsage) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.Usage("usage", i18n.G("[<remote>:]<project>"))
+	cmd.Short = i18n.G("Show the historical resource usage of a project")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show the historical resource usage of a project
+
+The usage of each instance is listed per period, followed by the total of the project.
+The range defaults to the last 24 hours.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus project usage default
+    Show the hourly usage of the default project over the last 24 hours.
+
+incus project usage default --from 2024-01-01T00:00:00Z --to 2024-02-01T00:00:00Z --granularity daily
+    Show the daily usage of the default project during January 2024.`))
+	cmd.Flags().StringVar(&c.flagFrom, "from", "", i18n.G("Start of the range (RFC3339 or duration before the end, e.g. 7d)")+"``")
+	cmd.Flags().StringVar(&c.flagTo, "to", "", i18n.G("End of the range (RFC3339)")+"``")
+	cmd.Flags().StringVar(&c.flagGranularity, "granularity", "hourly", i18n.G("Length of the periods (hourly or daily)")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpProjects(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdProjectUsage) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	if !slices.Contains([]string{"hourly", "daily"}, c.flagGranularity) {
+		return fmt.Errorf(i18n.G("Invalid granularity %q"), c.flagGranularity)
+	}
+
+	// Parse the range.
+	var to time.Time
+	if c.flagTo != "" {
+		to, err = time.Parse(time.RFC3339, c.flagTo)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Invalid end of range %q: %w"), c.flagTo, err)
+		}
+	}
+
+	var from time.Time
+	if c.flagFrom != "" {
+		from, err = time.Parse(time.RFC3339, c.flagFrom)
+		if err != nil {
+			// Allow for a length of time before the end of the range.
+			if to.IsZero() {
+				to = time.Now()
+			}
+
+			end, errExpiry := instance.GetExpiry(to, c.flagFrom)
+			if errExpiry != nil || !end.After(to) {
+				return fmt.Errorf(i18n.G("Invalid start of range %q: %w"), c.flagFrom, err)
+			}
+
+			from = to.Add(-end.Sub(to))
+		}
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing project name"))
+	}
+
+	usage, err := resource.server.GetProjectUsage(resource.name, from, to, c.flagGranularity)
+	if err != nil {
+		return err
+	}
+
+	// Render the output
+	row := func(period string, instanceName string, counters api.ProjectUsageCounters) []string {
+		return []string{
+			period,
+			instanceName,
+			time.Duration(counters.CPUSeconds * float64(time.Second)).Round(time.Second).String(),
+			fmt.Sprintf(i18n.G("%s-hours"), units.GetByteSizeStringIEC(counters.MemoryByteSeconds/3600, 2)),
+			units.GetByteSizeStringIEC(counters.DiskReadBytes, 2),
+			units.GetByteSizeStringIEC(counters.DiskWrittenBytes, 2),
+			units.GetByteSizeStringIEC(counters.NetworkReceivedBytes, 2),
+			units.GetByteSizeStringIEC(counters.NetworkSentBytes, 2),
+		}
+	}
+
+	periodFormat := "2006-01-02 15:04"
+	if usage.Granularity == "daily" {
+		periodFormat = "2006-01-02"
+	}
+
+	data := [][]string{}
+	for _, inst := range usage.Instances {
+		for _, period := range inst.Periods {
+			data = append(data, row(period.Start.Local().Format(periodFormat), inst.Name, period.ProjectUsageCounters))
+		}
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	data = append(data, row(i18n.G("TOTAL"), "", usage.Total))
+
+	header := []string{
+		i18n.G("PERIOD"),
+		i18n.G("INSTANCE"),
+		i18n.G("CPU TIME"),
+		i18n.G("MEMORY"),
+		i18n.G("DISK READ"),
+		i18n.G("DISK WRITTEN"),
+		i18n.G("NETWORK RECEIVED"),
+		i18n.G("NETWORK SENT"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, usage)
+}
+
 // Get current project.
 type cmdProjectGetCurrent struct {
 	global  *cmdGlobal
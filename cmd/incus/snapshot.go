@@ -41,6 +41,10 @@ func (c *cmdSnapshot) Command() *cobra.Command {
 	snapshotDeleteCmd := cmdSnapshotDelete{global: c.global, snapshot: c}
 	cmd.AddCommand(snapshotDeleteCmd.Command())
 
+	// Diff.
+	snapshotDiffCmd := cmdSnapshotDiff{global: c.global, snapshot: c}
+	cmd.AddCommand(snapshotDiffCmd.Command())
+
 	// List.
 	snapshotListCmd := cmdSnapshotList{global: c.global, snapshot: c}
 	cmd.AddCommand(snapshotListCmd.Command())
@@ -302,6 +306,113 @@ func (c *cmdSnapshotDelete) doDelete(d incus.InstanceServer, instName string, na
 	return op.Wait()
 }
 
+// Diff.
+type cmdSnapshotDiff struct {
+	global   *cmdGlobal
+	snapshot *cmdSnapshot
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdSnapshotDiff) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.Usage("diff", i18n.G("[<remote>:]<instance> <snapshot> [<other snapshot>]"))
+	cmd.Short = i18n.G("List the files changed since an instance snapshot")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List the files changed since an instance snapshot
+
+The files of the snapshot are compared with the current files of the instance
+or, if provided, with another snapshot of the instance.
+Paths are relative to the root of the instance volume.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus snapshot diff u1 snap0
+	List the files of "u1" which changed since "snap0" was taken.
+
+incus snapshot diff u1 snap0 snap1
+	List the files which changed between snapshots "snap0" and "snap1" of "u1".`))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		if len(args) < 3 {
+			return c.global.cmpInstanceSnapshots(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdSnapshotDiff) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, 3)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing instance name"))
+	}
+
+	// The snapshots are compared through the root disk volume of the instance.
+	inst, _, err := resource.server.GetInstance(resource.name)
+	if err != nil {
+		return err
+	}
+
+	_, rootDisk, err := instance.GetRootDiskDevice(inst.ExpandedDevices)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to find the root disk of the instance: %w"), err)
+	}
+
+	var against string
+	if len(args) > 2 {
+		against = args[2]
+	}
+
+	diff, err := resource.server.GetStoragePoolVolumeSnapshotDiff(rootDisk["pool"], inst.Type, resource.name, args[1], against)
+	if err != nil {
+		return err
+	}
+
+	return renderSnapshotDiff(c.flagFormat, diff)
+}
+
+// renderSnapshotDiff prints the paths which differ between snapshots.
+func renderSnapshotDiff(format string, diff []api.StorageVolumeSnapshotDiff) error {
+	data := [][]string{}
+	for _, entry := range diff {
+		data = append(data, []string{strings.ToUpper(entry.Type), entry.Path, entry.NewPath})
+	}
+
+	header := []string{
+		i18n.G("TYPE"),
+		i18n.G("PATH"),
+		i18n.G("NEW PATH"),
+	}
+
+	return cli.RenderTable(os.Stdout, format, header, data, diff)
+}
+
 // List.
 type cmdSnapshotList struct {
 	global   *cmdGlobal
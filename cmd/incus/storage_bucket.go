@@ -1479,6 +1479,8 @@ type cmdStorageBucketExport struct {
 	storageBucket *cmdStorageBucket
 
 	flagCompressionAlgorithm string
+
+	upload backupUploadFlags
 }
 
 // Command generates the command definition.
@@ -1494,6 +1496,7 @@ func (c *cmdStorageBucketExport) Command() *cobra.Command {
 
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Define a compression algorithm: for backup or none")+"``")
 	cmd.Flags().StringVar(&c.storageBucket.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	c.upload.register(cmd)
 
 	cmd.RunE = c.Run
 
@@ -1531,10 +1534,20 @@ func (c *cmdStorageBucketExport) Run(cmd *cobra.Command, args []string) error {
 		s = s.UseTarget(c.storageBucket.flagTarget)
 	}
 
+	uploadTarget, err := c.upload.target()
+	if err != nil {
+		return err
+	}
+
+	if uploadTarget != nil && len(args) > 2 {
+		return errors.New(i18n.G("A target path can't be used when uploading the backup"))
+	}
+
 	req := api.StorageBucketBackupsPost{
 		Name:                 "",
 		ExpiresAt:            time.Now().Add(23 * time.Hour),
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		Target:               uploadTarget,
 	}
 
 	op, err := s.CreateStoragePoolBucketBackup(pool.name, bucketName, req)
@@ -1568,6 +1581,15 @@ func (c *cmdStorageBucketExport) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// The server deletes the backup once it has been uploaded.
+	if uploadTarget != nil {
+		if !c.global.flagQuiet {
+			fmt.Println(i18n.G("Backup uploaded successfully!"))
+		}
+
+		return nil
+	}
+
 	// Get name of backup
 	utStr := op.Get().Resources["backups"][0]
 	u, err := url.Parse(utStr)
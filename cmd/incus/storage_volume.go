@@ -3369,6 +3369,10 @@ func (c *cmdStorageVolumeSnapshot) Command() *cobra.Command {
 	storageVolumeSnapshotDeleteCmd := cmdStorageVolumeSnapshotDelete{global: c.global, storage: c.storage, storageVolume: c.storageVolume, storageVolumeSnapshot: c}
 	cmd.AddCommand(storageVolumeSnapshotDeleteCmd.Command())
 
+	// Diff
+	storageVolumeSnapshotDiffCmd := cmdStorageVolumeSnapshotDiff{global: c.global, storage: c.storage, storageVolume: c.storageVolume, storageVolumeSnapshot: c}
+	cmd.AddCommand(storageVolumeSnapshotDiffCmd.Command())
+
 	// List
 	storageVolumeSnapshotListCmd := cmdStorageVolumeSnapshotList{global: c.global, storage: c.storage, storageVolume: c.storageVolume, storageVolumeSnapshot: c}
 	cmd.AddCommand(storageVolumeSnapshotListCmd.Command())
@@ -3641,6 +3645,101 @@ func (c *cmdStorageVolumeSnapshotDelete) Run(cmd *cobra.Command, args []string)
 	return nil
 }
 
+// Snapshot diff.
+type cmdStorageVolumeSnapshotDiff struct {
+	global                *cmdGlobal
+	storage               *cmdStorage
+	storageVolume         *cmdStorageVolume
+	storageVolumeSnapshot *cmdStorageVolumeSnapshot
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdStorageVolumeSnapshotDiff) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = cli.Usage("diff", i18n.G("[<remote>:]<pool> <volume> <snapshot> [<other snapshot>]"))
+	cmd.Short = i18n.G("List the files changed since a storage volume snapshot")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List the files changed since a storage volume snapshot
+
+The files of the snapshot are compared with the current files of the volume
+or, if provided, with another snapshot of the volume.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus storage volume snapshot diff default data snap0
+	List the files of the "data" volume which changed since "snap0" was taken.
+
+incus storage volume snapshot diff default data snap0 snap1
+	List the files which changed between snapshots "snap0" and "snap1" of the "data" volume.`))
+	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpStoragePoolVolumes(args[0])
+		}
+
+		if len(args) < 4 {
+			return c.global.cmpStoragePoolVolumeSnapshots(args[0], args[1])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdStorageVolumeSnapshotDiff) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 3, 4)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing pool name"))
+	}
+
+	client := resource.server
+
+	// Use the provided target.
+	if c.storage.flagTarget != "" {
+		client = client.UseTarget(c.storage.flagTarget)
+	}
+
+	// Parse the input
+	volName, volType := parseVolume("custom", args[1])
+
+	var against string
+	if len(args) > 3 {
+		against = args[3]
+	}
+
+	diff, err := client.GetStoragePoolVolumeSnapshotDiff(resource.name, volType, volName, args[2], against)
+	if err != nil {
+		return err
+	}
+
+	return renderSnapshotDiff(c.flagFormat, diff)
+}
+
 // Snapshot list.
 type cmdStorageVolumeSnapshotList struct {
 	global                *cmdGlobal
@@ -4083,6 +4182,8 @@ type cmdStorageVolumeExport struct {
 	flagVolumeOnly           bool
 	flagOptimizedStorage     bool
 	flagCompressionAlgorithm string
+
+	upload backupUploadFlags
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -4098,6 +4199,7 @@ func (c *cmdStorageVolumeExport) Command() *cobra.Command {
 		i18n.G("Use storage driver optimized format (can only be restored on a similar pool, ignored for ISO storage volumes)"))
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Compression algorithm to use (none for uncompressed, ignored for ISO storage volumes)")+"``")
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	c.upload.register(cmd)
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -4141,6 +4243,15 @@ func (c *cmdStorageVolumeExport) Run(cmd *cobra.Command, args []string) error {
 		d = d.UseTarget(c.storage.flagTarget)
 	}
 
+	uploadTarget, err := c.upload.target()
+	if err != nil {
+		return err
+	}
+
+	if uploadTarget != nil && len(args) > 2 {
+		return errors.New(i18n.G("A target path can't be used when uploading the backup"))
+	}
+
 	volumeOnly := c.flagVolumeOnly
 
 	volName, volType := parseVolume("custom", args[1])
@@ -4160,6 +4271,7 @@ func (c *cmdStorageVolumeExport) Run(cmd *cobra.Command, args []string) error {
 		VolumeOnly:           volumeOnly,
 		OptimizedStorage:     c.flagOptimizedStorage,
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		Target:               uploadTarget,
 	}
 
 	op, err := d.CreateStorageVolumeBackup(name, volName, req)
@@ -4193,6 +4305,15 @@ func (c *cmdStorageVolumeExport) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// The server deletes the backup once it has been uploaded.
+	if uploadTarget != nil {
+		if !c.global.flagQuiet {
+			fmt.Println(i18n.G("Backup uploaded successfully!"))
+		}
+
+		return nil
+	}
+
 	// Get name of backup
 	uStr := op.Get().Resources["backups"][0]
 	u, err := url.Parse(uStr)
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// backupUploadFlags holds the flags used to upload a backup to an external target.
+type backupUploadFlags struct {
+	flagProtocol    string
+	flagURL         string
+	flagPath        string
+	flagBucket      string
+	flagAccessKey   string
+	flagSecretKey   string
+	flagUsername    string
+	flagPassword    string
+	flagPrivateKey  string
+	flagHostKey     string
+	flagCertificate string
+}
+
+// register adds the upload flags to the command.
+func (f *backupUploadFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.flagProtocol, "upload-protocol", "", i18n.G("Upload the backup to an external target instead of downloading it (s3, sftp, http or local)")+"``")
+	cmd.Flags().StringVar(&f.flagURL, "upload-url", "", i18n.G("URL of the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagPath, "upload-path", "", i18n.G("Path of the backup on the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagBucket, "upload-bucket", "", i18n.G("S3 bucket to upload the backup to")+"``")
+	cmd.Flags().StringVar(&f.flagAccessKey, "upload-access-key", "", i18n.G("S3 access key for the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagSecretKey, "upload-secret-key", "", i18n.G("S3 secret key for the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagUsername, "upload-username", "", i18n.G("Username for the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagPassword, "upload-password", "", i18n.G("Password for the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagPrivateKey, "upload-private-key", "", i18n.G("Path to the SSH private key for the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagHostKey, "upload-host-key", "", i18n.G("Expected SSH host key of the upload target")+"``")
+	cmd.Flags().StringVar(&f.flagCertificate, "upload-certificate", "", i18n.G("Path to the certificate to trust for the upload target")+"``")
+}
+
+// target returns the backup target described by the flags or nil if no upload was requested.
+func (f *backupUploadFlags) target() (*api.BackupTarget, error) {
+	if f.flagProtocol == "" {
+		return nil, nil
+	}
+
+	target := &api.BackupTarget{
+		Protocol:   f.flagProtocol,
+		URL:        f.flagURL,
+		Path:       f.flagPath,
+		BucketName: f.flagBucket,
+		AccessKey:  f.flagAccessKey,
+		SecretKey:  f.flagSecretKey,
+		Username:   f.flagUsername,
+		Password:   f.flagPassword,
+		HostKey:    f.flagHostKey,
+	}
+
+	if f.flagPrivateKey != "" {
+		content, err := os.ReadFile(f.flagPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		target.PrivateKey = string(content)
+	}
+
+	if f.flagCertificate != "" {
+		content, err := os.ReadFile(f.flagCertificate)
+		if err != nil {
+			return nil, err
+		}
+
+		target.Certificate = string(content)
+	}
+
+	return target, nil
+}
@@ -66,6 +66,7 @@ var api10 = []APIEndpoint{
 	instanceMetadataTemplatesCmd,
 	instancesCmd,
 	instanceRebuildCmd,
+	instanceReplicationCmd,
 	instanceSFTPCmd,
 	instanceSnapshotCmd,
 	instanceSnapshotsCmd,
@@ -85,9 +86,11 @@ var api10 = []APIEndpoint{
 	networkLeasesCmd,
 	networksCmd,
 	networkStateCmd,
+	networkRotateKeysCmd,
 	networkACLCmd,
 	networkACLsCmd,
 	networkACLLogCmd,
+	networkACLStateCmd,
 	networkAddressSetCmd,
 	networkAddressSetsCmd,
 	networkAllocationsCmd,
@@ -113,9 +116,12 @@ var api10 = []APIEndpoint{
 	projectCmd,
 	projectsCmd,
 	projectStateCmd,
+	projectUsageCmd,
 	projectAccessCmd,
 	storagePoolCmd,
 	storagePoolResourcesCmd,
+	storagePoolScrubCmd,
+	storagePoolStateCmd,
 	storagePoolsCmd,
 	storagePoolBucketsCmd,
 	storagePoolBucketCmd,
@@ -127,6 +133,7 @@ var api10 = []APIEndpoint{
 	storagePoolVolumesCmd,
 	storagePoolVolumeSnapshotsTypeCmd,
 	storagePoolVolumeSnapshotTypeCmd,
+	storagePoolVolumeSnapshotTypeDiffCmd,
 	storagePoolVolumesTypeCmd,
 	storagePoolVolumeTypeCmd,
 	storagePoolVolumeTypeSFTPCmd,
@@ -134,6 +141,7 @@ var api10 = []APIEndpoint{
 	storagePoolVolumeTypeCustomBackupsCmd,
 	storagePoolVolumeTypeCustomBackupCmd,
 	storagePoolVolumeTypeCustomBackupExportCmd,
+	storagePoolVolumeTypeCustomReplicationCmd,
 	storagePoolVolumeTypeStateCmd,
 	warningsCmd,
 	warningCmd,
@@ -1044,6 +1052,15 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	// Compile and load the instance validation scriptlet.
+	value, ok = clusterChanged["instances.validation.scriptlet"]
+	if ok {
+		err := scriptletLoad.InstanceValidationSet(value)
+		if err != nil {
+			return fmt.Errorf("Failed saving instance validation scriptlet: %w", err)
+		}
+	}
+
 	// Setup the authorization scriptlet.
 	value, ok = clusterChanged["authorization.scriptlet"]
 	if ok {
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +21,7 @@ import (
 	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/locking"
 	"github.com/lxc/incus/v6/internal/server/metrics"
+	"github.com/lxc/incus/v6/internal/server/network/acl"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -104,7 +106,13 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 	var projectNames []string
 	var intMetrics *metrics.MetricSet
 
-	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+	// Get the network ACL rule counters ahead of the transaction as they may require database access.
+	aclCounters, err := acl.LocalRuleCounters(s)
+	if err != nil {
+		logger.Warn("Failed to get network ACL rule counters", logger.Ctx{"err": err})
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// Figure out the projects to retrieve.
 		if projectName != "" {
 			projectNames = []string{projectName}
@@ -124,6 +132,11 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		// Add internal metrics.
 		intMetrics = internalMetrics(ctx, s, tx)
 
+		// Add network ACL metrics.
+		if len(aclCounters) > 0 {
+			intMetrics.Merge(networkACLMetrics(ctx, tx, aclCounters))
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -400,3 +413,34 @@ func internalMetrics(ctx context.Context, s *state.State, tx *db.ClusterTx) *met
 
 	return out
 }
+
+// networkACLMetrics returns the metrics for the given network ACL rule counters.
+func networkACLMetrics(ctx context.Context, tx *db.ClusterTx, counters map[acl.RuleCounterKey]api.NetworkACLRuleCounters) *metrics.MetricSet {
+	out := metrics.NewMetricSet(nil)
+
+	acls, err := dbCluster.GetNetworkACLs(ctx, tx.Tx())
+	if err != nil {
+		logger.Warn("Failed to get network ACLs", logger.Ctx{"err": err})
+		return out
+	}
+
+	aclsByID := make(map[int64]dbCluster.NetworkACL, len(acls))
+	for _, networkACL := range acls {
+		aclsByID[int64(networkACL.ID)] = networkACL
+	}
+
+	for key, counter := range counters {
+		networkACL, ok := aclsByID[key.ACLID]
+		if !ok {
+			continue
+		}
+
+		// The "name" label isn't used as it would get the sample treated as an instance metric.
+		labels := map[string]string{"project": networkACL.Project, "acl": networkACL.Name, "direction": key.Direction, "rule": strconv.Itoa(key.Rule)}
+
+		out.AddSamples(metrics.NetworkACLRuleBytesTotal, metrics.Sample{Labels: labels, Value: float64(counter.Bytes)})
+		out.AddSamples(metrics.NetworkACLRulePacketsTotal, metrics.Sample{Labels: labels, Value: float64(counter.Packets)})
+	}
+
+	return out
+}
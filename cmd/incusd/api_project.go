@@ -12,11 +12,13 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/filter"
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -60,6 +62,12 @@ var projectStateCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: projectStateGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
 }
 
+var projectUsageCmd = APIEndpoint{
+	Path: "projects/{name}/usage",
+
+	Get: APIEndpointAction{Handler: projectUsageGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
+}
+
 var projectAccessCmd = APIEndpoint{
 	Path: "projects/{name}/access",
 
@@ -1336,6 +1344,116 @@ func projectStateGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, &state)
 }
 
+// swagger:operation GET /1.0/projects/{name}/usage projects project_usage_get
+//
+//	Get the project usage
+//
+//	Gets the historical resource consumption of the project's instances, aggregated per period.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: from
+//	    description: Start of the range (RFC3339, defaults to 24 hours before the end)
+//	    type: string
+//	    example: 2024-01-01T00:00:00Z
+//	  - in: query
+//	    name: to
+//	    description: End of the range (RFC3339, defaults to now)
+//	    type: string
+//	    example: 2024-01-02T00:00:00Z
+//	  - in: query
+//	    name: granularity
+//	    description: Length of the periods (hourly or daily)
+//	    type: string
+//	    example: daily
+//	responses:
+//	  "200":
+//	    description: Project usage
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectUsage"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectUsageGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	to := time.Now().UTC()
+	if r.FormValue("to") != "" {
+		to, err = time.Parse(time.RFC3339, r.FormValue("to"))
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid end of range %q: %w", r.FormValue("to"), err))
+		}
+	}
+
+	from := to.Add(-24 * time.Hour)
+	if r.FormValue("from") != "" {
+		from, err = time.Parse(time.RFC3339, r.FormValue("from"))
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid start of range %q: %w", r.FormValue("from"), err))
+		}
+	}
+
+	if !from.Before(to) {
+		return response.BadRequest(errors.New("The start of the range must be before its end"))
+	}
+
+	granularity := r.FormValue("granularity")
+	if granularity == "" {
+		granularity = "hourly"
+	}
+
+	if !slices.Contains([]string{"hourly", "daily"}, granularity) {
+		return response.BadRequest(fmt.Errorf("Invalid granularity %q", granularity))
+	}
+
+	var usages []cluster.InstanceUsage
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check that the project exists.
+		_, err := cluster.GetProject(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		usages, err = cluster.GetInstancesUsage(ctx, tx.Tx(), name, from, to)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, projectUsageRollup(usages, from, to, granularity))
+}
+
 // Check if a project is empty.
 func projectIsEmpty(ctx context.Context, project *cluster.Project, tx *db.ClusterTx) (bool, error) {
 	usedBy, err := projectUsedBy(ctx, tx, project)
@@ -1548,6 +1666,15 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Whether to prevent creating instance or volume backups
 		"restricted.backups": isEitherAllowOrBlock,
 
+		// gendoc:generate(entity=project, group=restricted, key=restricted.backups.targets)
+		// Specify a comma-delimited list of backup targets (`backups.target.NAME.*` server configuration) that scheduled backups of instances and custom volumes of this project can be uploaded to.
+		// If {config:option}`project-restricted:restricted` is set to `true`, only the listed targets can be used.
+		// ---
+		//  type: string
+		//  defaultdesc: no targets when `restricted` is `true`
+		//  shortdesc: Which backup targets can be used in this project
+		"restricted.backups.targets": validate.Optional(validate.IsListOf(internalInstance.IsBackupTargetName)),
+
 		// gendoc:generate(entity=project, group=restricted, key=restricted.cluster.groups)
 		// If specified, this option prevents targeting cluster groups other than the provided ones.
 		// ---
@@ -1800,6 +1927,15 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Which network zones can be used in this project
 		"restricted.networks.zones": validate.IsListOf(validate.IsAny),
 
+		// gendoc:generate(entity=project, group=restricted, key=restricted.replication.targets)
+		// Specify a comma-delimited list of replication targets (`replication.NAME.*` server configuration) that instances and custom volumes of this project can be replicated to.
+		// If {config:option}`project-restricted:restricted` is set to `true`, only the listed targets can be used.
+		// ---
+		//  type: string
+		//  defaultdesc: no targets when `restricted` is `true`
+		//  shortdesc: Which replication targets can be used in this project
+		"restricted.replication.targets": validate.Optional(validate.IsListOf(internalInstance.IsReplicationTargetName)),
+
 		// gendoc:generate(entity=project, group=restricted, key=restricted.snapshots)
 		//
 		// ---
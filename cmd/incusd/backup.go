@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -35,8 +36,8 @@ import (
 	"github.com/lxc/incus/v6/shared/util"
 )
 
-// Create a new backup.
-func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.Instance, op *operations.Operation) error {
+// Create a new backup. When parent is set, the backup is made incremental against that earlier backup.
+func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.Instance, parent string, op *operations.Operation) error {
 	l := logger.AddContext(logger.Ctx{"project": sourceInst.Project().Name, "instance": sourceInst.Name(), "name": args.Name})
 	l.Debug("Instance backup started")
 	defer l.Debug("Instance backup finished")
@@ -55,6 +56,21 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		args.OptimizedStorage = false
 	}
 
+	// Resolve what an incremental backup builds on.
+	var parents []string
+	var parentSnapshot string
+	if parent != "" {
+		parents, parentSnapshot, err = backupLoadParent(s, &backup.Info{Project: sourceInst.Project().Name, Type: backup.InstanceTypeToBackupType(api.InstanceType(sourceInst.Type().String()))}, parent)
+		if err != nil {
+			return err
+		}
+
+		// Fall back to non-optimized backups when the driver can't produce optimized incremental ones.
+		if args.OptimizedStorage && !pool.Driver().Info().OptimizedIncrementalBackups {
+			args.OptimizedStorage = false
+		}
+	}
+
 	// Create the database entry.
 	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.CreateInstanceBackup(ctx, args)
@@ -178,7 +194,7 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 
 	// Write index file.
 	l.Debug("Adding backup index file")
-	err = backupWriteIndex(sourceInst, pool, b.OptimizedStorage(), !b.InstanceOnly(), tarWriter)
+	err = backupWriteIndex(sourceInst, pool, b.OptimizedStorage(), !b.InstanceOnly(), parents, parentSnapshot, tarWriter)
 
 	// Check compression errors.
 	if compressErr != nil {
@@ -190,7 +206,7 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Error writing backup index file: %w", err)
 	}
 
-	err = pool.BackupInstance(sourceInst, tarWriter, b.OptimizedStorage(), !b.InstanceOnly(), nil)
+	err = pool.BackupInstance(sourceInst, tarWriter, b.OptimizedStorage(), !b.InstanceOnly(), parentSnapshot, nil)
 	if err != nil {
 		return fmt.Errorf("Backup create: %w", err)
 	}
@@ -223,8 +239,56 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 	return nil
 }
 
+// backupLoadParent reads the index of the stored parent backup of an incremental backup and returns the
+// chain of backups the new backup depends on along with the snapshot it should be relative to.
+func backupLoadParent(s *state.State, info *backup.Info, parent string) ([]string, string, error) {
+	path := backup.ChainPath(info, parent)
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", fmt.Errorf("Parent backup %q not found", parent)
+		}
+
+		return nil, "", fmt.Errorf("Failed opening parent backup %q: %w", parent, err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	parentInfo, err := backup.GetInfo(f, s.OS, path)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed reading parent backup %q: %w", parent, err)
+	}
+
+	// Use the most recent snapshot captured by the parent backup chain as the base.
+	parentSnapshot := parentInfo.ParentSnapshot
+	if len(parentInfo.Snapshots) > 0 {
+		parentSnapshot = parentInfo.Snapshots[len(parentInfo.Snapshots)-1]
+	}
+
+	if parentSnapshot == "" {
+		return nil, "", fmt.Errorf("Parent backup %q doesn't include any snapshot to base an incremental backup on", parent)
+	}
+
+	return append(parentInfo.Parents, parent), parentSnapshot, nil
+}
+
+// backupIndexSnapshots returns the snapshots stored in a backup. For incremental backups, only the snapshots
+// newer than the parent snapshot are stored.
+func backupIndexSnapshots(snapNames []string, parentSnapshot string) []string {
+	if parentSnapshot == "" {
+		return snapNames
+	}
+
+	idx := slices.Index(snapNames, parentSnapshot)
+	if idx < 0 {
+		return snapNames
+	}
+
+	return snapNames[idx+1:]
+}
+
 // backupWriteIndex generates an index.yaml file and then writes it to the root of the backup tarball.
-func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, optimized bool, snapshots bool, tarWriter *instancewriter.InstanceTarWriter) error {
+func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, optimized bool, snapshots bool, parents []string, parentSnapshot string, tarWriter *instancewriter.InstanceTarWriter) error {
 	// Indicate whether the driver will include a driver-specific optimized header.
 	poolDriverOptimizedHeader := false
 	if optimized {
@@ -259,6 +323,8 @@ func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, opti
 		OptimizedStorage: &optimized,
 		OptimizedHeader:  &poolDriverOptimizedHeader,
 		Config:           config,
+		Parents:          parents,
+		ParentSnapshot:   parentSnapshot,
 	}
 
 	if snapshots {
@@ -266,6 +332,8 @@ func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, opti
 		for _, s := range config.Snapshots {
 			indexInfo.Snapshots = append(indexInfo.Snapshots, s.Name)
 		}
+
+		indexInfo.Snapshots = backupIndexSnapshots(indexInfo.Snapshots, parentSnapshot)
 	}
 
 	// Convert to YAML.
@@ -367,6 +435,9 @@ func pruneExpiredInstanceBackups(ctx context.Context, s *state.State) error {
 		return fmt.Errorf("Unable to retrieve the list of expired instance backups: %w", err)
 	}
 
+	// Incremental backup dependencies, scanned once per instance.
+	dependencies := map[int]map[string][]string{}
+
 	for _, b := range backups {
 		inst, err := instance.LoadByID(s, b.InstanceID)
 		if err != nil {
@@ -374,6 +445,21 @@ func pruneExpiredInstanceBackups(ctx context.Context, s *state.State) error {
 		}
 
 		instBackup := backup.NewInstanceBackup(s, inst, b.ID, b.Name, b.CreationDate, b.ExpiryDate, b.InstanceOnly, b.OptimizedStorage)
+
+		// Keep backups which incremental backups still depend on, they get pruned once those are gone.
+		if dependencies[b.InstanceID] == nil {
+			dependencies[b.InstanceID], err = instBackup.ChainDependencies()
+			if err != nil {
+				return fmt.Errorf("Error checking dependents of instance backup %q: %w", b.Name, err)
+			}
+		}
+
+		dependents := dependencies[b.InstanceID][b.Name]
+		if len(dependents) > 0 {
+			logger.Debug("Skipping expired instance backup with incremental dependents", logger.Ctx{"backup": b.Name, "dependents": dependents})
+			continue
+		}
+
 		err = instBackup.Delete()
 		if err != nil {
 			return fmt.Errorf("Error deleting instance backup %q: %w", b.Name, err)
@@ -383,7 +469,7 @@ func pruneExpiredInstanceBackups(ctx context.Context, s *state.State) error {
 	return nil
 }
 
-func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, projectName string, poolName string, volumeName string) error {
+func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, projectName string, poolName string, volumeName string, parent string) error {
 	l := logger.AddContext(logger.Ctx{"project": projectName, "storage_volume": volumeName, "name": args.Name})
 	l.Debug("Volume backup started")
 	defer l.Debug("Volume backup finished")
@@ -418,6 +504,25 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 		args.OptimizedStorage = false
 	}
 
+	// Resolve what an incremental backup builds on.
+	var parents []string
+	var parentSnapshot string
+	if parent != "" {
+		if contentType == drivers.ContentTypeISO {
+			return errors.New("Incremental backups aren't supported for ISO volumes")
+		}
+
+		parents, parentSnapshot, err = backupLoadParent(s, &backup.Info{Project: projectName, Pool: pool.Name(), Type: backup.TypeCustom}, parent)
+		if err != nil {
+			return err
+		}
+
+		// Fall back to non-optimized backups when the driver can't produce optimized incremental ones.
+		if args.OptimizedStorage && !pool.Driver().Info().OptimizedIncrementalBackups {
+			args.OptimizedStorage = false
+		}
+	}
+
 	// Create the database entry.
 	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.CreateStoragePoolVolumeBackup(ctx, args)
@@ -482,7 +587,7 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 
 	// If dealing with an ISO volume, we want to return it unaltered.
 	if contentType == drivers.ContentTypeISO {
-		err = pool.BackupCustomVolume(projectName, volumeName, instancewriter.NewInstanceRawWriter(fileWriter), backupRow.OptimizedStorage, !backupRow.VolumeOnly, nil)
+		err = pool.BackupCustomVolume(projectName, volumeName, instancewriter.NewInstanceRawWriter(fileWriter), backupRow.OptimizedStorage, !backupRow.VolumeOnly, "", nil)
 		if err != nil {
 			return fmt.Errorf("Backup create: %w", err)
 		}
@@ -516,7 +621,7 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 
 		// Write index file.
 		l.Debug("Adding backup index file")
-		err = volumeBackupWriteIndex(projectName, volumeName, pool, backupRow.OptimizedStorage, !backupRow.VolumeOnly, tarWriter)
+		err = volumeBackupWriteIndex(projectName, volumeName, pool, backupRow.OptimizedStorage, !backupRow.VolumeOnly, parents, parentSnapshot, tarWriter)
 
 		// Check compression errors.
 		if compressErr != nil {
@@ -528,7 +633,7 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 			return fmt.Errorf("Error writing backup index file: %w", err)
 		}
 
-		err = pool.BackupCustomVolume(projectName, volumeName, tarWriter, backupRow.OptimizedStorage, !backupRow.VolumeOnly, nil)
+		err = pool.BackupCustomVolume(projectName, volumeName, tarWriter, backupRow.OptimizedStorage, !backupRow.VolumeOnly, parentSnapshot, nil)
 		if err != nil {
 			return fmt.Errorf("Backup create: %w", err)
 		}
@@ -561,7 +666,7 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 }
 
 // volumeBackupWriteIndex generates an index.yaml file and then writes it to the root of the backup tarball.
-func volumeBackupWriteIndex(projectName string, volumeName string, pool storagePools.Pool, optimized bool, snapshots bool, tarWriter *instancewriter.InstanceTarWriter) error {
+func volumeBackupWriteIndex(projectName string, volumeName string, pool storagePools.Pool, optimized bool, snapshots bool, parents []string, parentSnapshot string, tarWriter *instancewriter.InstanceTarWriter) error {
 	// Indicate whether the driver will include a driver-specific optimized header.
 	poolDriverOptimizedHeader := false
 	if optimized {
@@ -581,6 +686,8 @@ func volumeBackupWriteIndex(projectName string, volumeName string, pool storageP
 		OptimizedHeader:  &poolDriverOptimizedHeader,
 		Type:             backup.TypeCustom,
 		Config:           config,
+		Parents:          parents,
+		ParentSnapshot:   parentSnapshot,
 	}
 
 	if snapshots {
@@ -588,6 +695,8 @@ func volumeBackupWriteIndex(projectName string, volumeName string, pool storageP
 		for _, s := range config.VolumeSnapshots {
 			indexInfo.Snapshots = append(indexInfo.Snapshots, s.Name)
 		}
+
+		indexInfo.Snapshots = backupIndexSnapshots(indexInfo.Snapshots, parentSnapshot)
 	}
 
 	// Convert to YAML.
@@ -616,6 +725,7 @@ func volumeBackupWriteIndex(projectName string, volumeName string, pool storageP
 
 func pruneExpiredStorageVolumeBackups(ctx context.Context, s *state.State) error {
 	var volumeBackups []*backup.VolumeBackup
+	var volumeIDs []int64
 
 	// Get the list of expired backups.
 	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
@@ -641,6 +751,7 @@ func pruneExpiredStorageVolumeBackups(ctx context.Context, s *state.State) error
 			volBackup := backup.NewVolumeBackup(s, vol.ProjectName, vol.PoolName, vol.Name, b.ID, b.Name, b.CreationDate, b.ExpiryDate, b.VolumeOnly, b.OptimizedStorage)
 
 			volumeBackups = append(volumeBackups, volBackup)
+			volumeIDs = append(volumeIDs, b.VolumeID)
 		}
 
 		return nil
@@ -650,9 +761,26 @@ func pruneExpiredStorageVolumeBackups(ctx context.Context, s *state.State) error
 	}
 
 	// The deletion is done outside of the transaction to avoid any unnecessary IO while inside of
-	// the transaction.
-	for _, b := range volumeBackups {
-		err := b.Delete()
+	// the transaction. Incremental backup dependencies are scanned once per volume.
+	dependencies := map[int64]map[string][]string{}
+
+	for i, b := range volumeBackups {
+		// Keep backups which incremental backups still depend on, they get pruned once those are gone.
+		volumeID := volumeIDs[i]
+		if dependencies[volumeID] == nil {
+			dependencies[volumeID], err = b.ChainDependencies()
+			if err != nil {
+				return fmt.Errorf("Error checking dependents of storage volume backup %q: %w", b.Name(), err)
+			}
+		}
+
+		dependents := dependencies[volumeID][b.Name()]
+		if len(dependents) > 0 {
+			logger.Debug("Skipping expired storage volume backup with incremental dependents", logger.Ctx{"backup": b.Name(), "dependents": dependents})
+			continue
+		}
+
+		err = b.Delete()
 		if err != nil {
 			return fmt.Errorf("Error deleting storage volume backup %q: %w", b.Name(), err)
 		}
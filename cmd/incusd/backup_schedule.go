@@ -0,0 +1,606 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	backupTarget "github.com/lxc/incus/v6/internal/server/backup/target"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// scheduledBackupPrefix and scheduledBackupTimeLayout define the names of scheduled backups.
+const (
+	scheduledBackupPrefix     = "auto-"
+	scheduledBackupTimeLayout = "20060102-150405"
+)
+
+// scheduledBackupName returns the name of a scheduled backup taken at the given time.
+// Scheduled backups are named after their creation time so that uploaded backups never collide.
+func scheduledBackupName(t time.Time) string {
+	return scheduledBackupPrefix + t.UTC().Format(scheduledBackupTimeLayout)
+}
+
+// scheduledBackupTime returns the creation time of the scheduled backup with the given name.
+func scheduledBackupTime(name string) (time.Time, error) {
+	timestamp, found := strings.CutPrefix(name, scheduledBackupPrefix)
+	if !found {
+		return time.Time{}, fmt.Errorf("%q isn't the name of a scheduled backup", name)
+	}
+
+	return time.Parse(scheduledBackupTimeLayout, timestamp)
+}
+
+// uploadedBackupEntry returns the entry of the `volatile.backups.uploaded` list recording the scheduled backup
+// uploaded to the named target. The target is recorded so that the backup is deleted from the target it was
+// uploaded to even if `backups.target` changes in the meantime.
+func uploadedBackupEntry(targetName string, backupName string) string {
+	return targetName + "/" + backupName
+}
+
+// parseUploadedBackupEntry returns the target and backup names recorded in an entry of the `volatile.backups.uploaded` list.
+func parseUploadedBackupEntry(entry string) (string, string, error) {
+	targetName, backupName, found := strings.Cut(entry, "/")
+	if !found || targetName == "" {
+		return "", "", fmt.Errorf("Invalid uploaded backup entry %q", entry)
+	}
+
+	return targetName, backupName, nil
+}
+
+// expiredUploadedBackups splits the entries of uploaded scheduled backups into those which have expired at the
+// given time and those to keep. Nothing expires if no expiry is set.
+func expiredUploadedBackups(uploaded []string, expiry string, now time.Time) ([]string, []string, error) {
+	var expired, kept []string
+	for _, entry := range uploaded {
+		_, name, err := parseUploadedBackupEntry(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		created, err := scheduledBackupTime(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		expiryDate, err := internalInstance.GetExpiry(created, expiry)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !expiryDate.IsZero() && !expiryDate.After(now) {
+			expired = append(expired, entry)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+
+	return expired, kept, nil
+}
+
+// pruneUploadedBackups records the newly uploaded backup entry in the comma separated list of uploaded scheduled
+// backups of the project and deletes the expired ones from the target they were uploaded to, returned for each
+// target and backup name by getTarget. It returns the updated list of uploaded backups. Backups which fail to be
+// deleted are kept in the list so that their removal is retried with the next scheduled backup.
+func pruneUploadedBackups(ctx context.Context, s *state.State, projectName string, uploaded string, newEntry string, expiry string, now time.Time, getTarget func(targetName string, name string) (*api.BackupTarget, error)) (string, error) {
+	entries := util.SplitNTrimSpace(uploaded, ",", -1, true)
+
+	// Backups which never expire don't need to be tracked.
+	if expiry != "" {
+		entries = append(entries, newEntry)
+	}
+
+	expired, kept, err := expiredUploadedBackups(entries, expiry, now)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range expired {
+		err := deleteUploadedBackup(ctx, s, projectName, entry, getTarget)
+		if err != nil {
+			logger.Warn("Failed deleting expired scheduled backup from its target", logger.Ctx{"backup": entry, "err": err})
+			kept = append(kept, entry)
+		}
+	}
+
+	slices.Sort(kept)
+
+	return strings.Join(kept, ","), nil
+}
+
+// deleteUploadedBackup deletes the scheduled backup of the project recorded in the entry from its target.
+func deleteUploadedBackup(ctx context.Context, s *state.State, projectName string, entry string, getTarget func(targetName string, name string) (*api.BackupTarget, error)) error {
+	targetName, name, err := parseUploadedBackupEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	req, err := getTarget(targetName, name)
+	if err != nil {
+		return err
+	}
+
+	t, err := backupTarget.Load(s, projectName, req)
+	if err != nil {
+		return err
+	}
+
+	return t.Delete(ctx)
+}
+
+// scheduledBackupTarget returns the upload target of the scheduled backup file with the given name on the
+// backup target defined through the `backups.target.NAME.*` server configuration keys.
+func scheduledBackupTarget(s *state.State, targetName string, fileName string) (*api.BackupTarget, error) {
+	target, err := internalInstance.GetBackupTarget(s.GlobalConfig.BackupTarget(targetName), fileName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting backup target %q: %w", targetName, err)
+	}
+
+	return target, nil
+}
+
+func autoCreateBackupsTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+		var instances []instance.Instance
+		var volumes, remoteVolumes []db.StorageVolumeArgs
+		var memberCount int
+		var onlineMemberIDs []int64
+
+		// Get list of instances on the local member that are due to be backed up.
+		filter := dbCluster.InstanceFilter{Node: &s.ServerName}
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
+				inst, err := instance.Load(s, dbInst, p)
+				if err != nil {
+					return fmt.Errorf("Failed loading instance %q (project %q) for backup task: %w", dbInst.Name, dbInst.Project, err)
+				}
+
+				// Check if instance has a backup schedule.
+				schedule := inst.ExpandedConfig()["backups.schedule"]
+				if schedule == "" {
+					return nil
+				}
+
+				// Check if backup is scheduled.
+				if !snapshotIsScheduledNow(schedule, int64(inst.ID())) {
+					return nil
+				}
+
+				logger.Debug("Scheduling instance backup", logger.Ctx{"instance": inst.Name(), "project": inst.Project().Name})
+				instances = append(instances, inst)
+				return nil
+			}, filter)
+		})
+		if err != nil {
+			logger.Error("Failed getting instance backup schedule info", logger.Ctx{"err": err})
+			return
+		}
+
+		// Get list of custom volumes that are due to be backed up.
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			allVolumes, err := tx.GetStoragePoolVolumesWithType(ctx, db.StoragePoolVolumeTypeCustom, true)
+			if err != nil {
+				return fmt.Errorf("Failed getting volumes for backup task: %w", err)
+			}
+
+			for _, v := range allVolumes {
+				schedule := v.Config["backups.schedule"]
+				if schedule == "" || !snapshotIsScheduledNow(schedule, v.ID) {
+					continue
+				}
+
+				if v.NodeID < 0 {
+					// Keep a separate list of remote volumes in order to select a member to
+					// perform the backup later.
+					remoteVolumes = append(remoteVolumes, v)
+				} else {
+					volumes = append(volumes, v)
+				}
+			}
+
+			if len(remoteVolumes) > 0 {
+				members, err := tx.GetNodes(ctx)
+				if err != nil {
+					return fmt.Errorf("Failed getting cluster members: %w", err)
+				}
+
+				memberCount = len(members)
+				for _, member := range members {
+					if member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+						continue
+					}
+
+					onlineMemberIDs = append(onlineMemberIDs, member.ID)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed getting custom volume backup schedule info", logger.Ctx{"err": err})
+			return
+		}
+
+		if len(remoteVolumes) > 0 {
+			// Skip remote custom volumes if there are no online members, as we can't be sure that the
+			// cluster isn't partitioned and we may end up backing up the volume on multiple members.
+			if memberCount > 1 && len(onlineMemberIDs) <= 0 {
+				logger.Error("Skipping remote volumes for scheduled custom volume backup task due to no online members")
+			} else {
+				localMemberID := s.DB.Cluster.GetNodeID()
+
+				for _, v := range remoteVolumes {
+					// A stable random member is chosen to perform the backup.
+					if memberCount > 1 {
+						selectedMemberID, err := localUtil.GetStableRandomInt64FromList(v.ID, onlineMemberIDs)
+						if err != nil {
+							logger.Error("Failed scheduling remote custom volume backup", logger.Ctx{"volName": v.Name, "project": v.ProjectName, "pool": v.PoolName, "err": err})
+							continue
+						}
+
+						if localMemberID != selectedMemberID {
+							continue
+						}
+					}
+
+					volumes = append(volumes, v)
+				}
+			}
+		}
+
+		if len(instances) > 0 {
+			opRun := func(op *operations.Operation) error {
+				return autoCreateInstanceBackups(ctx, s, instances, op)
+			}
+
+			op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.BackupCreate, nil, nil, opRun, nil, nil, nil)
+			if err != nil {
+				logger.Error("Failed creating scheduled instance backup operation", logger.Ctx{"err": err})
+			} else {
+				logger.Info("Creating scheduled instance backups")
+
+				err = op.Start()
+				if err != nil {
+					logger.Error("Failed starting scheduled instance backup operation", logger.Ctx{"err": err})
+				} else {
+					err = op.Wait(ctx)
+					if err != nil {
+						logger.Error("Failed scheduled instance backups", logger.Ctx{"err": err})
+					} else {
+						logger.Info("Done creating scheduled instance backups")
+					}
+				}
+			}
+		}
+
+		if len(volumes) > 0 {
+			opRun := func(op *operations.Operation) error {
+				return autoCreateCustomVolumeBackups(ctx, s, volumes)
+			}
+
+			op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.CustomVolumeBackupCreate, nil, nil, opRun, nil, nil, nil)
+			if err != nil {
+				logger.Error("Failed creating scheduled custom volume backup operation", logger.Ctx{"err": err})
+			} else {
+				logger.Info("Creating scheduled custom volume backups")
+
+				err = op.Start()
+				if err != nil {
+					logger.Error("Failed starting scheduled custom volume backup operation", logger.Ctx{"err": err})
+				} else {
+					err = op.Wait(ctx)
+					if err != nil {
+						logger.Error("Failed scheduled custom volume backups", logger.Ctx{"err": err})
+					} else {
+						logger.Info("Done creating scheduled custom volume backups")
+					}
+				}
+			}
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
+
+// autoCreateInstanceBackups creates the scheduled backups of the given instances. A failure to back up
+// one instance is recorded as a warning on that instance and doesn't prevent backing up the others.
+func autoCreateInstanceBackups(ctx context.Context, s *state.State, instances []instance.Instance, op *operations.Operation) error {
+	var failed int
+	for _, inst := range instances {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+		err = autoCreateInstanceBackup(s, inst, op)
+		if err != nil {
+			l.Error("Failed creating scheduled backup", logger.Ctx{"err": err})
+			failed++
+
+			warnErr := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.UpsertWarningLocalNode(ctx, inst.Project().Name, dbCluster.TypeInstance, inst.ID(), warningtype.ScheduledBackupFailure, err.Error())
+			})
+			if warnErr != nil {
+				l.Warn("Failed to create scheduled backup failure warning", logger.Ctx{"err": warnErr})
+			}
+
+			continue
+		}
+
+		warnErr := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, inst.Project().Name, warningtype.ScheduledBackupFailure, dbCluster.TypeInstance, inst.ID())
+		if warnErr != nil {
+			l.Warn("Failed to resolve scheduled backup failure warning", logger.Ctx{"err": warnErr})
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("Failed creating %d scheduled instance backups", failed)
+	}
+
+	return nil
+}
+
+// autoCreateInstanceBackup creates a scheduled backup of the instance and uploads it if configured.
+func autoCreateInstanceBackup(s *state.State, inst instance.Instance, op *operations.Operation) error {
+	config := inst.ExpandedConfig()
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return project.AllowBackupCreation(tx, inst.Project().Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	backupName := scheduledBackupName(now)
+
+	expiry, err := internalInstance.GetExpiry(now, config["backups.expiry"])
+	if err != nil {
+		return fmt.Errorf("Failed getting backups.expiry date: %w", err)
+	}
+
+	getTarget := func(targetName string, name string) (*api.BackupTarget, error) {
+		return scheduledBackupTarget(s, targetName, fmt.Sprintf("%s-%s", project.Instance(inst.Project().Name, inst.Name()), name))
+	}
+
+	var target *api.BackupTarget
+	targetName := config["backups.target"]
+	if targetName != "" {
+		// Check the project restrictions as they may have changed since the target was set.
+		instProject := inst.Project()
+		err = project.AllowBackupTarget(&instProject, targetName)
+		if err != nil {
+			return err
+		}
+
+		target, err = getTarget(targetName, backupName)
+		if err != nil {
+			return err
+		}
+	}
+
+	fullName := inst.Name() + internalInstance.SnapshotDelimiter + backupName
+	args := db.InstanceBackup{
+		Name:                 fullName,
+		InstanceID:           inst.ID(),
+		CreationDate:         now,
+		ExpiryDate:           expiry,
+		CompressionAlgorithm: config["backups.compression_algorithm"],
+	}
+
+	err = backupCreate(s, args, inst, "", op)
+	if err != nil {
+		return err
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	entry, err := instance.BackupLoadByName(s, inst.Project().Name, fullName)
+	if err != nil {
+		return err
+	}
+
+	err = entry.Upload(target)
+	if err != nil {
+		return fmt.Errorf("Failed uploading backup %q: %w", backupName, err)
+	}
+
+	// Only keep uploaded backups on the target.
+	err = entry.Delete()
+	if err != nil {
+		return err
+	}
+
+	uploaded, err := pruneUploadedBackups(context.TODO(), s, inst.Project().Name, config["volatile.backups.uploaded"], uploadedBackupEntry(targetName, backupName), config["backups.expiry"], now, getTarget)
+	if err != nil {
+		return err
+	}
+
+	return inst.VolatileSet(map[string]string{"volatile.backups.uploaded": uploaded})
+}
+
+// autoCreateCustomVolumeBackups creates the scheduled backups of the given custom volumes. A failure to
+// back up one volume is recorded as a warning on that volume and doesn't prevent backing up the others.
+func autoCreateCustomVolumeBackups(ctx context.Context, s *state.State, volumes []db.StorageVolumeArgs) error {
+	var failed int
+	for _, v := range volumes {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		l := logger.AddContext(logger.Ctx{"project": v.ProjectName, "pool": v.PoolName, "volume": v.Name})
+
+		err = autoCreateCustomVolumeBackup(ctx, s, v)
+		if err != nil {
+			l.Error("Failed creating scheduled backup", logger.Ctx{"err": err})
+			failed++
+
+			warnErr := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.UpsertWarningLocalNode(ctx, v.ProjectName, dbCluster.TypeStorageVolume, int(v.ID), warningtype.ScheduledBackupFailure, err.Error())
+			})
+			if warnErr != nil {
+				l.Warn("Failed to create scheduled backup failure warning", logger.Ctx{"err": warnErr})
+			}
+
+			continue
+		}
+
+		warnErr := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, v.ProjectName, warningtype.ScheduledBackupFailure, dbCluster.TypeStorageVolume, int(v.ID))
+		if warnErr != nil {
+			l.Warn("Failed to resolve scheduled backup failure warning", logger.Ctx{"err": warnErr})
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("Failed creating %d scheduled custom volume backups", failed)
+	}
+
+	return nil
+}
+
+// autoCreateCustomVolumeBackup creates a scheduled backup of the custom volume and uploads it if configured.
+func autoCreateCustomVolumeBackup(ctx context.Context, s *state.State, v db.StorageVolumeArgs) error {
+	var p *api.Project
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		err := project.AllowBackupCreation(tx, v.ProjectName)
+		if err != nil {
+			return err
+		}
+
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), v.ProjectName)
+		if err != nil {
+			return err
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	backupName := scheduledBackupName(now)
+
+	expiry, err := internalInstance.GetExpiry(now, v.Config["backups.expiry"])
+	if err != nil {
+		return fmt.Errorf("Failed getting backups.expiry date: %w", err)
+	}
+
+	getTarget := func(targetName string, name string) (*api.BackupTarget, error) {
+		return scheduledBackupTarget(s, targetName, fmt.Sprintf("%s_%s-%s", v.PoolName, project.StorageVolume(v.ProjectName, v.Name), name))
+	}
+
+	var target *api.BackupTarget
+	targetName := v.Config["backups.target"]
+	if targetName != "" {
+		// Check the project restrictions as they may have changed since the target was set.
+		err = project.AllowBackupTarget(p, targetName)
+		if err != nil {
+			return err
+		}
+
+		target, err = getTarget(targetName, backupName)
+		if err != nil {
+			return err
+		}
+	}
+
+	fullName := v.Name + internalInstance.SnapshotDelimiter + backupName
+	args := db.StoragePoolVolumeBackup{
+		Name:                 fullName,
+		VolumeID:             v.ID,
+		CreationDate:         now,
+		ExpiryDate:           expiry,
+		CompressionAlgorithm: v.Config["backups.compression_algorithm"],
+	}
+
+	err = volumeBackupCreate(s, args, v.ProjectName, v.PoolName, v.Name, "")
+	if err != nil {
+		return err
+	}
+
+	s.Events.SendLifecycle(v.ProjectName, lifecycle.StorageVolumeBackupCreated.Event(v.PoolName, db.StoragePoolVolumeTypeNameCustom, fullName, v.ProjectName, nil, logger.Ctx{"type": db.StoragePoolVolumeTypeNameCustom}))
+
+	if target == nil {
+		return nil
+	}
+
+	entry, err := storagePoolVolumeBackupLoadByName(ctx, s, v.ProjectName, v.PoolName, fullName)
+	if err != nil {
+		return err
+	}
+
+	err = entry.Upload(target)
+	if err != nil {
+		return fmt.Errorf("Failed uploading backup %q: %w", backupName, err)
+	}
+
+	// Only keep uploaded backups on the target.
+	err = entry.Delete()
+	if err != nil {
+		return err
+	}
+
+	uploaded, err := pruneUploadedBackups(ctx, s, v.ProjectName, v.Config["volatile.backups.uploaded"], uploadedBackupEntry(targetName, backupName), v.Config["backups.expiry"], now, getTarget)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		poolID, err := tx.GetStoragePoolID(ctx, v.PoolName)
+		if err != nil {
+			return err
+		}
+
+		dbVol, err := tx.GetStoragePoolVolume(ctx, poolID, v.ProjectName, db.StoragePoolVolumeTypeCustom, v.Name, true)
+		if err != nil {
+			return err
+		}
+
+		if uploaded == "" {
+			delete(dbVol.Config, "volatile.backups.uploaded")
+		} else {
+			dbVol.Config["volatile.backups.uploaded"] = uploaded
+		}
+
+		return tx.UpdateStoragePoolVolume(ctx, v.ProjectName, v.Name, db.StoragePoolVolumeTypeCustom, poolID, dbVol.Description, dbVol.Config)
+	})
+}
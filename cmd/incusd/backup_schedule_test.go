@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledBackupName(t *testing.T) {
+	created := time.Date(2024, 3, 5, 7, 9, 11, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	name := scheduledBackupName(created)
+	assert.Equal(t, "auto-20240305-050911", name)
+
+	parsed, err := scheduledBackupTime(name)
+	require.NoError(t, err)
+	assert.True(t, created.Equal(parsed))
+
+	for _, name := range []string{"manual-20240305-050911", "auto-20240305", "20240305-050911"} {
+		_, err := scheduledBackupTime(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestExpiredUploadedBackups(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	uploaded := []string{
+		uploadedBackupEntry("nas", scheduledBackupName(now.Add(-72*time.Hour))),
+		uploadedBackupEntry("offsite", scheduledBackupName(now.Add(-48*time.Hour))),
+		uploadedBackupEntry("offsite", scheduledBackupName(now.Add(-24*time.Hour))),
+		uploadedBackupEntry("offsite", scheduledBackupName(now)),
+	}
+
+	tests := []struct {
+		name        string
+		uploaded    []string
+		expiry      string
+		wantExpired []string
+		wantKept    []string
+		wantErr     bool
+	}{
+		{
+			name:     "No expiry",
+			uploaded: uploaded,
+			wantKept: uploaded,
+		},
+		{
+			name:        "Expiry boundary is inclusive",
+			uploaded:    uploaded,
+			expiry:      "2d",
+			wantExpired: uploaded[:2],
+			wantKept:    uploaded[2:],
+		},
+		{
+			name:        "Everything expired",
+			uploaded:    uploaded[:3],
+			expiry:      "1H",
+			wantExpired: uploaded[:3],
+		},
+		{
+			name:     "Invalid name",
+			uploaded: []string{"offsite/backup0"},
+			expiry:   "1d",
+			wantErr:  true,
+		},
+		{
+			name:     "Missing target",
+			uploaded: []string{scheduledBackupName(now)},
+			expiry:   "1d",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid expiry",
+			uploaded: uploaded,
+			expiry:   "1x",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired, kept, err := expiredUploadedBackups(tt.uploaded, tt.expiry, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExpired, expired)
+			assert.Equal(t, tt.wantKept, kept)
+		})
+	}
+}
+
+func TestParseUploadedBackupEntry(t *testing.T) {
+	targetName, backupName, err := parseUploadedBackupEntry(uploadedBackupEntry("offsite", "auto-20240305-050911"))
+	require.NoError(t, err)
+	assert.Equal(t, "offsite", targetName)
+	assert.Equal(t, "auto-20240305-050911", backupName)
+
+	for _, entry := range []string{"auto-20240305-050911", "/auto-20240305-050911"} {
+		_, _, err := parseUploadedBackupEntry(entry)
+		assert.Error(t, err, entry)
+	}
+}
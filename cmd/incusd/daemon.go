@@ -50,6 +50,7 @@ import (
 	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/logging"
+	"github.com/lxc/incus/v6/internal/server/network/acl"
 	"github.com/lxc/incus/v6/internal/server/network/ovn"
 	"github.com/lxc/incus/v6/internal/server/network/ovs"
 	networkZone "github.com/lxc/incus/v6/internal/server/network/zone"
@@ -1438,11 +1439,14 @@ func (d *Daemon) init() error {
 	syslogSocketEnabled := d.localConfig.SyslogSocket()
 	openfgaAPIURL, openfgaAPIToken, openfgaStoreID := d.globalConfig.OpenFGA()
 	instancePlacementScriptlet := d.globalConfig.InstancesPlacementScriptlet()
+	instanceValidationScriptlet := d.globalConfig.InstancesValidationScriptlet()
 	authorizationScriptlet := d.globalConfig.AuthorizationScriptlet()
 
 	d.endpoints.NetworkUpdateTrustedProxy(d.globalConfig.HTTPSTrustedProxy())
 	d.globalConfigMu.Unlock()
 
+	d.internalListener.AddHandler("network-address-sets", networkAddressSetsSelectorsRefresh(d))
+
 	d.loggingController = logging.NewLoggingController(d.internalListener)
 	err = d.loggingController.Setup(d.State())
 	if err != nil {
@@ -1572,6 +1576,14 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Load instance validation scriptlet.
+	if instanceValidationScriptlet != "" {
+		err = scriptletLoad.InstanceValidationSet(instanceValidationScriptlet)
+		if err != nil {
+			logger.Warn("Failed loading instance validation scriptlet", logger.Ctx{"err": err})
+		}
+	}
+
 	// Apply all patches that need to be run after networks are initialized.
 	err = patchesApply(d, patchPostNetworks)
 	if err != nil {
@@ -1592,9 +1604,17 @@ func (d *Daemon) init() error {
 			prefixPath = "/dev"
 		}
 
+		var pollInterval time.Duration
+		if os.Getenv("INCUS_DEVMONITOR_POLL_INTERVAL") != "" {
+			pollInterval, err = time.ParseDuration(os.Getenv("INCUS_DEVMONITOR_POLL_INTERVAL"))
+			if err != nil {
+				return fmt.Errorf("Invalid device monitor poll interval: %w", err)
+			}
+		}
+
 		logger.Info("Starting device monitor")
 
-		d.devmonitor, err = fsmonitor.New(d.State().ShutdownCtx, prefixPath)
+		d.devmonitor, err = fsmonitor.New(d.State().ShutdownCtx, prefixPath, pollInterval)
 		if err != nil {
 			return err
 		}
@@ -1687,6 +1707,27 @@ func (d *Daemon) init() error {
 		// Prune expired custom volume snapshots and take snapshots of custom volumes (minutely check of configurable cron expression)
 		d.tasks.Add(pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d))
 
+		// Take scheduled backups of instances and custom volumes (minutely check of configurable cron expression)
+		d.tasks.Add(autoCreateBackupsTask(d))
+
+		// Replicate instances and custom volumes to their replication targets (minutely check of configurable cron expression)
+		d.tasks.Add(replicationTask(d))
+
+		// Send DNS NOTIFY for changed network zones (minutely)
+		d.tasks.Add(networkZonesNotifyTask(d))
+
+		// Roll over DNSSEC keys of network zones (hourly)
+		d.tasks.Add(networkZonesDNSSECRolloverTask(d))
+
+		// Refresh network address sets selecting instances (minutely)
+		d.tasks.Add(networkAddressSetsSelectorsTask(d))
+
+		// Record instance usage (every 5 minutes)
+		d.tasks.Add(instanceUsageTask(d))
+
+		// Check the health of the storage pools (every 10 minutes)
+		d.tasks.Add(storagePoolHealthTask(d))
+
 		// Remove resolved warnings (daily)
 		d.tasks.Add(pruneResolvedWarningsTask(d))
 
@@ -2281,7 +2322,9 @@ func (d *Daemon) setupSyslogSocket(enable bool) error {
 
 	logger.Debug("Starting syslog socket")
 
-	err := syslog.Listen(ctx, d.events)
+	err := syslog.Listen(ctx, d.events, func(message string) map[string]string {
+		return acl.OVNLogEventContext(d.State(), message)
+	})
 	if err != nil {
 		return err
 	}
@@ -2481,6 +2524,13 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 
 		// Refresh cluster certificates cached.
 		updateCertificateCache(d)
+
+		// Let the networks react to the new cluster members.
+		err = networkHandleHeartbeat(s, heartbeatData)
+		if err != nil {
+			stateChangeTaskFailure = true
+			logger.Error("Error handling heartbeat in networks", logger.Ctx{"err": err})
+		}
 	}
 
 	// Refresh event listeners from heartbeat members (after certificates refreshed if needed).
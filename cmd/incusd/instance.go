@@ -20,16 +20,19 @@ import (
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v6/internal/server/locking"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/scriptlet"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	"github.com/lxc/incus/v6/internal/server/task"
 	"github.com/lxc/incus/v6/shared/api"
+	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/util"
@@ -843,3 +846,59 @@ func instanceOperationLock(ctx context.Context, projectName string, instanceName
 
 	return locking.Lock(ctx, fmt.Sprintf("InstanceOperation_%s", project.Instance(projectName, instanceName)))
 }
+
+// instanceValidationRun runs the instance validation scriptlet when one is configured.
+// The profiles are applied to the local configuration and devices of the request to expose the expanded
+// configuration and devices to the scriptlet which may in turn modify the local configuration and devices.
+func instanceValidationRun(s *state.State, req *apiScriptlet.InstanceValidation, profiles []api.Profile) error {
+	if s.GlobalConfig.InstancesValidationScriptlet() == "" {
+		return nil
+	}
+
+	if req.Config == nil {
+		req.Config = map[string]string{}
+	}
+
+	if req.Devices == nil {
+		req.Devices = map[string]map[string]string{}
+	}
+
+	req.Profiles = make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		req.Profiles = append(req.Profiles, profile.Name)
+	}
+
+	req.ExpandedConfig = db.ExpandInstanceConfig(req.Config, profiles)
+	req.ExpandedDevices = db.ExpandInstanceDevices(deviceConfig.NewDevices(req.Devices), profiles).CloneNative()
+
+	err := scriptlet.InstanceValidationRun(logger.Log, req)
+	if err != nil {
+		// Rejections are reported as is.
+		if api.StatusErrorCheck(err, http.StatusBadRequest) {
+			return err
+		}
+
+		return fmt.Errorf("Failed instance validation scriptlet: %w", err)
+	}
+
+	return nil
+}
+
+// instanceStripWriteOnly removes the device configuration keys holding secrets from a rendered instance or
+// snapshot before returning it through the API.
+func instanceStripWriteOnly(render any) {
+	switch inst := render.(type) {
+	case *api.Instance:
+		inst.Devices = deviceConfig.StripWriteOnly(inst.Devices)
+		inst.ExpandedDevices = deviceConfig.StripWriteOnly(inst.ExpandedDevices)
+	case *api.InstanceFull:
+		instanceStripWriteOnly(&inst.Instance)
+		for i := range inst.Snapshots {
+			instanceStripWriteOnly(&inst.Snapshots[i])
+		}
+
+	case *api.InstanceSnapshot:
+		inst.Devices = deviceConfig.StripWriteOnly(inst.Devices)
+		inst.ExpandedDevices = deviceConfig.StripWriteOnly(inst.ExpandedDevices)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
+	backupTarget "github.com/lxc/incus/v6/internal/server/backup/target"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/instance"
@@ -306,6 +307,28 @@ func instanceBackupsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("Backup names may not contain slashes"))
 	}
 
+	// Validate the upload target.
+	if req.Target != nil {
+		_, err = backupTarget.Load(s, inst.Project().Name, req.Target)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
+	// Validate the parent backup.
+	var parentName string
+	if req.Parent != "" {
+		if strings.Contains(req.Parent, "/") {
+			return response.BadRequest(errors.New("Backup names may not contain slashes"))
+		}
+
+		parentName = name + internalInstance.SnapshotDelimiter + req.Parent
+		_, err = instance.BackupLoadByName(s, projectName, parentName)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed loading parent backup %q: %w", req.Parent, err))
+		}
+	}
+
 	fullName := name + internalInstance.SnapshotDelimiter + req.Name
 	instanceOnly := req.InstanceOnly
 
@@ -321,7 +344,7 @@ func instanceBackupsPost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		// Create the backup.
-		err := backupCreate(s, args, inst, op)
+		err := backupCreate(s, args, inst, parentName, op)
 		if err != nil {
 			return err
 		}
@@ -517,6 +540,15 @@ func instanceBackupPost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	dependents, err := backup.Dependents()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if len(dependents) > 0 {
+		return response.BadRequest(fmt.Errorf("Backup is the parent of incremental backups: %s", strings.Join(dependents, ", ")))
+	}
+
 	newName := name + internalInstance.SnapshotDelimiter + req.Name
 
 	rename := func(op *operations.Operation) error {
@@ -600,6 +632,15 @@ func instanceBackupDelete(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	dependents, err := backup.Dependents()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if len(dependents) > 0 {
+		return response.BadRequest(fmt.Errorf("Backup is the parent of incremental backups: %s", strings.Join(dependents, ", ")))
+	}
+
 	remove := func(op *operations.Operation) error {
 		err := backup.Delete()
 		if err != nil {
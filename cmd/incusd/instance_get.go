@@ -147,5 +147,7 @@ func instanceGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	instanceStripWriteOnly(state)
+
 	return response.SyncResponseETag(true, state, etag)
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/response"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/shared/api"
+	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
 	"github.com/lxc/incus/v6/shared/osarch"
 )
 
@@ -182,6 +183,8 @@ func instancePatch(d *Daemon, r *http.Request) response.Response {
 				req.Devices[k] = v
 			}
 		}
+
+		deviceConfig.KeepWriteOnly(req.Devices, c.LocalDevices().CloneNative())
 	}
 
 	// Check project limits.
@@ -217,6 +220,24 @@ func instancePatch(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Run the instance validation scriptlet.
+	validationReq := apiScriptlet.InstanceValidation{
+		Reason:  apiScriptlet.InstanceValidationReasonUpdate,
+		Project: projectName,
+		Name:    name,
+		Type:    c.Type().String(),
+		Config:  req.Config,
+		Devices: req.Devices,
+	}
+
+	err = instanceValidationRun(s, &validationReq, apiProfiles)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req.Config = validationReq.Config
+	req.Devices = validationReq.Devices
+
 	// Update container configuration
 	args := db.InstanceArgs{
 		Architecture: architecture,
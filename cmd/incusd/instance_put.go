@@ -25,6 +25,7 @@ import (
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
+	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
 	"github.com/lxc/incus/v6/shared/osarch"
 	"github.com/lxc/incus/v6/shared/revert"
 )
@@ -125,6 +126,9 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 	var do func(*operations.Operation) error
 	var opType operationtype.Type
 	if configRaw.Restore == "" {
+		// Keep the secrets which aren't returned through the API.
+		deviceConfig.KeepWriteOnly(configRaw.Devices, inst.LocalDevices().CloneNative())
+
 		// Check project limits.
 		apiProfiles := make([]api.Profile, 0, len(configRaw.Profiles))
 		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -158,6 +162,24 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 			return response.SmartError(err)
 		}
 
+		// Run the instance validation scriptlet.
+		validationReq := apiScriptlet.InstanceValidation{
+			Reason:  apiScriptlet.InstanceValidationReasonUpdate,
+			Project: projectName,
+			Name:    name,
+			Type:    inst.Type().String(),
+			Config:  configRaw.Config,
+			Devices: configRaw.Devices,
+		}
+
+		err = instanceValidationRun(s, &validationReq, apiProfiles)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		configRaw.Config = validationReq.Config
+		configRaw.Devices = validationReq.Devices
+
 		// Update container configuration
 		do = func(op *operations.Operation) error {
 			inst.SetOperation(op)
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// swagger:operation GET /1.0/instances/{name}/replication instances instance_replication_get
+//
+//	Get the replication state
+//
+//	Gets the replication target and lag of the instance.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Replication state
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Replication"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceReplicationGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(errors.New("Invalid instance name"))
+	}
+
+	// Handle requests targeted to an instance on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	poolName, err := inst.StoragePool()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	info, err := replicationInfo(inst.ExpandedConfig(), poolName, inst.Project().Name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	_, info.Running = replicationRunning.Load(replicationInstanceKey(inst.ID()))
+
+	return response.SyncResponse(true, info)
+}
+
+// swagger:operation POST /1.0/instances/{name}/replication instances instance_replication_post
+//
+//	Replicate the instance
+//
+//	Replicates the instance to its replication target (`sync`) or performs a planned failover (`failover`).
+//	A failover replicates the stopped instance one last time and then reverses the direction of the replication.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: replication
+//	    description: Replication request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ReplicationPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceReplicationPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(errors.New("Invalid instance name"))
+	}
+
+	// Handle requests targeted to an instance on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	req := api.ReplicationPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if inst.ExpandedConfig()["replication.target"] == "" {
+		return response.BadRequest(errors.New("Replication isn't configured"))
+	}
+
+	var run func(op *operations.Operation) error
+
+	switch req.Action {
+	case "", api.ReplicationActionSync:
+		run = func(op *operations.Operation) error {
+			return replicateInstance(s, inst, op)
+		}
+
+	case api.ReplicationActionFailover:
+		if inst.IsRunning() {
+			return response.BadRequest(errors.New("The instance must be stopped for failover"))
+		}
+
+		if req.Target == "" {
+			return response.BadRequest(errors.New("Failover requires the replication target pointing back to this server"))
+		}
+
+		err = internalInstance.IsReplicationTargetName(req.Target)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		run = func(op *operations.Operation) error {
+			return failoverInstance(s, inst, req.Target, op)
+		}
+
+	default:
+		return response.BadRequest(fmt.Errorf("Unknown replication action %q", req.Action))
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceReplicate, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
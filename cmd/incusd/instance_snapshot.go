@@ -190,6 +190,7 @@ func instanceSnapshotsGet(d *Daemon, r *http.Request) response.Response {
 				continue
 			}
 
+			instanceStripWriteOnly(render)
 			resultMap = append(resultMap, render.(*api.InstanceSnapshot))
 		}
 	}
@@ -581,6 +582,8 @@ func snapshotGet(snapInst instance.Instance) response.Response {
 		return response.SmartError(err)
 	}
 
+	instanceStripWriteOnly(render)
+
 	etag := []any{snapInst.ExpiryDate()}
 	return response.SyncResponseETag(true, render.(*api.InstanceSnapshot), etag)
 }
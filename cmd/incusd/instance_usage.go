@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/metrics"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// instanceUsageInterval is how often the usage of the local instances is recorded.
+const instanceUsageInterval = 5 * time.Minute
+
+// instanceUsageCounters holds the cumulative counters of an instance at a given time.
+type instanceUsageCounters struct {
+	timestamp            time.Time
+	cpuSeconds           float64
+	memoryBytes          int64
+	diskReadBytes        int64
+	diskWrittenBytes     int64
+	networkReceivedBytes int64
+	networkSentBytes     int64
+}
+
+// instanceUsageCountersFromMetrics sums the metrics of an instance into its usage counters.
+func instanceUsageCountersFromMetrics(timestamp time.Time, metricSet *metrics.MetricSet) instanceUsageCounters {
+	sum := func(metricType metrics.MetricType) float64 {
+		var total float64
+		for _, sample := range metricSet.Samples(metricType) {
+			// Skip the loopback interface which doesn't reflect actual traffic.
+			if sample.Labels["device"] == "lo" {
+				continue
+			}
+
+			// Only count the CPU time actually used by the instance.
+			if slices.Contains([]string{"idle", "iowait", "steal"}, sample.Labels["mode"]) {
+				continue
+			}
+
+			total += sample.Value
+		}
+
+		return total
+	}
+
+	counters := instanceUsageCounters{
+		timestamp:            timestamp,
+		cpuSeconds:           sum(metrics.CPUSecondsTotal),
+		diskReadBytes:        int64(sum(metrics.DiskReadBytesTotal)),
+		diskWrittenBytes:     int64(sum(metrics.DiskWrittenBytesTotal)),
+		networkReceivedBytes: int64(sum(metrics.NetworkReceiveBytesTotal)),
+		networkSentBytes:     int64(sum(metrics.NetworkTransmitBytesTotal)),
+	}
+
+	// Prefer the memory as seen from within the instance, falling back to the resident set size.
+	memTotal := sum(metrics.MemoryMemTotalBytes)
+	memAvailable := sum(metrics.MemoryMemAvailableBytes)
+	if memTotal > 0 && memAvailable <= memTotal {
+		counters.memoryBytes = int64(memTotal - memAvailable)
+	} else {
+		counters.memoryBytes = int64(sum(metrics.MemoryRSSBytes))
+	}
+
+	return counters
+}
+
+// instanceUsageDelta returns the usage between the previous and current counters.
+// Counters going backwards indicate that the instance was restarted, in which case the current value is
+// the usage since the restart. Memory usage is accounted using the current value over the elapsed time.
+func instanceUsageDelta(previous instanceUsageCounters, current instanceUsageCounters) dbCluster.InstanceUsage {
+	deltaInt := func(previous int64, current int64) int64 {
+		if current < previous {
+			return current
+		}
+
+		return current - previous
+	}
+
+	usage := dbCluster.InstanceUsage{
+		PeriodStart:          current.timestamp,
+		DiskReadBytes:        deltaInt(previous.diskReadBytes, current.diskReadBytes),
+		DiskWrittenBytes:     deltaInt(previous.diskWrittenBytes, current.diskWrittenBytes),
+		NetworkReceivedBytes: deltaInt(previous.networkReceivedBytes, current.networkReceivedBytes),
+		NetworkSentBytes:     deltaInt(previous.networkSentBytes, current.networkSentBytes),
+	}
+
+	if current.cpuSeconds < previous.cpuSeconds {
+		usage.CPUSeconds = current.cpuSeconds
+	} else {
+		usage.CPUSeconds = current.cpuSeconds - previous.cpuSeconds
+	}
+
+	elapsed := current.timestamp.Sub(previous.timestamp)
+	if elapsed > 0 {
+		usage.MemoryByteSeconds = int64(float64(current.memoryBytes) * elapsed.Seconds())
+	}
+
+	return usage
+}
+
+// instanceUsageTask records the resource usage of the running local instances into the cluster database.
+// Usage is recorded against the project and instance name so it's kept when the instance moves to
+// another cluster member.
+func instanceUsageTask(d *Daemon) (task.Func, task.Schedule) {
+	// Last counters of each instance, keyed by project and instance name.
+	previous := map[string]instanceUsageCounters{}
+
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		instances, err := instance.LoadNodeAll(s, instancetype.Any)
+		if err != nil {
+			logger.Error("Failed loading instances for usage accounting", logger.Ctx{"err": err})
+			return
+		}
+
+		// Gather information about host interfaces once.
+		hostInterfaces, _ := net.Interfaces()
+
+		usages := []dbCluster.InstanceUsage{}
+		current := make(map[string]instanceUsageCounters, len(instances))
+		for _, inst := range instances {
+			if !inst.IsRunning() {
+				continue
+			}
+
+			instanceMetrics, err := inst.Metrics(hostInterfaces)
+			if err != nil {
+				if !errors.Is(err, instanceDrivers.ErrInstanceIsStopped) {
+					logger.Warn("Failed getting instance metrics for usage accounting", logger.Ctx{"instance": inst.Name(), "project": inst.Project().Name, "err": err})
+				}
+
+				continue
+			}
+
+			key := inst.Project().Name + "/" + inst.Name()
+			counters := instanceUsageCountersFromMetrics(time.Now(), instanceMetrics)
+			current[key] = counters
+
+			// The first sample only serves as a reference.
+			last, ok := previous[key]
+			if !ok {
+				continue
+			}
+
+			usage := instanceUsageDelta(last, counters)
+			usage.Project = inst.Project().Name
+			usage.Instance = inst.Name()
+			usages = append(usages, usage)
+		}
+
+		// Forget about instances which are no longer running locally.
+		previous = current
+
+		// Prune the expired usage from the leader only as the records are shared by the cluster.
+		pruneUsage := true
+		if s.ServerClustered {
+			leader, err := s.Cluster.LeaderAddress()
+			if err != nil || leader != s.LocalConfig.ClusterAddress() {
+				pruneUsage = false
+			}
+		}
+
+		retention := s.GlobalConfig.InstancesUsageRetentionDays()
+		if pruneUsage && retention > 0 {
+			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				return dbCluster.DeleteInstancesUsageBefore(ctx, tx.Tx(), time.Now().AddDate(0, 0, -int(retention)))
+			})
+			if err != nil {
+				logger.Error("Failed pruning instance usage", logger.Ctx{"err": err})
+			}
+		}
+
+		if len(usages) == 0 {
+			return
+		}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			for _, usage := range usages {
+				err := dbCluster.AddInstanceUsage(ctx, tx.Tx(), usage)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed recording instance usage", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(instanceUsageInterval)
+}
+
+// addProjectUsageCounters adds the usage to the counters.
+func addProjectUsageCounters(counters *api.ProjectUsageCounters, usage dbCluster.InstanceUsage) {
+	counters.CPUSeconds += usage.CPUSeconds
+	counters.MemoryByteSeconds += usage.MemoryByteSeconds
+	counters.DiskReadBytes += usage.DiskReadBytes
+	counters.DiskWrittenBytes += usage.DiskWrittenBytes
+	counters.NetworkReceivedBytes += usage.NetworkReceivedBytes
+	counters.NetworkSentBytes += usage.NetworkSentBytes
+}
+
+// projectUsageRollup aggregates the hourly usage records of a project into periods of the given granularity
+// ("hourly" or "daily"), both for the project as a whole and for each of its instances.
+// The usage records are expected to be ordered by period and instance name.
+func projectUsageRollup(usages []dbCluster.InstanceUsage, from time.Time, to time.Time, granularity string) *api.ProjectUsage {
+	periodStart := func(t time.Time) time.Time {
+		t = t.UTC()
+		if granularity == "daily" {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		}
+
+		return t.Truncate(time.Hour)
+	}
+
+	// addToPeriods adds the usage to the last period if matching, creating a new period otherwise.
+	addToPeriods := func(periods []api.ProjectUsagePeriod, start time.Time, usage dbCluster.InstanceUsage) []api.ProjectUsagePeriod {
+		if len(periods) == 0 || !periods[len(periods)-1].Start.Equal(start) {
+			periods = append(periods, api.ProjectUsagePeriod{Start: start})
+		}
+
+		addProjectUsageCounters(&periods[len(periods)-1].ProjectUsageCounters, usage)
+
+		return periods
+	}
+
+	result := &api.ProjectUsage{
+		From:        from.UTC(),
+		To:          to.UTC(),
+		Granularity: granularity,
+		Periods:     []api.ProjectUsagePeriod{},
+		Instances:   []api.ProjectUsageInstance{},
+	}
+
+	instanceIndexes := map[string]int{}
+	for _, usage := range usages {
+		start := periodStart(usage.PeriodStart)
+
+		addProjectUsageCounters(&result.Total, usage)
+		result.Periods = addToPeriods(result.Periods, start, usage)
+
+		idx, ok := instanceIndexes[usage.Instance]
+		if !ok {
+			idx = len(result.Instances)
+			instanceIndexes[usage.Instance] = idx
+			result.Instances = append(result.Instances, api.ProjectUsageInstance{Name: usage.Instance, Periods: []api.ProjectUsagePeriod{}})
+		}
+
+		addProjectUsageCounters(&result.Instances[idx].Total, usage)
+		result.Instances[idx].Periods = addToPeriods(result.Instances[idx].Periods, start, usage)
+	}
+
+	slices.SortFunc(result.Instances, func(a api.ProjectUsageInstance, b api.ProjectUsageInstance) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/metrics"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestInstanceUsageCountersFromMetrics(t *testing.T) {
+	now := time.Now()
+
+	set := metrics.NewMetricSet(nil)
+	set.AddSamples(metrics.CPUSecondsTotal,
+		metrics.Sample{Value: 8, Labels: map[string]string{"cpu": "0", "mode": "user"}},
+		metrics.Sample{Value: 2, Labels: map[string]string{"cpu": "0", "mode": "system"}},
+		metrics.Sample{Value: 1000, Labels: map[string]string{"cpu": "0", "mode": "idle"}},
+		metrics.Sample{Value: 20, Labels: map[string]string{"cpu": "0", "mode": "iowait"}},
+		metrics.Sample{Value: 30, Labels: map[string]string{"cpu": "0", "mode": "steal"}},
+		metrics.Sample{Value: 4, Labels: map[string]string{"cpu": "1", "mode": "user"}},
+		metrics.Sample{Value: 1, Labels: map[string]string{"cpu": "1", "mode": "nice"}},
+		metrics.Sample{Value: 900, Labels: map[string]string{"cpu": "1", "mode": "idle"}})
+	set.AddSamples(metrics.NetworkReceiveBytesTotal, metrics.Sample{Value: 100, Labels: map[string]string{"device": "eth0"}}, metrics.Sample{Value: 1000, Labels: map[string]string{"device": "lo"}})
+	set.AddSamples(metrics.MemoryMemTotalBytes, metrics.Sample{Value: 1024})
+	set.AddSamples(metrics.MemoryMemAvailableBytes, metrics.Sample{Value: 256})
+	set.AddSamples(metrics.MemoryRSSBytes, metrics.Sample{Value: 512})
+
+	counters := instanceUsageCountersFromMetrics(now, set)
+	assert.Equal(t, instanceUsageCounters{timestamp: now, cpuSeconds: 15, memoryBytes: 768, networkReceivedBytes: 100}, counters)
+
+	// Fall back to the resident set size without memory information from within the instance.
+	set = metrics.NewMetricSet(nil)
+	set.AddSamples(metrics.MemoryRSSBytes, metrics.Sample{Value: 512})
+
+	counters = instanceUsageCountersFromMetrics(now, set)
+	assert.Equal(t, int64(512), counters.memoryBytes)
+}
+
+func TestInstanceUsageDelta(t *testing.T) {
+	now := time.Now()
+
+	previous := instanceUsageCounters{timestamp: now.Add(-5 * time.Minute), cpuSeconds: 10, diskReadBytes: 100, networkSentBytes: 1000}
+	current := instanceUsageCounters{timestamp: now, cpuSeconds: 25, memoryBytes: 1024, diskReadBytes: 150, networkSentBytes: 200}
+
+	usage := instanceUsageDelta(previous, current)
+	assert.Equal(t, dbCluster.InstanceUsage{
+		PeriodStart:       now,
+		CPUSeconds:        15,
+		MemoryByteSeconds: 1024 * 300,
+		DiskReadBytes:     50,
+
+		// The counter was reset by a restart.
+		NetworkSentBytes: 200,
+	}, usage)
+}
+
+func TestProjectUsageRollup(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+
+	usages := []dbCluster.InstanceUsage{
+		{Project: "p1", Instance: "c2", PeriodStart: from, CPUSeconds: 1, DiskReadBytes: 10},
+		{Project: "p1", Instance: "c1", PeriodStart: from.Add(time.Hour), CPUSeconds: 2},
+		{Project: "p1", Instance: "c2", PeriodStart: from.Add(time.Hour), CPUSeconds: 3},
+		{Project: "p1", Instance: "c1", PeriodStart: from.Add(25 * time.Hour), CPUSeconds: 4, NetworkSentBytes: 20},
+	}
+
+	hourly := projectUsageRollup(usages, from, to, "hourly")
+	assert.Equal(t, "hourly", hourly.Granularity)
+	assert.Equal(t, api.ProjectUsageCounters{CPUSeconds: 10, DiskReadBytes: 10, NetworkSentBytes: 20}, hourly.Total)
+	assert.Equal(t, []api.ProjectUsagePeriod{
+		{Start: from, ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 1, DiskReadBytes: 10}},
+		{Start: from.Add(time.Hour), ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 5}},
+		{Start: from.Add(25 * time.Hour), ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 4, NetworkSentBytes: 20}},
+	}, hourly.Periods)
+
+	daily := projectUsageRollup(usages, from, to, "daily")
+	assert.Equal(t, []api.ProjectUsagePeriod{
+		{Start: from, ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 6, DiskReadBytes: 10}},
+		{Start: from.Add(24 * time.Hour), ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 4, NetworkSentBytes: 20}},
+	}, daily.Periods)
+
+	// Instances are sorted by name.
+	assert.Equal(t, []api.ProjectUsageInstance{
+		{
+			Name:  "c1",
+			Total: api.ProjectUsageCounters{CPUSeconds: 6, NetworkSentBytes: 20},
+			Periods: []api.ProjectUsagePeriod{
+				{Start: from, ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 2}},
+				{Start: from.Add(24 * time.Hour), ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 4, NetworkSentBytes: 20}},
+			},
+		},
+		{
+			Name:  "c2",
+			Total: api.ProjectUsageCounters{CPUSeconds: 4, DiskReadBytes: 10},
+			Periods: []api.ProjectUsagePeriod{
+				{Start: from, ProjectUsageCounters: api.ProjectUsageCounters{CPUSeconds: 4, DiskReadBytes: 10}},
+			},
+		},
+	}, daily.Instances)
+}
@@ -158,6 +158,14 @@ var instanceBackupExportCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: instanceBackupExportGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanManageBackups, "name")},
 }
 
+var instanceReplicationCmd = APIEndpoint{
+	Name: "instanceReplication",
+	Path: "instances/{name}/replication",
+
+	Get:  APIEndpointAction{Handler: instanceReplicationGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Post: APIEndpointAction{Handler: instanceReplicationPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
 var instanceAccessCmd = APIEndpoint{
 	Name: "access",
 	Path: "instances/{name}/access",
@@ -453,6 +453,10 @@ func instancesGet(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	for _, inst := range resultFullList {
+		instanceStripWriteOnly(inst)
+	}
+
 	if recursion == 0 {
 		resultList := make([]string, 0, len(resultFullList))
 		for i := range resultFullList {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"slices"
@@ -25,6 +26,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	localMigration "github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
@@ -316,12 +318,23 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 
 	instanceOnly := req.Source.InstanceOnly
 
+	// Devices missing their secrets, added once received from the source.
+	pendingDevices := map[string]map[string]string{}
+	var srcInfo *localMigration.Info
+
 	if inst == nil {
 		_, err := storagePools.LoadByName(s, storagePool)
 		if err != nil {
 			return response.InternalError(err)
 		}
 
+		// Device secrets aren't returned through the API and so are missing when the migration is driven by a
+		// client, hold back those devices until the source instance config is received.
+		for _, devName := range deviceConfig.MissingWriteOnly(args.Devices.CloneNative()) {
+			pendingDevices[devName] = args.Devices[devName].Clone()
+			delete(args.Devices, devName)
+		}
+
 		// Create the instance DB record for main instance.
 		// Note: At this stage we do not yet know if snapshots are going to be received and so we cannot
 		// create their DB records. This will be done if needed in the migrationSink.do() function called
@@ -367,6 +380,12 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 		StoragePool:           storagePool,
 	}
 
+	if len(pendingDevices) > 0 {
+		migrationArgs.InfoReceived = func(info *localMigration.Info) {
+			srcInfo = info
+		}
+	}
+
 	// Check if the pool is changing at all.
 	if r != nil && isClusterNotification(r) && inst != nil {
 		_, currentPool, _ := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
@@ -457,6 +476,13 @@ func createFromMigration(ctx context.Context, s *state.State, r *http.Request, p
 			}
 		}
 
+		if len(pendingDevices) > 0 {
+			err = instanceMigrationAddWriteOnlyDevices(s, inst, pendingDevices, srcInfo)
+			if err != nil {
+				return err
+			}
+		}
+
 		runReverter.Success()
 
 		return instanceCreateFinish(s, req, args, op)
@@ -577,6 +603,9 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 		req.Devices[key] = value
 	}
 
+	// Keep the device secrets of the source, which aren't returned through the API.
+	deviceConfig.KeepWriteOnly(req.Devices, sourceDevices.CloneNative())
+
 	if req.Stateful {
 		sourceName, _, _ := api.GetParentAndSnapshotName(source.Name())
 		if sourceName != req.Name {
@@ -1212,6 +1241,24 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		if !clusterNotification {
+			// Run the instance validation scriptlet, allowing it to adjust the request before the limits are checked.
+			validationReq := apiScriptlet.InstanceValidation{
+				Reason:  apiScriptlet.InstanceValidationReasonCreate,
+				Project: targetProjectName,
+				Name:    req.Name,
+				Type:    string(req.Type),
+				Config:  req.Config,
+				Devices: req.Devices,
+			}
+
+			err = instanceValidationRun(s, &validationReq, profiles)
+			if err != nil {
+				return err
+			}
+
+			req.Config = validationReq.Config
+			req.Devices = validationReq.Devices
+
 			// Check that the project's limits are not violated. Note this check is performed after
 			// automatically generated config values (such as ones from an InstanceType) have been set.
 			err = project.AllowInstanceCreation(tx, targetProjectName, req)
@@ -1466,6 +1513,45 @@ func clusterCopyContainerInternal(ctx context.Context, s *state.State, r *http.R
 	return createFromMigration(ctx, s, nil, projectName, profiles, req)
 }
 
+// instanceMigrationAddWriteOnlyDevices adds the devices held back during migration for missing their secrets, taking
+// the secrets from the devices of the source instance.
+func instanceMigrationAddWriteOnlyDevices(s *state.State, inst instance.Instance, pendingDevices map[string]map[string]string, srcInfo *localMigration.Info) error {
+	if srcInfo != nil && srcInfo.Config != nil && srcInfo.Config.Container != nil {
+		deviceConfig.KeepWriteOnly(pendingDevices, srcInfo.Config.Container.Devices)
+	}
+
+	missing := deviceConfig.MissingWriteOnly(pendingDevices)
+	if len(missing) > 0 {
+		return fmt.Errorf("Migration source didn't provide the secrets of devices %s", strings.Join(missing, ", "))
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name())
+	if err != nil {
+		return fmt.Errorf("Failed to load instance: %w", err)
+	}
+
+	devices := inst.LocalDevices().CloneNative()
+	maps.Copy(devices, pendingDevices)
+
+	args := db.InstanceArgs{
+		Architecture: inst.Architecture(),
+		Config:       inst.LocalConfig(),
+		Description:  inst.Description(),
+		Devices:      deviceConfig.NewDevices(devices),
+		Ephemeral:    inst.IsEphemeral(),
+		Profiles:     inst.Profiles(),
+		Project:      inst.Project().Name,
+		ExpiryDate:   inst.ExpiryDate(),
+	}
+
+	err = inst.Update(args, false)
+	if err != nil {
+		return fmt.Errorf("Failed adding devices %s: %w", strings.Join(slices.Sorted(maps.Keys(pendingDevices)), ", "), err)
+	}
+
+	return nil
+}
+
 func instanceCreateFinish(s *state.State, req *api.InstancesPost, args db.InstanceArgs, op *operations.Operation) error {
 	if req == nil || !req.Start {
 		return nil
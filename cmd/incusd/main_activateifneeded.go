@@ -138,9 +138,16 @@ func (c *cmdActivateifneeded) run(_ *cobra.Command, _ []string) error {
 			_, err := incus.ConnectIncusUnix("", nil)
 			return err
 		}
+
+		// Check for scheduled instance backups
+		if config["backups.schedule"] != "" {
+			logger.Debugf("Daemon has scheduled instance backups, activating...")
+			_, err := incus.ConnectIncusUnix("", nil)
+			return err
+		}
 	}
 
-	// Check for scheduled volume snapshots
+	// Check for scheduled volume snapshots and backups
 	var volumes []db.StorageVolumeArgs
 	err = d.State().DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		volumes, err = tx.GetStoragePoolVolumesWithType(ctx, db.StoragePoolVolumeTypeCustom, false)
@@ -160,6 +167,12 @@ func (c *cmdActivateifneeded) run(_ *cobra.Command, _ []string) error {
 			_, err := incus.ConnectIncusUnix("", nil)
 			return err
 		}
+
+		if vol.Config["backups.schedule"] != "" {
+			logger.Debugf("Daemon has scheduled volume backups, activating...")
+			_, err := incus.ConnectIncusUnix("", nil)
+			return err
+		}
 	}
 
 	logger.Debugf("No need to start the daemon now")
@@ -188,6 +188,7 @@ type migrationSink struct {
 	clusterMoveSourceName string
 	refresh               bool
 	refreshExcludeOlder   bool
+	infoReceived          func(info *localMigration.Info)
 }
 
 // MigrationSinkArgs arguments to configure migration sink.
@@ -207,6 +208,7 @@ type migrationSinkArgs struct {
 	RefreshExcludeOlder   bool
 	ClusterMoveSourceName string
 	Snapshots             []*migration.Snapshot
+	InfoReceived          func(info *localMigration.Info)
 
 	// Storage specific fields
 	StoragePool string
@@ -173,6 +173,7 @@ func newMigrationSink(args *migrationSinkArgs) (*migrationSink, error) {
 		push:                  args.Push,
 		refresh:               args.Refresh,
 		refreshExcludeOlder:   args.RefreshExcludeOlder,
+		infoReceived:          args.InfoReceived,
 	}
 
 	secretNames := []string{api.SecretNameControl, api.SecretNameFilesystem}
@@ -283,6 +284,7 @@ func (c *migrationSink) do(instOp *operationlock.InstanceOperation) error {
 		InstanceOperation:   instOp,
 		Refresh:             c.refresh,
 		RefreshExcludeOlder: c.refreshExcludeOlder,
+		InfoReceived:        c.infoReceived,
 	})
 	if err != nil {
 		l.Error("Failed migration on target", logger.Ctx{"err": err})
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -20,6 +23,8 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
@@ -302,6 +307,11 @@ func networkAddressSetsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("The network address set already exists"))
 	}
 
+	err = networkAddressSetCheckSelectorAccess(s, r, projectName, req.Config)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	err = addressset.Create(s, projectName, &req)
 	if err != nil {
 		return response.SmartError(err)
@@ -561,6 +571,11 @@ func networkAddressSetPut(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	err = networkAddressSetCheckSelectorAccess(s, r, projectName, req.Config)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
 
 	err = netAddrSet.Update(&req, clientType)
@@ -648,3 +663,128 @@ func networkAddressSetPost(d *Daemon, r *http.Request) response.Response {
 
 	return response.SyncResponseLocation(true, nil, lc.Source)
 }
+
+// networkAddressSetCheckSelectorAccess checks that the requestor can view the project whose instances are selected
+// by the address set, as their addresses get exposed through it.
+func networkAddressSetCheckSelectorAccess(s *state.State, r *http.Request, projectName string, config map[string]string) error {
+	selectorProjectName := config["selector.project"]
+	if selectorProjectName == "" || selectorProjectName == projectName {
+		return nil
+	}
+
+	err := s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(selectorProjectName), auth.EntitlementCanView)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// networkAddressSetsSelectorsDelay is how long instance and profile changes are collected before refreshing the
+// address sets selecting instances, so that bursts of changes only cause a single refresh.
+const networkAddressSetsSelectorsDelay = 2 * time.Second
+
+// networkAddressSetsSelectorsRefresh returns an internal event handler refreshing the address sets selecting
+// instances whenever an instance or profile changes on this member.
+// Changes are grouped by project and only the address sets possibly affected by the changed instances are refreshed.
+func networkAddressSetsSelectorsRefresh(d *Daemon) func(event api.Event) {
+	actions := []string{
+		api.EventLifecycleInstanceStarted,
+		api.EventLifecycleInstanceStopped,
+		api.EventLifecycleInstanceShutdown,
+		api.EventLifecycleInstanceRestarted,
+		api.EventLifecycleInstanceUpdated,
+		api.EventLifecycleInstanceRenamed,
+		api.EventLifecycleInstanceMigrated,
+		api.EventLifecycleInstanceDeleted,
+		api.EventLifecycleProfileUpdated,
+	}
+
+	var mu sync.Mutex
+	var timer *time.Timer
+
+	// Changed instance names by project, a nil list meaning that all the project's instances may have changed.
+	pending := map[string][]string{}
+
+	refresh := func() {
+		mu.Lock()
+		changes := pending
+		pending = map[string][]string{}
+		timer = nil
+		mu.Unlock()
+
+		for projectName, instanceNames := range changes {
+			err := addressset.RefreshSelectors(d.State(), clusterRequest.ClientTypeNormal, projectName, instanceNames)
+			if err != nil {
+				logger.Warn("Failed refreshing network address sets", logger.Ctx{"project": projectName, "err": err})
+			}
+		}
+	}
+
+	return func(event api.Event) {
+		if event.Type != api.EventTypeLifecycle {
+			return
+		}
+
+		lifecycleEvent := api.EventLifecycle{}
+		err := json.Unmarshal(event.Metadata, &lifecycleEvent)
+		if err != nil || !slices.Contains(actions, lifecycleEvent.Action) {
+			return
+		}
+
+		projectName := lifecycleEvent.Project
+		if projectName == "" {
+			projectName = event.Project
+		}
+
+		if projectName == "" {
+			projectName = api.ProjectDefaultName
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		instanceNames, found := pending[projectName]
+		if lifecycleEvent.Action == api.EventLifecycleProfileUpdated || lifecycleEvent.Name == "" {
+			// Profile changes may affect any instance of the project.
+			instanceNames = nil
+		} else if !found || instanceNames != nil {
+			instanceNames = append(instanceNames, lifecycleEvent.Name)
+
+			oldName, ok := lifecycleEvent.Context["old_name"].(string)
+			if ok && oldName != "" {
+				instanceNames = append(instanceNames, oldName)
+			}
+		}
+
+		pending[projectName] = instanceNames
+
+		if timer == nil {
+			timer = time.AfterFunc(networkAddressSetsSelectorsDelay, refresh)
+		}
+	}
+}
+
+// networkAddressSetsSelectorsTask periodically refreshes the address sets selecting instances, to follow the
+// address changes which don't come with a lifecycle event (such as DHCP leases).
+func networkAddressSetsSelectorsTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		// Only update OVN and notify the other members from the leader when clustered.
+		clientType := clusterRequest.ClientTypeNormal
+		if s.ServerClustered {
+			leader, err := s.Cluster.LeaderAddress()
+			if err != nil || leader != s.LocalConfig.ClusterAddress() {
+				clientType = clusterRequest.ClientTypeNotifier
+			}
+		}
+
+		err := addressset.RefreshSelectors(s, clientType, "", nil)
+		if err != nil {
+			logger.Warn("Failed refreshing network address sets", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(time.Minute)
+}
@@ -855,11 +855,78 @@ func networkIntegrationPost(d *Daemon, r *http.Request) response.Response {
 
 // networkIntegrationValidate validates the configuration keys/values for network integration.
 func networkIntegrationValidate(integrationType string, inUse bool, oldConfig map[string]string, config map[string]string) error {
-	if integrationType != "ovn" {
+	var configKeys map[string]func(value string) error
+
+	// Keys which can't be changed while the integration is in use.
+	var fixedKeys []string
+
+	switch integrationType {
+	case "ovn":
+		configKeys = networkIntegrationValidateOVN()
+		fixedKeys = []string{"ovn.transit.pattern"}
+	case "bgp":
+		configKeys = networkIntegrationValidateBGP()
+		fixedKeys = []string{"bgp.peer.address", "bgp.peer.asn", "bgp.peer.password", "bgp.peer.holdtime"}
+	case "vlan":
+		configKeys = networkIntegrationValidateVLAN()
+		fixedKeys = []string{"vlan.id", "vlan.ipv4.gateway", "vlan.ipv6.gateway", "vlan.routes"}
+	default:
 		return fmt.Errorf("Invalid integration type %q", integrationType)
 	}
 
-	configKeys := map[string]func(value string) error{
+	for k, v := range config {
+		// User keys are free for all.
+
+		// gendoc:generate(entity=network_integration, group=common, key=user.*)
+		// User keys can be used in search.
+		// ---
+		//  type: string
+		//  shortdesc: Free form user key/value storage
+		if strings.HasPrefix(k, "user.") {
+			continue
+		}
+
+		validator, ok := configKeys[k]
+		if !ok {
+			return fmt.Errorf("Invalid network integration configuration key %q", k)
+		}
+
+		err := validator(v)
+		if err != nil {
+			return fmt.Errorf("Invalid network integration configuration key %q value", k)
+		}
+	}
+
+	switch integrationType {
+	case "bgp":
+		if config["bgp.peer.address"] == "" || config["bgp.peer.asn"] == "" {
+			return errors.New("The BGP peer address and ASN are required")
+		}
+
+	case "vlan":
+		if config["vlan.id"] == "" {
+			return errors.New("The VLAN ID is required")
+		}
+
+		if config["vlan.ipv4.gateway"] == "" && config["vlan.ipv6.gateway"] == "" {
+			return errors.New("At least one of the IPv4 or IPv6 gateway addresses is required")
+		}
+	}
+
+	if oldConfig != nil && inUse {
+		for _, k := range fixedKeys {
+			if oldConfig[k] != config[k] {
+				return fmt.Errorf("The %q configuration key cannot be changed while the integration is in use", k)
+			}
+		}
+	}
+
+	return nil
+}
+
+// networkIntegrationValidateOVN returns the validation rules for OVN interconnection integrations.
+func networkIntegrationValidateOVN() map[string]func(value string) error {
+	return map[string]func(value string) error{
 		// gendoc:generate(entity=network_integration, group=ovn, key=ovn.northbound_connection)
 		//
 		// ---
@@ -912,33 +979,80 @@ func networkIntegrationValidate(integrationType string, inUse bool, oldConfig ma
 		//  shortdesc: Template for the transit switch name
 		"ovn.transit.pattern": validate.IsAny,
 	}
+}
 
-	for k, v := range config {
-		// User keys are free for all.
-
-		// gendoc:generate(entity=network_integration, group=common, key=user.*)
-		// User keys can be used in search.
+// networkIntegrationValidateBGP returns the validation rules for BGP router integrations.
+func networkIntegrationValidateBGP() map[string]func(value string) error {
+	return map[string]func(value string) error{
+		// gendoc:generate(entity=network_integration, group=bgp, key=bgp.peer.address)
+		//
 		// ---
 		//  type: string
-		//  shortdesc: Free form user key/value storage
-		if strings.HasPrefix(k, "user.") {
-			continue
-		}
+		//  scope: global
+		//  shortdesc: Address of the external BGP router
+		"bgp.peer.address": validate.Optional(validate.IsNetworkAddress),
 
-		validator, ok := configKeys[k]
-		if !ok {
-			return fmt.Errorf("Invalid network integration configuration key %q", k)
-		}
+		// gendoc:generate(entity=network_integration, group=bgp, key=bgp.peer.asn)
+		//
+		// ---
+		//  type: integer
+		//  scope: global
+		//  shortdesc: ASN of the external BGP router
+		"bgp.peer.asn": validate.Optional(validate.IsInRange(1, 4294967294)),
 
-		err := validator(v)
-		if err != nil {
-			return fmt.Errorf("Invalid network integration configuration key %q value", k)
-		}
-	}
+		// gendoc:generate(entity=network_integration, group=bgp, key=bgp.peer.password)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: (no password)
+		//  shortdesc: Password for the BGP session
+		"bgp.peer.password": validate.Optional(validate.IsAny),
 
-	if oldConfig != nil && oldConfig["ovn.transit.pattern"] != config["ovn.transit.pattern"] && inUse {
-		return errors.New("The OVN transit switch pattern cannot be changed while the integration is in use")
+		// gendoc:generate(entity=network_integration, group=bgp, key=bgp.peer.holdtime)
+		//
+		// ---
+		//  type: integer
+		//  scope: global
+		//  defaultdesc: `180`
+		//  shortdesc: Hold time for the BGP session (in seconds)
+		"bgp.peer.holdtime": validate.Optional(validate.IsInRange(9, 65535)),
 	}
+}
 
-	return nil
+// networkIntegrationValidateVLAN returns the validation rules for bridged VLAN gateway integrations.
+func networkIntegrationValidateVLAN() map[string]func(value string) error {
+	return map[string]func(value string) error{
+		// gendoc:generate(entity=network_integration, group=vlan, key=vlan.id)
+		//
+		// ---
+		//  type: integer
+		//  scope: global
+		//  shortdesc: VLAN ID of the gateway network on the uplink
+		"vlan.id": validate.Optional(validate.IsNetworkVLAN),
+
+		// gendoc:generate(entity=network_integration, group=vlan, key=vlan.ipv4.gateway)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: IPv4 address of the gateway and subnet of the VLAN (CIDR)
+		"vlan.ipv4.gateway": validate.Optional(validate.IsNetworkAddressCIDRV4),
+
+		// gendoc:generate(entity=network_integration, group=vlan, key=vlan.ipv6.gateway)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: IPv6 address of the gateway and subnet of the VLAN (CIDR)
+		"vlan.ipv6.gateway": validate.Optional(validate.IsNetworkAddressCIDRV6),
+
+		// gendoc:generate(entity=network_integration, group=vlan, key=vlan.routes)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Comma-separated list of subnets reachable through the gateway
+		"vlan.routes": validate.Optional(validate.IsListOf(validate.IsNetwork)),
+	}
 }
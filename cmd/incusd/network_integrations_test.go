@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkIntegrationValidate(t *testing.T) {
+	// Unknown types and keys are rejected.
+	assert.Error(t, networkIntegrationValidate("foo", false, nil, map[string]string{}))
+	assert.Error(t, networkIntegrationValidate("bgp", false, nil, map[string]string{"bgp.peer.address": "192.0.2.1", "bgp.peer.asn": "65000", "vlan.id": "100"}))
+
+	// BGP integrations require a peer.
+	assert.NoError(t, networkIntegrationValidate("bgp", false, nil, map[string]string{"bgp.peer.address": "192.0.2.1", "bgp.peer.asn": "65000", "user.foo": "bar"}))
+	assert.Error(t, networkIntegrationValidate("bgp", false, nil, map[string]string{"bgp.peer.address": "192.0.2.1"}))
+	assert.Error(t, networkIntegrationValidate("bgp", false, nil, map[string]string{"bgp.peer.address": "192.0.2.1", "bgp.peer.asn": "0"}))
+
+	// VLAN integrations require a VLAN and a gateway.
+	assert.NoError(t, networkIntegrationValidate("vlan", false, nil, map[string]string{"vlan.id": "100", "vlan.ipv4.gateway": "198.51.100.1/24", "vlan.routes": "203.0.113.0/24"}))
+	assert.Error(t, networkIntegrationValidate("vlan", false, nil, map[string]string{"vlan.id": "100"}))
+	assert.Error(t, networkIntegrationValidate("vlan", false, nil, map[string]string{"vlan.ipv4.gateway": "198.51.100.1/24"}))
+	assert.Error(t, networkIntegrationValidate("vlan", false, nil, map[string]string{"vlan.id": "100", "vlan.ipv4.gateway": "2001:db8::1/64"}))
+
+	// The peering configuration can't be changed while in use.
+	oldConfig := map[string]string{"vlan.id": "100", "vlan.ipv4.gateway": "198.51.100.1/24"}
+	newConfig := map[string]string{"vlan.id": "200", "vlan.ipv4.gateway": "198.51.100.1/24"}
+	assert.NoError(t, networkIntegrationValidate("vlan", false, oldConfig, newConfig))
+	assert.Error(t, networkIntegrationValidate("vlan", true, oldConfig, newConfig))
+	assert.NoError(t, networkIntegrationValidate("vlan", true, oldConfig, map[string]string{"vlan.id": "100", "vlan.ipv4.gateway": "198.51.100.1/24", "user.foo": "bar"}))
+}
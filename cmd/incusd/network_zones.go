@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/task"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
@@ -231,6 +234,7 @@ func networkZonesGet(d *Daemon, r *http.Request) response.Response {
 
 			netzoneInfo := netzone.Info()
 			netzoneInfo.UsedBy, _ = netzone.UsedBy() // Ignore errors in UsedBy, will return nil.
+			netzoneInfo.DNSSEC, _ = netzone.DNSSEC() // Ignore errors in DNSSEC, will return nil.
 			netzoneInfo.Project = projectName
 
 			if clauses != nil && len(clauses.Clauses) > 0 {
@@ -453,6 +457,11 @@ func networkZoneGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	info.DNSSEC, err = netzone.DNSSEC()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	return response.SyncResponseETag(true, info, netzone.Etag())
 }
 
@@ -580,3 +589,124 @@ func networkZonePut(d *Daemon, r *http.Request) response.Response {
 
 	return response.EmptySyncResponse
 }
+
+// networkZonesNotifyTask sends DNS NOTIFY messages to the peers of network zones whose records changed,
+// for example following instance changes.
+func networkZonesNotifyTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		// Only notify from the leader when clustered.
+		if s.ServerClustered {
+			leader, err := s.Cluster.LeaderAddress()
+			if err != nil || leader != s.LocalConfig.ClusterAddress() {
+				return
+			}
+		}
+
+		var zoneNames []string
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			zones, err := dbCluster.GetNetworkZones(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			for _, z := range zones {
+				config, err := dbCluster.GetNetworkZoneConfig(ctx, tx.Tx(), z.ID)
+				if err != nil {
+					return err
+				}
+
+				// Only consider zones with peers to notify.
+				for k, v := range config {
+					if strings.HasPrefix(k, "peers.") && strings.HasSuffix(k, ".address") && v != "" {
+						zoneNames = append(zoneNames, z.Name)
+						break
+					}
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed loading network zones for DNS NOTIFY", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, zoneName := range zoneNames {
+			z, err := zone.LoadByName(s, zoneName)
+			if err != nil {
+				logger.Warn("Failed loading network zone for DNS NOTIFY", logger.Ctx{"zone": zoneName, "err": err})
+				continue
+			}
+
+			content, err := z.Content()
+			if err != nil {
+				logger.Warn("Failed rendering network zone for DNS NOTIFY", logger.Ctx{"zone": zoneName, "err": err})
+				continue
+			}
+
+			err = s.DNS.NotifyIfChanged(*z.Info(), content.String())
+			if err != nil {
+				logger.Warn("Failed sending DNS NOTIFY", logger.Ctx{"zone": zoneName, "err": err})
+			}
+		}
+	}
+
+	return f, task.Every(time.Minute)
+}
+
+// networkZonesDNSSECRolloverTask generates and rolls over the DNSSEC keys of network zones.
+func networkZonesDNSSECRolloverTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		// Only manage the keys from the leader when clustered.
+		if s.ServerClustered {
+			leader, err := s.Cluster.LeaderAddress()
+			if err != nil || leader != s.LocalConfig.ClusterAddress() {
+				return
+			}
+		}
+
+		var zoneNames []string
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			zones, err := dbCluster.GetNetworkZones(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			for _, z := range zones {
+				config, err := dbCluster.GetNetworkZoneConfig(ctx, tx.Tx(), z.ID)
+				if err != nil {
+					return err
+				}
+
+				if util.IsTrue(config["dnssec.enabled"]) {
+					zoneNames = append(zoneNames, z.Name)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed loading network zones for DNSSEC key rollover", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, zoneName := range zoneNames {
+			z, err := zone.LoadByName(s, zoneName)
+			if err != nil {
+				logger.Warn("Failed loading network zone for DNSSEC key rollover", logger.Ctx{"zone": zoneName, "err": err})
+				continue
+			}
+
+			err = z.RolloverDNSSECKeys()
+			if err != nil {
+				logger.Warn("Failed rolling over DNSSEC keys", logger.Ctx{"zone": zoneName, "err": err})
+			}
+		}
+	}
+
+	return f, task.Every(time.Hour)
+}
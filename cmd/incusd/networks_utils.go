@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"slices"
 
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/network"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
@@ -38,3 +42,33 @@ func networkUpdateOVNChassis(s *state.State, heartbeatData *cluster.APIHeartbeat
 	networkOVNChassis = &runChassis
 	return nil
 }
+
+// networkHandleHeartbeat gets called on heartbeats when the cluster members have changed, letting the
+// networks of the default project update their peering with the other members.
+func networkHandleHeartbeat(s *state.State, heartbeatData *cluster.APIHeartbeat) error {
+	var networkNames []string
+
+	err := s.DB.Cluster.Transaction(s.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		networkNames, err = tx.GetCreatedNetworkNamesByProject(ctx, api.ProjectDefaultName)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to load networks: %w", err)
+	}
+
+	for _, networkName := range networkNames {
+		n, err := network.LoadByName(s, api.ProjectDefaultName, networkName)
+		if err != nil {
+			return fmt.Errorf("Failed to load network %q: %w", networkName, err)
+		}
+
+		err = n.HandleHeartbeat(heartbeatData)
+		if err != nil {
+			return fmt.Errorf("Failed handling heartbeat for network %q: %w", networkName, err)
+		}
+	}
+
+	return nil
+}
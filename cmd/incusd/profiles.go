@@ -250,6 +250,7 @@ func profilesGet(d *Daemon, r *http.Request) response.Response {
 					}
 				}
 
+				apiProfile.Devices = deviceConfig.StripWriteOnly(apiProfile.Devices)
 				fullResults = append(fullResults, *apiProfile)
 				linkResults = append(linkResults, apiProfile.URL(version.APIVersion, profile.Project).String())
 			}
@@ -501,6 +502,8 @@ func profileGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	etag := []any{resp.Config, resp.Description, resp.Devices}
+	resp.Devices = deviceConfig.StripWriteOnly(resp.Devices)
+
 	return response.SyncResponseETag(true, resp, etag)
 }
 
@@ -596,6 +599,9 @@ func profilePut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Keep the secrets which aren't returned through the API.
+	deviceConfig.KeepWriteOnly(req.Devices, profile.Devices)
+
 	err = doProfileUpdate(r.Context(), s, *p, name, profile, req)
 
 	if err == nil && !isClusterNotification(r) {
@@ -740,6 +746,8 @@ func profilePatch(d *Daemon, r *http.Request) response.Response {
 				req.Devices[k] = v
 			}
 		}
+
+		deviceConfig.KeepWriteOnly(req.Devices, profile.Devices)
 	}
 
 	requestor := request.CreateRequestor(r)
@@ -14,6 +14,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
+	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
 )
 
 func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profileName string, profile *api.Profile, req api.ProfilePut) error {
@@ -86,6 +87,33 @@ func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profile
 		}
 	}
 
+	// Run the instance validation scriptlet against all instances using the profile with the new profile applied.
+	for _, inst := range insts {
+		profiles := make([]api.Profile, 0, len(inst.Profiles))
+		for _, instProfile := range inst.Profiles {
+			if instProfile.Name == profileName {
+				instProfile.Config = req.Config
+				instProfile.Devices = req.Devices
+			}
+
+			profiles = append(profiles, instProfile)
+		}
+
+		validationReq := apiScriptlet.InstanceValidation{
+			Reason:  apiScriptlet.InstanceValidationReasonProfile,
+			Project: inst.Project,
+			Name:    inst.Name,
+			Type:    inst.Type.String(),
+			Config:  inst.Config,
+			Devices: inst.Devices.CloneNative(),
+		}
+
+		err = instanceValidationRun(s, &validationReq, profiles)
+		if err != nil {
+			return fmt.Errorf("Instance %q in project %q: %w", inst.Name, inst.Project, err)
+		}
+	}
+
 	// Update the database.
 	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		devices, err := cluster.APIToDevices(req.Devices)
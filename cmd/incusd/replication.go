@@ -0,0 +1,893 @@
+package main
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	incus "github.com/lxc/incus/v6/client"
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/internal/server/task"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/osarch"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// replicationRunning tracks the instances and custom volumes being replicated, to avoid concurrent replications.
+var replicationRunning = sync.Map{}
+
+// replicationInstanceKey returns the key tracking the replication of an instance.
+func replicationInstanceKey(instID int) string {
+	return fmt.Sprintf("instance/%d", instID)
+}
+
+// replicationVolumeKey returns the key tracking the replication of a custom volume.
+func replicationVolumeKey(volID int64) string {
+	return fmt.Sprintf("volume/%d", volID)
+}
+
+// replicationSnapshotName returns the name of a replication snapshot taken at the given time.
+func replicationSnapshotName(t time.Time) string {
+	return internalInstance.ReplicationSnapshotPrefix + t.UTC().Format("20060102-150405")
+}
+
+// replicationRetention returns the number of replication snapshots to keep.
+func replicationRetention(config map[string]string) int {
+	retention, err := strconv.Atoi(config["replication.snapshots.retention"])
+	if err != nil || retention < 1 {
+		return 1
+	}
+
+	return retention
+}
+
+// replicationInfo returns the replication state described by the config of an instance or custom volume.
+func replicationInfo(config map[string]string, poolName string, projectName string) (*api.Replication, error) {
+	if config["replication.target"] == "" {
+		return nil, api.StatusErrorf(http.StatusNotFound, "Replication isn't configured")
+	}
+
+	info := &api.Replication{
+		Target:   config["replication.target"],
+		Pool:     poolName,
+		Project:  projectName,
+		Schedule: config["replication.schedule"],
+		Lag:      -1,
+	}
+
+	if config["replication.target.pool"] != "" {
+		info.Pool = config["replication.target.pool"]
+	}
+
+	if config["replication.target.project"] != "" {
+		info.Project = config["replication.target.project"]
+	}
+
+	lastSuccess, err := time.Parse(time.RFC3339, config["volatile.replication.last_success"])
+	if err == nil {
+		info.LastSuccess = lastSuccess
+		info.Lag = int64(time.Since(lastSuccess).Seconds())
+	}
+
+	return info, nil
+}
+
+// replicationConnect connects to the replication target named in the config,
+// authenticating with the client certificate defined for that target.
+func replicationConnect(s *state.State, config map[string]string, p *api.Project) (incus.InstanceServer, error) {
+	targetName := config["replication.target"]
+
+	// Check the project restrictions as they may have changed since the target was set.
+	err := project.AllowReplicationTarget(p, targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	address, fingerprint, clientCert, clientKey := s.GlobalConfig.ReplicationTarget(targetName)
+	if address == "" {
+		return nil, fmt.Errorf("Replication target %q isn't defined", targetName)
+	}
+
+	if clientCert == "" || clientKey == "" {
+		return nil, fmt.Errorf("Replication target %q is missing a client certificate", targetName)
+	}
+
+	targetURL, err := internalInstance.GetReplicationTarget(address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = replicationCheckAddress(targetName, targetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse replicating to this server or to another member of its cluster.
+	cert, err := localtls.GetRemoteCertificate(targetURL, version.UserAgent)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting certificate of replication target %q: %w", targetName, err)
+	}
+
+	certFingerprint := localtls.CertFingerprint(cert)
+	if certFingerprint == s.Endpoints.NetworkCert().Fingerprint() {
+		return nil, fmt.Errorf("Replication target %q is this server or a member of its cluster", targetName)
+	}
+
+	// Pin the certificate of the target server if a fingerprint is configured.
+	var serverCert string
+	if fingerprint != "" {
+		if certFingerprint != fingerprint {
+			return nil, fmt.Errorf("Certificate fingerprint mismatch for replication target %q", targetName)
+		}
+
+		serverCert = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+
+	args := &incus.ConnectionArgs{
+		TLSServerCert: serverCert,
+		TLSClientCert: clientCert,
+		TLSClientKey:  clientKey,
+		UserAgent:     version.UserAgent,
+		Proxy:         s.Proxy,
+		SkipGetEvents: true,
+	}
+
+	client, err := incus.ConnectIncus(targetURL, args)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to replication target %q: %w", targetName, err)
+	}
+
+	projectName := p.Name
+	if config["replication.target.project"] != "" {
+		projectName = config["replication.target.project"]
+	}
+
+	return client.UseProject(projectName), nil
+}
+
+// replicationCheckAddress refuses replication targets resolving to an address of this server.
+func replicationCheckAddress(targetName string, targetURL string) error {
+	uri, err := url.Parse(targetURL)
+	if err != nil {
+		return err
+	}
+
+	ips, err := net.LookupIP(uri.Hostname())
+	if err != nil {
+		return fmt.Errorf("Failed resolving address of replication target %q: %w", targetName, err)
+	}
+
+	localAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("Failed getting local addresses: %w", err)
+	}
+
+	for _, ip := range ips {
+		if replicationIsLocalAddress(ip, localAddrs) {
+			return fmt.Errorf("Replication target %q resolves to an address of this server", targetName)
+		}
+	}
+
+	return nil
+}
+
+// replicationIsLocalAddress returns whether the IP address is a loopback, unspecified or local interface address.
+func replicationIsLocalAddress(ip net.IP, localAddrs []net.Addr) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() {
+		return true
+	}
+
+	for _, addr := range localAddrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replicationReverseConfig returns the replication config to apply to the replica to replicate it back to this server
+// through the named replication target defined on the target server.
+func replicationReverseConfig(config map[string]string, targetName string, poolName string, projectName string) map[string]string {
+	reverse := map[string]string{
+		"replication.target":         targetName,
+		"replication.target.pool":    poolName,
+		"replication.target.project": projectName,
+	}
+
+	for _, key := range []string{"replication.schedule", "replication.snapshots.retention"} {
+		if config[key] != "" {
+			reverse[key] = config[key]
+		}
+	}
+
+	return reverse
+}
+
+// replicationTargetOperation returns the push target matching the migration operation created on the target server.
+func replicationTargetOperation(target incus.InstanceServer, targetOp incus.Operation) (string, map[string]string, string, error) {
+	info, err := target.GetConnectionInfo()
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	opAPI := targetOp.Get()
+
+	secrets := map[string]string{}
+	for k, v := range opAPI.Metadata {
+		val, ok := v.(string)
+		if ok {
+			secrets[k] = val
+		}
+	}
+
+	return fmt.Sprintf("%s/1.0/operations/%s", info.URL, url.PathEscape(opAPI.ID)), secrets, info.Certificate, nil
+}
+
+// replicateInstance snapshots the instance, prunes the replication snapshots over the retention count and
+// refreshes the replica on the target server.
+func replicateInstance(s *state.State, inst instance.Instance, op *operations.Operation) error {
+	key := replicationInstanceKey(inst.ID())
+	_, loaded := replicationRunning.LoadOrStore(key, struct{}{})
+	if loaded {
+		return api.StatusErrorf(http.StatusConflict, "Replication of instance %q is already running", inst.Name())
+	}
+
+	defer replicationRunning.Delete(key)
+
+	config := inst.ExpandedConfig()
+	if config["replication.target"] == "" {
+		return errors.New("Replication isn't configured")
+	}
+
+	instProject := inst.Project()
+	target, err := replicationConnect(s, config, &instProject)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	err = inst.Snapshot(replicationSnapshotName(now), time.Time{}, false)
+	if err != nil {
+		return fmt.Errorf("Failed creating replication snapshot: %w", err)
+	}
+
+	snapshots, err := inst.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	snapshotNames := make([]string, 0, len(snapshots))
+	snapshotsByName := make(map[string]instance.Instance, len(snapshots))
+	for _, snap := range snapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(snap.Name())
+		snapshotNames = append(snapshotNames, snapName)
+		snapshotsByName[snapName] = snap
+	}
+
+	for _, snapName := range internalInstance.ReplicationSnapshotsToDelete(snapshotNames, replicationRetention(config)) {
+		err = snapshotsByName[snapName].Delete(true)
+		if err != nil {
+			return fmt.Errorf("Failed deleting replication snapshot %q: %w", snapName, err)
+		}
+	}
+
+	// Send the instance to the target, pointing its root disk to the target pool.
+	poolName, err := inst.StoragePool()
+	if err != nil {
+		return err
+	}
+
+	if config["replication.target.pool"] != "" {
+		poolName = config["replication.target.pool"]
+	}
+
+	instConfig := map[string]string{}
+	for k, v := range inst.LocalConfig() {
+		if internalInstance.IsReplicationConfigKey(k) || !internalInstance.InstanceIncludeWhenCopying(k, true) {
+			continue
+		}
+
+		instConfig[k] = v
+	}
+
+	devices := inst.LocalDevices().CloneNative()
+	rootDevName, rootDev, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+	if err != nil {
+		return err
+	}
+
+	rootDev["pool"] = poolName
+	devices[rootDevName] = rootDev
+
+	architecture, err := osarch.ArchitectureName(inst.Architecture())
+	if err != nil {
+		return err
+	}
+
+	profiles := make([]string, 0, len(inst.Profiles()))
+	for _, profile := range inst.Profiles() {
+		profiles = append(profiles, profile.Name)
+	}
+
+	req := api.InstancesPost{
+		Name: inst.Name(),
+		InstancePut: api.InstancePut{
+			Architecture: architecture,
+			Config:       instConfig,
+			Devices:      devices,
+			Profiles:     profiles,
+			Description:  inst.Description(),
+			Ephemeral:    inst.IsEphemeral(),
+		},
+		Type: api.InstanceType(inst.Type().String()),
+		Source: api.InstanceSource{
+			Type:              "migration",
+			Mode:              "push",
+			Refresh:           true,
+			AllowInconsistent: true,
+			BaseImage:         config["volatile.base_image"],
+		},
+	}
+
+	targetOp, err := target.CreateInstance(req)
+	if err != nil {
+		return fmt.Errorf("Failed creating replica: %w", err)
+	}
+
+	opURL, secrets, certificate, err := replicationTargetOperation(target, targetOp)
+	if err != nil {
+		_ = targetOp.Cancel()
+		return err
+	}
+
+	ws, err := newMigrationSource(inst, false, false, true, "", "", &api.InstancePostTarget{Operation: opURL, Websockets: secrets, Certificate: certificate})
+	if err != nil {
+		_ = targetOp.Cancel()
+		return err
+	}
+
+	err = ws.do(op)
+	if err != nil {
+		_ = targetOp.Cancel()
+		return fmt.Errorf("Failed sending instance to replication target: %w", err)
+	}
+
+	err = targetOp.Wait()
+	if err != nil {
+		return fmt.Errorf("Failed refreshing replica: %w", err)
+	}
+
+	return inst.VolatileSet(map[string]string{"volatile.replication.last_success": now.UTC().Format(time.RFC3339)})
+}
+
+// failoverInstance replicates the stopped instance one last time and then reverses the direction of the
+// replication, the replica becoming the source replicated back to this server.
+func failoverInstance(s *state.State, inst instance.Instance, reverseTarget string, op *operations.Operation) error {
+	config := inst.LocalConfig()
+	if config["replication.target"] == "" {
+		return errors.New("Failover requires replication to be configured on the instance itself")
+	}
+
+	if inst.IsRunning() {
+		return errors.New("The instance must be stopped for failover")
+	}
+
+	err := replicateInstance(s, inst, op)
+	if err != nil {
+		return err
+	}
+
+	instProject := inst.Project()
+	target, err := replicationConnect(s, config, &instProject)
+	if err != nil {
+		return err
+	}
+
+	poolName, err := inst.StoragePool()
+	if err != nil {
+		return err
+	}
+
+	replica, etag, err := target.GetInstance(inst.Name())
+	if err != nil {
+		return fmt.Errorf("Failed getting replica: %w", err)
+	}
+
+	replicaPut := replica.Writable()
+	maps.Copy(replicaPut.Config, replicationReverseConfig(config, reverseTarget, poolName, inst.Project().Name))
+
+	targetOp, err := target.UpdateInstance(inst.Name(), replicaPut, etag)
+	if err != nil {
+		return fmt.Errorf("Failed promoting replica: %w", err)
+	}
+
+	err = targetOp.Wait()
+	if err != nil {
+		return fmt.Errorf("Failed promoting replica: %w", err)
+	}
+
+	// Stop replicating from this server.
+	newConfig := map[string]string{}
+	for k, v := range inst.LocalConfig() {
+		if internalInstance.IsReplicationConfigKey(k) {
+			continue
+		}
+
+		newConfig[k] = v
+	}
+
+	args := db.InstanceArgs{
+		Architecture: inst.Architecture(),
+		Config:       newConfig,
+		Description:  inst.Description(),
+		Devices:      inst.LocalDevices(),
+		Ephemeral:    inst.IsEphemeral(),
+		Profiles:     inst.Profiles(),
+		Project:      inst.Project().Name,
+		ExpiryDate:   inst.ExpiryDate(),
+	}
+
+	err = inst.Update(args, false)
+	if err != nil {
+		return fmt.Errorf("Failed removing replication configuration: %w", err)
+	}
+
+	return nil
+}
+
+// replicationLoadCustomVolume loads the custom volume along with its project.
+func replicationLoadCustomVolume(ctx context.Context, s *state.State, pool storagePools.Pool, projectName string, volName string) (*db.StorageVolume, *api.Project, error) {
+	var dbVol *db.StorageVolume
+	var p *api.Project
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		dbVol, err = tx.GetStoragePoolVolume(ctx, pool.ID(), projectName, db.StoragePoolVolumeTypeCustom, volName, true)
+		if err != nil {
+			return err
+		}
+
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dbVol, p, nil
+}
+
+// replicateCustomVolume snapshots the custom volume, prunes the replication snapshots over the retention count
+// and refreshes the replica on the target server.
+func replicateCustomVolume(ctx context.Context, s *state.State, projectName string, poolName string, volName string, op *operations.Operation) error {
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return err
+	}
+
+	dbVol, p, err := replicationLoadCustomVolume(ctx, s, pool, projectName, volName)
+	if err != nil {
+		return err
+	}
+
+	key := replicationVolumeKey(dbVol.ID)
+	_, loaded := replicationRunning.LoadOrStore(key, struct{}{})
+	if loaded {
+		return api.StatusErrorf(http.StatusConflict, "Replication of volume %q is already running", volName)
+	}
+
+	defer replicationRunning.Delete(key)
+
+	config := dbVol.Config
+	if config["replication.target"] == "" {
+		return errors.New("Replication isn't configured")
+	}
+
+	target, err := replicationConnect(s, config, p)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	err = pool.CreateCustomVolumeSnapshot(projectName, volName, replicationSnapshotName(now), time.Time{}, op)
+	if err != nil {
+		return fmt.Errorf("Failed creating replication snapshot: %w", err)
+	}
+
+	var snapshots []db.StorageVolumeArgs
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		snapshots, err = tx.GetLocalStoragePoolVolumeSnapshotsWithType(ctx, projectName, volName, db.StoragePoolVolumeTypeCustom, pool.ID())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	snapshotNames := make([]string, 0, len(snapshots))
+	for _, snap := range snapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(snap.Name)
+		snapshotNames = append(snapshotNames, snapName)
+	}
+
+	for _, snapName := range internalInstance.ReplicationSnapshotsToDelete(snapshotNames, replicationRetention(config)) {
+		err = pool.DeleteCustomVolumeSnapshot(projectName, volName+internalInstance.SnapshotDelimiter+snapName, op)
+		if err != nil {
+			return fmt.Errorf("Failed deleting replication snapshot %q: %w", snapName, err)
+		}
+	}
+
+	// Send the volume to the target.
+	targetPool := poolName
+	if config["replication.target.pool"] != "" {
+		targetPool = config["replication.target.pool"]
+	}
+
+	volConfig := map[string]string{}
+	for k, v := range config {
+		if internalInstance.IsReplicationConfigKey(k) || strings.HasPrefix(k, "volatile.") {
+			continue
+		}
+
+		volConfig[k] = v
+	}
+
+	req := api.StorageVolumesPost{
+		Name:        volName,
+		Type:        db.StoragePoolVolumeTypeNameCustom,
+		ContentType: dbVol.ContentType,
+		StorageVolumePut: api.StorageVolumePut{
+			Config:      volConfig,
+			Description: dbVol.Description,
+		},
+		Source: api.StorageVolumeSource{
+			Type:    "migration",
+			Mode:    "push",
+			Refresh: true,
+		},
+	}
+
+	targetOp, err := target.CreateStoragePoolVolumeFromMigration(targetPool, req)
+	if err != nil {
+		return fmt.Errorf("Failed creating replica: %w", err)
+	}
+
+	opURL, secrets, certificate, err := replicationTargetOperation(target, targetOp)
+	if err != nil {
+		_ = targetOp.Cancel()
+		return err
+	}
+
+	ws, err := newStorageMigrationSource(false, &api.StorageVolumePostTarget{Operation: opURL, Websockets: secrets, Certificate: certificate})
+	if err != nil {
+		_ = targetOp.Cancel()
+		return err
+	}
+
+	err = ws.DoStorage(s, projectName, poolName, volName, op)
+	if err != nil {
+		_ = targetOp.Cancel()
+		return fmt.Errorf("Failed sending volume to replication target: %w", err)
+	}
+
+	err = targetOp.Wait()
+	if err != nil {
+		return fmt.Errorf("Failed refreshing replica: %w", err)
+	}
+
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbVol, err := tx.GetStoragePoolVolume(ctx, pool.ID(), projectName, db.StoragePoolVolumeTypeCustom, volName, true)
+		if err != nil {
+			return err
+		}
+
+		dbVol.Config["volatile.replication.last_success"] = now.UTC().Format(time.RFC3339)
+
+		return tx.UpdateStoragePoolVolume(ctx, projectName, volName, db.StoragePoolVolumeTypeCustom, pool.ID(), dbVol.Description, dbVol.Config)
+	})
+}
+
+// failoverCustomVolume replicates the unused custom volume one last time and then reverses the direction
+// of the replication, the replica becoming the source replicated back to this server.
+func failoverCustomVolume(ctx context.Context, s *state.State, projectName string, poolName string, volName string, reverseTarget string, op *operations.Operation) error {
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return err
+	}
+
+	dbVol, p, err := replicationLoadCustomVolume(ctx, s, pool, projectName, volName)
+	if err != nil {
+		return err
+	}
+
+	config := dbVol.Config
+	if config["replication.target"] == "" {
+		return errors.New("Replication isn't configured")
+	}
+
+	// Check that no running instance is using the volume.
+	err = storagePools.VolumeUsedByInstanceDevices(s, poolName, projectName, &dbVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+		inst, err := instance.Load(s, dbInst, project)
+		if err != nil {
+			return err
+		}
+
+		if inst.IsRunning() {
+			return errors.New("Volume is still in use by running instances")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = replicateCustomVolume(ctx, s, projectName, poolName, volName, op)
+	if err != nil {
+		return err
+	}
+
+	target, err := replicationConnect(s, config, p)
+	if err != nil {
+		return err
+	}
+
+	targetPool := poolName
+	if config["replication.target.pool"] != "" {
+		targetPool = config["replication.target.pool"]
+	}
+
+	replica, etag, err := target.GetStoragePoolVolume(targetPool, db.StoragePoolVolumeTypeNameCustom, volName)
+	if err != nil {
+		return fmt.Errorf("Failed getting replica: %w", err)
+	}
+
+	replicaPut := replica.StorageVolumePut
+	maps.Copy(replicaPut.Config, replicationReverseConfig(config, reverseTarget, poolName, projectName))
+
+	err = target.UpdateStoragePoolVolume(targetPool, db.StoragePoolVolumeTypeNameCustom, volName, replicaPut, etag)
+	if err != nil {
+		return fmt.Errorf("Failed promoting replica: %w", err)
+	}
+
+	// Stop replicating from this server.
+	newConfig := map[string]string{}
+	for k, v := range config {
+		if internalInstance.IsReplicationConfigKey(k) {
+			continue
+		}
+
+		newConfig[k] = v
+	}
+
+	err = pool.UpdateCustomVolume(projectName, volName, dbVol.Description, newConfig, op)
+	if err != nil {
+		return fmt.Errorf("Failed removing replication configuration: %w", err)
+	}
+
+	return nil
+}
+
+// replicationTask replicates the instances and custom volumes whose replication is scheduled.
+func replicationTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+		var instances []instance.Instance
+		var volumes, remoteVolumes []db.StorageVolumeArgs
+		var memberCount int
+		var onlineMemberIDs []int64
+
+		// Get list of instances on the local member that are due to be replicated.
+		filter := dbCluster.InstanceFilter{Node: &s.ServerName}
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
+				inst, err := instance.Load(s, dbInst, p)
+				if err != nil {
+					return fmt.Errorf("Failed loading instance %q (project %q) for replication task: %w", dbInst.Name, dbInst.Project, err)
+				}
+
+				config := inst.ExpandedConfig()
+				if config["replication.target"] == "" || config["replication.schedule"] == "" {
+					return nil
+				}
+
+				if !snapshotIsScheduledNow(config["replication.schedule"], int64(inst.ID())) {
+					return nil
+				}
+
+				instances = append(instances, inst)
+				return nil
+			}, filter)
+		})
+		if err != nil {
+			logger.Error("Failed getting instance replication schedule info", logger.Ctx{"err": err})
+			return
+		}
+
+		// Get list of custom volumes that are due to be replicated.
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			allVolumes, err := tx.GetStoragePoolVolumesWithType(ctx, db.StoragePoolVolumeTypeCustom, true)
+			if err != nil {
+				return fmt.Errorf("Failed getting volumes for replication task: %w", err)
+			}
+
+			for _, v := range allVolumes {
+				if v.Config["replication.target"] == "" || v.Config["replication.schedule"] == "" {
+					continue
+				}
+
+				if !snapshotIsScheduledNow(v.Config["replication.schedule"], v.ID) {
+					continue
+				}
+
+				if v.NodeID < 0 {
+					remoteVolumes = append(remoteVolumes, v)
+				} else {
+					volumes = append(volumes, v)
+				}
+			}
+
+			if len(remoteVolumes) > 0 {
+				members, err := tx.GetNodes(ctx)
+				if err != nil {
+					return fmt.Errorf("Failed getting cluster members: %w", err)
+				}
+
+				memberCount = len(members)
+				for _, member := range members {
+					if member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+						continue
+					}
+
+					onlineMemberIDs = append(onlineMemberIDs, member.ID)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed getting custom volume replication schedule info", logger.Ctx{"err": err})
+			return
+		}
+
+		// A stable random member replicates each remote custom volume.
+		if len(remoteVolumes) > 0 && (memberCount <= 1 || len(onlineMemberIDs) > 0) {
+			localMemberID := s.DB.Cluster.GetNodeID()
+
+			for _, v := range remoteVolumes {
+				if memberCount > 1 {
+					selectedMemberID, err := localUtil.GetStableRandomInt64FromList(v.ID, onlineMemberIDs)
+					if err != nil || localMemberID != selectedMemberID {
+						continue
+					}
+				}
+
+				volumes = append(volumes, v)
+			}
+		}
+
+		// Each replication runs as its own operation so that a slow replication doesn't hold back the others.
+		for _, inst := range instances {
+			// Skip instances whose previous replication is still running.
+			_, running := replicationRunning.Load(replicationInstanceKey(inst.ID()))
+			if running {
+				continue
+			}
+
+			opRun := func(op *operations.Operation) error {
+				err := replicateInstance(s, inst, op)
+				replicationRecordResult(s, inst.Project().Name, dbCluster.TypeInstance, inst.ID(), err)
+
+				return err
+			}
+
+			resources := map[string][]api.URL{}
+			resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", inst.Name())}
+
+			replicationStartTask(s, inst.Project().Name, operationtype.InstanceReplicate, resources, opRun)
+		}
+
+		for _, v := range volumes {
+			// Skip volumes whose previous replication is still running.
+			_, running := replicationRunning.Load(replicationVolumeKey(v.ID))
+			if running {
+				continue
+			}
+
+			opRun := func(op *operations.Operation) error {
+				err := replicateCustomVolume(context.Background(), s, v.ProjectName, v.PoolName, v.Name, op)
+				replicationRecordResult(s, v.ProjectName, dbCluster.TypeStorageVolume, int(v.ID), err)
+
+				return err
+			}
+
+			resources := map[string][]api.URL{}
+			resources["storage_volumes"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", v.PoolName, "volumes", db.StoragePoolVolumeTypeNameCustom, v.Name)}
+
+			replicationStartTask(s, v.ProjectName, operationtype.CustomVolumeReplicate, resources, opRun)
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
+
+// replicationStartTask starts a scheduled replication as a background operation.
+func replicationStartTask(s *state.State, projectName string, opType operationtype.Type, resources map[string][]api.URL, opRun func(op *operations.Operation) error) {
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, opType, resources, nil, opRun, nil, nil, nil)
+	if err != nil {
+		logger.Error("Failed creating replication operation", logger.Ctx{"project": projectName, "err": err})
+		return
+	}
+
+	err = op.Start()
+	if err != nil {
+		logger.Error("Failed starting replication operation", logger.Ctx{"project": projectName, "err": err})
+	}
+}
+
+// replicationRecordResult raises a warning on the replicated entity if the replication failed and resolves it otherwise.
+func replicationRecordResult(s *state.State, projectName string, entityType int, entityID int, err error) {
+	l := logger.AddContext(logger.Ctx{"project": projectName, "entityType": entityType, "entityID": entityID})
+
+	if err != nil {
+		l.Error("Failed scheduled replication", logger.Ctx{"err": err})
+
+		warnErr := s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpsertWarningLocalNode(ctx, projectName, entityType, entityID, warningtype.ReplicationFailure, err.Error())
+		})
+		if warnErr != nil {
+			l.Warn("Failed to create replication failure warning", logger.Ctx{"err": warnErr})
+		}
+
+		return
+	}
+
+	warnErr := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, projectName, warningtype.ReplicationFailure, entityType, entityID)
+	if warnErr != nil {
+		l.Warn("Failed to resolve replication failure warning", logger.Ctx{"err": warnErr})
+	}
+}
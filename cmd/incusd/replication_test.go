@@ -0,0 +1,139 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestReplicationInfo(t *testing.T) {
+	lastSuccess := time.Date(2024, 3, 5, 7, 9, 11, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		config       map[string]string
+		want         *api.Replication
+		wantNotFound bool
+	}{
+		{
+			name:         "Not configured",
+			config:       map[string]string{"replication.schedule": "@hourly"},
+			wantNotFound: true,
+		},
+		{
+			name:   "Never replicated",
+			config: map[string]string{"replication.target": "backup-site", "replication.schedule": "@hourly"},
+			want:   &api.Replication{Target: "backup-site", Pool: "default", Project: "foo", Schedule: "@hourly", Lag: -1},
+		},
+		{
+			name: "Target pool and project",
+			config: map[string]string{
+				"replication.target":         "backup-site",
+				"replication.target.pool":    "remote",
+				"replication.target.project": "replicas",
+			},
+			want: &api.Replication{Target: "backup-site", Pool: "remote", Project: "replicas", Lag: -1},
+		},
+		{
+			name: "Invalid last success",
+			config: map[string]string{
+				"replication.target":                "backup-site",
+				"volatile.replication.last_success": "yesterday",
+			},
+			want: &api.Replication{Target: "backup-site", Pool: "default", Project: "foo", Lag: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := replicationInfo(tt.config, "default", "foo")
+			if tt.wantNotFound {
+				assert.True(t, api.StatusErrorCheck(err, http.StatusNotFound))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, info)
+		})
+	}
+
+	// The lag is computed from the last successful replication.
+	info, err := replicationInfo(map[string]string{"replication.target": "backup-site", "volatile.replication.last_success": lastSuccess.Format(time.RFC3339)}, "default", "foo")
+	require.NoError(t, err)
+	assert.True(t, lastSuccess.Equal(info.LastSuccess))
+	assert.GreaterOrEqual(t, info.Lag, int64(time.Since(lastSuccess).Seconds())-1)
+}
+
+func TestReplicationReverseConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   map[string]string
+	}{
+		{
+			name:   "Defaults",
+			config: map[string]string{"replication.target": "backup-site"},
+			want: map[string]string{
+				"replication.target":         "primary-site",
+				"replication.target.pool":    "default",
+				"replication.target.project": "foo",
+			},
+		},
+		{
+			name: "Schedule and retention are kept",
+			config: map[string]string{
+				"replication.target":                "backup-site",
+				"replication.target.pool":           "remote",
+				"replication.target.project":        "replicas",
+				"replication.schedule":              "@hourly",
+				"replication.snapshots.retention":   "3",
+				"volatile.replication.last_success": "2024-03-05T07:09:11Z",
+			},
+			want: map[string]string{
+				"replication.target":              "primary-site",
+				"replication.target.pool":         "default",
+				"replication.target.project":      "foo",
+				"replication.schedule":            "@hourly",
+				"replication.snapshots.retention": "3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, replicationReverseConfig(tt.config, "primary-site", "default", "foo"))
+		})
+	}
+}
+
+func TestReplicationIsLocalAddress(t *testing.T) {
+	localAddrs := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.ParseIP("2001:db8::1"), Mask: net.CIDRMask(64, 128)},
+	}
+
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"0.0.0.0", true},
+		{"10.0.0.1", true},
+		{"2001:db8::1", true},
+		{"10.0.0.2", false},
+		{"2001:db8::2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			assert.Equal(t, tt.want, replicationIsLocalAddress(net.ParseIP(tt.address), localAddrs))
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/backup"
+	backupTarget "github.com/lxc/incus/v6/internal/server/backup/target"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
@@ -367,6 +368,14 @@ func storagePoolBucketBackupsPost(d *Daemon, r *http.Request) response.Response
 		return response.BadRequest(errors.New("Backup names may not contain slashes"))
 	}
 
+	// Validate the upload target.
+	if req.Target != nil {
+		_, err = backupTarget.Load(s, projectName, req.Target)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	fullName := bucketName + internalInstance.SnapshotDelimiter + req.Name
 
 	do := func(op *operations.Operation) error {
@@ -382,6 +391,27 @@ func storagePoolBucketBackupsPost(d *Daemon, r *http.Request) response.Response
 			return fmt.Errorf("Create bucket backup: %w", err)
 		}
 
+		// Upload it if requested.
+		if req.Target != nil {
+			// Load the backup.
+			entry, err := storagePoolBucketBackupLoadByName(context.TODO(), s, projectName, poolName, fullName)
+			if err != nil {
+				return err
+			}
+
+			// Upload it.
+			err = entry.Upload(req.Target)
+			if err != nil {
+				return err
+			}
+
+			// Delete the backup on successful upload.
+			err = entry.Delete()
+			if err != nil {
+				return err
+			}
+		}
+
 		s.Events.SendLifecycle(projectName, lifecycle.StorageBucketBackupCreated.Event(poolName, args.Name, projectName, op.Requestor(), nil))
 
 		return nil
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// storagePoolHealthInterval is how often the health of the storage pools is checked.
+const storagePoolHealthInterval = 10 * time.Minute
+
+var storagePoolStateCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/state",
+
+	Get: APIEndpointAction{Handler: storagePoolStateGet, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanView, "poolName")},
+}
+
+var storagePoolScrubCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/scrub",
+
+	Post: APIEndpointAction{Handler: storagePoolScrubPost, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanEdit, "poolName")},
+}
+
+// swagger:operation GET /1.0/storage-pools/{poolName}/state storage storage_pool_state_get
+//
+//	Get the storage pool state
+//
+//	Gets the usage information and health of the storage pool.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Storage pool state
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/StoragePoolState"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolStateGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	poolState, err := pool.GetState()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, poolState)
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/scrub storage storage_pool_scrub_post
+//
+//	Scrub the storage pool
+//
+//	Starts a background scrub of the storage pool.
+//	The progress and result of the scrub are reported in the storage pool state.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolScrubPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = pool.Scrub()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// storagePoolHealthTask periodically checks the health of the storage pools and raises a warning
+// for each pool which is degraded or failed. Remote storage pools are only checked by the leader.
+func storagePoolHealthTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		isLeader := !s.ServerClustered
+		if s.ServerClustered {
+			leader, err := s.Cluster.LeaderAddress()
+			if err != nil {
+				logger.Error("Failed getting leader address for storage pool health check", logger.Ctx{"err": err})
+				return
+			}
+
+			isLeader = leader == s.LocalConfig.ClusterAddress()
+		}
+
+		var poolNames []string
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+
+			return err
+		})
+		if err != nil {
+			if !response.IsNotFoundError(err) {
+				logger.Error("Failed getting storage pools for health check", logger.Ctx{"err": err})
+			}
+
+			return
+		}
+
+		for _, poolName := range poolNames {
+			pool, err := storagePools.LoadByName(s, poolName)
+			if err != nil {
+				logger.Error("Failed loading storage pool for health check", logger.Ctx{"pool": poolName, "err": err})
+				continue
+			}
+
+			if pool.LocalStatus() != api.StoragePoolStatusCreated || (pool.Driver().Info().Remote && !isLeader) {
+				continue
+			}
+
+			storagePoolCheckHealth(ctx, s, pool)
+		}
+	}
+
+	return f, task.Every(storagePoolHealthInterval)
+}
+
+// storagePoolCheckHealth raises a warning if the storage pool is degraded or failed and resolves it otherwise.
+// Warnings of remote storage pools aren't tied to a cluster member, so that they're resolved by whichever member
+// is the leader at the time the pool recovers.
+func storagePoolCheckHealth(ctx context.Context, s *state.State, pool storagePools.Pool) {
+	l := logger.AddContext(logger.Ctx{"pool": pool.Name()})
+
+	remote := pool.Driver().Info().Remote
+
+	health, err := pool.Driver().GetHealth()
+	if err != nil {
+		if !errors.Is(err, storageDrivers.ErrNotSupported) {
+			l.Warn("Failed getting storage pool health", logger.Ctx{"err": err})
+		}
+
+		return
+	}
+
+	if health.Status != api.StoragePoolHealthHealthy {
+		msg := "Storage pool is " + health.Status
+		if len(health.Messages) > 0 {
+			msg += ": " + strings.Join(health.Messages, "; ")
+		}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			if remote {
+				return tx.UpsertWarning(ctx, "", "", cluster.TypeStoragePool, int(pool.ID()), warningtype.StoragePoolUnhealthy, msg)
+			}
+
+			return tx.UpsertWarningLocalNode(ctx, "", cluster.TypeStoragePool, int(pool.ID()), warningtype.StoragePoolUnhealthy, msg)
+		})
+		if err != nil {
+			l.Warn("Failed to create storage pool health warning", logger.Ctx{"err": err})
+		}
+
+		return
+	}
+
+	if remote {
+		err = warnings.ResolveWarningsByNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", "", warningtype.StoragePoolUnhealthy, cluster.TypeStoragePool, int(pool.ID()))
+	} else {
+		err = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningtype.StoragePoolUnhealthy, cluster.TypeStoragePool, int(pool.ID()))
+	}
+
+	if err != nil {
+		l.Warn("Failed to resolve storage pool health warning", logger.Ctx{"err": err})
+	}
+}
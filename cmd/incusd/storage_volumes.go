@@ -498,6 +498,7 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 				vol.UsedBy = project.FilterUsedBy(s.Authorizer, r, volumeUsedBy)
 			}
 
+			vol.Config = storagePools.VolumeStripWriteOnly(vol.Config)
 			volumes = append(volumes, vol)
 		}
 
@@ -1776,6 +1777,8 @@ func storagePoolVolumeGet(d *Daemon, r *http.Request) response.Response {
 
 	etag := []any{volumeName, dbVolume.Type, dbVolume.Config}
 
+	dbVolume.Config = storagePools.VolumeStripWriteOnly(dbVolume.Config)
+
 	return response.SyncResponseETag(true, dbVolume.StorageVolume, etag)
 }
 
@@ -1894,6 +1897,9 @@ func storagePoolVolumePut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Keep the secrets which aren't returned to clients.
+	storagePools.VolumeKeepWriteOnly(req.Config, dbVolume.Config)
+
 	// Use an empty operation for this sync response to pass the requestor
 	op := &operations.Operation{}
 	op.SetRequestor(r)
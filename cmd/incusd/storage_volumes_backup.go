@@ -15,6 +15,7 @@ import (
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/backup"
+	backupTarget "github.com/lxc/incus/v6/internal/server/backup/target"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
@@ -430,6 +431,29 @@ func storagePoolVolumeTypeCustomBackupsPost(d *Daemon, r *http.Request) response
 		return response.BadRequest(fmt.Errorf("Invalid storage volume backup name: %w", err))
 	}
 
+	// Validate the upload target.
+	if req.Target != nil {
+		_, err = backupTarget.Load(s, projectName, req.Target)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
+	// Validate the parent backup.
+	var parentName string
+	if req.Parent != "" {
+		err = validate.IsAPIName(req.Parent, false)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid parent backup name: %w", err))
+		}
+
+		parentName = volumeName + internalInstance.SnapshotDelimiter + req.Parent
+		_, err = storagePoolVolumeBackupLoadByName(r.Context(), s, projectName, poolName, parentName)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed loading parent backup %q: %w", req.Parent, err))
+		}
+	}
+
 	fullName := volumeName + internalInstance.SnapshotDelimiter + req.Name
 	volumeOnly := req.VolumeOnly
 
@@ -445,7 +469,7 @@ func storagePoolVolumeTypeCustomBackupsPost(d *Daemon, r *http.Request) response
 		}
 
 		// Create the backup.
-		err := volumeBackupCreate(s, args, projectName, poolName, volumeName)
+		err := volumeBackupCreate(s, args, projectName, poolName, volumeName, parentName)
 		if err != nil {
 			return err
 		}
@@ -453,7 +477,7 @@ func storagePoolVolumeTypeCustomBackupsPost(d *Daemon, r *http.Request) response
 		// Upload it if requested.
 		if req.Target != nil {
 			// Load the backup.
-			entry, err := storagePoolVolumeBackupLoadByName(r.Context(), s, projectName, poolName, volumeName)
+			entry, err := storagePoolVolumeBackupLoadByName(context.TODO(), s, projectName, poolName, fullName)
 			if err != nil {
 				return err
 			}
@@ -705,6 +729,15 @@ func storagePoolVolumeTypeCustomBackupPost(d *Daemon, r *http.Request) response.
 		return response.SmartError(err)
 	}
 
+	dependents, err := entry.Dependents()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if len(dependents) > 0 {
+		return response.BadRequest(fmt.Errorf("Backup is the parent of incremental backups: %s", strings.Join(dependents, ", ")))
+	}
+
 	newName := volumeName + internalInstance.SnapshotDelimiter + req.Name
 
 	rename := func(op *operations.Operation) error {
@@ -821,6 +854,15 @@ func storagePoolVolumeTypeCustomBackupDelete(d *Daemon, r *http.Request) respons
 		return response.SmartError(err)
 	}
 
+	dependents, err := entry.Dependents()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if len(dependents) > 0 {
+		return response.BadRequest(fmt.Errorf("Backup is the parent of incremental backups: %s", strings.Join(dependents, ", ")))
+	}
+
 	remove := func(op *operations.Operation) error {
 		err := entry.Delete()
 		if err != nil {
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+var storagePoolVolumeTypeCustomReplicationCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/volumes/{type}/{volumeName}/replication",
+
+	Get:  APIEndpointAction{Handler: storagePoolVolumeTypeCustomReplicationGet, AccessHandler: allowPermission(auth.ObjectTypeStorageVolume, auth.EntitlementCanView, "poolName", "type", "volumeName", "location")},
+	Post: APIEndpointAction{Handler: storagePoolVolumeTypeCustomReplicationPost, AccessHandler: allowPermission(auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit, "poolName", "type", "volumeName", "location")},
+}
+
+// storagePoolVolumeTypeCustomReplicationLoad loads the custom volume targeted by a replication request.
+// A response is returned instead if the request must be forwarded to another cluster member or is invalid.
+func storagePoolVolumeTypeCustomReplicationLoad(s *state.State, r *http.Request) (string, string, *db.StorageVolume, response.Response) {
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return "", "", nil, response.SmartError(err)
+	}
+
+	volumeName, err := url.PathUnescape(mux.Vars(r)["volumeName"])
+	if err != nil {
+		return "", "", nil, response.SmartError(err)
+	}
+
+	volumeTypeName, err := url.PathUnescape(mux.Vars(r)["type"])
+	if err != nil {
+		return "", "", nil, response.SmartError(err)
+	}
+
+	// Check that the storage volume type is valid.
+	if volumeTypeName != db.StoragePoolVolumeTypeNameCustom {
+		return "", "", nil, response.BadRequest(fmt.Errorf("Invalid storage volume type %q", volumeTypeName))
+	}
+
+	projectName, err := project.StorageVolumeProject(s.DB.Cluster, request.ProjectParam(r), db.StoragePoolVolumeTypeCustom)
+	if err != nil {
+		return "", "", nil, response.SmartError(err)
+	}
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return "", "", nil, resp
+	}
+
+	resp = forwardedResponseIfVolumeIsRemote(s, r, poolName, projectName, volumeName, db.StoragePoolVolumeTypeCustom)
+	if resp != nil {
+		return "", "", nil, resp
+	}
+
+	var dbVolume *db.StorageVolume
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		poolID, _, _, err := tx.GetStoragePool(ctx, poolName)
+		if err != nil {
+			return err
+		}
+
+		dbVolume, err = tx.GetStoragePoolVolume(ctx, poolID, projectName, db.StoragePoolVolumeTypeCustom, volumeName, true)
+		return err
+	})
+	if err != nil {
+		return "", "", nil, response.SmartError(err)
+	}
+
+	return poolName, projectName, dbVolume, nil
+}
+
+// swagger:operation GET /1.0/storage-pools/{poolName}/volumes/{type}/{volumeName}/replication storage storage_pool_volume_type_replication_get
+//
+//	Get the storage volume replication state
+//
+//	Gets the replication target and lag of the custom storage volume.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Replication state
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Replication"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolVolumeTypeCustomReplicationGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	poolName, projectName, dbVolume, resp := storagePoolVolumeTypeCustomReplicationLoad(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	info, err := replicationInfo(dbVolume.Config, poolName, projectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	_, info.Running = replicationRunning.Load(replicationVolumeKey(dbVolume.ID))
+
+	return response.SyncResponse(true, info)
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/volumes/{type}/{volumeName}/replication storage storage_pool_volume_type_replication_post
+//
+//	Replicate the storage volume
+//
+//	Replicates the custom storage volume to its replication target (`sync`) or performs a planned failover (`failover`).
+//	A failover replicates the unused volume one last time and then reverses the direction of the replication.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: replication
+//	    description: Replication request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ReplicationPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolVolumeTypeCustomReplicationPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	poolName, projectName, dbVolume, resp := storagePoolVolumeTypeCustomReplicationLoad(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	req := api.ReplicationPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if dbVolume.Config["replication.target"] == "" {
+		return response.BadRequest(errors.New("Replication isn't configured"))
+	}
+
+	var run func(op *operations.Operation) error
+
+	switch req.Action {
+	case "", api.ReplicationActionSync:
+		run = func(op *operations.Operation) error {
+			return replicateCustomVolume(context.TODO(), s, projectName, poolName, dbVolume.Name, op)
+		}
+
+	case api.ReplicationActionFailover:
+		if req.Target == "" {
+			return response.BadRequest(errors.New("Failover requires the replication target pointing back to this server"))
+		}
+
+		err = internalInstance.IsReplicationTargetName(req.Target)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		run = func(op *operations.Operation) error {
+			return failoverCustomVolume(context.TODO(), s, projectName, poolName, dbVolume.Name, req.Target, op)
+		}
+
+	default:
+		return response.BadRequest(fmt.Errorf("Unknown replication action %q", req.Action))
+	}
+
+	resources := map[string][]api.URL{}
+	resources["storage_volumes"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "volumes", db.StoragePoolVolumeTypeNameCustom, dbVolume.Name)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.CustomVolumeReplicate, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
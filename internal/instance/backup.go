@@ -0,0 +1,95 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// IsBackupTargetName checks that the value is a suitable name for a backup target.
+// The name is part of the `backups.target.NAME.*` server configuration keys and so can't contain dots.
+func IsBackupTargetName(value string) error {
+	if strings.Contains(value, ".") {
+		return fmt.Errorf("Backup target name %q cannot contain dots", value)
+	}
+
+	return validate.IsAPIName(value, false)
+}
+
+// IsBackupTargetURL checks that the value is an `http://` or `https://` URL that backups can be uploaded under.
+// Credentials are configured separately and so can't be included in the URL.
+func IsBackupTargetURL(value string) error {
+	uri, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("Invalid backup target URL: %w", err)
+	}
+
+	if uri.Scheme != "http" && uri.Scheme != "https" {
+		return fmt.Errorf("Backup target %q must be an HTTP(S) URL", value)
+	}
+
+	if uri.Host == "" {
+		return errors.New("Backup target URL is missing a host")
+	}
+
+	if uri.User != nil {
+		return fmt.Errorf("Backup target %q can't include credentials", value)
+	}
+
+	return nil
+}
+
+// IsBackupTargetPath checks that the value is a path relative to the project directory of `storage.backups_target_path`.
+func IsBackupTargetPath(value string) error {
+	if !filepath.IsLocal(value) {
+		return fmt.Errorf("Backup target path %q must be relative and within the target directory", value)
+	}
+
+	return nil
+}
+
+// GetBackupTarget returns the upload target for a backup file with the given name on the backup target
+// defined by the config (the `backups.target.NAME.*` server configuration keys without their prefix).
+func GetBackupTarget(config map[string]string, fileName string) (*api.BackupTarget, error) {
+	switch config["protocol"] {
+	case "http":
+		err := IsBackupTargetURL(config["url"])
+		if err != nil {
+			return nil, err
+		}
+
+		return &api.BackupTarget{
+			Protocol: "http",
+			URL:      config["url"],
+			Path:     fileName,
+			Username: config["username"],
+			Password: config["password"],
+		}, nil
+
+	case "local":
+		path := config["path"]
+		if path == "" {
+			path = "."
+		}
+
+		err := IsBackupTargetPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &api.BackupTarget{
+			Protocol: "local",
+			Path:     filepath.Join(path, fileName),
+		}, nil
+
+	case "":
+		return nil, errors.New("Backup target isn't defined")
+	}
+
+	return nil, fmt.Errorf("Unsupported backup target protocol %q", config["protocol"])
+}
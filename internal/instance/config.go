@@ -29,6 +29,49 @@ var HugePageSizeSuffix = [...]string{"64KB", "1MB", "2MB", "1GB"}
 
 // InstanceConfigKeysAny is a map of config key to validator. (keys applying to containers AND virtual machines).
 var InstanceConfigKeysAny = map[string]func(value string) error{
+	// gendoc:generate(entity=instance, group=backups, key=backups.compression_algorithm)
+	// Specify which compression algorithm to use for scheduled backups.
+	// ---
+	//  type: string
+	//  defaultdesc: same as the project or server `backups.compression_algorithm`
+	//  liveupdate: yes
+	//  shortdesc: Compression algorithm to use for scheduled backups
+	"backups.compression_algorithm": validate.Optional(validate.IsCompressionAlgorithm),
+
+	// gendoc:generate(entity=instance, group=backups, key=backups.expiry)
+	// Specify an expression like `1M 2H 3d 4w 5m 6y`.
+	// Backups are deleted once expired, including those uploaded to `backups.target`.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: When scheduled backups are to be deleted
+	"backups.expiry": func(value string) error {
+		// Validate expression
+		_, err := GetExpiry(time.Time{}, value)
+		return err
+	},
+
+	// gendoc:generate(entity=instance, group=backups, key=backups.schedule)
+	// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-and-space-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or leave empty to disable automatic backups.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: Schedule for automatic instance backups
+	"backups.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly", "@never"})),
+
+	// gendoc:generate(entity=instance, group=backups, key=backups.target)
+	// Specify the name of the backup target that scheduled backups are uploaded to.
+	// Backup targets are defined by the server administrator through the `backups.target.NAME.*` server configuration keys.
+	// Backups are removed from the server once uploaded.
+	// Leave empty to keep scheduled backups on the server.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: Backup target that scheduled backups are uploaded to
+	"backups.target": validate.Optional(IsBackupTargetName),
+
 	// gendoc:generate(entity=instance, group=boot, key=boot.autorestart)
 	// If set to `true` will attempt up to 10 restarts over a 1 minute period upon unexpected instance exit.
 	// ---
@@ -290,6 +333,53 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Raw idmap configuration
 	"raw.idmap": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=replication, key=replication.schedule)
+	// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-and-space-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or leave empty to only replicate on request.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: Schedule for automatic replication of the instance
+	"replication.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly", "@never"})),
+
+	// gendoc:generate(entity=instance, group=replication, key=replication.snapshots.retention)
+	// Each replication creates a snapshot which is sent to the target server.
+	// Only the specified number of the most recent replication snapshots is kept, on both servers.
+	// ---
+	//  type: integer
+	//  defaultdesc: `1`
+	//  liveupdate: yes
+	//  shortdesc: Number of replication snapshots to keep
+	"replication.snapshots.retention": validate.Optional(validate.IsInRange(1, 1000)),
+
+	// gendoc:generate(entity=instance, group=replication, key=replication.target)
+	// Specify the name of the replication target that the instance is replicated to.
+	// Replication targets are defined by the server administrator through the `replication.NAME.*` server configuration keys.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: Replication target that the instance is replicated to
+	"replication.target": validate.Optional(IsReplicationTargetName),
+
+	// gendoc:generate(entity=instance, group=replication, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  defaultdesc: same as the root disk pool
+	//  liveupdate: yes
+	//  shortdesc: Storage pool of the replica on the target server
+	"replication.target.pool": validate.IsAny,
+
+	// gendoc:generate(entity=instance, group=replication, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  defaultdesc: same as the instance project
+	//  liveupdate: yes
+	//  shortdesc: Project of the replica on the target server
+	"replication.target.project": validate.IsAny,
+
 	// gendoc:generate(entity=instance, group=security, key=security.guestapi)
 	// See {ref}`dev-incus` for more information.
 	// ---
@@ -374,6 +464,13 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Template hook
 	"volatile.apply_template": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.backups.uploaded)
+	// Each entry is formatted as `<target>/<backup>` so that uploaded backups are deleted from the target they were uploaded to once they expire.
+	// ---
+	//  type: string
+	//  shortdesc: Comma-separated list of the uploaded scheduled backups
+	"volatile.backups.uploaded": validate.IsAny,
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.base_image)
 	// The hash of the image that the instance was created from (empty if the instance was not created from an image).
 	// ---
@@ -431,6 +528,13 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Timestamp of last move by automatic live-migration
 	"volatile.rebalance.last_move": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.replication.last_success)
+	//
+	// ---
+	//  type: string
+	//  shortdesc: Time of the last successful replication (RFC3339)
+	"volatile.replication.last_success": validate.IsAny,
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.uuid)
 	// The instance UUID is globally unique across all servers and projects.
 	// ---
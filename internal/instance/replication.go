@@ -0,0 +1,91 @@
+package instance
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// ReplicationSnapshotPrefix is the name prefix of the snapshots created by replication.
+const ReplicationSnapshotPrefix = "replication-"
+
+// GetReplicationTarget returns the normalized URL of the server described by a `replication.NAME.address` value.
+// The value must be an `https://` URL, optionally including a port (defaults to 8443).
+func GetReplicationTarget(value string) (string, error) {
+	uri, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("Invalid replication target: %w", err)
+	}
+
+	if uri.Scheme != "https" {
+		return "", fmt.Errorf("Replication target %q must be an HTTPS URL", value)
+	}
+
+	if uri.Hostname() == "" {
+		return "", errors.New("Replication target URL is missing a host")
+	}
+
+	if uri.User != nil || (uri.Path != "" && uri.Path != "/") || uri.RawQuery != "" {
+		return "", fmt.Errorf("Replication target %q must only contain a host and port", value)
+	}
+
+	host := uri.Host
+	if uri.Port() == "" {
+		host = net.JoinHostPort(uri.Hostname(), "8443")
+	}
+
+	return "https://" + host, nil
+}
+
+// IsReplicationTargetName checks that the value is a suitable name for a replication target.
+// The name is part of the `replication.NAME.*` server configuration keys and so can't contain dots.
+func IsReplicationTargetName(value string) error {
+	if strings.Contains(value, ".") {
+		return fmt.Errorf("Replication target name %q cannot contain dots", value)
+	}
+
+	return validate.IsAPIName(value, false)
+}
+
+// IsReplicationConfigKey returns whether the config key is related to replication.
+// Those keys are specific to the source and aren't replicated.
+func IsReplicationConfigKey(key string) bool {
+	return strings.HasPrefix(key, "replication.") || strings.HasPrefix(key, "volatile.replication.")
+}
+
+// ReplicationSnapshotsToDelete returns the replication snapshots which are over the retention count.
+// The snapshot names must be ordered from oldest to newest; snapshots not created by replication are ignored.
+func ReplicationSnapshotsToDelete(snapshotNames []string, retention int) []string {
+	replicated := []string{}
+	for _, name := range snapshotNames {
+		if strings.HasPrefix(name, ReplicationSnapshotPrefix) {
+			replicated = append(replicated, name)
+		}
+	}
+
+	if len(replicated) <= retention {
+		return nil
+	}
+
+	return slices.Clone(replicated[:len(replicated)-retention])
+}
+
+// IsCertificateFingerprint checks that the value is a full SHA-256 certificate fingerprint.
+func IsCertificateFingerprint(value string) error {
+	if len(value) != 64 {
+		return fmt.Errorf("Invalid certificate fingerprint %q: Must be 64 characters long", value)
+	}
+
+	_, err := hex.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("Invalid certificate fingerprint %q: %w", value, err)
+	}
+
+	return nil
+}
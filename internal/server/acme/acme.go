@@ -2,6 +2,7 @@ package acme
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -9,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -100,6 +103,11 @@ func UpdateCertificate(s *state.State, challengeType string, clustered bool, dom
 		return nil, nil
 	}
 
+	return issueCertificate(s, challengeType, []string{domain}, email, caURL)
+}
+
+// issueCertificate runs lego to issue a certificate covering the domains.
+func issueCertificate(s *state.State, challengeType string, domains []string, email string, caURL string) (*CertKeyPair, error) {
 	tmpDir, err := os.MkdirTemp("", "lego")
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create temporary directory: %w", err)
@@ -116,12 +124,15 @@ func UpdateCertificate(s *state.State, challengeType string, clustered bool, dom
 
 	args := []string{
 		"--accept-tos",
-		"--domains", domain,
 		"--email", email,
 		"--path", tmpDir,
 		"--server", caURL,
 	}
 
+	for _, domain := range domains {
+		args = append(args, "--domains", domain)
+	}
+
 	if challengeType == "DNS-01" {
 		provider, environment, resolvers := s.GlobalConfig.ACMEDNS()
 
@@ -153,7 +164,9 @@ func UpdateCertificate(s *state.State, challengeType string, clustered bool, dom
 		return nil, fmt.Errorf("Failed to run lego command: %w", err)
 	}
 
-	// Load the generated certificate.
+	// Load the generated certificate, named after the first domain (with wildcards replaced).
+	domain := strings.ReplaceAll(domains[0], "*", "_")
+
 	certData, err := os.ReadFile(filepath.Join(tmpDir, "certificates", fmt.Sprintf("%s.crt", domain)))
 	if err != nil {
 		return nil, err
@@ -174,3 +187,71 @@ func UpdateCertificate(s *state.State, challengeType string, clustered bool, dom
 		PrivateKey:  keyData,
 	}, nil
 }
+
+// hostCertificatesMu serializes the issuance of host certificates.
+var hostCertificatesMu sync.Mutex
+
+// hostCertificateNeedsUpdate returns true if any of the hosts isn't covered by the certificate or it's valid for
+// less than 30 days.
+func hostCertificateNeedsUpdate(hosts []string, cert *x509.Certificate) bool {
+	return slices.ContainsFunc(hosts, func(host string) bool { return certificateNeedsUpdate(host, cert) })
+}
+
+// HostCertificate returns a certificate covering the host names, issued through ACME using the server's ACME
+// configuration. Issued certificates are stored on the server and only renewed once valid for less than 30 days.
+func HostCertificate(s *state.State, hosts []string) (*CertKeyPair, error) {
+	_, email, caURL, agreeToS, challengeType := s.GlobalConfig.ACME()
+	if email == "" || !agreeToS {
+		return nil, errors.New("Issuing ACME certificates requires acme.email and acme.agree_tos to be set")
+	}
+
+	if len(hosts) == 0 {
+		return nil, errors.New("Issuing ACME certificates requires host names")
+	}
+
+	hosts = slices.Clone(hosts)
+	slices.Sort(hosts)
+	hosts = slices.Compact(hosts)
+
+	hostCertificatesMu.Lock()
+	defer hostCertificatesMu.Unlock()
+
+	// Certificates are stored by the hash of the host names they cover.
+	name := fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(hosts, ","))))
+	certFilename := internalUtil.VarPath("acme", name+".crt")
+	keyFilename := internalUtil.VarPath("acme", name+".key")
+
+	certData, certErr := os.ReadFile(certFilename)
+	keyData, keyErr := os.ReadFile(keyFilename)
+	if certErr == nil && keyErr == nil {
+		keyPair, err := tls.X509KeyPair(certData, keyData)
+		if err == nil && !hostCertificateNeedsUpdate(hosts, keyPair.Leaf) {
+			return &CertKeyPair{Certificate: certData, PrivateKey: keyData}, nil
+		}
+	}
+
+	l := logger.AddContext(logger.Ctx{"hosts": hosts, "caURL": caURL, "challenge": challengeType})
+	l.Info("Issuing ACME certificate for host names")
+
+	newCert, err := issueCertificate(s, challengeType, hosts, email, caURL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(internalUtil.VarPath("acme"), 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create ACME certificates directory: %w", err)
+	}
+
+	err = os.WriteFile(keyFilename, newCert.PrivateKey, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to write ACME certificate key: %w", err)
+	}
+
+	err = os.WriteFile(certFilename, newCert.Certificate, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to write ACME certificate: %w", err)
+	}
+
+	return newCert, nil
+}
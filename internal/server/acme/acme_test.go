@@ -72,3 +72,16 @@ func Test_certificateNeedsUpdate(t *testing.T) {
 		})
 	}
 }
+
+func Test_hostCertificateNeedsUpdate(t *testing.T) {
+	cert := &x509.Certificate{
+		DNSNames: []string{"foo.example.net", "bar.example.net"},
+		NotAfter: time.Now().Add(90 * 24 * time.Hour),
+	}
+
+	require.False(t, hostCertificateNeedsUpdate([]string{"bar.example.net", "foo.example.net"}, cert))
+	require.True(t, hostCertificateNeedsUpdate([]string{"foo.example.net", "baz.example.net"}, cert))
+
+	cert.NotAfter = time.Now().Add(15 * 24 * time.Hour)
+	require.True(t, hostCertificateNeedsUpdate([]string{"foo.example.net"}, cert))
+}
@@ -132,3 +132,9 @@ func (b *BucketBackup) Render() *api.StorageBucketBackup {
 		CompressionAlgorithm: b.compressionAlgorithm,
 	}
 }
+
+// Upload pushes the backup to external storage.
+func (b *BucketBackup) Upload(req *api.BackupTarget) error {
+	backupPath := internalUtil.VarPath("backups", "buckets", b.poolName, project.StorageBucket(b.projectName, b.name))
+	return b.upload(backupPath, b.projectName, req)
+}
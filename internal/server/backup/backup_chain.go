@@ -0,0 +1,177 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/sys"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/archive"
+)
+
+// ChainLink represents a single backup within an incremental backup chain.
+type ChainLink struct {
+	Info *Info
+	Data io.ReadSeeker
+}
+
+// ChainPath returns the path of the stored backup file with the given name for the source described by info.
+func ChainPath(info *Info, name string) string {
+	if info.Type == TypeCustom {
+		return internalUtil.VarPath("backups", "custom", info.Pool, project.StorageVolume(info.Project, name))
+	}
+
+	return internalUtil.VarPath("backups", "instances", project.Instance(info.Project, name))
+}
+
+// LoadChain returns the backups that need to be replayed, oldest first, to restore the backup described by
+// info. For a full backup this is only the backup itself. For an incremental backup, the parent backups are
+// loaded from the local backup store and validated. The returned function closes any opened parent backup.
+func LoadChain(sysOS *sys.OS, info *Info, data io.ReadSeeker) ([]ChainLink, func(), error) {
+	var files []*os.File
+	cleanup := func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}
+
+	links := make([]ChainLink, 0, len(info.Parents)+1)
+	for _, name := range info.Parents {
+		path := ChainPath(info, name)
+
+		f, err := os.Open(path)
+		if err != nil {
+			cleanup()
+
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil, fmt.Errorf("Parent backup %q not found", name)
+			}
+
+			return nil, nil, fmt.Errorf("Failed opening parent backup %q: %w", name, err)
+		}
+
+		files = append(files, f)
+
+		_, algo, _, err := archive.DetectCompressionFile(f)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("Failed detecting compression of parent backup %q: %w", name, err)
+		}
+
+		if algo == ".squashfs" {
+			cleanup()
+			return nil, nil, fmt.Errorf("Parent backup %q uses squashfs compression which isn't supported for incremental restores", name)
+		}
+
+		parentInfo, err := GetInfo(f, sysOS, path)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("Failed reading parent backup %q: %w", name, err)
+		}
+
+		parentInfo.Project = info.Project
+		parentInfo.Pool = info.Pool
+		links = append(links, ChainLink{Info: parentInfo, Data: f})
+	}
+
+	links = append(links, ChainLink{Info: info, Data: data})
+
+	err := validateChain(links)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return links, cleanup, nil
+}
+
+// validateChain checks that each backup of the chain applies on top of the previous ones.
+func validateChain(links []ChainLink) error {
+	var snapshots []string
+	for i, link := range links {
+		if link.Info.Type != links[len(links)-1].Info.Type {
+			return fmt.Errorf("Backup chain mixes %q and %q backups", link.Info.Type, links[len(links)-1].Info.Type)
+		}
+
+		if i == 0 {
+			if link.Info.ParentSnapshot != "" {
+				return errors.New("First backup of the chain must be a full backup")
+			}
+		} else {
+			if link.Info.ParentSnapshot == "" {
+				return errors.New("Backup chain contains an unexpected full backup")
+			}
+
+			if !slices.Contains(snapshots, link.Info.ParentSnapshot) {
+				return fmt.Errorf("Snapshot %q required by incremental backup isn't part of its parent backups", link.Info.ParentSnapshot)
+			}
+		}
+
+		for _, snapName := range link.Info.Snapshots {
+			if !slices.Contains(snapshots, snapName) {
+				snapshots = append(snapshots, snapName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ChainSnapshots returns the names of all snapshots restored by replaying the chain, oldest first.
+func ChainSnapshots(links []ChainLink) []string {
+	var snapshots []string
+	for _, link := range links {
+		for _, snapName := range link.Info.Snapshots {
+			if !slices.Contains(snapshots, snapName) {
+				snapshots = append(snapshots, snapName)
+			}
+		}
+	}
+
+	return snapshots
+}
+
+// chainDependencies maps the names of the backups stored in dir to the names of the backups which depend on them
+// as part of their incremental backup chain. The backups are named after parentName, the instance or volume name.
+func chainDependencies(sysOS *sys.OS, dir string, parentName string) (map[string][]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string][]string{}, nil
+		}
+
+		return nil, err
+	}
+
+	dependencies := map[string][]string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		siblingName := parentName + internalInstance.SnapshotDelimiter + entry.Name()
+		path := filepath.Join(dir, entry.Name())
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed opening backup %q: %w", siblingName, err)
+		}
+
+		info, err := GetInfo(f, sysOS, path)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading index of backup %q: %w", siblingName, err)
+		}
+
+		for _, parent := range info.Parents {
+			dependencies[parent] = append(dependencies[parent], entry.Name())
+		}
+	}
+
+	return dependencies, nil
+}
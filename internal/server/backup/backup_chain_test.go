@@ -0,0 +1,122 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_validateChain(t *testing.T) {
+	full := &Info{Type: TypeContainer, Snapshots: []string{"snap0"}}
+	incr1 := &Info{Type: TypeContainer, ParentSnapshot: "snap0", Snapshots: []string{"snap1"}}
+	incr2 := &Info{Type: TypeContainer, ParentSnapshot: "snap1", Snapshots: []string{"snap2"}}
+
+	tests := []struct {
+		name    string
+		infos   []*Info
+		wantErr string
+	}{
+		{
+			name:  "Full backup only",
+			infos: []*Info{full},
+		},
+		{
+			name:  "Valid chain",
+			infos: []*Info{full, incr1, incr2},
+		},
+		{
+			name:  "Incremental relative to an older snapshot",
+			infos: []*Info{full, incr1, {Type: TypeContainer, ParentSnapshot: "snap0"}},
+		},
+		{
+			name:    "Starts with an incremental backup",
+			infos:   []*Info{incr1, incr2},
+			wantErr: "First backup of the chain must be a full backup",
+		},
+		{
+			name:    "Full backup in the middle",
+			infos:   []*Info{full, full},
+			wantErr: "Backup chain contains an unexpected full backup",
+		},
+		{
+			name:    "Missing intermediate backup",
+			infos:   []*Info{full, incr2},
+			wantErr: `Snapshot "snap1" required by incremental backup isn't part of its parent backups`,
+		},
+		{
+			name:    "Mixed types",
+			infos:   []*Info{{Type: TypeVM, Snapshots: []string{"snap0"}}, incr1},
+			wantErr: `Backup chain mixes "virtual-machine" and "container" backups`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := make([]ChainLink, 0, len(tt.infos))
+			for _, info := range tt.infos {
+				links = append(links, ChainLink{Info: info})
+			}
+
+			err := validateChain(links)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChainSnapshots(t *testing.T) {
+	links := []ChainLink{
+		{Info: &Info{Snapshots: []string{"snap0", "snap1"}}},
+		{Info: &Info{Snapshots: []string{"snap1", "snap2"}}},
+		{Info: &Info{}},
+		{Info: &Info{Snapshots: []string{"snap3"}}},
+	}
+
+	assert.Equal(t, []string{"snap0", "snap1", "snap2", "snap3"}, ChainSnapshots(links))
+	assert.Nil(t, ChainSnapshots(nil))
+}
+
+func Test_chainDependencies(t *testing.T) {
+	dir := t.TempDir()
+
+	writeBackup := func(name string, info Info) {
+		data, err := yaml.Marshal(info)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: backupIndexPath, Mode: 0o600, Size: int64(len(data))}))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o600))
+	}
+
+	writeBackup("full", Info{Name: "c1"})
+	writeBackup("incr1", Info{Name: "c1", Parents: []string{"c1/full"}, ParentSnapshot: "snap0"})
+	writeBackup("incr2", Info{Name: "c1", Parents: []string{"c1/full", "c1/incr1"}, ParentSnapshot: "snap1"})
+	writeBackup("other", Info{Name: "c1"})
+
+	dependencies, err := chainDependencies(nil, dir, "c1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"c1/full": {"incr1", "incr2"}, "c1/incr1": {"incr2"}}, dependencies)
+
+	// A backup without a readable index may still depend on any other backup.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "partial"), []byte("incomplete"), 0o600))
+	_, err = chainDependencies(nil, dir, "c1")
+	assert.ErrorContains(t, err, `Failed reading index of backup "c1/partial"`)
+
+	dependencies, err = chainDependencies(nil, filepath.Join(dir, "missing"), "c1")
+	assert.NoError(t, err)
+	assert.Empty(t, dependencies)
+}
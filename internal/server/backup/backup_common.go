@@ -2,16 +2,11 @@ package backup
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
-	"net/http"
-	"net/url"
 	"os"
 	"time"
 
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
-
+	"github.com/lxc/incus/v6/internal/server/backup/target"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -52,50 +47,28 @@ func (b *CommonBackup) OptimizedStorage() bool {
 }
 
 // upload handles backup uploads.
-func (b *CommonBackup) upload(filePath string, req *api.BackupTarget) error {
-	if req.Protocol != "s3" {
-		return fmt.Errorf("Unsupported backup target protocol %q", req.Protocol)
-	}
-
-	// Set up an S3 client.
-	uri, err := url.Parse(req.URL)
+func (b *CommonBackup) upload(filePath string, projectName string, req *api.BackupTarget) error {
+	t, err := target.Load(b.state, projectName, req)
 	if err != nil {
 		return err
 	}
 
-	creds := credentials.NewStaticV4(req.AccessKey, req.SecretKey, "")
-
-	ts := &http.Transport{
-		MaxIdleConns:       10,
-		IdleConnTimeout:    30 * time.Second,
-		DisableCompression: true,
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true,
-			MinVersion:         tls.VersionTLS12,
-		},
-	}
-
-	client, err := minio.New(uri.Host, &minio.Options{
-		BucketLookup: minio.BucketLookupPath,
-		Creds:        creds,
-		Secure:       uri.Scheme == "https",
-		Transport:    ts,
-	})
+	// Upload the file.
+	f, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 
-	// Upload the object.
-	tr, err := os.Open(filePath)
+	defer func() { _ = f.Close() }()
+
+	fi, err := f.Stat()
 	if err != nil {
 		return err
 	}
 
-	defer tr.Close()
-
-	_, err = client.PutObject(context.Background(), req.BucketName, req.Path, tr, -1, minio.PutObjectOptions{})
+	err = t.Upload(context.Background(), f, fi.Size())
 	if err != nil {
-		return err
+		return fmt.Errorf("Failed uploading backup to %q target: %w", t.Protocol(), err)
 	}
 
 	return nil
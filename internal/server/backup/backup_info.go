@@ -54,6 +54,8 @@ type Info struct {
 	OptimizedHeader  *bool          `json:"optimized_header,omitempty" yaml:"optimized_header,omitempty"` // Optional field to handle older optimized backups that don't have this field.
 	Type             Type           `json:"type,omitempty" yaml:"type,omitempty"`                         // Type of backup.
 	Config           *config.Config `json:"config,omitempty" yaml:"config,omitempty"`                     // Equivalent of backup.yaml but embedded in index for quick retrieval.
+	Parents          []string       `json:"parents,omitempty" yaml:"parents,omitempty"`                   // Backups an incremental backup depends on, oldest first.
+	ParentSnapshot   string         `json:"parent_snapshot,omitempty" yaml:"parent_snapshot,omitempty"`   // Snapshot an incremental backup is relative to.
 }
 
 // GetInfo extracts backup information from a given ReadSeeker.
@@ -58,6 +58,25 @@ func (b *InstanceBackup) Instance() Instance {
 	return b.instance
 }
 
+// ChainDependencies maps the names of the stored backups of the instance to the names of the incremental backups
+// which depend on them.
+func (b *InstanceBackup) ChainDependencies() (map[string][]string, error) {
+	parentName, _, _ := api.GetParentAndSnapshotName(b.name)
+	backupsPath := internalUtil.VarPath("backups", "instances", project.Instance(b.instance.Project().Name, parentName))
+
+	return chainDependencies(b.state.OS, backupsPath, parentName)
+}
+
+// Dependents returns the names of the stored incremental backups of the instance which depend on this backup.
+func (b *InstanceBackup) Dependents() ([]string, error) {
+	dependencies, err := b.ChainDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies[b.name], nil
+}
+
 // Rename renames an instance backup.
 func (b *InstanceBackup) Rename(newName string) error {
 	oldBackupPath := internalUtil.VarPath("backups", "instances", project.Instance(b.instance.Project().Name, b.name))
@@ -157,5 +176,5 @@ func (b *InstanceBackup) Render() *api.InstanceBackup {
 // Upload pushes the backup to external storage.
 func (b *InstanceBackup) Upload(req *api.BackupTarget) error {
 	backupPath := internalUtil.VarPath("backups", "instances", project.Instance(b.instance.Project().Name, b.name))
-	return b.upload(backupPath, req)
+	return b.upload(backupPath, b.instance.Project().Name, req)
 }
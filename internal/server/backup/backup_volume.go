@@ -53,6 +53,25 @@ func (b *VolumeBackup) OptimizedStorage() bool {
 	return b.optimizedStorage
 }
 
+// ChainDependencies maps the names of the stored backups of the volume to the names of the incremental backups
+// which depend on them.
+func (b *VolumeBackup) ChainDependencies() (map[string][]string, error) {
+	parentName, _, _ := api.GetParentAndSnapshotName(b.name)
+	backupsPath := internalUtil.VarPath("backups", "custom", b.poolName, project.StorageVolume(b.projectName, parentName))
+
+	return chainDependencies(b.state.OS, backupsPath, parentName)
+}
+
+// Dependents returns the names of the stored incremental backups of the volume which depend on this backup.
+func (b *VolumeBackup) Dependents() ([]string, error) {
+	dependencies, err := b.ChainDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies[b.name], nil
+}
+
 // Rename renames a volume backup.
 func (b *VolumeBackup) Rename(newName string) error {
 	oldBackupPath := internalUtil.VarPath("backups", "custom", b.poolName, project.StorageVolume(b.projectName, b.name))
@@ -152,5 +171,5 @@ func (b *VolumeBackup) Render() *api.StorageVolumeBackup {
 // Upload pushes the backup to external storage.
 func (b *VolumeBackup) Upload(req *api.BackupTarget) error {
 	backupPath := internalUtil.VarPath("backups", "custom", b.poolName, project.StorageVolume(b.projectName, b.name))
-	return b.upload(backupPath, req)
+	return b.upload(backupPath, b.projectName, req)
 }
@@ -0,0 +1,23 @@
+package target
+
+import (
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type common struct {
+	state       *state.State
+	projectName string
+	req         *api.BackupTarget
+}
+
+func (d *common) init(s *state.State, projectName string, req *api.BackupTarget) {
+	d.state = s
+	d.projectName = projectName
+	d.req = req
+}
+
+// Protocol returns the protocol of the target.
+func (d *common) Protocol() string {
+	return d.req.Protocol
+}
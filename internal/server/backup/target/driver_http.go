@@ -0,0 +1,128 @@
+package target
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	incustls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// httpTarget uploads backups using an HTTP PUT request.
+type httpTarget struct {
+	common
+
+	uri    *url.URL
+	client *http.Client
+}
+
+func (d *httpTarget) validate() error {
+	uri, err := url.Parse(d.req.URL)
+	if err != nil {
+		return fmt.Errorf("Invalid URL: %w", err)
+	}
+
+	if uri.Scheme != "http" && uri.Scheme != "https" {
+		return fmt.Errorf("Unsupported URL scheme %q", uri.Scheme)
+	}
+
+	if uri.Host == "" {
+		return errors.New("URL is missing a host")
+	}
+
+	if d.req.Path != "" {
+		uri = uri.JoinPath(d.req.Path)
+	}
+
+	d.uri = uri
+	d.client = &http.Client{}
+
+	// Setup the client for self-signed certificates.
+	if d.req.Certificate != "" {
+		if uri.Scheme != "https" {
+			return errors.New("A certificate can only be provided for HTTPS targets")
+		}
+
+		certBlock, _ := pem.Decode([]byte(d.req.Certificate))
+		if certBlock == nil {
+			return errors.New("Invalid certificate")
+		}
+
+		cert, err := x509.ParseCertificate(certBlock.Bytes)
+		if err != nil {
+			return fmt.Errorf("Invalid certificate: %w", err)
+		}
+
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+
+		incustls.TLSConfigWithTrustedCert(tlsConfig, cert)
+		d.client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return nil
+}
+
+// Upload sends the backup as the body of a PUT request.
+func (d *httpTarget) Upload(ctx context.Context, r io.Reader, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, d.uri.String(), r)
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	if d.req.Username != "" || d.req.Password != "" {
+		req.SetBasicAuth(d.req.Username, d.req.Password)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Upload failed with status %q", resp.Status)
+	}
+
+	return nil
+}
+
+// Delete removes the backup using an HTTP DELETE request. A backup which is already gone isn't an error.
+func (d *httpTarget) Delete(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, d.uri.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	if d.req.Username != "" || d.req.Password != "" {
+		req.SetBasicAuth(d.req.Username, d.req.Password)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Delete failed with status %q", resp.Status)
+	}
+
+	return nil
+}
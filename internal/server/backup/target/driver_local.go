@@ -0,0 +1,161 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/lxc/incus/v6/shared/revert"
+)
+
+// local writes backups to a directory on the server, typically a network filesystem.
+// Each project gets its own sub-directory so that projects can't reach each other's backups.
+type local struct {
+	common
+
+	basePath string
+}
+
+func (d *local) validate() error {
+	if d.basePath == "" && d.state != nil && d.state.LocalConfig != nil {
+		d.basePath = d.state.LocalConfig.StorageBackupsTargetPath()
+	}
+
+	if d.basePath == "" {
+		return errors.New("The local target requires storage.backups_target_path to be set")
+	}
+
+	if d.projectName == "" {
+		return errors.New("The local target requires a project")
+	}
+
+	if d.req.URL != "" {
+		return errors.New("URL isn't supported")
+	}
+
+	if d.req.Path == "" {
+		return errors.New("Path is required")
+	}
+
+	if !filepath.IsLocal(d.req.Path) {
+		return fmt.Errorf("Path %q must be relative and within the target directory", d.req.Path)
+	}
+
+	return nil
+}
+
+// projectPath returns the directory holding the backups of the project.
+func (d *local) projectPath() string {
+	return filepath.Join(d.basePath, d.projectName)
+}
+
+// resolveTargetPath returns the path of the backup with any symlink in its parent directories resolved.
+// It fails if the resolved path escapes the project directory.
+func (d *local) resolveTargetPath() (string, error) {
+	projectPath, err := filepath.EvalSymlinks(d.projectPath())
+	if err != nil {
+		return "", err
+	}
+
+	targetPath := filepath.Join(d.projectPath(), d.req.Path)
+
+	dirPath, err := filepath.EvalSymlinks(filepath.Dir(targetPath))
+	if err != nil {
+		return "", err
+	}
+
+	relPath, err := filepath.Rel(projectPath, dirPath)
+	if err != nil || !filepath.IsLocal(relPath) {
+		return "", fmt.Errorf("Path %q resolves outside of the target directory", d.req.Path)
+	}
+
+	return filepath.Join(dirPath, filepath.Base(targetPath)), nil
+}
+
+// Upload writes the backup into the target directory.
+func (d *local) Upload(ctx context.Context, r io.Reader, size int64) error {
+	err := os.MkdirAll(filepath.Dir(filepath.Join(d.projectPath(), d.req.Path)), 0o700)
+	if err != nil {
+		return fmt.Errorf("Failed to create target directory: %w", err)
+	}
+
+	targetPath, err := d.resolveTargetPath()
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a partial upload never replaces an existing backup.
+	f, err := os.CreateTemp(filepath.Dir(targetPath), ".incus_backup_")
+	if err != nil {
+		return fmt.Errorf("Failed to create target file: %w", err)
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	})
+
+	_, err = io.Copy(f, &contextReader{ctx: ctx, r: r})
+	if err != nil {
+		return fmt.Errorf("Failed to write target file: %w", err)
+	}
+
+	err = f.Sync()
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(f.Name(), targetPath)
+	if err != nil {
+		return fmt.Errorf("Failed to move target file into place: %w", err)
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// Delete removes the backup from the target directory.
+func (d *local) Delete(ctx context.Context) error {
+	targetPath, err := d.resolveTargetPath()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	err = os.Remove(targetPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Failed to delete target file: %w", err)
+	}
+
+	return nil
+}
+
+// contextReader is an io.Reader which fails once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements io.Reader.
+func (cr *contextReader) Read(p []byte) (int, error) {
+	err := cr.ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return cr.r.Read(p)
+}
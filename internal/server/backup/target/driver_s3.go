@@ -0,0 +1,96 @@
+package target
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3 uploads backups to an S3 compatible object store.
+type s3 struct {
+	common
+
+	uri *url.URL
+}
+
+func (d *s3) validate() error {
+	uri, err := url.Parse(d.req.URL)
+	if err != nil {
+		return fmt.Errorf("Invalid URL: %w", err)
+	}
+
+	if uri.Scheme != "http" && uri.Scheme != "https" {
+		return fmt.Errorf("Unsupported URL scheme %q", uri.Scheme)
+	}
+
+	if uri.Host == "" {
+		return errors.New("URL is missing a host")
+	}
+
+	if d.req.BucketName == "" {
+		return errors.New("Bucket name is required")
+	}
+
+	if d.req.Path == "" {
+		return errors.New("Path is required")
+	}
+
+	d.uri = uri
+
+	return nil
+}
+
+// client returns a client for the object store.
+func (d *s3) client() (*minio.Client, error) {
+	creds := credentials.NewStaticV4(d.req.AccessKey, d.req.SecretKey, "")
+
+	ts := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    30 * time.Second,
+		DisableCompression: true,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			MinVersion:         tls.VersionTLS12,
+		},
+	}
+
+	return minio.New(d.uri.Host, &minio.Options{
+		BucketLookup: minio.BucketLookupPath,
+		Creds:        creds,
+		Secure:       d.uri.Scheme == "https",
+		Transport:    ts,
+	})
+}
+
+// Upload uploads the backup as an object in the configured bucket.
+func (d *s3) Upload(ctx context.Context, r io.Reader, size int64) error {
+	client, err := d.client()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PutObject(ctx, d.req.BucketName, d.req.Path, r, size, minio.PutObjectOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the backup object from the configured bucket.
+func (d *s3) Delete(ctx context.Context) error {
+	client, err := d.client()
+	if err != nil {
+		return err
+	}
+
+	return client.RemoveObject(ctx, d.req.BucketName, d.req.Path, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,178 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"path"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// sftpTarget uploads backups to an SFTP server.
+type sftpTarget struct {
+	common
+
+	address string
+	config  *ssh.ClientConfig
+}
+
+func (d *sftpTarget) validate() error {
+	uri, err := url.Parse(d.req.URL)
+	if err != nil {
+		return fmt.Errorf("Invalid URL: %w", err)
+	}
+
+	if uri.Scheme != "sftp" {
+		return fmt.Errorf("Unsupported URL scheme %q", uri.Scheme)
+	}
+
+	if uri.Hostname() == "" {
+		return errors.New("URL is missing a host")
+	}
+
+	port := uri.Port()
+	if port == "" {
+		port = "22"
+	}
+
+	if d.req.Path == "" {
+		return errors.New("Path is required")
+	}
+
+	if d.req.Username == "" {
+		return errors.New("Username is required")
+	}
+
+	if d.req.HostKey == "" {
+		return errors.New("Host key is required")
+	}
+
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(d.req.HostKey))
+	if err != nil {
+		return fmt.Errorf("Invalid host key: %w", err)
+	}
+
+	auth := []ssh.AuthMethod{}
+
+	if d.req.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(d.req.PrivateKey))
+		if err != nil {
+			return fmt.Errorf("Invalid private key: %w", err)
+		}
+
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+
+	if d.req.Password != "" {
+		auth = append(auth, ssh.Password(d.req.Password))
+	}
+
+	if len(auth) == 0 {
+		return errors.New("A password or private key is required")
+	}
+
+	d.address = net.JoinHostPort(uri.Hostname(), port)
+	d.config = &ssh.ClientConfig{
+		User:            d.req.Username,
+		Auth:            auth,
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+	}
+
+	return nil
+}
+
+// connect opens an SFTP session on the server. The session is interrupted once the context is cancelled and
+// must be closed with the returned function.
+func (d *sftpTarget) connect(ctx context.Context) (*sftp.Client, func(), error) {
+	dialer := &net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", d.address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, d.address, d.config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("Failed to connect to SFTP server: %w", err)
+	}
+
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		_ = sshClient.Close()
+		return nil, nil, fmt.Errorf("Failed to start SFTP session: %w", err)
+	}
+
+	// Interrupt the transfer if the context is cancelled.
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = sshClient.Close()
+		case <-done:
+		}
+	}()
+
+	cleanup := func() {
+		close(done)
+		_ = client.Close()
+		_ = sshClient.Close()
+	}
+
+	return client, cleanup, nil
+}
+
+// Upload writes the backup to the configured path on the SFTP server.
+func (d *sftpTarget) Upload(ctx context.Context, r io.Reader, size int64) error {
+	client, cleanup, err := d.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	err = client.MkdirAll(path.Dir(d.req.Path))
+	if err != nil {
+		return fmt.Errorf("Failed to create target directory: %w", err)
+	}
+
+	f, err := client.Create(d.req.Path)
+	if err != nil {
+		return fmt.Errorf("Failed to create target file: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	_, err = f.ReadFrom(r)
+	if err != nil {
+		return fmt.Errorf("Failed to write target file: %w", err)
+	}
+
+	return f.Close()
+}
+
+// Delete removes the backup from the SFTP server.
+func (d *sftpTarget) Delete(ctx context.Context) error {
+	client, cleanup, err := d.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	err = client.Remove(d.req.Path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Failed to delete target file: %w", err)
+	}
+
+	return nil
+}
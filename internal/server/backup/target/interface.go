@@ -0,0 +1,24 @@
+package target
+
+import (
+	"context"
+	"io"
+
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// driver is the extended internal interface.
+type driver interface {
+	Target
+
+	init(s *state.State, projectName string, req *api.BackupTarget)
+	validate() error
+}
+
+// Target represents a backup upload target.
+type Target interface {
+	Protocol() string
+	Upload(ctx context.Context, r io.Reader, size int64) error
+	Delete(ctx context.Context) error
+}
@@ -0,0 +1,34 @@
+package target
+
+import (
+	"fmt"
+
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+var drivers = map[string]func() driver{
+	"http":  func() driver { return &httpTarget{} },
+	"local": func() driver { return &local{} },
+	"s3":    func() driver { return &s3{} },
+	"sftp":  func() driver { return &sftpTarget{} },
+}
+
+// Load validates the backup target of a backup in the given project and returns a Target for it.
+func Load(s *state.State, projectName string, req *api.BackupTarget) (Target, error) {
+	df, ok := drivers[req.Protocol]
+	if !ok {
+		return nil, fmt.Errorf("Unsupported backup target protocol %q", req.Protocol)
+	}
+
+	d := df()
+
+	d.init(s, projectName, req)
+
+	err := d.validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %q backup target: %w", req.Protocol, err)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,216 @@
+package target
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     api.BackupTarget
+		wantErr bool
+	}{
+		{
+			name:    "Unknown protocol",
+			req:     api.BackupTarget{Protocol: "ftp", URL: "ftp://example.net", Path: "foo.tar.gz"},
+			wantErr: true,
+		},
+		{
+			name: "S3",
+			req:  api.BackupTarget{Protocol: "s3", URL: "https://s3.example.net", BucketName: "backups", Path: "foo.tar.gz"},
+		},
+		{
+			name:    "S3 without bucket",
+			req:     api.BackupTarget{Protocol: "s3", URL: "https://s3.example.net", Path: "foo.tar.gz"},
+			wantErr: true,
+		},
+		{
+			name: "HTTP",
+			req:  api.BackupTarget{Protocol: "http", URL: "https://backup.example.net/upload", Path: "foo.tar.gz"},
+		},
+		{
+			name:    "HTTP with invalid certificate",
+			req:     api.BackupTarget{Protocol: "http", URL: "https://backup.example.net", Certificate: "foo"},
+			wantErr: true,
+		},
+		{
+			name:    "SFTP without host key",
+			req:     api.BackupTarget{Protocol: "sftp", URL: "sftp://backup.example.net", Path: "foo.tar.gz", Username: "foo", Password: "bar"},
+			wantErr: true,
+		},
+		{
+			name: "SFTP",
+			req: api.BackupTarget{
+				Protocol: "sftp",
+				URL:      "sftp://backup.example.net:2222",
+				Path:     "/backups/foo.tar.gz",
+				Username: "foo",
+				Password: "bar",
+				HostKey:  "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOU5AX3FLcC05p3Y1OEb91UdaO1j1vvTp/hHXmtHt8WC",
+			},
+		},
+		{
+			name:    "SFTP with invalid URL scheme",
+			req:     api.BackupTarget{Protocol: "sftp", URL: "https://backup.example.net", Path: "foo.tar.gz", Username: "foo", Password: "bar"},
+			wantErr: true,
+		},
+		{
+			name:    "Local without target path",
+			req:     api.BackupTarget{Protocol: "local", Path: "foo.tar.gz"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(nil, "default", &tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLocalUpload(t *testing.T) {
+	basePath := t.TempDir()
+
+	for _, path := range []string{"../foo.tar.gz", "/foo.tar.gz", ""} {
+		d := &local{basePath: basePath}
+		d.init(nil, "default", &api.BackupTarget{Protocol: "local", Path: path})
+		assert.Error(t, d.validate(), "Path %q should be rejected", path)
+	}
+
+	d := &local{basePath: basePath}
+	d.init(nil, "", &api.BackupTarget{Protocol: "local", Path: "foo.tar.gz"})
+	assert.Error(t, d.validate(), "A project should be required")
+
+	d = &local{basePath: basePath}
+	d.init(nil, "default", &api.BackupTarget{Protocol: "local", Path: "instances/foo.tar.gz"})
+	require.NoError(t, d.validate())
+
+	content := []byte("backup content")
+	err := d.Upload(context.Background(), bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	written, err := os.ReadFile(filepath.Join(basePath, "default", "instances", "foo.tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, content, written)
+
+	// No temporary files should be left behind.
+	entries, err := os.ReadDir(filepath.Join(basePath, "default", "instances"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// Symlinks can't be used to reach the directory of another project.
+	require.NoError(t, os.MkdirAll(filepath.Join(basePath, "other"), 0o700))
+	require.NoError(t, os.Symlink(filepath.Join(basePath, "other"), filepath.Join(basePath, "default", "other")))
+
+	d = &local{basePath: basePath}
+	d.init(nil, "default", &api.BackupTarget{Protocol: "local", Path: "other/foo.tar.gz"})
+	require.NoError(t, d.validate())
+
+	err = d.Upload(context.Background(), bytes.NewReader(content), int64(len(content)))
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(basePath, "other", "foo.tar.gz"))
+}
+
+func TestHTTPUpload(t *testing.T) {
+	var received []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		if r.Method != http.MethodPut || r.URL.Path != "/upload/foo.tar.gz" || username != "foo" || password != "bar" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	content := []byte("backup content")
+
+	target, err := Load(nil, "default", &api.BackupTarget{Protocol: "http", URL: server.URL + "/upload", Path: "foo.tar.gz", Username: "foo", Password: "bar"})
+	require.NoError(t, err)
+
+	err = target.Upload(context.Background(), bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, content, received)
+
+	target, err = Load(nil, "default", &api.BackupTarget{Protocol: "http", URL: server.URL + "/other"})
+	require.NoError(t, err)
+
+	err = target.Upload(context.Background(), bytes.NewReader(content), int64(len(content)))
+	assert.Error(t, err)
+}
+
+func TestLocalDelete(t *testing.T) {
+	basePath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(basePath, "default"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "default", "foo.tar.gz"), []byte("backup content"), 0o600))
+
+	d := &local{basePath: basePath}
+	d.init(nil, "default", &api.BackupTarget{Protocol: "local", Path: "foo.tar.gz"})
+	require.NoError(t, d.validate())
+
+	require.NoError(t, d.Delete(context.Background()))
+	assert.NoFileExists(t, filepath.Join(basePath, "default", "foo.tar.gz"))
+
+	// Deleting a missing backup succeeds.
+	assert.NoError(t, d.Delete(context.Background()))
+
+	// Deleting from a project which never uploaded anything succeeds.
+	d = &local{basePath: basePath}
+	d.init(nil, "other", &api.BackupTarget{Protocol: "local", Path: "foo.tar.gz"})
+	require.NoError(t, d.validate())
+	assert.NoError(t, d.Delete(context.Background()))
+}
+
+func TestHTTPDelete(t *testing.T) {
+	var deleted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/upload/foo.tar.gz":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case "/upload/missing.tar.gz":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	for _, path := range []string{"foo.tar.gz", "missing.tar.gz"} {
+		target, err := Load(nil, "default", &api.BackupTarget{Protocol: "http", URL: server.URL + "/upload", Path: path})
+		require.NoError(t, err)
+		assert.NoError(t, target.Delete(context.Background()))
+	}
+
+	assert.Equal(t, []string{"/upload/foo.tar.gz"}, deleted)
+
+	target, err := Load(nil, "default", &api.BackupTarget{Protocol: "http", URL: server.URL + "/other", Path: "foo.tar.gz"})
+	require.NoError(t, err)
+	assert.Error(t, target.Delete(context.Background()))
+}
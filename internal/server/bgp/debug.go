@@ -5,6 +5,7 @@ type DebugInfo struct {
 	Server   DebugInfoServer   `json:"server" yaml:"server"`
 	Prefixes []DebugInfoPrefix `json:"prefixes" yaml:"prefixes"`
 	Peers    []DebugInfoPeer   `json:"peers" yaml:"peers"`
+	EVPN     []DebugInfoEVPN   `json:"evpn" yaml:"evpn"`
 }
 
 // DebugInfoServer exposes the shared listener configuration.
@@ -22,6 +23,15 @@ type DebugInfoPrefix struct {
 	Nexthop string `json:"nexthop" yaml:"nexthop"`
 }
 
+// DebugInfoEVPN exposes details on a single EVPN route.
+type DebugInfoEVPN struct {
+	Owner string `json:"owner" yaml:"owner"`
+	VNI   uint32 `json:"vni" yaml:"vni"`
+	VTEP  string `json:"vtep" yaml:"vtep"`
+	MAC   string `json:"mac" yaml:"mac"`
+	IP    string `json:"ip" yaml:"ip"`
+}
+
 // DebugInfoPeer exposes details on a single BGP peer.
 type DebugInfoPeer struct {
 	Address  string `json:"address" yaml:"address"`
@@ -73,5 +83,24 @@ func (s *Server) debug() DebugInfo {
 		debug.Prefixes = append(debug.Prefixes, entry)
 	}
 
+	// Fill in the EVPN routes.
+	debug.EVPN = []DebugInfoEVPN{}
+	for _, p := range s.evpnPaths {
+		entry := DebugInfoEVPN{}
+		entry.Owner = p.owner
+		entry.VNI = p.vni
+		entry.VTEP = p.vtep.String()
+
+		if p.mac != nil {
+			entry.MAC = p.mac.String()
+		}
+
+		if p.ip != nil {
+			entry.IP = p.ip.String()
+		}
+
+		debug.EVPN = append(debug.EVPN, entry)
+	}
+
 	return debug
 }
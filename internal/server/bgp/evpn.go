@@ -0,0 +1,504 @@
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+
+	"github.com/google/uuid"
+	bgpAPI "github.com/osrg/gobgp/v3/api"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// evpnFamily is the L2VPN EVPN address family.
+var evpnFamily = &bgpAPI.Family{Afi: bgpAPI.Family_AFI_L2VPN, Safi: bgpAPI.Family_SAFI_EVPN}
+
+// EVPN constants.
+const (
+	// evpnRouteTargetSubType is the extended community sub-type of route targets.
+	evpnRouteTargetSubType = 0x02
+
+	// evpnTunnelTypeVXLAN is the BGP encapsulation tunnel type of VXLAN.
+	evpnTunnelTypeVXLAN = 8
+
+	// evpnPMSITunnelTypeIngressReplication is the PMSI tunnel type used for head-end replication.
+	evpnPMSITunnelTypeIngressReplication = 6
+
+	// evpnASTrans is the AS number used in place of 4-byte AS numbers in 2-byte fields (RFC 6793).
+	evpnASTrans = 23456
+)
+
+// EVPNRoute represents an EVPN route learned from a peer.
+// Routes without a MAC address are inclusive multicast routes, used to flood broadcast, unknown unicast and
+// multicast traffic to the VTEP.
+type EVPNRoute struct {
+	VNI  uint32
+	VTEP net.IP
+	MAC  net.HardwareAddr
+	IP   net.IP
+}
+
+type evpnPath struct {
+	owner string
+	vni   uint32
+	rd    uint16
+	vtep  net.IP
+	mac   net.HardwareAddr
+	ip    net.IP
+}
+
+// AddEVPNInclusiveMulticast advertises the VTEP as interested in the broadcast, unknown unicast and multicast
+// traffic of the VXLAN network identifier.
+func (s *Server) AddEVPNInclusiveMulticast(vni uint32, vtep net.IP, owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addEVPNPath(evpnPath{owner: owner, vni: vni, vtep: vtep})
+}
+
+// AddEVPNMACIP advertises a MAC address (and optionally its IP address) as reachable through the VTEP
+// on the VXLAN network identifier.
+func (s *Server) AddEVPNMACIP(vni uint32, mac net.HardwareAddr, ip net.IP, vtep net.IP, owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addEVPNPath(evpnPath{owner: owner, vni: vni, vtep: vtep, mac: mac, ip: ip})
+}
+
+func (s *Server) addEVPNPath(p evpnPath) error {
+	// Check for an existing entry.
+	for _, existing := range s.evpnPaths {
+		if existing.owner == p.owner && existing.vni == p.vni && existing.vtep.Equal(p.vtep) && existing.mac.String() == p.mac.String() && existing.ip.Equal(p.ip) {
+			return nil
+		}
+	}
+
+	p.rd = s.evpnRD(p.vni)
+
+	var pathUUID string
+	if s.bgp != nil {
+		apiPath, err := s.evpnAPIPath(p)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.bgp.AddPath(context.Background(), &bgpAPI.AddPathRequest{Path: apiPath})
+		if err != nil {
+			return err
+		}
+
+		pathUUID = string(resp.Uuid)
+	} else {
+		// Generate a dummy UUID.
+		pathUUID = uuid.New().String()
+	}
+
+	// Add path to the map.
+	s.evpnPaths[pathUUID] = p
+
+	return nil
+}
+
+// evpnRD returns the local route distinguisher number of the VXLAN network identifier, allocating the lowest
+// free one for new identifiers. VXLAN network identifiers don't fit in the 2 bytes of the route distinguisher
+// so an index is used instead, the same way as FRR does.
+func (s *Server) evpnRD(vni uint32) uint16 {
+	rd, ok := s.evpnRDs[vni]
+	if ok {
+		return rd
+	}
+
+	used := make(map[uint16]struct{}, len(s.evpnRDs))
+	for _, rd := range s.evpnRDs {
+		used[rd] = struct{}{}
+	}
+
+	for rd = 1; ; rd++ {
+		_, ok := used[rd]
+		if !ok {
+			break
+		}
+	}
+
+	s.evpnRDs[vni] = rd
+
+	return rd
+}
+
+// evpnRouteTarget returns the route target of the VXLAN network identifier, made of the AS number and the
+// VXLAN network identifier. As both can't fit when using a 4-byte AS number, a 4-byte AS specific route target
+// is only used with VXLAN network identifiers fitting in 2 bytes, AS_TRANS being used otherwise.
+func evpnRouteTarget(asn uint32, vni uint32) (*anypb.Any, error) {
+	if asn > 0xffff && vni <= 0xffff {
+		return anypb.New(&bgpAPI.FourOctetAsSpecificExtended{
+			IsTransitive: true,
+			SubType:      evpnRouteTargetSubType,
+			Asn:          asn,
+			LocalAdmin:   vni,
+		})
+	}
+
+	if asn > 0xffff {
+		asn = evpnASTrans
+	}
+
+	return anypb.New(&bgpAPI.TwoOctetAsSpecificExtended{
+		IsTransitive: true,
+		SubType:      evpnRouteTargetSubType,
+		Asn:          asn,
+		LocalAdmin:   vni,
+	})
+}
+
+// evpnAPIPath converts an EVPN path to its GoBGP representation.
+func (s *Server) evpnAPIPath(p evpnPath) (*bgpAPI.Path, error) {
+	// The route distinguisher must be unique to this router and network, the route target is the same for all
+	// routers sharing the VXLAN network identifier.
+	rd, err := anypb.New(&bgpAPI.RouteDistinguisherIPAddress{
+		Admin:    s.routerID.String(),
+		Assigned: uint32(p.rd),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var nlri *anypb.Any
+	if p.mac != nil {
+		route := &bgpAPI.EVPNMACIPAdvertisementRoute{
+			Rd:         rd,
+			Esi:        &bgpAPI.EthernetSegmentIdentifier{Value: make([]byte, 9)},
+			MacAddress: p.mac.String(),
+			IpAddress:  "0.0.0.0",
+			Labels:     []uint32{p.vni},
+		}
+
+		if p.ip != nil {
+			route.IpAddress = p.ip.String()
+		}
+
+		nlri, err = anypb.New(route)
+	} else {
+		nlri, err = anypb.New(&bgpAPI.EVPNInclusiveMulticastEthernetTagRoute{
+			Rd:        rd,
+			IpAddress: p.vtep.String(),
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	aOrigin, err := anypb.New(&bgpAPI.OriginAttribute{Origin: 0})
+	if err != nil {
+		return nil, err
+	}
+
+	aNextHop, err := anypb.New(&bgpAPI.MpReachNLRIAttribute{
+		Family:   evpnFamily,
+		NextHops: []string{p.vtep.String()},
+		Nlris:    []*anypb.Any{nlri},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routeTarget, err := evpnRouteTarget(s.asn, p.vni)
+	if err != nil {
+		return nil, err
+	}
+
+	encap, err := anypb.New(&bgpAPI.EncapExtended{TunnelType: evpnTunnelTypeVXLAN})
+	if err != nil {
+		return nil, err
+	}
+
+	aCommunities, err := anypb.New(&bgpAPI.ExtendedCommunitiesAttribute{
+		Communities: []*anypb.Any{routeTarget, encap},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pattrs := []*anypb.Any{aOrigin, aNextHop, aCommunities}
+
+	// Inclusive multicast routes use ingress replication.
+	if p.mac == nil {
+		tunnelID := p.vtep.To4()
+		if tunnelID == nil {
+			tunnelID = p.vtep.To16()
+		}
+
+		aPMSI, err := anypb.New(&bgpAPI.PmsiTunnelAttribute{
+			Type:  evpnPMSITunnelTypeIngressReplication,
+			Label: p.vni,
+			Id:    tunnelID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		pattrs = append(pattrs, aPMSI)
+	}
+
+	return &bgpAPI.Path{
+		Family: evpnFamily,
+		Nlri:   nlri,
+		Pattrs: pattrs,
+	}, nil
+}
+
+// RemoveEVPNByOwner removes all EVPN routes for the provided owner.
+func (s *Server) RemoveEVPNByOwner(owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Make a copy of the paths dict to safely iterate (path removal mutates it).
+	paths := map[string]evpnPath{}
+	maps.Copy(paths, s.evpnPaths)
+
+	for pathUUID, p := range paths {
+		if p.owner != owner {
+			continue
+		}
+
+		// Remove it from the BGP server.
+		if s.bgp != nil {
+			err := s.bgp.DeletePath(context.Background(), &bgpAPI.DeletePathRequest{Uuid: []byte(pathUUID)})
+			if err != nil && err.Error() != "can't find a specified path" {
+				return err
+			}
+		}
+
+		delete(s.evpnPaths, pathUUID)
+	}
+
+	// Release the route distinguishers of the VXLAN network identifiers no longer advertised.
+	for vni := range s.evpnRDs {
+		inUse := false
+		for _, p := range s.evpnPaths {
+			if p.vni == vni {
+				inUse = true
+				break
+			}
+		}
+
+		if !inUse {
+			delete(s.evpnRDs, vni)
+		}
+	}
+
+	return nil
+}
+
+// EVPNRoutes returns the EVPN routes learned from peers for the VXLAN network identifier.
+// Routes are matched on the local administrator part of their route target, regardless of the AS number, so
+// that VTEPs in other autonomous systems can take part in the same network.
+func (s *Server) EVPNRoutes(vni uint32) ([]EVPNRoute, error) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	routes := []EVPNRoute{}
+	if s.bgp == nil {
+		return routes, nil
+	}
+
+	// Get the local VTEP addresses so our own routes can be skipped.
+	localVTEPs := map[string]struct{}{}
+	for _, p := range s.evpnPaths {
+		localVTEPs[p.vtep.String()] = struct{}{}
+	}
+
+	var parseErr error
+	err := s.bgp.ListPath(context.Background(), &bgpAPI.ListPathRequest{TableType: bgpAPI.TableType_GLOBAL, Family: evpnFamily}, func(d *bgpAPI.Destination) {
+		for _, p := range d.Paths {
+			if !p.Best || p.IsWithdraw {
+				continue
+			}
+
+			route, err := parseEVPNPath(p)
+			if err != nil {
+				parseErr = err
+				continue
+			}
+
+			if route == nil || route.VNI != vni {
+				continue
+			}
+
+			_, isLocal := localVTEPs[route.VTEP.String()]
+			if isLocal {
+				continue
+			}
+
+			routes = append(routes, *route)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if parseErr != nil {
+		logger.Warn("Failed parsing EVPN route", logger.Ctx{"err": parseErr})
+	}
+
+	return routes, nil
+}
+
+// parseEVPNPath parses a GoBGP path into an EVPN route.
+// Returns nil for routes other than MAC/IP advertisement and inclusive multicast routes.
+func parseEVPNPath(p *bgpAPI.Path) (*EVPNRoute, error) {
+	route := EVPNRoute{}
+
+	nlri, err := p.Nlri.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := nlri.(type) {
+	case *bgpAPI.EVPNMACIPAdvertisementRoute:
+		route.MAC, err = net.ParseMAC(r.MacAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		ip := net.ParseIP(r.IpAddress)
+		if ip != nil && !ip.IsUnspecified() {
+			route.IP = ip
+		}
+
+	case *bgpAPI.EVPNInclusiveMulticastEthernetTagRoute:
+	default:
+		return nil, nil
+	}
+
+	foundVNI := false
+	for _, pattr := range p.Pattrs {
+		attr, err := pattr.UnmarshalNew()
+		if err != nil {
+			return nil, err
+		}
+
+		switch a := attr.(type) {
+		case *bgpAPI.MpReachNLRIAttribute:
+			if len(a.NextHops) > 0 {
+				route.VTEP = net.ParseIP(a.NextHops[0])
+			}
+
+		case *bgpAPI.NextHopAttribute:
+			route.VTEP = net.ParseIP(a.NextHop)
+
+		case *bgpAPI.ExtendedCommunitiesAttribute:
+			for _, community := range a.Communities {
+				c, err := community.UnmarshalNew()
+				if err != nil {
+					return nil, err
+				}
+
+				switch rt := c.(type) {
+				case *bgpAPI.TwoOctetAsSpecificExtended:
+					if rt.SubType == evpnRouteTargetSubType {
+						route.VNI = rt.LocalAdmin
+						foundVNI = true
+					}
+
+				case *bgpAPI.FourOctetAsSpecificExtended:
+					if rt.SubType == evpnRouteTargetSubType {
+						route.VNI = rt.LocalAdmin
+						foundVNI = true
+					}
+				}
+			}
+		}
+	}
+
+	if route.VTEP == nil {
+		return nil, fmt.Errorf("EVPN route %q is missing its next hop", p.Nlri.String())
+	}
+
+	if !foundVNI {
+		return nil, nil
+	}
+
+	return &route, nil
+}
+
+// WatchEVPN registers a function called whenever EVPN routes change.
+func (s *Server) WatchEVPN(name string, fn func()) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evpnWatchers[name] = fn
+}
+
+// UnwatchEVPN unregisters a function previously registered with WatchEVPN.
+func (s *Server) UnwatchEVPN(name string) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.evpnWatchers, name)
+}
+
+// notifyEVPNWatchers calls the registered EVPN watchers.
+func (s *Server) notifyEVPNWatchers() {
+	s.mu.Lock()
+	watchers := make([]func(), 0, len(s.evpnWatchers))
+	for _, fn := range s.evpnWatchers {
+		watchers = append(watchers, fn)
+	}
+
+	s.mu.Unlock()
+
+	for _, fn := range watchers {
+		fn()
+	}
+}
+
+// watchEVPN starts watching for EVPN route changes until the context is cancelled.
+func (s *Server) watchEVPN(ctx context.Context) error {
+	// Coalesce bursts of route changes.
+	changed := make(chan struct{}, 1)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				s.notifyEVPNWatchers()
+			}
+		}
+	}()
+
+	return s.bgp.WatchEvent(ctx, &bgpAPI.WatchEventRequest{
+		Table: &bgpAPI.WatchEventRequest_Table{
+			Filters: []*bgpAPI.WatchEventRequest_Table_Filter{{Type: bgpAPI.WatchEventRequest_Table_Filter_BEST}},
+		},
+	}, func(r *bgpAPI.WatchEventResponse) {
+		table := r.GetTable()
+		if table == nil {
+			return
+		}
+
+		for _, p := range table.Paths {
+			if p.Family.GetAfi() != evpnFamily.Afi || p.Family.GetSafi() != evpnFamily.Safi {
+				continue
+			}
+
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+
+			return
+		}
+	})
+}
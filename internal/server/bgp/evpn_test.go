@@ -0,0 +1,207 @@
+package bgp
+
+import (
+	"net"
+	"testing"
+
+	bgpAPI "github.com/osrg/gobgp/v3/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// evpnPathAttrs returns the route distinguisher and route target of a GoBGP EVPN path.
+func evpnPathAttrs(t *testing.T, p *bgpAPI.Path) (*bgpAPI.RouteDistinguisherIPAddress, proto.Message) {
+	t.Helper()
+
+	nlri, err := p.Nlri.UnmarshalNew()
+	require.NoError(t, err)
+
+	var rdAny *anypb.Any
+	switch r := nlri.(type) {
+	case *bgpAPI.EVPNMACIPAdvertisementRoute:
+		rdAny = r.Rd
+	case *bgpAPI.EVPNInclusiveMulticastEthernetTagRoute:
+		rdAny = r.Rd
+	default:
+		t.Fatalf("Unexpected NLRI %T", nlri)
+	}
+
+	rd, err := rdAny.UnmarshalNew()
+	require.NoError(t, err)
+
+	var rt proto.Message
+	for _, pattr := range p.Pattrs {
+		attr, err := pattr.UnmarshalNew()
+		require.NoError(t, err)
+
+		communities, ok := attr.(*bgpAPI.ExtendedCommunitiesAttribute)
+		if !ok {
+			continue
+		}
+
+		rt, err = communities.Communities[0].UnmarshalNew()
+		require.NoError(t, err)
+	}
+
+	return rd.(*bgpAPI.RouteDistinguisherIPAddress), rt
+}
+
+func TestEVPNAPIPath(t *testing.T) {
+	vtep := net.ParseIP("192.0.2.1")
+	mac, _ := net.ParseMAC("00:16:3e:00:00:01")
+
+	tests := []struct {
+		name   string
+		asn    uint32
+		path   evpnPath
+		wantRT proto.Message
+	}{
+		{
+			name:   "Inclusive multicast",
+			asn:    65000,
+			path:   evpnPath{vni: 0x10001, vtep: vtep, rd: 1},
+			wantRT: &bgpAPI.TwoOctetAsSpecificExtended{IsTransitive: true, SubType: evpnRouteTargetSubType, Asn: 65000, LocalAdmin: 0x10001},
+		},
+		{
+			name:   "MAC/IP advertisement",
+			asn:    65000,
+			path:   evpnPath{vni: 100, vtep: vtep, mac: mac, ip: net.ParseIP("10.0.0.1"), rd: 2},
+			wantRT: &bgpAPI.TwoOctetAsSpecificExtended{IsTransitive: true, SubType: evpnRouteTargetSubType, Asn: 65000, LocalAdmin: 100},
+		},
+		{
+			name:   "4-byte AS number",
+			asn:    4200000000,
+			path:   evpnPath{vni: 100, vtep: vtep, rd: 3},
+			wantRT: &bgpAPI.FourOctetAsSpecificExtended{IsTransitive: true, SubType: evpnRouteTargetSubType, Asn: 4200000000, LocalAdmin: 100},
+		},
+		{
+			name:   "4-byte AS number with a large VNI",
+			asn:    4200000000,
+			path:   evpnPath{vni: 0x10001, vtep: vtep, rd: 4},
+			wantRT: &bgpAPI.TwoOctetAsSpecificExtended{IsTransitive: true, SubType: evpnRouteTargetSubType, Asn: evpnASTrans, LocalAdmin: 0x10001},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{asn: tt.asn, routerID: net.ParseIP("10.10.10.10")}
+
+			p, err := s.evpnAPIPath(tt.path)
+			require.NoError(t, err)
+
+			rd, rt := evpnPathAttrs(t, p)
+			assert.Equal(t, "10.10.10.10", rd.Admin)
+			assert.Equal(t, uint32(tt.path.rd), rd.Assigned)
+
+			assert.True(t, proto.Equal(tt.wantRT, rt), "Unexpected route target %v", rt)
+
+			// The generated path parses back to the same route.
+			route, err := parseEVPNPath(p)
+			require.NoError(t, err)
+			require.NotNil(t, route)
+			assert.Equal(t, tt.path.vni, route.VNI)
+			assert.True(t, route.VTEP.Equal(vtep))
+			assert.Equal(t, tt.path.mac.String(), route.MAC.String())
+			assert.True(t, route.IP.Equal(tt.path.ip))
+		})
+	}
+}
+
+func TestEVPNRD(t *testing.T) {
+	s := NewServer()
+
+	// VNIs sharing their lower bits still get distinct route distinguishers.
+	assert.Equal(t, uint16(1), s.evpnRD(1))
+	assert.Equal(t, uint16(2), s.evpnRD(0x10001))
+	assert.Equal(t, uint16(1), s.evpnRD(1))
+
+	// Released route distinguishers are reused.
+	require.NoError(t, s.AddEVPNInclusiveMulticast(0x20001, net.ParseIP("192.0.2.1"), "n1"))
+	require.NoError(t, s.AddEVPNInclusiveMulticast(0x30001, net.ParseIP("192.0.2.1"), "n2"))
+	assert.Equal(t, uint16(3), s.evpnRDs[0x20001])
+	assert.Equal(t, uint16(4), s.evpnRDs[0x30001])
+
+	require.NoError(t, s.RemoveEVPNByOwner("n1"))
+	_, ok := s.evpnRDs[0x20001]
+	assert.False(t, ok)
+	assert.Equal(t, uint16(1), s.evpnRD(0x40001))
+}
+
+func TestParseEVPNPath(t *testing.T) {
+	s := &Server{asn: 65000, routerID: net.ParseIP("10.10.10.10")}
+	vtep := net.ParseIP("192.0.2.1")
+
+	withoutRT, err := s.evpnAPIPath(evpnPath{vni: 100, vtep: vtep})
+	require.NoError(t, err)
+	withoutRT.Pattrs = withoutRT.Pattrs[:2]
+
+	withoutNextHop, err := s.evpnAPIPath(evpnPath{vni: 100, vtep: vtep})
+	require.NoError(t, err)
+	withoutNextHop.Pattrs = withoutNextHop.Pattrs[2:]
+
+	prefix, err := anypb.New(&bgpAPI.EVPNIPPrefixRoute{IpPrefix: "10.0.0.0", IpPrefixLen: 24})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    *bgpAPI.Path
+		want    *EVPNRoute
+		wantErr bool
+	}{
+		{
+			name: "Inclusive multicast",
+			path: func() *bgpAPI.Path {
+				p, err := s.evpnAPIPath(evpnPath{vni: 100, vtep: vtep})
+				require.NoError(t, err)
+				return p
+			}(),
+			want: &EVPNRoute{VNI: 100, VTEP: vtep},
+		},
+		{
+			name: "Without route target",
+			path: withoutRT,
+		},
+		{
+			name:    "Without next hop",
+			path:    withoutNextHop,
+			wantErr: true,
+		},
+		{
+			name: "Unsupported route type",
+			path: &bgpAPI.Path{Nlri: prefix},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := parseEVPNPath(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, route)
+		})
+	}
+}
+
+func TestPeerAfiSafis(t *testing.T) {
+	families := func(evpn bool) []string {
+		afiSafis, err := peerAfiSafis(evpn)
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, afiSafi := range afiSafis {
+			family := afiSafi.Config.Family
+			names = append(names, family.Afi.String()+"/"+family.Safi.String())
+		}
+
+		return names
+	}
+
+	assert.Equal(t, []string{"AFI_IP/SAFI_UNICAST", "AFI_IP6/SAFI_UNICAST"}, families(false))
+	assert.Equal(t, []string{"AFI_IP/SAFI_UNICAST", "AFI_IP6/SAFI_UNICAST", "AFI_L2VPN/SAFI_EVPN"}, families(true))
+}
@@ -31,6 +31,12 @@ type Server struct {
 	paths    map[string]path
 	peers    map[string]peer
 
+	// EVPN state.
+	evpnPaths    map[string]evpnPath
+	evpnRDs      map[uint32]uint16
+	evpnWatchers map[string]func()
+	evpnCancel   context.CancelFunc
+
 	mu sync.Mutex
 }
 
@@ -45,6 +51,7 @@ type peer struct {
 	asn      uint32
 	password string
 	holdtime uint64
+	evpn     bool
 	count    int
 }
 
@@ -52,8 +59,11 @@ type peer struct {
 func NewServer() *Server {
 	// Setup new struct.
 	s := &Server{
-		paths: map[string]path{},
-		peers: map[string]peer{},
+		paths:        map[string]path{},
+		peers:        map[string]peer{},
+		evpnPaths:    map[string]evpnPath{},
+		evpnRDs:      map[uint32]uint16{},
+		evpnWatchers: map[string]func(){},
 	}
 
 	return s
@@ -99,8 +109,8 @@ func (s *Server) start(address string, asn uint32, routerID net.IP) error {
 		RouterId: routerID.String(),
 		Asn:      asn,
 
-		// Always setup for IPv4 and IPv6.
-		Families: []uint32{0, 1},
+		// Always setup for IPv4, IPv6 and L2VPN EVPN.
+		Families: []uint32{0, 1, 9},
 
 		// Listen address.
 		ListenAddresses: []string{addrHost},
@@ -133,7 +143,7 @@ func (s *Server) start(address string, asn uint32, routerID net.IP) error {
 	// Add existing peers.
 	s.peers = map[string]peer{}
 	for _, peer := range oldPeers {
-		err := s.addPeer(peer.address, peer.asn, peer.password, peer.holdtime)
+		err := s.addPeer(peer.address, peer.asn, peer.password, peer.holdtime, peer.evpn)
 		if err != nil {
 			return err
 		}
@@ -144,6 +154,29 @@ func (s *Server) start(address string, asn uint32, routerID net.IP) error {
 	s.asn = asn
 	s.routerID = routerID
 
+	// Copy the EVPN path list.
+	oldEVPNPaths := map[string]evpnPath{}
+	maps.Copy(oldEVPNPaths, s.evpnPaths)
+
+	// Add existing EVPN paths (after recording the ASN and router ID they're derived from).
+	s.evpnPaths = map[string]evpnPath{}
+	for _, p := range oldEVPNPaths {
+		err := s.addEVPNPath(p)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watch for EVPN route changes.
+	ctx, cancel := context.WithCancel(context.Background())
+	err = s.watchEVPN(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	s.evpnCancel = cancel
+
 	return nil
 }
 
@@ -169,6 +202,12 @@ func (s *Server) stop() error {
 	// Restore peer list.
 	s.peers = oldPeers
 
+	// Stop watching for EVPN route changes.
+	if s.evpnCancel != nil {
+		s.evpnCancel()
+		s.evpnCancel = nil
+	}
+
 	// Stop the listener.
 	err := s.bgp.StopBgp(context.Background(), &bgpAPI.StopBgpRequest{})
 	if err != nil {
@@ -221,6 +260,9 @@ func (s *Server) configure(address string, asn uint32, routerID net.IP) error {
 		}
 	}
 
+	// Let the EVPN watchers refresh their routes (without holding the lock).
+	go s.notifyEVPNWatchers()
+
 	// All done.
 	reverter.Success()
 	return nil
@@ -396,10 +438,19 @@ func (s *Server) AddPeer(address net.IP, asn uint32, password string, holdTime u
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	return s.addPeer(address, asn, password, holdTime)
+	return s.addPeer(address, asn, password, holdTime, false)
+}
+
+// AddEVPNPeer adds a new BGP peer exchanging L2VPN EVPN routes on top of the IPv4 and IPv6 ones.
+func (s *Server) AddEVPNPeer(address net.IP, asn uint32, password string, holdTime uint64) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addPeer(address, asn, password, holdTime, true)
 }
 
-func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime uint64) error {
+func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime uint64, evpn bool) error {
 	// Look for an existing peer.
 	bgpPeer, bgpPeerExists := s.peers[address.String()]
 	if bgpPeerExists {
@@ -411,6 +462,10 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 			return fmt.Errorf("Peer %q already used but with a different password", address)
 		}
 
+		if bgpPeer.evpn != evpn {
+			return fmt.Errorf("Peer %q already used but with differing address families", address)
+		}
+
 		// Reuse the existing entry.
 		bgpPeer.count++
 		s.peers[address.String()] = bgpPeer
@@ -448,33 +503,17 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 		}
 	}
 
-	// Setup peer for dual-stack.
-	n.AfiSafis = make([]*bgpAPI.AfiSafi, 0)
-	for _, f := range []string{"ipv4-unicast", "ipv6-unicast"} {
-		rf, err := bgpPacket.GetRouteFamily(f)
-		if err != nil {
-			return err
-		}
-
-		afi, safi := bgpPacket.RouteFamilyToAfiSafi(rf)
-		family := &bgpAPI.Family{
-			Afi:  bgpAPI.Family_Afi(afi),
-			Safi: bgpAPI.Family_Safi(safi),
-		}
-
-		n.AfiSafis = append(n.AfiSafis, &bgpAPI.AfiSafi{
-			MpGracefulRestart: &bgpAPI.MpGracefulRestart{
-				Config: &bgpAPI.MpGracefulRestartConfig{
-					Enabled: true,
-				},
-			},
-			Config: &bgpAPI.AfiSafiConfig{Family: family},
-		})
+	// Setup peer for dual-stack and, when requested, EVPN.
+	afiSafis, err := peerAfiSafis(evpn)
+	if err != nil {
+		return err
 	}
 
+	n.AfiSafis = afiSafis
+
 	// Add the peer.
 	if s.bgp != nil {
-		err := s.bgp.AddPeer(context.Background(), &bgpAPI.AddPeerRequest{Peer: n})
+		err = s.bgp.AddPeer(context.Background(), &bgpAPI.AddPeerRequest{Peer: n})
 		if err != nil {
 			return err
 		}
@@ -490,6 +529,7 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 			asn:      asn,
 			password: password,
 			holdtime: holdTime,
+			evpn:     evpn,
 			count:    1,
 		}
 	}
@@ -497,6 +537,39 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 	return nil
 }
 
+// peerAfiSafis returns the address families enabled on a peer, IPv4 and IPv6 along with L2VPN EVPN for evpn peers.
+func peerAfiSafis(evpn bool) ([]*bgpAPI.AfiSafi, error) {
+	families := []string{"ipv4-unicast", "ipv6-unicast"}
+	if evpn {
+		families = append(families, "l2vpn-evpn")
+	}
+
+	afiSafis := make([]*bgpAPI.AfiSafi, 0, len(families))
+	for _, f := range families {
+		rf, err := bgpPacket.GetRouteFamily(f)
+		if err != nil {
+			return nil, err
+		}
+
+		afi, safi := bgpPacket.RouteFamilyToAfiSafi(rf)
+		family := &bgpAPI.Family{
+			Afi:  bgpAPI.Family_Afi(afi),
+			Safi: bgpAPI.Family_Safi(safi),
+		}
+
+		afiSafis = append(afiSafis, &bgpAPI.AfiSafi{
+			MpGracefulRestart: &bgpAPI.MpGracefulRestart{
+				Config: &bgpAPI.MpGracefulRestartConfig{
+					Enabled: true,
+				},
+			},
+			Config: &bgpAPI.AfiSafiConfig{Family: family},
+		})
+	}
+
+	return afiSafis, nil
+}
+
 // RemovePeer removes a prefix from the BGP server.
 func (s *Server) RemovePeer(address net.IP) error {
 	// Locking.
@@ -215,6 +215,16 @@ func (c *Config) InstancesPlacementScriptlet() string {
 	return c.m.GetString("instances.placement.scriptlet")
 }
 
+// InstancesUsageRetentionDays returns the number of days the instance usage is kept for.
+func (c *Config) InstancesUsageRetentionDays() int64 {
+	return c.m.GetInt64("instances.usage.retention")
+}
+
+// InstancesValidationScriptlet returns the instances validation scriptlet source code.
+func (c *Config) InstancesValidationScriptlet() string {
+	return c.m.GetString("instances.validation.scriptlet")
+}
+
 // AuthorizationScriptlet returns the authorization scriptlet source code.
 func (c *Config) AuthorizationScriptlet() string {
 	return c.m.GetString("authorization.scriptlet")
@@ -407,6 +417,19 @@ func (c *Config) LoggingConfigForLoki(loggerName string) (string, string, string
 	return c.m.GetString(addressKey), c.m.GetString(usernameKey), c.m.GetString(passwordKey), c.m.GetString(caCertKey), c.m.GetString(instanceKey), c.m.GetString(labelsKey), int(c.m.GetInt64(retryKey))
 }
 
+// LoggingConfigForOTLP returns all the OTLP settings needed to connect to a collector.
+func (c *Config) LoggingConfigForOTLP(loggerName string) (string, string, string, string, string, int) {
+	prefix := fmt.Sprintf("logging.%s", loggerName)
+	addressKey := fmt.Sprintf("%s.%s", prefix, "target.address")
+	usernameKey := fmt.Sprintf("%s.%s", prefix, "target.username")
+	passwordKey := fmt.Sprintf("%s.%s", prefix, "target.password")
+	caCertKey := fmt.Sprintf("%s.%s", prefix, "target.ca_cert")
+	instanceKey := fmt.Sprintf("%s.%s", prefix, "target.instance")
+	retryKey := fmt.Sprintf("%s.%s", prefix, "target.retry")
+
+	return c.m.GetString(addressKey), c.m.GetString(usernameKey), c.m.GetString(passwordKey), c.m.GetString(caCertKey), c.m.GetString(instanceKey), int(c.m.GetInt64(retryKey))
+}
+
 // LoggingConfigForWebhook returns the logging configuration for the webhook logger type.
 func (c *Config) LoggingConfigForWebhook(loggerName string) (string, string, string, string, int) {
 	prefix := fmt.Sprintf("logging.%s", loggerName)
@@ -419,6 +442,30 @@ func (c *Config) LoggingConfigForWebhook(loggerName string) (string, string, str
 	return c.m.GetString(addressKey), c.m.GetString(usernameKey), c.m.GetString(passwordKey), c.m.GetString(caCertKey), int(c.m.GetInt64(retryKey))
 }
 
+// ReplicationTarget returns the address, certificate fingerprint, client certificate and client key of the replication target.
+func (c *Config) ReplicationTarget(targetName string) (string, string, string, string) {
+	prefix := fmt.Sprintf("replication.%s", targetName)
+	addressKey := fmt.Sprintf("%s.%s", prefix, "address")
+	fingerprintKey := fmt.Sprintf("%s.%s", prefix, "fingerprint")
+	clientCertificateKey := fmt.Sprintf("%s.%s", prefix, "client_certificate")
+	clientKeyKey := fmt.Sprintf("%s.%s", prefix, "client_key")
+
+	return c.m.GetString(addressKey), c.m.GetString(fingerprintKey), c.m.GetString(clientCertificateKey), c.m.GetString(clientKeyKey)
+}
+
+// BackupTarget returns the configuration of the backup target, keyed by the `backups.target.NAME.*` key suffixes.
+func (c *Config) BackupTarget(targetName string) map[string]string {
+	config := map[string]string{}
+	for _, key := range []string{"protocol", "url", "username", "password", "path"} {
+		value := c.m.GetString(fmt.Sprintf("backups.target.%s.%s", targetName, key))
+		if value != "" {
+			config[key] = value
+		}
+	}
+
+	return config
+}
+
 // Dump current configuration keys and their values. Keys with values matching
 // their defaults are omitted.
 func (c *Config) Dump() map[string]string {
@@ -841,6 +888,25 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Instance placement scriptlet for automatic instance placement
 	"instances.placement.scriptlet": {Validator: validate.Optional(scriptletLoad.InstancePlacementValidate)},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.usage.retention)
+	// Specify the number of days after which the recorded instance usage is deleted.
+	// Set to `0` to keep it forever.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `365`
+	//  shortdesc: How long the usage of instances is kept
+	"instances.usage.retention": {Type: config.Int64, Default: "365", Validator: validate.Optional(validate.IsUint32)},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.validation.scriptlet)
+	// When validating or adjusting instance changes with custom logic, this option stores the scriptlet.
+	// See {ref}`instance-validation-scriptlet` for more information.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Instance validation scriptlet for instance and profile changes
+	"instances.validation.scriptlet": {Validator: validate.Optional(scriptletLoad.InstanceValidationValidate)},
+
 	// gendoc:generate(entity=server, group=loki, key=loki.auth.username)
 	//
 	// ---
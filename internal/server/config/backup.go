@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// IsBackupTargetConfig reports whether the config key is for a backup target configuration.
+func IsBackupTargetConfig(key string) bool {
+	return strings.HasPrefix(key, "backups.target.")
+}
+
+// GetBackupTargetRuleForKey returns the rule for the specified backup target config key.
+func GetBackupTargetRuleForKey(key string) (Key, error) {
+	fields := strings.Split(key, ".")
+	if len(fields) != 4 || internalInstance.IsBackupTargetName(fields[2]) != nil {
+		return Key{}, fmt.Errorf("%s is not a valid backup target config key", key)
+	}
+
+	switch fields[3] {
+	case "protocol":
+		// gendoc:generate(entity=server, group=backups, key=backups.target.NAME.protocol)
+		// Possible values are `http` (upload with a `PUT` request under `backups.target.NAME.url`) and `local` (write into `backups.target.NAME.path`).
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Protocol used to upload backups to the target
+		return Key{Validator: validate.Optional(validate.IsOneOf("http", "local"))}, nil
+	case "url":
+		// gendoc:generate(entity=server, group=backups, key=backups.target.NAME.url)
+		// Specify the `http://` or `https://` URL that backups are uploaded under.
+		// Expired backups are deleted with a `DELETE` request.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: URL of an `http` backup target
+		return Key{Validator: validate.Optional(internalInstance.IsBackupTargetURL)}, nil
+	case "username":
+		// gendoc:generate(entity=server, group=backups, key=backups.target.NAME.username)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: User name used to authenticate with an `http` backup target
+		return Key{}, nil
+	case "password":
+		// gendoc:generate(entity=server, group=backups, key=backups.target.NAME.password)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Password used to authenticate with an `http` backup target
+		return Key{}, nil
+	case "path":
+		// gendoc:generate(entity=server, group=backups, key=backups.target.NAME.path)
+		// The path is relative to the sub-directory of `storage.backups_target_path` named after the project of the backed up instance or volume.
+		// ---
+		//  type: string
+		//  scope: global
+		//  defaultdesc: the project directory
+		//  shortdesc: Directory that a `local` backup target writes backups into
+		return Key{Validator: validate.Optional(internalInstance.IsBackupTargetPath)}, nil
+	}
+
+	return Key{}, fmt.Errorf("%s is not a valid backup target config key", key)
+}
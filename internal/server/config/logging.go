@@ -63,7 +63,7 @@ func GetLoggingRuleForKey(key string) (Key, error) {
 		//  type: string
 		//  scope: global
 		//  defaultdesc: Local server host name or cluster member name
-		//  shortdesc: Name to use as the instance field in Loki events.
+		//  shortdesc: Name to use as the instance field in Loki events or the service instance in OTLP events.
 		return Key{}, nil
 	case "target.labels":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.target.labels)
@@ -87,8 +87,8 @@ func GetLoggingRuleForKey(key string) (Key, error) {
 		// ---
 		//  type: string
 		//  scope: global
-		//  shortdesc: The type of the logger. One of `loki`, `syslog` or `webhook`.
-		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("syslog", "loki", "webhook")))}, nil
+		//  shortdesc: The type of the logger. One of `loki`, `otlp`, `syslog` or `webhook`.
+		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("syslog", "loki", "otlp", "webhook")))}, nil
 	case "target.retry":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.target.retry)
 		//
@@ -101,12 +101,13 @@ func GetLoggingRuleForKey(key string) (Key, error) {
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.types)
 		// Specify a comma-separated list of events to send to the logger.
 		// The events can be any combination of `lifecycle`, `logging`, and `network-acl`.
+		// The `otlp` logger also supports `operation` to send completed operations as spans.
 		// ---
 		//  type: string
 		//  scope: global
 		//  defaultdesc: `lifecycle,logging`
 		//  shortdesc: Events to send to the logger
-		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl"))), Default: "lifecycle,logging"}, nil
+		return Key{Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl", "operation"))), Default: "lifecycle,logging"}, nil
 	case "logging.level":
 		// gendoc:generate(entity=server, group=logging, key=logging.NAME.logging.level)
 		//
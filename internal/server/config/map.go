@@ -122,6 +122,14 @@ func (m *Map) GetRaw(name string) string {
 		return value
 	}
 
+	if IsReplicationConfig(name) {
+		return value
+	}
+
+	if IsBackupTargetConfig(name) {
+		return value
+	}
+
 	// Schema key
 	key := m.schema.mustGetKey(name)
 	if !ok {
@@ -133,7 +141,7 @@ func (m *Map) GetRaw(name string) string {
 
 // GetString returns the value of the given key, which must be of type String.
 func (m *Map) GetString(name string) string {
-	if !internalInstance.IsUserConfig(name) && !IsLoggingConfig(name) {
+	if !internalInstance.IsUserConfig(name) && !IsLoggingConfig(name) && !IsReplicationConfig(name) && !IsBackupTargetConfig(name) {
 		m.schema.assertKeyType(name, String)
 	}
 
@@ -235,6 +243,24 @@ func (m *Map) set(name string, value string, initial bool) (bool, error) {
 		m.schema[name] = rule
 	}
 
+	if IsReplicationConfig(name) {
+		rule, err := GetReplicationRuleForKey(name)
+		if err != nil {
+			return false, err
+		}
+
+		m.schema[name] = rule
+	}
+
+	if IsBackupTargetConfig(name) {
+		rule, err := GetBackupTargetRuleForKey(name)
+		if err != nil {
+			return false, err
+		}
+
+		m.schema[name] = rule
+	}
+
 	key, ok := m.schema[name]
 	if !ok {
 		return false, errors.New("unknown key")
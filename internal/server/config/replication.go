@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// IsReplicationConfig reports whether the config key is for a replication target configuration.
+func IsReplicationConfig(key string) bool {
+	return strings.HasPrefix(key, "replication.")
+}
+
+// GetReplicationRuleForKey returns the rule for the specified replication target config key.
+func GetReplicationRuleForKey(key string) (Key, error) {
+	fields := strings.Split(key, ".")
+	if len(fields) != 3 || internalInstance.IsReplicationTargetName(fields[1]) != nil {
+		return Key{}, fmt.Errorf("%s is not a valid replication config key", key)
+	}
+
+	switch fields[2] {
+	case "address":
+		// gendoc:generate(entity=server, group=replication, key=replication.NAME.address)
+		// Specify the `https://` URL of the target server, optionally including a port (defaults to `8443`).
+		// The target server can't be this server or a member of its cluster.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Address of the replication target
+		return Key{Validator: validate.Optional(func(value string) error {
+			_, err := internalInstance.GetReplicationTarget(value)
+			return err
+		})}, nil
+	case "fingerprint":
+		// gendoc:generate(entity=server, group=replication, key=replication.NAME.fingerprint)
+		// If set, the certificate of the target server must match this fingerprint.
+		// Otherwise, the certificate must be trusted by the system.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: SHA-256 fingerprint of the target server certificate
+		return Key{Validator: validate.Optional(internalInstance.IsCertificateFingerprint)}, nil
+	case "client_certificate":
+		// gendoc:generate(entity=server, group=replication, key=replication.NAME.client_certificate)
+		// This certificate must be trusted by the target server, ideally restricted to the projects holding the replicas.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: PEM encoded client certificate used to authenticate with the target server
+		return Key{}, nil
+	case "client_key":
+		// gendoc:generate(entity=server, group=replication, key=replication.NAME.client_key)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: PEM encoded private key of the client certificate
+		return Key{}, nil
+	}
+
+	return Key{}, fmt.Errorf("%s is not a valid replication config key", key)
+}
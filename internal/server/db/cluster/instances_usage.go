@@ -0,0 +1,96 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// InstanceUsage is a value object holding the resource usage of an instance over an hourly period.
+// Usage is recorded against the project and instance name so it outlives the instance itself.
+type InstanceUsage struct {
+	Project              string
+	Instance             string
+	PeriodStart          time.Time
+	CPUSeconds           float64
+	MemoryByteSeconds    int64
+	DiskReadBytes        int64
+	DiskWrittenBytes     int64
+	NetworkReceivedBytes int64
+	NetworkSentBytes     int64
+}
+
+// AddInstanceUsage adds the usage to the period of the instance, creating the record if missing.
+// The period start is truncated to the hour.
+func AddInstanceUsage(ctx context.Context, db dbtx, usage InstanceUsage) error {
+	q := `
+INSERT INTO instances_usage (project_id, instance_name, period_start, cpu_seconds, memory_byte_seconds, disk_read_bytes, disk_written_bytes, network_received_bytes, network_sent_bytes)
+  VALUES ((SELECT projects.id FROM projects WHERE projects.name = ?), ?, ?, ?, ?, ?, ?, ?, ?)
+  ON CONFLICT (project_id, instance_name, period_start) DO UPDATE SET
+    cpu_seconds = cpu_seconds + excluded.cpu_seconds,
+    memory_byte_seconds = memory_byte_seconds + excluded.memory_byte_seconds,
+    disk_read_bytes = disk_read_bytes + excluded.disk_read_bytes,
+    disk_written_bytes = disk_written_bytes + excluded.disk_written_bytes,
+    network_received_bytes = network_received_bytes + excluded.network_received_bytes,
+    network_sent_bytes = network_sent_bytes + excluded.network_sent_bytes
+`
+
+	_, err := db.ExecContext(ctx, q, usage.Project, usage.Instance, usage.PeriodStart.UTC().Truncate(time.Hour), usage.CPUSeconds, usage.MemoryByteSeconds, usage.DiskReadBytes, usage.DiskWrittenBytes, usage.NetworkReceivedBytes, usage.NetworkSentBytes)
+	if err != nil {
+		return fmt.Errorf("Failed adding usage of instance %q in project %q: %w", usage.Instance, usage.Project, err)
+	}
+
+	return nil
+}
+
+// DeleteInstancesUsageBefore deletes the usage of all instances over the hourly periods starting before the
+// given time.
+func DeleteInstancesUsageBefore(ctx context.Context, db dbtx, before time.Time) error {
+	_, err := db.ExecContext(ctx, "DELETE FROM instances_usage WHERE period_start < ?", before.UTC())
+	if err != nil {
+		return fmt.Errorf("Failed deleting instance usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetInstancesUsage returns the usage of all instances of the project over the hourly periods starting
+// between from (included) and to (excluded), ordered by period and instance name.
+func GetInstancesUsage(ctx context.Context, db dbtx, project string, from time.Time, to time.Time) ([]InstanceUsage, error) {
+	q := `
+SELECT projects.name, instances_usage.instance_name, instances_usage.period_start, instances_usage.cpu_seconds, instances_usage.memory_byte_seconds,
+  instances_usage.disk_read_bytes, instances_usage.disk_written_bytes, instances_usage.network_received_bytes, instances_usage.network_sent_bytes
+  FROM instances_usage
+  JOIN projects ON projects.id = instances_usage.project_id
+  WHERE projects.name = ? AND instances_usage.period_start >= ? AND instances_usage.period_start < ?
+  ORDER BY instances_usage.period_start, instances_usage.instance_name
+`
+
+	rows, err := db.QueryContext(ctx, q, project, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading usage of project %q: %w", project, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	usages := []InstanceUsage{}
+	for rows.Next() {
+		usage := InstanceUsage{}
+
+		err = rows.Scan(&usage.Project, &usage.Instance, &usage.PeriodStart, &usage.CPUSeconds, &usage.MemoryByteSeconds, &usage.DiskReadBytes, &usage.DiskWrittenBytes, &usage.NetworkReceivedBytes, &usage.NetworkSentBytes)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading usage of project %q: %w", project, err)
+		}
+
+		usages = append(usages, usage)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading usage of project %q: %w", project, err)
+	}
+
+	return usages, nil
+}
@@ -0,0 +1,75 @@
+//go:build linux && cgo && !agent
+
+package cluster_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+)
+
+func TestInstancesUsage(t *testing.T) {
+	db, err := cluster.Schema().ExerciseUpdate(78, nil)
+	require.NoError(t, err)
+
+	defer func() { _ = db.Close() }()
+
+	_, err = db.Exec("INSERT INTO projects (name, description) VALUES ('p1', '')")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	add := func(project string, instance string, periodStart time.Time, cpu float64, sent int64) {
+		err := cluster.AddInstanceUsage(ctx, db, cluster.InstanceUsage{
+			Project:          project,
+			Instance:         instance,
+			PeriodStart:      periodStart,
+			CPUSeconds:       cpu,
+			NetworkSentBytes: sent,
+		})
+		require.NoError(t, err)
+	}
+
+	// Usage within the same hour is summed up.
+	add("default", "c1", start.Add(5*time.Minute), 1.5, 100)
+	add("default", "c1", start.Add(10*time.Minute), 2, 50)
+	add("default", "c2", start.Add(time.Hour), 3, 0)
+	add("default", "c1", start.Add(2*time.Hour), 1, 0)
+	add("p1", "c1", start, 10, 10)
+
+	// Usage can't be recorded for missing projects.
+	err = cluster.AddInstanceUsage(ctx, db, cluster.InstanceUsage{Project: "missing", Instance: "c1", PeriodStart: start})
+	assert.Error(t, err)
+
+	usages, err := cluster.GetInstancesUsage(ctx, db, "default", start, start.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+
+	assert.Equal(t, "c1", usages[0].Instance)
+	assert.True(t, start.Equal(usages[0].PeriodStart))
+	assert.InDelta(t, 3.5, usages[0].CPUSeconds, 0.001)
+	assert.Equal(t, int64(150), usages[0].NetworkSentBytes)
+
+	assert.Equal(t, "c2", usages[1].Instance)
+	assert.True(t, start.Add(time.Hour).Equal(usages[1].PeriodStart))
+	assert.InDelta(t, 3, usages[1].CPUSeconds, 0.001)
+
+	usages, err = cluster.GetInstancesUsage(ctx, db, "p1", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, "p1", usages[0].Project)
+
+	// Usage is removed along with the project.
+	_, err = db.Exec("PRAGMA foreign_keys=ON; DELETE FROM projects WHERE name = 'p1'")
+	require.NoError(t, err)
+
+	usages, err = cluster.GetInstancesUsage(ctx, db, "p1", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, usages)
+}
@@ -35,11 +35,19 @@ import (
 const (
 	// NetworkIntegrationTypeOVN represents an OVN network integration.
 	NetworkIntegrationTypeOVN = iota
+
+	// NetworkIntegrationTypeBGP represents a BGP router network integration.
+	NetworkIntegrationTypeBGP
+
+	// NetworkIntegrationTypeVLAN represents a bridged VLAN gateway network integration.
+	NetworkIntegrationTypeVLAN
 )
 
 // NetworkIntegrationTypeNames is a map between DB type to their string representation.
 var NetworkIntegrationTypeNames = map[int]string{
-	NetworkIntegrationTypeOVN: "ovn",
+	NetworkIntegrationTypeOVN:  "ovn",
+	NetworkIntegrationTypeBGP:  "bgp",
+	NetworkIntegrationTypeVLAN: "vlan",
 }
 
 // NetworkIntegration is a value object holding db-related details about a network integration.
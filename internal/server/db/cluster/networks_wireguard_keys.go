@@ -0,0 +1,56 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target networks_wireguard_keys.mapper.go
+//generate-database:mapper reset -i -b "//go:build linux && cgo && !agent"
+//
+// Statements:
+//generate-database:mapper stmt -e NetworkWireguardKeyPair objects table=networks_wireguard_keys
+//generate-database:mapper stmt -e NetworkWireguardKeyPair objects-by-NetworkID table=networks_wireguard_keys
+//generate-database:mapper stmt -e NetworkWireguardKeyPair create table=networks_wireguard_keys
+//
+// Methods:
+//generate-database:mapper method -i -e NetworkWireguardKeyPair GetMany table=networks_wireguard_keys
+//generate-database:mapper method -i -e NetworkWireguardKeyPair Create table=networks_wireguard_keys
+
+// NetworkWireguardKeyPair is a value object holding db-related details about the WireGuard key pair of a
+// cluster member for a network.
+type NetworkWireguardKeyPair struct {
+	ID         int   `db:"order=yes"`
+	NetworkID  int64 `db:"primary=yes"`
+	NodeID     int64 `db:"primary=yes"`
+	PrivateKey string
+	PublicKey  string
+}
+
+// NetworkWireguardKeyPairFilter defines the optional WHERE-clause fields.
+type NetworkWireguardKeyPairFilter struct {
+	NetworkID *int64
+}
+
+// UpdateNetworkWireguardKeyPair replaces the WireGuard key pair of a cluster member for a network.
+func UpdateNetworkWireguardKeyPair(ctx context.Context, db dbtx, networkID int64, nodeID int64, privateKey string, publicKey string) error {
+	result, err := db.ExecContext(ctx, "UPDATE networks_wireguard_keys SET private_key=?, public_key=? WHERE network_id=? AND node_id=?", privateKey, publicKey, networkID, nodeID)
+	if err != nil {
+		return fmt.Errorf("Failed updating network WireGuard key: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query updated %d rows instead of 1", n)
+	}
+
+	return nil
+}
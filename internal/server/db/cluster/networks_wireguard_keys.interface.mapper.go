@@ -0,0 +1,16 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import "context"
+
+// NetworkWireguardKeyPairGenerated is an interface of generated methods for NetworkWireguardKeyPair.
+type NetworkWireguardKeyPairGenerated interface {
+	// GetNetworkWireguardKeyPairs returns all available NetworkWireguardKeyPairs.
+	// generator: NetworkWireguardKeyPair GetMany
+	GetNetworkWireguardKeyPairs(ctx context.Context, db dbtx, filters ...NetworkWireguardKeyPairFilter) ([]NetworkWireguardKeyPair, error)
+
+	// CreateNetworkWireguardKeyPair adds a new NetworkWireguardKeyPair to the database.
+	// generator: NetworkWireguardKeyPair Create
+	CreateNetworkWireguardKeyPair(ctx context.Context, db dbtx, object NetworkWireguardKeyPair) (int64, error)
+}
@@ -0,0 +1,195 @@
+//go:build linux && cgo && !agent
+
+// Code generated by generate-database from the incus project - DO NOT EDIT.
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var networkWireguardKeyPairObjects = RegisterStmt(`
+SELECT networks_wireguard_keys.id, networks_wireguard_keys.network_id, networks_wireguard_keys.node_id, networks_wireguard_keys.private_key, networks_wireguard_keys.public_key
+  FROM networks_wireguard_keys
+  ORDER BY networks_wireguard_keys.id
+`)
+
+var networkWireguardKeyPairObjectsByNetworkID = RegisterStmt(`
+SELECT networks_wireguard_keys.id, networks_wireguard_keys.network_id, networks_wireguard_keys.node_id, networks_wireguard_keys.private_key, networks_wireguard_keys.public_key
+  FROM networks_wireguard_keys
+  WHERE ( networks_wireguard_keys.network_id = ? )
+  ORDER BY networks_wireguard_keys.id
+`)
+
+var networkWireguardKeyPairCreate = RegisterStmt(`
+INSERT INTO networks_wireguard_keys (network_id, node_id, private_key, public_key)
+  VALUES (?, ?, ?, ?)
+`)
+
+// networkWireguardKeyPairColumns returns a string of column names to be used with a SELECT statement for the entity.
+// Use this function when building statements to retrieve database entries matching the NetworkWireguardKeyPair entity.
+func networkWireguardKeyPairColumns() string {
+	return "networks_wireguard_keys.id, networks_wireguard_keys.network_id, networks_wireguard_keys.node_id, networks_wireguard_keys.private_key, networks_wireguard_keys.public_key"
+}
+
+// getNetworkWireguardKeyPairs can be used to run handwritten sql.Stmts to return a slice of objects.
+func getNetworkWireguardKeyPairs(ctx context.Context, stmt *sql.Stmt, args ...any) ([]NetworkWireguardKeyPair, error) {
+	objects := make([]NetworkWireguardKeyPair, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		n := NetworkWireguardKeyPair{}
+		err := scan(&n.ID, &n.NetworkID, &n.NodeID, &n.PrivateKey, &n.PublicKey)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, n)
+
+		return nil
+	}
+
+	err := selectObjects(ctx, stmt, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"networks_wireguard_keys\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// getNetworkWireguardKeyPairsRaw can be used to run handwritten query strings to return a slice of objects.
+func getNetworkWireguardKeyPairsRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]NetworkWireguardKeyPair, error) {
+	objects := make([]NetworkWireguardKeyPair, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		n := NetworkWireguardKeyPair{}
+		err := scan(&n.ID, &n.NetworkID, &n.NodeID, &n.PrivateKey, &n.PublicKey)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, n)
+
+		return nil
+	}
+
+	err := scan(ctx, db, sql, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"networks_wireguard_keys\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetNetworkWireguardKeyPairs returns all available NetworkWireguardKeyPairs.
+// generator: NetworkWireguardKeyPair GetMany
+func GetNetworkWireguardKeyPairs(ctx context.Context, db dbtx, filters ...NetworkWireguardKeyPairFilter) (_ []NetworkWireguardKeyPair, _err error) {
+	defer func() {
+		_err = mapErr(_err, "NetworkWireguardKeyPair")
+	}()
+
+	var err error
+
+	// Result slice.
+	objects := make([]NetworkWireguardKeyPair, 0)
+
+	// Pick the prepared statement and arguments to use based on active criteria.
+	var sqlStmt *sql.Stmt
+	args := []any{}
+	queryParts := [2]string{}
+
+	if len(filters) == 0 {
+		sqlStmt, err = Stmt(db, networkWireguardKeyPairObjects)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get \"networkWireguardKeyPairObjects\" prepared statement: %w", err)
+		}
+	}
+
+	for i, filter := range filters {
+		if filter.NetworkID != nil {
+			args = append(args, []any{filter.NetworkID}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, networkWireguardKeyPairObjectsByNetworkID)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"networkWireguardKeyPairObjectsByNetworkID\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(networkWireguardKeyPairObjectsByNetworkID)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"networkWireguardKeyPairObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.NetworkID == nil {
+			return nil, fmt.Errorf("Cannot filter on empty NetworkWireguardKeyPairFilter")
+		} else {
+			return nil, errors.New("No statement exists for the given Filter")
+		}
+	}
+
+	// Select.
+	if sqlStmt != nil {
+		objects, err = getNetworkWireguardKeyPairs(ctx, sqlStmt, args...)
+	} else {
+		queryStr := strings.Join(queryParts[:], "ORDER BY")
+		objects, err = getNetworkWireguardKeyPairsRaw(ctx, db, queryStr, args...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"networks_wireguard_keys\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// CreateNetworkWireguardKeyPair adds a new NetworkWireguardKeyPair to the database.
+// generator: NetworkWireguardKeyPair Create
+func CreateNetworkWireguardKeyPair(ctx context.Context, db dbtx, object NetworkWireguardKeyPair) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "NetworkWireguardKeyPair")
+	}()
+
+	args := make([]any, 4)
+
+	// Populate the statement arguments.
+	args[0] = object.NetworkID
+	args[1] = object.NodeID
+	args[2] = object.PrivateKey
+	args[3] = object.PublicKey
+
+	// Prepared statement to use.
+	stmt, err := Stmt(db, networkWireguardKeyPairCreate)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"networkWireguardKeyPairCreate\" prepared statement: %w", err)
+	}
+
+	// Execute the statement.
+	result, err := stmt.Exec(args...)
+	if err != nil && strings.HasPrefix(err.Error(), "UNIQUE constraint failed:") {
+		return -1, ErrConflict
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to create \"networks_wireguard_keys\" entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("Failed to fetch \"networks_wireguard_keys\" entry ID: %w", err)
+	}
+
+	return id, nil
+}
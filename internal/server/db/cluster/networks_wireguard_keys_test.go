@@ -0,0 +1,64 @@
+//go:build linux && cgo && !agent
+
+package cluster_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+)
+
+func TestNetworkWireguardKeyPairs(t *testing.T) {
+	db, err := cluster.Schema().ExerciseUpdate(79, nil)
+	require.NoError(t, err)
+
+	defer func() { _ = db.Close() }()
+
+	cluster.PreparedStmts, err = cluster.PrepareStmts(db, false)
+	require.NoError(t, err)
+
+	for _, node := range []string{"(1, 'n1', '', '1.2.3.4:666', 1, 32, ?, 0, 1)", "(2, 'n2', '', '5.6.7.8:666', 1, 32, ?, 0, 1)"} {
+		_, err = db.Exec("INSERT INTO nodes (id, name, description, address, schema, api_extensions, heartbeat, state, arch) VALUES "+node, time.Now())
+		require.NoError(t, err)
+	}
+
+	_, err = db.Exec("INSERT INTO networks (id, project_id, name, description, state, type) VALUES (1, 1, 'wg0', '', 1, 6)")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	networkID := int64(1)
+
+	_, err = cluster.CreateNetworkWireguardKeyPair(ctx, db, cluster.NetworkWireguardKeyPair{NetworkID: networkID, NodeID: 1, PrivateKey: "private1", PublicKey: "public1"})
+	require.NoError(t, err)
+
+	_, err = cluster.CreateNetworkWireguardKeyPair(ctx, db, cluster.NetworkWireguardKeyPair{NetworkID: networkID, NodeID: 2, PrivateKey: "private2", PublicKey: "public2"})
+	require.NoError(t, err)
+
+	// A member has a single key pair per network.
+	_, err = cluster.CreateNetworkWireguardKeyPair(ctx, db, cluster.NetworkWireguardKeyPair{NetworkID: networkID, NodeID: 2, PrivateKey: "private3", PublicKey: "public3"})
+	assert.Error(t, err)
+
+	err = cluster.UpdateNetworkWireguardKeyPair(ctx, db, networkID, 2, "private4", "public4")
+	require.NoError(t, err)
+
+	keyPairs, err := cluster.GetNetworkWireguardKeyPairs(ctx, db, cluster.NetworkWireguardKeyPairFilter{NetworkID: &networkID})
+	require.NoError(t, err)
+	require.Len(t, keyPairs, 2)
+
+	assert.Equal(t, "public1", keyPairs[0].PublicKey)
+	assert.Equal(t, "private4", keyPairs[1].PrivateKey)
+	assert.Equal(t, "public4", keyPairs[1].PublicKey)
+
+	// The keys are removed along with the cluster member.
+	_, err = db.Exec("DELETE FROM nodes WHERE id = 2")
+	require.NoError(t, err)
+
+	keyPairs, err = cluster.GetNetworkWireguardKeyPairs(ctx, db)
+	require.NoError(t, err)
+	assert.Len(t, keyPairs, 1)
+}
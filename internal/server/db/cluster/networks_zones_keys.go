@@ -0,0 +1,65 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Code generation directives.
+//
+//generate-database:mapper target networks_zones_keys.mapper.go
+//generate-database:mapper reset -i -b "//go:build linux && cgo && !agent"
+//
+// Statements:
+//generate-database:mapper stmt -e NetworkZoneKeyPair objects table=networks_zones_keys
+//generate-database:mapper stmt -e NetworkZoneKeyPair objects-by-ID table=networks_zones_keys
+//generate-database:mapper stmt -e NetworkZoneKeyPair objects-by-NetworkZoneID table=networks_zones_keys
+//generate-database:mapper stmt -e NetworkZoneKeyPair create table=networks_zones_keys
+//generate-database:mapper stmt -e NetworkZoneKeyPair delete-by-ID table=networks_zones_keys
+//generate-database:mapper stmt -e NetworkZoneKeyPair delete-by-NetworkZoneID table=networks_zones_keys
+//
+// Methods:
+//generate-database:mapper method -i -e NetworkZoneKeyPair GetMany table=networks_zones_keys
+//generate-database:mapper method -i -e NetworkZoneKeyPair Create table=networks_zones_keys
+//generate-database:mapper method -i -e NetworkZoneKeyPair DeleteOne-by-ID table=networks_zones_keys
+//generate-database:mapper method -i -e NetworkZoneKeyPair DeleteMany-by-NetworkZoneID table=networks_zones_keys
+
+// NetworkZoneKeyPair is a value object holding db-related details about a DNSSEC key pair of a network zone.
+type NetworkZoneKeyPair struct {
+	ID            int    `db:"order=yes"`
+	NetworkZoneID int    `db:"primary=yes"`
+	Role          string // Either "ksk" or "zsk".
+	State         string // One of "published", "active" or "retired".
+	PublicKey     string `db:"primary=yes"`
+	PrivateKey    string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NetworkZoneKeyPairFilter defines the optional WHERE-clause fields.
+type NetworkZoneKeyPairFilter struct {
+	ID            *int
+	NetworkZoneID *int
+}
+
+// UpdateNetworkZoneKeyPairState changes the state of a DNSSEC key.
+func UpdateNetworkZoneKeyPairState(ctx context.Context, db dbtx, id int, state string, updatedAt time.Time) error {
+	result, err := db.ExecContext(ctx, "UPDATE networks_zones_keys SET state=?, updated_at=? WHERE id=?", state, updatedAt, id)
+	if err != nil {
+		return fmt.Errorf("Failed updating network zone key state: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n != 1 {
+		return fmt.Errorf("Query updated %d rows instead of 1", n)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+//go:build linux && cgo && !agent
+
+package cluster
+
+import "context"
+
+// NetworkZoneKeyPairGenerated is an interface of generated methods for NetworkZoneKeyPair.
+type NetworkZoneKeyPairGenerated interface {
+	// GetNetworkZoneKeyPairs returns all available NetworkZoneKeyPairs.
+	// generator: NetworkZoneKeyPair GetMany
+	GetNetworkZoneKeyPairs(ctx context.Context, db dbtx, filters ...NetworkZoneKeyPairFilter) ([]NetworkZoneKeyPair, error)
+
+	// CreateNetworkZoneKeyPair adds a new NetworkZoneKeyPair to the database.
+	// generator: NetworkZoneKeyPair Create
+	CreateNetworkZoneKeyPair(ctx context.Context, db dbtx, object NetworkZoneKeyPair) (int64, error)
+
+	// DeleteNetworkZoneKeyPair deletes the NetworkZoneKeyPair matching the given key parameters.
+	// generator: NetworkZoneKeyPair DeleteOne-by-ID
+	DeleteNetworkZoneKeyPair(ctx context.Context, db dbtx, id int) error
+
+	// DeleteNetworkZoneKeyPairs deletes the NetworkZoneKeyPair matching the given key parameters.
+	// generator: NetworkZoneKeyPair DeleteMany-by-NetworkZoneID
+	DeleteNetworkZoneKeyPairs(ctx context.Context, db dbtx, networkZoneID int) error
+}
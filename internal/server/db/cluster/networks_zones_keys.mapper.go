@@ -0,0 +1,293 @@
+//go:build linux && cgo && !agent
+
+// Code generated by generate-database from the incus project - DO NOT EDIT.
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var networkZoneKeyPairObjects = RegisterStmt(`
+SELECT networks_zones_keys.id, networks_zones_keys.network_zone_id, networks_zones_keys.role, networks_zones_keys.state, networks_zones_keys.public_key, networks_zones_keys.private_key, networks_zones_keys.created_at, networks_zones_keys.updated_at
+  FROM networks_zones_keys
+  ORDER BY networks_zones_keys.id
+`)
+
+var networkZoneKeyPairObjectsByID = RegisterStmt(`
+SELECT networks_zones_keys.id, networks_zones_keys.network_zone_id, networks_zones_keys.role, networks_zones_keys.state, networks_zones_keys.public_key, networks_zones_keys.private_key, networks_zones_keys.created_at, networks_zones_keys.updated_at
+  FROM networks_zones_keys
+  WHERE ( networks_zones_keys.id = ? )
+  ORDER BY networks_zones_keys.id
+`)
+
+var networkZoneKeyPairObjectsByNetworkZoneID = RegisterStmt(`
+SELECT networks_zones_keys.id, networks_zones_keys.network_zone_id, networks_zones_keys.role, networks_zones_keys.state, networks_zones_keys.public_key, networks_zones_keys.private_key, networks_zones_keys.created_at, networks_zones_keys.updated_at
+  FROM networks_zones_keys
+  WHERE ( networks_zones_keys.network_zone_id = ? )
+  ORDER BY networks_zones_keys.id
+`)
+
+var networkZoneKeyPairCreate = RegisterStmt(`
+INSERT INTO networks_zones_keys (network_zone_id, role, state, public_key, private_key, created_at, updated_at)
+  VALUES (?, ?, ?, ?, ?, ?, ?)
+`)
+
+var networkZoneKeyPairDeleteByID = RegisterStmt(`
+DELETE FROM networks_zones_keys WHERE id = ?
+`)
+
+var networkZoneKeyPairDeleteByNetworkZoneID = RegisterStmt(`
+DELETE FROM networks_zones_keys WHERE network_zone_id = ?
+`)
+
+// networkZoneKeyPairColumns returns a string of column names to be used with a SELECT statement for the entity.
+// Use this function when building statements to retrieve database entries matching the NetworkZoneKeyPair entity.
+func networkZoneKeyPairColumns() string {
+	return "networks_zones_keys.id, networks_zones_keys.network_zone_id, networks_zones_keys.role, networks_zones_keys.state, networks_zones_keys.public_key, networks_zones_keys.private_key, networks_zones_keys.created_at, networks_zones_keys.updated_at"
+}
+
+// getNetworkZoneKeyPairs can be used to run handwritten sql.Stmts to return a slice of objects.
+func getNetworkZoneKeyPairs(ctx context.Context, stmt *sql.Stmt, args ...any) ([]NetworkZoneKeyPair, error) {
+	objects := make([]NetworkZoneKeyPair, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		n := NetworkZoneKeyPair{}
+		err := scan(&n.ID, &n.NetworkZoneID, &n.Role, &n.State, &n.PublicKey, &n.PrivateKey, &n.CreatedAt, &n.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, n)
+
+		return nil
+	}
+
+	err := selectObjects(ctx, stmt, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"networks_zones_keys\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// getNetworkZoneKeyPairsRaw can be used to run handwritten query strings to return a slice of objects.
+func getNetworkZoneKeyPairsRaw(ctx context.Context, db dbtx, sql string, args ...any) ([]NetworkZoneKeyPair, error) {
+	objects := make([]NetworkZoneKeyPair, 0)
+
+	dest := func(scan func(dest ...any) error) error {
+		n := NetworkZoneKeyPair{}
+		err := scan(&n.ID, &n.NetworkZoneID, &n.Role, &n.State, &n.PublicKey, &n.PrivateKey, &n.CreatedAt, &n.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, n)
+
+		return nil
+	}
+
+	err := scan(ctx, db, sql, dest, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"networks_zones_keys\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// GetNetworkZoneKeyPairs returns all available NetworkZoneKeyPairs.
+// generator: NetworkZoneKeyPair GetMany
+func GetNetworkZoneKeyPairs(ctx context.Context, db dbtx, filters ...NetworkZoneKeyPairFilter) (_ []NetworkZoneKeyPair, _err error) {
+	defer func() {
+		_err = mapErr(_err, "NetworkZoneKeyPair")
+	}()
+
+	var err error
+
+	// Result slice.
+	objects := make([]NetworkZoneKeyPair, 0)
+
+	// Pick the prepared statement and arguments to use based on active criteria.
+	var sqlStmt *sql.Stmt
+	args := []any{}
+	queryParts := [2]string{}
+
+	if len(filters) == 0 {
+		sqlStmt, err = Stmt(db, networkZoneKeyPairObjects)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get \"networkZoneKeyPairObjects\" prepared statement: %w", err)
+		}
+	}
+
+	for i, filter := range filters {
+		if filter.NetworkZoneID != nil && filter.ID == nil {
+			args = append(args, []any{filter.NetworkZoneID}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, networkZoneKeyPairObjectsByNetworkZoneID)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"networkZoneKeyPairObjectsByNetworkZoneID\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(networkZoneKeyPairObjectsByNetworkZoneID)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"networkZoneKeyPairObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.ID != nil && filter.NetworkZoneID == nil {
+			args = append(args, []any{filter.ID}...)
+			if len(filters) == 1 {
+				sqlStmt, err = Stmt(db, networkZoneKeyPairObjectsByID)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get \"networkZoneKeyPairObjectsByID\" prepared statement: %w", err)
+				}
+
+				break
+			}
+
+			query, err := StmtString(networkZoneKeyPairObjectsByID)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get \"networkZoneKeyPairObjects\" prepared statement: %w", err)
+			}
+
+			parts := strings.SplitN(query, "ORDER BY", 2)
+			if i == 0 {
+				copy(queryParts[:], parts)
+				continue
+			}
+
+			_, where, _ := strings.Cut(parts[0], "WHERE")
+			queryParts[0] += "OR" + where
+		} else if filter.ID == nil && filter.NetworkZoneID == nil {
+			return nil, fmt.Errorf("Cannot filter on empty NetworkZoneKeyPairFilter")
+		} else {
+			return nil, errors.New("No statement exists for the given Filter")
+		}
+	}
+
+	// Select.
+	if sqlStmt != nil {
+		objects, err = getNetworkZoneKeyPairs(ctx, sqlStmt, args...)
+	} else {
+		queryStr := strings.Join(queryParts[:], "ORDER BY")
+		objects, err = getNetworkZoneKeyPairsRaw(ctx, db, queryStr, args...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch from \"networks_zones_keys\" table: %w", err)
+	}
+
+	return objects, nil
+}
+
+// CreateNetworkZoneKeyPair adds a new NetworkZoneKeyPair to the database.
+// generator: NetworkZoneKeyPair Create
+func CreateNetworkZoneKeyPair(ctx context.Context, db dbtx, object NetworkZoneKeyPair) (_ int64, _err error) {
+	defer func() {
+		_err = mapErr(_err, "NetworkZoneKeyPair")
+	}()
+
+	args := make([]any, 7)
+
+	// Populate the statement arguments.
+	args[0] = object.NetworkZoneID
+	args[1] = object.Role
+	args[2] = object.State
+	args[3] = object.PublicKey
+	args[4] = object.PrivateKey
+	args[5] = object.CreatedAt
+	args[6] = object.UpdatedAt
+
+	// Prepared statement to use.
+	stmt, err := Stmt(db, networkZoneKeyPairCreate)
+	if err != nil {
+		return -1, fmt.Errorf("Failed to get \"networkZoneKeyPairCreate\" prepared statement: %w", err)
+	}
+
+	// Execute the statement.
+	result, err := stmt.Exec(args...)
+	if err != nil && strings.HasPrefix(err.Error(), "UNIQUE constraint failed:") {
+		return -1, ErrConflict
+	}
+
+	if err != nil {
+		return -1, fmt.Errorf("Failed to create \"networks_zones_keys\" entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("Failed to fetch \"networks_zones_keys\" entry ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// DeleteNetworkZoneKeyPair deletes the NetworkZoneKeyPair matching the given key parameters.
+// generator: NetworkZoneKeyPair DeleteOne-by-ID
+func DeleteNetworkZoneKeyPair(ctx context.Context, db dbtx, id int) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "NetworkZoneKeyPair")
+	}()
+
+	stmt, err := Stmt(db, networkZoneKeyPairDeleteByID)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"networkZoneKeyPairDeleteByID\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(id)
+	if err != nil {
+		return fmt.Errorf("Delete \"networks_zones_keys\": %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	} else if n > 1 {
+		return fmt.Errorf("Query deleted %d NetworkZoneKeyPair rows instead of 1", n)
+	}
+
+	return nil
+}
+
+// DeleteNetworkZoneKeyPairs deletes the NetworkZoneKeyPair matching the given key parameters.
+// generator: NetworkZoneKeyPair DeleteMany-by-NetworkZoneID
+func DeleteNetworkZoneKeyPairs(ctx context.Context, db dbtx, networkZoneID int) (_err error) {
+	defer func() {
+		_err = mapErr(_err, "NetworkZoneKeyPair")
+	}()
+
+	stmt, err := Stmt(db, networkZoneKeyPairDeleteByNetworkZoneID)
+	if err != nil {
+		return fmt.Errorf("Failed to get \"networkZoneKeyPairDeleteByNetworkZoneID\" prepared statement: %w", err)
+	}
+
+	result, err := stmt.Exec(networkZoneID)
+	if err != nil {
+		return fmt.Errorf("Delete \"networks_zones_keys\": %w", err)
+	}
+
+	_, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Fetch affected rows: %w", err)
+	}
+
+	return nil
+}
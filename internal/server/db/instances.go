@@ -916,6 +916,54 @@ WHERE instances.id=?
 	return project, name, err
 }
 
+// GetInstanceProjectAndNameByUUID returns the project and the name of the instance with the given volatile UUID.
+func (c *ClusterTx) GetInstanceProjectAndNameByUUID(ctx context.Context, uuid string) (string, string, error) {
+	var project string
+	var name string
+	q := `
+SELECT projects.name, instances.name
+  FROM instances
+  JOIN projects ON projects.id = instances.project_id
+  JOIN instances_config ON instances_config.instance_id = instances.id
+WHERE instances_config.key = "volatile.uuid" AND instances_config.value = ?
+`
+	err := c.tx.QueryRowContext(ctx, q, uuid).Scan(&project, &name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", api.StatusErrorf(http.StatusNotFound, "Instance not found")
+	}
+
+	return project, name, err
+}
+
+// GetInstanceNICByHwaddr returns the project, the instance name and the device name of the instance NIC using
+// the given MAC address.
+func (c *ClusterTx) GetInstanceNICByHwaddr(ctx context.Context, hwaddr string) (string, string, string, error) {
+	var project string
+	var name string
+	var device string
+	q := `
+SELECT projects.name, instances.name, substr(instances_config.key, 10, length(instances_config.key) - 16)
+  FROM instances
+  JOIN projects ON projects.id = instances.project_id
+  JOIN instances_config ON instances_config.instance_id = instances.id
+WHERE instances_config.key LIKE "volatile.%.hwaddr" AND lower(instances_config.value) = lower(?)
+UNION
+SELECT projects.name, instances.name, instances_devices.name
+  FROM instances
+  JOIN projects ON projects.id = instances.project_id
+  JOIN instances_devices ON instances_devices.instance_id = instances.id
+  JOIN instances_devices_config ON instances_devices_config.instance_device_id = instances_devices.id
+WHERE instances_devices_config.key = "hwaddr" AND lower(instances_devices_config.value) = lower(?)
+LIMIT 1
+`
+	err := c.tx.QueryRowContext(ctx, q, hwaddr, hwaddr).Scan(&project, &name, &device)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", "", api.StatusErrorf(http.StatusNotFound, "Instance NIC not found")
+	}
+
+	return project, name, device, err
+}
+
 // GetInstanceID returns the ID of the instance with the given name.
 func (c *ClusterTx) GetInstanceID(ctx context.Context, project, name string) (int, error) {
 	id, err := cluster.GetInstanceID(ctx, c.tx, project, name)
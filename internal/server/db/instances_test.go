@@ -5,6 +5,7 @@ package db_test
 import (
 	"context"
 	"database/sql"
+	"net/http"
 	"testing"
 	"time"
 
@@ -436,6 +437,38 @@ func TestGetInstancesByMemberAddress(t *testing.T) {
 		}, result)
 }
 
+func TestGetInstanceNICByHwaddr(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	addContainer(t, tx, 1, "c1")
+	addContainerConfig(t, tx, "c1", "volatile.uuid", "c3b1d0bb-8e36-4fce-9a0d-2a4c42c7c5b6")
+	addContainerConfig(t, tx, "c1", "volatile.eth0.hwaddr", "10:66:6a:aa:bb:cc")
+
+	addContainer(t, tx, 1, "c2")
+	addContainerDevice(t, tx, "c2", "eth1", "nic", map[string]string{"hwaddr": "10:66:6a:dd:ee:ff"})
+
+	project, name, device, err := tx.GetInstanceNICByHwaddr(context.Background(), "10:66:6A:AA:BB:CC")
+	require.NoError(t, err)
+	assert.Equal(t, "default", project)
+	assert.Equal(t, "c1", name)
+	assert.Equal(t, "eth0", device)
+
+	project, name, device, err = tx.GetInstanceNICByHwaddr(context.Background(), "10:66:6a:dd:ee:ff")
+	require.NoError(t, err)
+	assert.Equal(t, "default", project)
+	assert.Equal(t, "c2", name)
+	assert.Equal(t, "eth1", device)
+
+	_, _, _, err = tx.GetInstanceNICByHwaddr(context.Background(), "10:66:6a:00:00:00")
+	assert.True(t, api.StatusErrorCheck(err, http.StatusNotFound))
+
+	project, name, err = tx.GetInstanceProjectAndNameByUUID(context.Background(), "c3b1d0bb-8e36-4fce-9a0d-2a4c42c7c5b6")
+	require.NoError(t, err)
+	assert.Equal(t, "default", project)
+	assert.Equal(t, "c1", name)
+}
+
 func TestGetInstancePool(t *testing.T) {
 	dbCluster, cleanup := db.NewTestCluster(t)
 	defer cleanup()
@@ -292,6 +292,39 @@ WHERE networks.id = ? AND networks.state = ?
 	return configs, nil
 }
 
+// GetNetworkMembersConfig returns the member specific configuration of the network, keyed by member ID.
+func (c *ClusterTx) GetNetworkMembersConfig(ctx context.Context, networkID int64) (map[int64]map[string]string, error) {
+	q := `
+	SELECT node_id, key, value
+	FROM networks_config
+	WHERE network_id=? AND node_id IS NOT NULL
+	`
+
+	configs := map[int64]map[string]string{}
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		var nodeID int64
+		var key, value string
+
+		err := scan(&nodeID, &key, &value)
+		if err != nil {
+			return err
+		}
+
+		if configs[nodeID] == nil {
+			configs[nodeID] = map[string]string{}
+		}
+
+		configs[nodeID][key] = value
+
+		return nil
+	}, networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
 // CreatePendingNetwork creates a new pending network on the node with the given name.
 func (c *ClusterTx) CreatePendingNetwork(ctx context.Context, node string, projectName string, name string, description string, netType NetworkType, conf map[string]string) error {
 	// First check if a network with the given name exists, and, if so, that it's in the pending state.
@@ -561,11 +594,13 @@ type NetworkType int
 
 // Network types.
 const (
-	NetworkTypeBridge   NetworkType = iota // Network type bridge.
-	NetworkTypeMacvlan                     // Network type macvlan.
-	NetworkTypeSriov                       // Network type sriov.
-	NetworkTypeOVN                         // Network type ovn.
-	NetworkTypePhysical                    // Network type physical.
+	NetworkTypeBridge    NetworkType = iota // Network type bridge.
+	NetworkTypeMacvlan                      // Network type macvlan.
+	NetworkTypeSriov                        // Network type sriov.
+	NetworkTypeOVN                          // Network type ovn.
+	NetworkTypePhysical                     // Network type physical.
+	NetworkTypeVXLAN                        // Network type vxlan.
+	NetworkTypeWireguard                    // Network type wireguard.
 )
 
 // NetworkNode represents a network node.
@@ -693,6 +728,10 @@ func networkFillType(network *api.Network, netType NetworkType) {
 		network.Type = "ovn"
 	case NetworkTypePhysical:
 		network.Type = "physical"
+	case NetworkTypeVXLAN:
+		network.Type = "vxlan"
+	case NetworkTypeWireguard:
+		network.Type = "wireguard"
 	default:
 		network.Type = "" // Unknown
 	}
@@ -891,7 +930,17 @@ var nodeSpecificNetworkConfig = []string{
 	"bgp.ipv4.nexthop",
 	"bgp.ipv6.nexthop",
 	"bridge.external_interfaces",
+	"bridge.ipv4.address",
+	"bridge.ipv6.address",
+	"ipv6.delegation.interface",
 	"parent",
+	"volatile.ipv6.delegation.address",
+	"volatile.ipv6.delegation.prefix",
+	"vxlan.interface",
+	"vxlan.local",
+	"wireguard.ipv4.address",
+	"wireguard.ipv6.address",
+	"wireguard.routes",
 }
 
 // nodeSpecificNetworkConfigRe lists dynamic network config keys which are node-specific.
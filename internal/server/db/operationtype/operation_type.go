@@ -79,6 +79,8 @@ const (
 	BucketBackupRemove
 	BucketBackupRename
 	BucketBackupRestore
+	InstanceReplicate
+	CustomVolumeReplicate
 )
 
 // Description return a human-readable description of the operation type.
@@ -210,6 +212,10 @@ func (t Type) Description() string {
 		return "Renaming bucket backup"
 	case BucketBackupRestore:
 		return "Restoring bucket backup"
+	case InstanceReplicate:
+		return "Replicating instance"
+	case CustomVolumeReplicate:
+		return "Replicating storage volume"
 	default:
 		return "Executing operation"
 	}
@@ -301,6 +307,11 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 	case BucketBackupRestore:
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit
 
+	case InstanceReplicate:
+		return auth.ObjectTypeInstance, auth.EntitlementCanEdit
+	case CustomVolumeReplicate:
+		return auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit
+
 	default:
 		return "", ""
 	}
@@ -56,6 +56,14 @@ const (
 	StoragePoolUnvailable
 	// UnableToUpdateClusterCertificate represents the unable to update cluster certificate warning.
 	UnableToUpdateClusterCertificate
+	// ScheduledBackupFailure represents the failure of a scheduled instance or custom volume backup.
+	ScheduledBackupFailure
+	// StoragePoolUnhealthy represents a storage pool reported as degraded or failed by its storage driver.
+	StoragePoolUnhealthy
+	// ReplicationFailure represents the failure of a scheduled instance or custom volume replication.
+	ReplicationFailure
+	// PrefixDelegationFailure represents the failure to apply a change of the IPv6 prefix delegated to a network.
+	PrefixDelegationFailure
 )
 
 // TypeNames associates a warning code to its name.
@@ -84,6 +92,10 @@ var TypeNames = map[Type]string{
 	InstanceTypeNotOperational:        "Instance type not operational",
 	StoragePoolUnvailable:             "Storage pool unavailable",
 	UnableToUpdateClusterCertificate:  "Unable to update cluster certificate",
+	ScheduledBackupFailure:            "Failed to create scheduled backup",
+	StoragePoolUnhealthy:              "Storage pool unhealthy",
+	ReplicationFailure:                "Failed to replicate",
+	PrefixDelegationFailure:           "Failed to apply delegated IPv6 prefix",
 }
 
 // Severity returns the severity of the warning type.
@@ -137,6 +149,14 @@ func (t Type) Severity() Severity {
 		return SeverityHigh
 	case UnableToUpdateClusterCertificate:
 		return SeverityLow
+	case ScheduledBackupFailure:
+		return SeverityModerate
+	case StoragePoolUnhealthy:
+		return SeverityHigh
+	case ReplicationFailure:
+		return SeverityModerate
+	case PrefixDelegationFailure:
+		return SeverityModerate
 	}
 
 	return SeverityLow
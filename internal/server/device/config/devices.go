@@ -234,3 +234,76 @@ func (list Devices) Reversed() DevicesSortable {
 	sort.Sort(sort.Reverse(sortable))
 	return sortable
 }
+
+// writeOnlyKeys lists the device configuration keys holding secrets which aren't returned through the API, per
+// device type. Each of them is associated with the key which must remain set for the secret to be kept when a
+// configuration retrieved through the API is written back.
+var writeOnlyKeys = map[string]map[string]string{
+	"proxy": {"http.tls.key": "http.tls.certificate"},
+}
+
+// StripWriteOnly returns a copy of the devices without the configuration keys holding secrets.
+func StripWriteOnly(devices map[string]map[string]string) map[string]map[string]string {
+	if devices == nil {
+		return nil
+	}
+
+	stripped := make(map[string]map[string]string, len(devices))
+	for devName, devConfig := range devices {
+		keys := writeOnlyKeys[devConfig["type"]]
+		if len(keys) == 0 {
+			stripped[devName] = devConfig
+			continue
+		}
+
+		newConfig := make(map[string]string, len(devConfig))
+		for k, v := range devConfig {
+			_, ok := keys[k]
+			if !ok {
+				newConfig[k] = v
+			}
+		}
+
+		stripped[devName] = newConfig
+	}
+
+	return stripped
+}
+
+// KeepWriteOnly copies the configuration keys holding secrets from the old devices to the new devices of the same
+// name and type which don't set them, so that configurations retrieved through the API can be written back as is.
+func KeepWriteOnly(newDevices map[string]map[string]string, oldDevices map[string]map[string]string) {
+	for devName, newConfig := range newDevices {
+		oldConfig, ok := oldDevices[devName]
+		if !ok || newConfig == nil || oldConfig["type"] != newConfig["type"] {
+			continue
+		}
+
+		for k, requiredKey := range writeOnlyKeys[newConfig["type"]] {
+			_, ok := newConfig[k]
+			if ok || oldConfig[k] == "" || newConfig[requiredKey] == "" {
+				continue
+			}
+
+			newConfig[k] = oldConfig[k]
+		}
+	}
+}
+
+// MissingWriteOnly returns the sorted names of the devices which set the keys requiring a secret but not the secret
+// itself, such as devices retrieved through the API.
+func MissingWriteOnly(devices map[string]map[string]string) []string {
+	names := []string{}
+	for devName, devConfig := range devices {
+		for k, requiredKey := range writeOnlyKeys[devConfig["type"]] {
+			if devConfig[requiredKey] != "" && devConfig[k] == "" {
+				names = append(names, devName)
+				break
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -49,3 +49,79 @@ func TestSortableDevices(t *testing.T) {
 	result = devices.Reversed()
 	assert.Equal(t, expectedReversed, result)
 }
+
+func TestStripWriteOnly(t *testing.T) {
+	devices := map[string]map[string]string{
+		"eth0":  {"type": "nic", "network": "incusbr0"},
+		"https": {"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert", "http.tls.key": "key"},
+	}
+
+	stripped := StripWriteOnly(devices)
+	assert.Equal(t, map[string]map[string]string{
+		"eth0":  {"type": "nic", "network": "incusbr0"},
+		"https": {"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert"},
+	}, stripped)
+
+	// The original devices are left untouched.
+	assert.Equal(t, "key", devices["https"]["http.tls.key"])
+	assert.Nil(t, StripWriteOnly(nil))
+}
+
+func TestKeepWriteOnly(t *testing.T) {
+	oldDevices := map[string]map[string]string{
+		"https": {"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert", "http.tls.key": "key"},
+	}
+
+	tests := []struct {
+		name   string
+		device map[string]string
+		want   map[string]string
+	}{
+		{
+			name:   "Key omitted",
+			device: map[string]string{"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert"},
+			want:   map[string]string{"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert", "http.tls.key": "key"},
+		},
+		{
+			name:   "Key replaced",
+			device: map[string]string{"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert2", "http.tls.key": "key2"},
+			want:   map[string]string{"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert2", "http.tls.key": "key2"},
+		},
+		{
+			name:   "Key cleared",
+			device: map[string]string{"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert", "http.tls.key": ""},
+			want:   map[string]string{"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert", "http.tls.key": ""},
+		},
+		{
+			name:   "Certificate removed",
+			device: map[string]string{"type": "proxy"},
+			want:   map[string]string{"type": "proxy"},
+		},
+		{
+			name:   "Different device type",
+			device: map[string]string{"type": "nic", "http.tls.certificate": "cert"},
+			want:   map[string]string{"type": "nic", "http.tls.certificate": "cert"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newDevices := map[string]map[string]string{"https": tt.device}
+			KeepWriteOnly(newDevices, oldDevices)
+			assert.Equal(t, tt.want, newDevices["https"])
+		})
+	}
+}
+
+func TestMissingWriteOnly(t *testing.T) {
+	devices := map[string]map[string]string{
+		"eth0":   {"type": "nic", "network": "incusbr0"},
+		"https":  {"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert"},
+		"https2": {"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert", "http.tls.key": "key"},
+		"http":   {"type": "proxy", "http": "true"},
+		"admin":  {"type": "proxy", "http.tls": "custom", "http.tls.certificate": "cert", "http.tls.key": ""},
+	}
+
+	assert.Equal(t, []string{"admin", "https"}, MissingWriteOnly(devices))
+	assert.Empty(t, MissingWriteOnly(nil))
+}
@@ -44,6 +44,8 @@ func newByType(state *state.State, projectName string, conf deviceConfig.Device)
 			dev = &nicSRIOV{}
 		case "ovn":
 			dev = &nicOVN{}
+		case "vhost-user":
+			dev = &nicVhostUser{}
 		}
 
 	case "infiniband":
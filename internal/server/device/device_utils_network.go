@@ -609,6 +609,97 @@ func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device,
 	return nil
 }
 
+// networkValidateQoSClass checks that the QoS class used by the NIC is defined on the managed network.
+func networkValidateQoSClass(config deviceConfig.Device, n network.Network) error {
+	className := config["qos.class"]
+	if className == "" {
+		return nil
+	}
+
+	if config["limits.egress"] != "" || config["limits.max"] != "" {
+		return errors.New(`"qos.class" can't be used in conjunction with "limits.egress" or "limits.max"`)
+	}
+
+	if n == nil {
+		return errors.New(`"qos.class" requires a managed parent network`)
+	}
+
+	if !network.QoSClassExists(n.Config(), className) {
+		return fmt.Errorf("QoS class %q isn't defined on network %q", className, n.Name())
+	}
+
+	return nil
+}
+
+// networkSetupHostVethQoS puts the traffic coming from the veth device specified in the config into its QoS class.
+// The traffic is redirected to the QoS interface of the network defining the class, and marked with the class DSCP
+// value (if any). Must be called after networkSetupHostVethLimits as both use the ingress qdisc of the veth device.
+func networkSetupHostVethQoS(d *deviceCommon, n network.Network, bridged bool) error {
+	veth := d.config["host_name"]
+
+	err := d.state.Firewall.InstanceClearDSCP(d.inst.Project().Name, d.inst.Name(), veth)
+	if err != nil {
+		return err
+	}
+
+	// The ingress qdisc is in use by the egress limits, which can't be combined with a QoS class.
+	if d.config["limits.egress"] != "" || d.config["limits.max"] != "" {
+		return nil
+	}
+
+	qdiscIngress := &ip.QdiscIngress{Qdisc: ip.Qdisc{Dev: veth, Handle: "ffff:0"}}
+	err = qdiscIngress.Delete()
+	if err != nil && !errors.Is(err, unix.ENOENT) {
+		return err
+	}
+
+	className := d.config["qos.class"]
+	if className == "" {
+		return nil
+	}
+
+	if n == nil || !network.QoSClassExists(n.Config(), className) {
+		return fmt.Errorf("Unknown QoS class %q", className)
+	}
+
+	err = qdiscIngress.Add()
+	if err != nil {
+		return fmt.Errorf("Failed to create ingress tc qdisc: %s", err)
+	}
+
+	// Set the packet priority to the class ID before redirecting it to the network's QoS interface.
+	skbEdit := &ip.ActionSkbEdit{Priority: network.QoSClassPriority(className)}
+	mirred := &ip.ActionMirred{Dev: network.QoSInterfaceName(n.Name())}
+	filter := &ip.U32Filter{Filter: ip.Filter{Dev: veth, Parent: "ffff:0", Protocol: "all"}, Value: 0, Mask: 0, Actions: []ip.Action{skbEdit, mirred}}
+	err = filter.Add()
+	if err != nil {
+		return fmt.Errorf("Failed to create QoS tc filter: %s", err)
+	}
+
+	dscp, err := network.QoSClassDSCP(n.Config(), className)
+	if err != nil {
+		return err
+	}
+
+	if dscp >= 0 {
+		if bridged && d.state.Firewall.String() == "xtables" {
+			return errors.New("Failed to setup instance device DSCP marking. The xtables firewall driver does not support required functionality.")
+		}
+
+		err = d.state.Firewall.InstanceSetupDSCP(d.inst.Project().Name, d.inst.Name(), veth, uint8(dscp))
+		if err != nil {
+			return fmt.Errorf("Failed to setup instance device DSCP marking: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// networkClearHostVethQoS clears any DSCP marking of the traffic coming from the veth device specified in the config.
+func networkClearHostVethQoS(d *deviceCommon) error {
+	return d.state.Firewall.InstanceClearDSCP(d.inst.Project().Name, d.inst.Name(), d.config["host_name"])
+}
+
 // networkClearHostVethLimits clears any network rate limits to the veth device specified in the config.
 func networkClearHostVethLimits(d *deviceCommon) error {
 	err := d.state.Firewall.InstanceClearNetPrio(d.inst.Project().Name, d.inst.Name(), d.config["host_name"])
@@ -682,6 +773,33 @@ func bgpAddPrefix(d *deviceCommon, n network.Network, config map[string]string)
 		}
 	}
 
+	// Advertise the NIC location on networks relying on EVPN.
+	evpnNet, ok := n.(network.EVPNNetwork)
+	if ok {
+		hwaddr := config["hwaddr"]
+		if hwaddr == "" {
+			hwaddr = d.volatileGet()["hwaddr"]
+		}
+
+		mac, err := net.ParseMAC(hwaddr)
+		if err != nil {
+			return fmt.Errorf("Failed parsing MAC address %q: %w", hwaddr, err)
+		}
+
+		ips := []net.IP{}
+		for _, key := range []string{"ipv4.address", "ipv6.address"} {
+			address := net.ParseIP(config[key])
+			if address != nil {
+				ips = append(ips, address)
+			}
+		}
+
+		err = evpnNet.EVPNAdvertiseNIC(bgpOwner, mac, ips)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -692,7 +810,13 @@ func bgpRemovePrefix(d *deviceCommon, config map[string]string) error {
 	}
 
 	// Load the network configuration.
-	err := d.state.BGP.RemovePrefixByOwner(fmt.Sprintf("instance_%d_%s", d.inst.ID(), d.name))
+	bgpOwner := fmt.Sprintf("instance_%d_%s", d.inst.ID(), d.name)
+	err := d.state.BGP.RemovePrefixByOwner(bgpOwner)
+	if err != nil {
+		return err
+	}
+
+	err = d.state.BGP.RemoveEVPNByOwner(bgpOwner)
 	if err != nil {
 		return err
 	}
@@ -29,6 +29,7 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		"limits.egress":                        validate.IsAny,
 		"limits.max":                           validate.IsAny,
 		"limits.priority":                      validate.Optional(validate.IsUint32),
+		"qos.class":                            validate.IsAny,
 		"security.mac_filtering":               validate.IsAny,
 		"security.ipv4_filtering":              validate.IsAny,
 		"security.ipv6_filtering":              validate.IsAny,
@@ -56,6 +57,7 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		"security.promiscuous":                 validate.Optional(validate.IsBool),
 		"mode":                                 validate.Optional(validate.IsOneOf("bridge", "vepa", "passthru", "private")),
 		"io.bus":                               validate.Optional(func(_ string) error { return nicCheckIsVM(instConf) }, validate.IsOneOf("virtio", "usb")),
+		"queues":                               validate.Optional(func(_ string) error { return nicCheckIsVM(instConf) }, validate.IsInRange(1, 256)),
 	}
 
 	validators := map[string]func(value string) error{}
@@ -164,6 +164,14 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: The priority for outgoing traffic, to be used by the kernel queuing discipline to prioritize network packets
 		"limits.priority",
 
+		// gendoc:generate(entity=devices, group=nic_bridged, key=qos.class)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: The QoS class of the parent managed network to put the outgoing traffic into (see {ref}`network-bridge-qos`)
+		"qos.class",
+
 		// gendoc:generate(entity=devices, group=nic_bridged, key=ipv4.address)
 		//
 		// ---
@@ -316,6 +324,15 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		//  managed: no
 		//  shortdesc: Override the bus for the device (can be `virtio` or `usb`) (VM only)
 		"io.bus",
+
+		// gendoc:generate(entity=devices, group=nic_bridged, key=queues)
+		//
+		// ---
+		//  type: integer
+		//  default: number of vCPUs (minimum of 2)
+		//  managed: no
+		//  shortdesc: The number of queue pairs of the NIC (VM only)
+		"queues",
 	}
 
 	// checkWithManagedNetwork validates the device's settings against the managed network.
@@ -324,8 +341,26 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 			return errors.New("Specified network is not fully created")
 		}
 
-		if n.Type() != "bridge" {
-			return errors.New("Specified network must be of type bridge")
+		if !slices.Contains([]string{"bridge", "vxlan", "wireguard"}, n.Type()) {
+			return errors.New("Specified network must be of type bridge, vxlan or wireguard")
+		}
+
+		// VXLAN networks don't manage addressing, static addresses are only used for the EVPN routes.
+		if n.Type() == "vxlan" {
+			if d.config["network"] == "" {
+				return errors.New(`VXLAN networks must be specified using the "network" property`)
+			}
+
+			return nil
+		}
+
+		// WireGuard networks don't manage addressing either, the NIC is connected to the bridge of the network.
+		if n.Type() == "wireguard" {
+			if d.config["network"] == "" {
+				return errors.New(`WireGuard networks must be specified using the "network" property`)
+			}
+
+			return nil
 		}
 
 		netConfig := n.Config()
@@ -380,7 +415,7 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 				return fmt.Errorf("Device IP address %q not within network %q subnet", d.config["ipv6.address"], n.Name())
 			}
 
-			parentAddress := netConfig["ipv6.address"]
+			parentAddress := network.BridgeIPv6Address(netConfig)
 			if slices.Contains([]string{"", "none"}, parentAddress) {
 				return nil
 			}
@@ -452,10 +487,23 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 
 		// Link device to network bridge.
 		d.config["parent"] = d.config["network"]
+		if d.network.Type() == "wireguard" {
+			d.config["parent"] = network.WireguardBridgeName(d.network.ID())
+		}
 
 		// Apply network level config options to device config before validation.
 		if netConfig["bridge.mtu"] != "" {
 			d.config["mtu"] = netConfig["bridge.mtu"]
+		} else if d.network.Type() == "vxlan" {
+			d.config["mtu"] = netConfig["mtu"]
+			if d.config["mtu"] == "" {
+				d.config["mtu"] = "1450"
+			}
+		} else if d.network.Type() == "wireguard" {
+			d.config["mtu"] = netConfig["mtu"]
+			if d.config["mtu"] == "" {
+				d.config["mtu"] = "1420"
+			}
 		}
 	} else {
 		// If no network property supplied, then parent property is required.
@@ -589,6 +637,11 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		return err
 	}
 
+	err = networkValidateQoSClass(d.config, d.network)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -679,6 +732,10 @@ func (d *nicBridged) validateEnvironment() error {
 	}
 
 	if !util.PathExists(fmt.Sprintf("/sys/class/net/%s", d.config["parent"])) {
+		if d.network != nil && d.network.Type() == "wireguard" {
+			return fmt.Errorf(`Network %q has no bridge on this server, set "bridge.ipv4.address" or "bridge.ipv6.address" on it`, d.network.Name())
+		}
+
 		return fmt.Errorf("Parent device %q doesn't exist", d.config["parent"])
 	}
 
@@ -693,7 +750,7 @@ func (d *nicBridged) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering", "security.acls", "security.acls.default.egress.action", "security.acls.default.egress.logged", "security.acls.default.ingress.action", "security.acls.default.ingress.logged"}
+	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "qos.class", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering", "security.acls", "security.acls.default.egress.action", "security.acls.default.egress.logged", "security.acls.default.ingress.action", "security.acls.default.ingress.logged"}
 }
 
 // Add is run when a device is added to a non-snapshot instance whether or not the instance is running.
@@ -799,6 +856,12 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 		return nil, err
 	}
 
+	// Apply host-side QoS class.
+	err = networkSetupHostVethQoS(&d.deviceCommon, d.network, true)
+	if err != nil {
+		return nil, err
+	}
+
 	// Disable IPv6 on host-side veth interface (prevents host-side interface getting link-local address)
 	// which isn't needed because the host-side interface is connected to a bridge.
 	err = localUtil.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/disable_ipv6", saveData["host_name"]), "1")
@@ -863,6 +926,7 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 
 		if brNetfilterEnabled {
 			var listenAddresses map[int64]string
+			var loadBalancers int
 
 			err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 				networkID := d.network.ID()
@@ -880,6 +944,16 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 					}
 				}
 
+				// Load balancers are handled the same way as forwards.
+				dbLoadBalancers, err := cluster.GetNetworkLoadBalancers(ctx, tx.Tx(), cluster.NetworkLoadBalancerFilter{
+					NetworkID: &networkID,
+				})
+				if err != nil {
+					return err
+				}
+
+				loadBalancers = len(dbLoadBalancers)
+
 				return nil
 			})
 			if err != nil {
@@ -890,7 +964,7 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 			// bridge port in case any of the forwards target this NIC and the instance attempts to
 			// connect to the forward's listener. Without hairpin mode on the target of the forward
 			// will not be able to connect to the listener.
-			if len(listenAddresses) > 0 {
+			if len(listenAddresses) > 0 || loadBalancers > 0 {
 				link := &ip.Link{Name: saveData["host_name"]}
 				err = link.BridgeLinkSetHairpin(true)
 				if err != nil {
@@ -927,6 +1001,7 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 			[]deviceConfig.RunConfigItem{
 				{Key: "devName", Value: d.name},
 				{Key: "mtu", Value: fmt.Sprintf("%d", mtu)},
+				{Key: "queues", Value: d.config["queues"]},
 			}...)
 	}
 
@@ -1007,6 +1082,12 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 			return err
 		}
 
+		// Apply host-side QoS class.
+		err = networkSetupHostVethQoS(&d.deviceCommon, d.network, true)
+		if err != nil {
+			return err
+		}
+
 		// Apply and host-side network filters (uses enriched host_name from networkVethFillFromVolatile).
 		r, err := d.setupHostFilters(oldConfig)
 		if err != nil {
@@ -1070,6 +1151,11 @@ func (d *nicBridged) Stop() (*deviceConfig.RunConfig, error) {
 		return nil, err
 	}
 
+	err = networkClearHostVethQoS(&d.deviceCommon)
+	if err != nil {
+		return nil, err
+	}
+
 	// Setup post-stop actions.
 	runConf := deviceConfig.RunConfig{
 		PostHooks: []func() error{d.postStop},
@@ -1458,8 +1544,9 @@ func (d *nicBridged) setFilters() (err error) {
 		}
 
 		// Add IPv6 router.
-		if netConfig["ipv6.address"] != "" && netConfig["ipv6.address"] != "none" {
-			ipv6DNS = append(ipv6DNS, strings.Split(netConfig["ipv6.address"], "/")[0])
+		ipv6Address := network.BridgeIPv6Address(netConfig)
+		if ipv6Address != "" && ipv6Address != "none" {
+			ipv6DNS = append(ipv6DNS, strings.Split(ipv6Address, "/")[0])
 		}
 	}
 
@@ -1964,7 +2051,7 @@ func (d *nicBridged) State() (*api.InstanceStateNetwork, error) {
 		// Extract subnet sizes from bridge addresses if available.
 		netConfig := d.network.Config()
 		_, v4subnet, _ := net.ParseCIDR(netConfig["ipv4.address"])
-		_, v6subnet, _ := net.ParseCIDR(netConfig["ipv6.address"])
+		_, v6subnet, _ := net.ParseCIDR(network.BridgeIPv6Address(netConfig))
 
 		if v4subnet != nil {
 			mask, _ := v4subnet.Mask.Size()
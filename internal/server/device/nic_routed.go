@@ -15,6 +15,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/ip"
 	"github.com/lxc/incus/v6/internal/server/network"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/util"
@@ -29,6 +30,8 @@ var nicRoutedIPGateway = map[string]net.IP{
 type nicRouted struct {
 	deviceCommon
 	effectiveParentName string
+
+	network network.Network // Populated in validateConfig() when using a QoS class.
 }
 
 // CanHotPlug returns whether the device can be managed whilst the instance is running.
@@ -44,7 +47,7 @@ func (d *nicRouted) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority"}
+	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "qos.class"}
 }
 
 // validateConfig checks the supplied config for correctness.
@@ -141,6 +144,13 @@ func (d *nicRouted) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: The priority for outgoing traffic, to be used by the kernel queuing discipline to prioritize network packets
 		"limits.priority",
 
+		// gendoc:generate(entity=devices, group=nic_routed, key=qos.class)
+		//
+		// ---
+		//  type: string
+		//  shortdesc: The QoS class of the parent managed network to put the outgoing traffic into (see {ref}`network-bridge-qos`)
+		"qos.class",
+
 		// gendoc:generate(entity=devices, group=nic_routed, key=ipv4.gateway)
 		//
 		// ---
@@ -287,6 +297,21 @@ func (d *nicRouted) validateConfig(instConf instance.ConfigReader) error {
 		return err
 	}
 
+	// Load the parent managed network, used for the QoS classes and by WireGuard uplinks.
+	if d.config["parent"] != "" {
+		// api.ProjectDefaultName is used here as bridge and wireguard networks don't support projects.
+		d.network, _ = network.LoadByName(d.state, api.ProjectDefaultName, d.config["parent"])
+	}
+
+	if d.wireguardParent() && (d.config["vlan"] != "" || util.IsTrue(d.config["gvrp"])) {
+		return errors.New("The vlan and gvrp settings can't be used with a wireguard parent network")
+	}
+
+	err = networkValidateQoSClass(d.config, d.network)
+	if err != nil {
+		return err
+	}
+
 	// Detect duplicate IPs in config.
 	for _, key := range []string{"ipv4.address", "ipv6.address"} {
 		ips := make(map[string]struct{})
@@ -325,7 +350,13 @@ func (d *nicRouted) validateEnvironment() error {
 		return errors.New("Requires name property to start")
 	}
 
-	if d.config["parent"] != "" {
+	if d.wireguardParent() {
+		// WireGuard parents route the addresses of the NIC to the peers, no neighbour proxy is needed.
+		err := d.validateWireguardParentEnvironment()
+		if err != nil {
+			return err
+		}
+	} else if d.config["parent"] != "" {
 		// Check parent interface exists (don't use d.effectiveParentName here as we want to check the
 		// parent of any VLAN interface exists too). The VLAN interface will be created later if needed.
 		if !network.InterfaceExists(d.config["parent"]) {
@@ -419,6 +450,42 @@ func (d *nicRouted) validateEnvironment() error {
 	return nil
 }
 
+// wireguardParent returns whether the parent is a managed wireguard network.
+func (d *nicRouted) wireguardParent() bool {
+	return d.network != nil && d.network.Type() == "wireguard"
+}
+
+// validateWireguardParentEnvironment checks that the traffic of the NIC can be forwarded to a wireguard parent.
+func (d *nicRouted) validateWireguardParentEnvironment() error {
+	if !network.InterfaceExists(d.config["parent"]) {
+		return fmt.Errorf("Parent device %q doesn't exist", d.config["parent"])
+	}
+
+	d.effectiveParentName = d.config["parent"]
+
+	sysctls := []string{}
+	if d.config["ipv4.address"] != "" {
+		sysctls = append(sysctls, "net/ipv4/ip_forward")
+	}
+
+	if d.config["ipv6.address"] != "" {
+		sysctls = append(sysctls, "net/ipv6/conf/all/forwarding", fmt.Sprintf("net/ipv6/conf/%s/forwarding", d.effectiveParentName))
+	}
+
+	for _, sysctl := range sysctls {
+		sysctlVal, err := localUtil.SysctlGet(sysctl)
+		if err != nil {
+			return fmt.Errorf("Error reading net sysctl %s: %w", sysctl, err)
+		}
+
+		if sysctlVal != "1\n" {
+			return fmt.Errorf("Routed mode requires sysctl %s=1", strings.ReplaceAll(sysctl, "/", "."))
+		}
+	}
+
+	return nil
+}
+
 // checkIPAvailability checks using ARP and NDP neighbour probes whether any of the NIC's IPs are already in use.
 func (d *nicRouted) checkIPAvailability(parent string) error {
 	var addresses []net.IP
@@ -504,7 +571,7 @@ func (d *nicRouted) Start() (*deviceConfig.RunConfig, error) {
 		}
 	}
 
-	if d.effectiveParentName != "" {
+	if d.effectiveParentName != "" && !d.wireguardParent() {
 		err := d.checkIPAvailability(d.effectiveParentName)
 		if err != nil {
 			return nil, err
@@ -553,6 +620,12 @@ func (d *nicRouted) Start() (*deviceConfig.RunConfig, error) {
 		return nil, err
 	}
 
+	// Apply host-side QoS class.
+	err = networkSetupHostVethQoS(&d.deviceCommon, d.network, false)
+	if err != nil {
+		return nil, err
+	}
+
 	// Attempt to disable IPv6 router advertisement acceptance from instance.
 	err = localUtil.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/accept_ra", saveData["host_name"]), "0")
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
@@ -678,7 +751,7 @@ func (d *nicRouted) Start() (*deviceConfig.RunConfig, error) {
 			}
 
 			// If there is a parent interface, add neighbour proxy entry.
-			if d.effectiveParentName != "" {
+			if d.effectiveParentName != "" && !d.wireguardParent() {
 				np := ip.NeighProxy{
 					DevName: d.effectiveParentName,
 					Addr:    net.ParseIP(addrStr),
@@ -752,6 +825,15 @@ func (d *nicRouted) Start() (*deviceConfig.RunConfig, error) {
 		return nil, err
 	}
 
+	// Have the other cluster members route the addresses of the NIC to the local member.
+	routesNetwork, ok := d.network.(network.InstanceRoutesNetwork)
+	if ok && d.wireguardParent() {
+		err = routesNetwork.RefreshInstanceRoutes()
+		if err != nil {
+			d.logger.Warn("Failed refreshing the routes of the parent network on the other cluster members", logger.Ctx{"parent": d.config["parent"], "err": err})
+		}
+	}
+
 	// Perform instance NIC configuration.
 	runConf := deviceConfig.RunConfig{}
 	runConf.NetworkInterface = []deviceConfig.RunConfigItem{
@@ -856,6 +938,12 @@ func (d *nicRouted) Update(oldDevices deviceConfig.Devices, isRunning bool) erro
 		if err != nil {
 			return err
 		}
+
+		// Apply host-side QoS class.
+		err = networkSetupHostVethQoS(&d.deviceCommon, d.network, false)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -871,6 +959,11 @@ func (d *nicRouted) Stop() (*deviceConfig.RunConfig, error) {
 		return nil, err
 	}
 
+	err = networkClearHostVethQoS(&d.deviceCommon)
+	if err != nil {
+		return nil, err
+	}
+
 	runConf := deviceConfig.RunConfig{
 		PostHooks: []func() error{d.postStop},
 	}
@@ -0,0 +1,181 @@
+package device
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+type nicVhostUser struct {
+	deviceCommon
+}
+
+// CanHotPlug returns whether the device can be managed whilst the instance is running. Returns true.
+func (d *nicVhostUser) CanHotPlug() bool {
+	return true
+}
+
+// validateConfig checks the supplied config for correctness.
+func (d *nicVhostUser) validateConfig(instConf instance.ConfigReader) error {
+	if !instanceSupported(instConf.Type(), instancetype.VM) {
+		return ErrUnsupportedDevType
+	}
+
+	requiredFields := []string{
+		// gendoc:generate(entity=devices, group=nic_vhost_user, key=socket)
+		//
+		// ---
+		//  type: string
+		//  required: yes
+		//  shortdesc: Path to the vhost-user socket of the userspace switch, relative to the directory set in `network.vhost_user.sockets_path`
+		"socket",
+	}
+
+	optionalFields := []string{
+		// gendoc:generate(entity=devices, group=nic_vhost_user, key=name)
+		//
+		// ---
+		//  type: string
+		//  default: kernel assigned
+		//  shortdesc: The name of the interface inside the instance
+		"name",
+
+		// gendoc:generate(entity=devices, group=nic_vhost_user, key=mtu)
+		//
+		// ---
+		//  type: integer
+		//  default: kernel assigned
+		//  shortdesc: The Maximum Transmit Unit (MTU) of the new interface
+		"mtu",
+
+		// gendoc:generate(entity=devices, group=nic_vhost_user, key=hwaddr)
+		//
+		// ---
+		//  type: string
+		//  default: randomly assigned
+		//  shortdesc: The MAC address of the new interface
+		"hwaddr",
+
+		// gendoc:generate(entity=devices, group=nic_vhost_user, key=queues)
+		//
+		// ---
+		//  type: integer
+		//  default: number of vCPUs (minimum of 2)
+		//  shortdesc: The number of queue pairs of the NIC (must be supported by the userspace switch)
+		"queues",
+
+		// gendoc:generate(entity=devices, group=nic_vhost_user, key=boot.priority)
+		//
+		// ---
+		//  type: integer
+		//  shortdesc: Boot priority for VMs (higher value boots first)
+		"boot.priority",
+	}
+
+	rules := nicValidationRules(requiredFields, optionalFields, instConf)
+	rules["socket"] = func(value string) error {
+		err := validate.IsNotEmpty(value)
+		if err != nil {
+			return err
+		}
+
+		if !filepath.IsLocal(value) {
+			return fmt.Errorf("Socket path %q must be relative and within the vhost-user sockets directory", value)
+		}
+
+		return nil
+	}
+
+	err := d.config.Validate(rules)
+	if err != nil {
+		return err
+	}
+
+	// The userspace switch maps the VM memory, which isn't shared when the memory is bound to the NUMA nodes of
+	// pinned vCPUs unless backed by hugepages.
+	instConfig := instConf.ExpandedConfig()
+	_, err = strconv.Atoi(instConfig["limits.cpu"])
+	if instConfig["limits.cpu"] != "" && err != nil && util.IsFalseOrEmpty(instConfig["limits.memory.hugepages"]) {
+		return errors.New(`vhost-user NICs require "limits.memory.hugepages" when "limits.cpu" pins the vCPUs`)
+	}
+
+	return nil
+}
+
+// socketPath returns the path to the vhost-user socket, checking that it is within the sockets directory.
+func (d *nicVhostUser) socketPath() (string, error) {
+	socketsPath := d.state.LocalConfig.NetworkVhostUserSocketsPath()
+	if socketsPath == "" {
+		return "", errors.New("vhost-user NICs require network.vhost_user.sockets_path to be set")
+	}
+
+	socketsPath, err := filepath.EvalSymlinks(socketsPath)
+	if err != nil {
+		return "", fmt.Errorf("Failed resolving vhost-user sockets directory: %w", err)
+	}
+
+	socketPath, err := filepath.EvalSymlinks(filepath.Join(socketsPath, d.config["socket"]))
+	if err != nil {
+		return "", fmt.Errorf("vhost-user socket %q doesn't exist", d.config["socket"])
+	}
+
+	relPath, err := filepath.Rel(socketsPath, socketPath)
+	if err != nil || !filepath.IsLocal(relPath) {
+		return "", fmt.Errorf("vhost-user socket %q isn't within the vhost-user sockets directory", d.config["socket"])
+	}
+
+	return socketPath, nil
+}
+
+// validateEnvironment checks the runtime environment for correctness.
+func (d *nicVhostUser) validateEnvironment() error {
+	// The userspace switch needs access to the VM memory, which is only shared on x86_64.
+	if d.inst.Architecture() != osarch.ARCH_64BIT_INTEL_X86 {
+		return errors.New("vhost-user NICs are only supported on x86_64")
+	}
+
+	_, err := d.socketPath()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Start is run when the device is added to a running instance or instance is starting up.
+func (d *nicVhostUser) Start() (*deviceConfig.RunConfig, error) {
+	err := d.validateEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	socketPath, err := d.socketPath()
+	if err != nil {
+		return nil, err
+	}
+
+	runConf := deviceConfig.RunConfig{}
+	runConf.NetworkInterface = []deviceConfig.RunConfigItem{
+		{Key: "name", Value: d.config["name"]},
+		{Key: "hwaddr", Value: d.config["hwaddr"]},
+		{Key: "devName", Value: d.name},
+		{Key: "mtu", Value: d.config["mtu"]},
+		{Key: "vhostUserSocket", Value: socketPath},
+		{Key: "queues", Value: d.config["queues"]},
+	}
+
+	return &runConf, nil
+}
+
+// Stop is run when the device is removed from the instance.
+func (d *nicVhostUser) Stop() (*deviceConfig.RunConfig, error) {
+	return &deviceConfig.RunConfig{}, nil
+}
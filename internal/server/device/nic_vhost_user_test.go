@@ -0,0 +1,112 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// testConfigReader is a minimal instance.ConfigReader used to validate device configurations.
+type testConfigReader struct {
+	instType instancetype.Type
+	config   map[string]string
+	devices  deviceConfig.Devices
+}
+
+func (c *testConfigReader) Project() api.Project                  { return api.Project{Name: api.ProjectDefaultName} }
+func (c *testConfigReader) Type() instancetype.Type               { return c.instType }
+func (c *testConfigReader) Architecture() int                     { return 0 }
+func (c *testConfigReader) ID() int                               { return 1 }
+func (c *testConfigReader) Name() string                          { return "v1" }
+func (c *testConfigReader) ExpandedConfig() map[string]string     { return c.config }
+func (c *testConfigReader) ExpandedDevices() deviceConfig.Devices { return c.devices }
+func (c *testConfigReader) LocalConfig() map[string]string        { return c.config }
+func (c *testConfigReader) LocalDevices() deviceConfig.Devices    { return c.devices }
+
+func Test_nicVhostUserValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		container  bool
+		instConfig map[string]string
+		config     deviceConfig.Device
+		wantErr    string
+	}{
+		{
+			name:   "Valid",
+			config: deviceConfig.Device{"socket": "ovs/vhu0", "queues": "4", "hwaddr": "00:16:3e:00:00:01"},
+		},
+		{
+			name:      "Container",
+			container: true,
+			config:    deviceConfig.Device{"socket": "vhu0"},
+			wantErr:   ErrUnsupportedDevType.Error(),
+		},
+		{
+			name:    "Missing socket",
+			config:  deviceConfig.Device{},
+			wantErr: `Invalid value for device option "socket": Required value`,
+		},
+		{
+			name:    "Absolute socket",
+			config:  deviceConfig.Device{"socket": "/run/openvswitch/vhu0"},
+			wantErr: `Invalid value for device option "socket": Socket path "/run/openvswitch/vhu0" must be relative and within the vhost-user sockets directory`,
+		},
+		{
+			name:    "Socket outside of the directory",
+			config:  deviceConfig.Device{"socket": "../vhu0"},
+			wantErr: `Invalid value for device option "socket": Socket path "../vhu0" must be relative and within the vhost-user sockets directory`,
+		},
+		{
+			name:    "Too many queues",
+			config:  deviceConfig.Device{"socket": "vhu0", "queues": "257"},
+			wantErr: `Invalid value for device option "queues": Value isn't within valid range. Must be between 1 and 256`,
+		},
+		{
+			name:    "Unknown option",
+			config:  deviceConfig.Device{"socket": "vhu0", "parent": "eth0"},
+			wantErr: `Invalid device option "parent"`,
+		},
+		{
+			name:       "Pinned vCPUs without hugepages",
+			instConfig: map[string]string{"limits.cpu": "0-3"},
+			config:     deviceConfig.Device{"socket": "vhu0"},
+			wantErr:    `vhost-user NICs require "limits.memory.hugepages" when "limits.cpu" pins the vCPUs`,
+		},
+		{
+			name:       "Pinned vCPUs with hugepages",
+			instConfig: map[string]string{"limits.cpu": "0-3", "limits.memory.hugepages": "true"},
+			config:     deviceConfig.Device{"socket": "vhu0"},
+		},
+		{
+			name:       "vCPU count",
+			instConfig: map[string]string{"limits.cpu": "4"},
+			config:     deviceConfig.Device{"socket": "vhu0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instType := instancetype.VM
+			if tt.container {
+				instType = instancetype.Container
+			}
+
+			tt.config["type"] = "nic"
+			tt.config["nictype"] = "vhost-user"
+
+			instConf := &testConfigReader{instType: instType, config: tt.instConfig, devices: deviceConfig.Devices{"eth0": tt.config}}
+			d := &nicVhostUser{deviceCommon{name: "eth0", config: tt.config}}
+
+			err := d.validateConfig(instConf)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package device
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
@@ -16,6 +19,7 @@ import (
 	liblxc "github.com/lxc/go-lxc"
 
 	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/server/acme"
 	"github.com/lxc/incus/v6/internal/server/apparmor"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
@@ -170,6 +174,57 @@ func (d *proxy) validateConfig(instConf instance.ConfigReader) error {
 		// default: `false`
 		// shortdesc: Whether to use the HAProxy PROXY protocol to transmit sender information
 		"proxy_protocol": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=devices, group=proxy, key=http)
+		//
+		// ---
+		// type: bool
+		// required: no
+		// default: `false`
+		// shortdesc: Whether to act as an HTTP reverse proxy routing requests by host name and path (see {ref}`devices-proxy-http-mode`)
+		"http": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=devices, group=proxy, key=http.hosts)
+		//
+		// ---
+		// type: string
+		// required: no
+		// shortdesc: Comma-separated list of host names (`example.com` or `*.example.com`) routed to the instance (all host names if empty)
+		"http.hosts": validate.Optional(validate.IsListOf(validateHTTPHost)),
+
+		// gendoc:generate(entity=devices, group=proxy, key=http.path)
+		//
+		// ---
+		// type: string
+		// required: no
+		// default: `/`
+		// shortdesc: Path prefix of the requests routed to the instance
+		"http.path": validate.Optional(validateHTTPPath),
+
+		// gendoc:generate(entity=devices, group=proxy, key=http.tls)
+		//
+		// ---
+		// type: string
+		// required: no
+		// default: `none`
+		// shortdesc: How to terminate TLS (`none`, `acme` to use certificates issued for `http.hosts` through the server's ACME configuration or `custom` to use `http.tls.certificate` and `http.tls.key`)
+		"http.tls": validate.Optional(validate.IsOneOf("none", "acme", "custom")),
+
+		// gendoc:generate(entity=devices, group=proxy, key=http.tls.certificate)
+		//
+		// ---
+		// type: string
+		// required: no
+		// shortdesc: PEM encoded certificate (and intermediate certificates) used when `http.tls` is `custom`
+		"http.tls.certificate": validate.Optional(validateHTTPTLSCertificate),
+
+		// gendoc:generate(entity=devices, group=proxy, key=http.tls.key)
+		//
+		// ---
+		// type: string
+		// required: no
+		// shortdesc: PEM encoded private key used when `http.tls` is `custom` (write-only, not returned through the API)
+		"http.tls.key": validate.Optional(validateHTTPTLSKey),
 	}
 
 	err := d.config.Validate(rules)
@@ -177,8 +232,8 @@ func (d *proxy) validateConfig(instConf instance.ConfigReader) error {
 		return err
 	}
 
-	if instConf.Type() == instancetype.VM && util.IsFalseOrEmpty(d.config["nat"]) {
-		return errors.New("Only NAT mode is supported for proxies on VM instances")
+	if instConf.Type() == instancetype.VM && util.IsFalseOrEmpty(d.config["nat"]) && util.IsFalseOrEmpty(d.config["http"]) {
+		return errors.New("Only NAT and HTTP modes are supported for proxies on VM instances")
 	}
 
 	listenAddr, err := network.ProxyParseAddr(d.config["listen"])
@@ -210,6 +265,19 @@ func (d *proxy) validateConfig(instConf instance.ConfigReader) error {
 		return errors.New("Only proxy devices for non-abstract unix sockets can carry uid, gid, or mode properties")
 	}
 
+	if util.IsTrue(d.config["http"]) {
+		err = d.validateHTTP(instConf, listenAddr, connectAddr)
+		if err != nil {
+			return err
+		}
+	} else {
+		for k, v := range d.config {
+			if strings.HasPrefix(k, "http.") && v != "" {
+				return fmt.Errorf("The %q option can only be used when http mode is enabled", k)
+			}
+		}
+	}
+
 	if util.IsTrue(d.config["nat"]) {
 		if d.inst != nil {
 			// Default project always has networks feature so don't bother loading the project config
@@ -259,6 +327,169 @@ func (d *proxy) validateConfig(instConf instance.ConfigReader) error {
 	return nil
 }
 
+// validateHTTP checks the HTTP reverse proxy mode settings.
+func (d *proxy) validateHTTP(instConf instance.ConfigReader, listenAddr *deviceConfig.ProxyAddress, connectAddr *deviceConfig.ProxyAddress) error {
+	if util.IsTrue(d.config["nat"]) {
+		return errors.New("HTTP mode cannot be combined with NAT mode")
+	}
+
+	if util.IsTrue(d.config["proxy_protocol"]) {
+		return errors.New("HTTP mode cannot be combined with the PROXY protocol")
+	}
+
+	if d.config["bind"] != "" && d.config["bind"] != "host" {
+		return errors.New("Only host-bound proxies can use HTTP mode")
+	}
+
+	if listenAddr.ConnType != "tcp" || len(listenAddr.Ports) != 1 {
+		return errors.New("HTTP mode requires a single TCP listen port")
+	}
+
+	if connectAddr.ConnType != "tcp" || len(connectAddr.Ports) != 1 {
+		return errors.New("HTTP mode requires a single TCP connect port")
+	}
+
+	// VMs are reached from the host, only allow connecting to the instance's own addresses.
+	if instConf.Type() == instancetype.VM && !proxyInstanceStaticAddress(instConf.ExpandedDevices(), net.ParseIP(connectAddr.Address)) {
+		return fmt.Errorf("Connect IP %q must be one of the instance's static addresses for HTTP proxies on VM instances", connectAddr.Address)
+	}
+
+	switch d.config["http.tls"] {
+	case "custom":
+		if d.config["http.tls.certificate"] == "" || d.config["http.tls.key"] == "" {
+			return errors.New("Both http.tls.certificate and http.tls.key are required when http.tls is custom")
+		}
+
+		_, err := tls.X509KeyPair([]byte(d.config["http.tls.certificate"]), []byte(d.config["http.tls.key"]))
+		if err != nil {
+			return fmt.Errorf("Invalid HTTP TLS certificate or key: %w", err)
+		}
+
+	case "acme":
+		if d.config["http.tls.certificate"] != "" || d.config["http.tls.key"] != "" {
+			return errors.New("The http.tls.certificate and http.tls.key options can only be used when http.tls is custom")
+		}
+
+		hosts := util.SplitNTrimSpace(d.config["http.hosts"], ",", -1, true)
+		if len(hosts) == 0 {
+			return errors.New("The http.hosts option is required when http.tls is acme")
+		}
+
+		if d.state != nil && d.state.GlobalConfig != nil {
+			_, email, _, agreeToS, challengeType := d.state.GlobalConfig.ACME()
+			if email == "" || !agreeToS {
+				return errors.New("Using http.tls=acme requires the acme.email and acme.agree_tos server options to be set")
+			}
+
+			if challengeType != "DNS-01" && slices.ContainsFunc(hosts, func(host string) bool { return strings.HasPrefix(host, "*.") }) {
+				return errors.New("Wildcard host names in http.hosts require the DNS-01 ACME challenge when http.tls is acme")
+			}
+		}
+
+	default:
+		if d.config["http.tls.certificate"] != "" || d.config["http.tls.key"] != "" {
+			return errors.New("The http.tls.certificate and http.tls.key options can only be used when http.tls is custom")
+		}
+	}
+
+	return nil
+}
+
+// proxyInstanceStaticAddress returns whether the address is one of the static addresses of the instance NICs.
+func proxyInstanceStaticAddress(devices deviceConfig.Devices, address net.IP) bool {
+	if address == nil {
+		return false
+	}
+
+	for _, devConfig := range devices {
+		if devConfig["type"] != "nic" {
+			continue
+		}
+
+		for _, key := range []string{"ipv4.address", "ipv6.address"} {
+			if address.Equal(net.ParseIP(devConfig[key])) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// validateHTTPTLSCertificate checks a PEM encoded certificate chain.
+func validateHTTPTLSCertificate(value string) error {
+	rest := []byte(value)
+	count := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		_, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("Invalid certificate: %w", err)
+		}
+
+		count++
+	}
+
+	if count == 0 {
+		return errors.New("No PEM encoded certificate found")
+	}
+
+	return nil
+}
+
+// validateHTTPTLSKey checks a PEM encoded private key.
+func validateHTTPTLSKey(value string) error {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return errors.New("No PEM encoded private key found")
+	}
+
+	_, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err == nil {
+		return nil
+	}
+
+	_, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err == nil {
+		return nil
+	}
+
+	_, err = x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return errors.New("Invalid private key")
+	}
+
+	return nil
+}
+
+// validateHTTPHost checks an HTTP proxy host name, optionally prefixed with a `*.` wildcard.
+func validateHTTPHost(value string) error {
+	hostName, _ := strings.CutPrefix(value, "*.")
+	if hostName != strings.ToLower(hostName) {
+		return fmt.Errorf("Host name %q must be lower case", value)
+	}
+
+	return validate.IsHostname(hostName)
+}
+
+// validateHTTPPath checks an HTTP proxy path prefix.
+func validateHTTPPath(value string) error {
+	if !strings.HasPrefix(value, "/") {
+		return fmt.Errorf("Path %q must start with /", value)
+	}
+
+	if strings.ContainsAny(value, "?# ") {
+		return fmt.Errorf("Path %q cannot contain a query, fragment or whitespace", value)
+	}
+
+	return nil
+}
+
 // validateEnvironment checks the runtime environment for correctness.
 func (d *proxy) validateEnvironment() error {
 	if d.name == "" {
@@ -334,6 +565,15 @@ func (d *proxy) Start() (*deviceConfig.RunConfig, error) {
 	runConf := deviceConfig.RunConfig{}
 	runConf.PostHooks = []func() error{
 		func() error {
+			if util.IsTrue(d.config["http"]) {
+				err = d.setupHTTP()
+				if err != nil {
+					return fmt.Errorf("Failed to start device %q: %w", d.name, err)
+				}
+
+				return nil // Don't proceed with forkproxy setup.
+			}
+
 			if util.IsTrue(d.config["nat"]) {
 				err = d.setupNAT()
 				if err != nil {
@@ -459,8 +699,26 @@ func (d *proxy) checkProcStarted(logPath string) (bool, error) {
 	return false, nil
 }
 
+// Register re-adds the HTTP reverse proxy route of running instances after a daemon restart.
+func (d *proxy) Register() error {
+	if util.IsFalseOrEmpty(d.config["http"]) {
+		return nil
+	}
+
+	return d.setupHTTP()
+}
+
 // Stop is run when the device is removed from the instance.
 func (d *proxy) Stop() (*deviceConfig.RunConfig, error) {
+	if util.IsTrue(d.config["http"]) {
+		listenAddress, err := d.httpListenAddress()
+		if err == nil {
+			proxyHTTPRemoveRoute(listenAddress, d.httpRouteOwner())
+		}
+
+		return nil, nil
+	}
+
 	// Remove possible iptables entries
 	err := d.state.Firewall.InstanceClearProxyNAT(d.inst.Project().Name, d.inst.Name(), d.name)
 	if err != nil {
@@ -601,6 +859,108 @@ func (d *proxy) setupNAT() error {
 	return nil
 }
 
+// httpListenAddress returns the host address and port the HTTP reverse proxy listens on.
+func (d *proxy) httpListenAddress() (string, error) {
+	listenAddr, err := network.ProxyParseAddr(d.config["listen"])
+	if err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(listenAddr.Address, strconv.FormatUint(listenAddr.Ports[0], 10)), nil
+}
+
+// httpRouteOwner returns the name identifying the device's route on the HTTP reverse proxy listener.
+func (d *proxy) httpRouteOwner() string {
+	return fmt.Sprintf("%s/%s", project.Instance(d.inst.Project().Name, d.inst.Name()), d.name)
+}
+
+// setupHTTP adds the device's route to the HTTP reverse proxy listening on its listen address.
+// Connections to containers are made from within their network namespace, as with the forkproxy process,
+// while VMs are reached from the host.
+func (d *proxy) setupHTTP() error {
+	listenAddress, err := d.httpListenAddress()
+	if err != nil {
+		return err
+	}
+
+	connectAddr, err := network.ProxyParseAddr(d.config["connect"])
+	if err != nil {
+		return err
+	}
+
+	route := &proxyHTTPRoute{
+		owner:   d.httpRouteOwner(),
+		project: d.inst.Project().Name,
+		path:    d.config["http.path"],
+	}
+
+	if route.path == "" {
+		route.path = "/"
+	}
+
+	if d.config["http.hosts"] != "" {
+		for _, host := range util.SplitNTrimSpace(d.config["http.hosts"], ",", -1, true) {
+			route.hosts = append(route.hosts, strings.ToLower(host))
+		}
+	}
+
+	if d.config["http.tls"] == "custom" {
+		cert, err := tls.X509KeyPair([]byte(d.config["http.tls.certificate"]), []byte(d.config["http.tls.key"]))
+		if err != nil {
+			return fmt.Errorf("Invalid HTTP TLS certificate or key: %w", err)
+		}
+
+		route.getCertificate = func() (*tls.Certificate, error) {
+			return &cert, nil
+		}
+	}
+
+	if d.config["http.tls"] == "acme" {
+		s := d.state
+		hosts := slices.Clone(route.hosts)
+		acmeCert := newProxyHTTPACMECertificate(func() (*tls.Certificate, error) {
+			certInfo, err := acme.HostCertificate(s, hosts)
+			if err != nil {
+				return nil, err
+			}
+
+			cert, err := tls.X509KeyPair(certInfo.Certificate, certInfo.PrivateKey)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid ACME certificate: %w", err)
+			}
+
+			return &cert, nil
+		})
+
+		route.getCertificate = acmeCert.get
+	}
+
+	dial := (&net.Dialer{}).DialContext
+	if d.inst.Type() == instancetype.Container {
+		netnsFile, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", d.inst.InitPID()))
+		if err != nil {
+			return fmt.Errorf("Failed opening instance network namespace: %w", err)
+		}
+
+		netns := newProxyHTTPNetns(netnsFile)
+		route.netns = netns
+		dial = func(ctx context.Context, network string, address string) (net.Conn, error) {
+			return proxyHTTPDialNetns(ctx, netns, network, address)
+		}
+	}
+
+	target := net.JoinHostPort(connectAddr.Address, strconv.FormatUint(connectAddr.Ports[0], 10))
+	route.handler, route.transport = proxyHTTPHandler(target, dial)
+
+	err = proxyHTTPAddRoute(listenAddress, route)
+	if err != nil {
+		proxyHTTPCloseRoute(route)
+		return err
+	}
+
+	return nil
+}
+
 func (d *proxy) setupProxyProcInfo() (*proxyProcInfo, error) {
 	cname := project.Instance(d.inst.Project().Name, d.inst.Name())
 	cc, err := liblxc.NewContainer(cname, d.state.OS.LxcPath)
@@ -0,0 +1,468 @@
+package device
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// proxyHTTPRoute represents an HTTP reverse proxy route registered by a proxy device.
+type proxyHTTPRoute struct {
+	owner   string
+	project string
+	hosts   []string
+	path    string
+
+	// getCertificate returns the certificate used to terminate TLS for the route's hosts (nil for plain HTTP).
+	getCertificate func() (*tls.Certificate, error)
+
+	handler   http.Handler
+	transport *http.Transport
+	netns     *proxyHTTPNetns
+}
+
+// proxyHTTPNetns is a reference counted handle on the network namespace that a route connects from.
+// The namespace is only closed once its route is removed and no connection is being established from it anymore,
+// so that a file descriptor number reused in the meantime is never entered.
+type proxyHTTPNetns struct {
+	mu   sync.Mutex
+	file *os.File
+	refs int
+}
+
+// newProxyHTTPNetns returns a handle on the network namespace, holding a reference until closed.
+func newProxyHTTPNetns(file *os.File) *proxyHTTPNetns {
+	return &proxyHTTPNetns{file: file, refs: 1}
+}
+
+// acquire returns the network namespace file, holding a reference until released.
+// It fails once the handle was closed and the network namespace isn't in use anymore.
+func (n *proxyHTTPNetns) acquire() (*os.File, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.refs == 0 {
+		return nil, errors.New("Instance network namespace isn't available anymore")
+	}
+
+	n.refs++
+
+	return n.file, nil
+}
+
+// release drops a reference, closing the network namespace once unused.
+func (n *proxyHTTPNetns) release() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.refs--
+	if n.refs == 0 {
+		_ = n.file.Close()
+	}
+}
+
+// proxyHTTPACMECertificate holds the certificate of a route using `http.tls=acme`.
+// Certificates are issued and renewed in the background so that TLS handshakes never wait on the ACME server,
+// handshakes failing until the first certificate was issued.
+type proxyHTTPACMECertificate struct {
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	updating    bool
+	nextAttempt time.Time
+
+	issue func() (*tls.Certificate, error)
+}
+
+// newProxyHTTPACMECertificate returns a certificate issued with the function, starting its issuance.
+func newProxyHTTPACMECertificate(issue func() (*tls.Certificate, error)) *proxyHTTPACMECertificate {
+	c := &proxyHTTPACMECertificate{issue: issue}
+
+	c.mu.Lock()
+	c.refreshLocked()
+	c.mu.Unlock()
+
+	return c
+}
+
+// refreshLocked starts issuing a certificate if there's none yet or the current one expires within 30 days.
+// Failed attempts are retried after an hour. The lock must be held.
+func (c *proxyHTTPACMECertificate) refreshLocked() {
+	now := time.Now()
+	if c.updating || now.Before(c.nextAttempt) {
+		return
+	}
+
+	if c.cert != nil && (c.cert.Leaf == nil || now.Before(c.cert.Leaf.NotAfter.Add(-30*24*time.Hour))) {
+		return
+	}
+
+	c.updating = true
+	go func() {
+		cert, err := c.issue()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.updating = false
+		if err != nil {
+			logger.Warn("Failed issuing ACME certificate for HTTP proxy", logger.Ctx{"err": err})
+			c.nextAttempt = time.Now().Add(time.Hour)
+			return
+		}
+
+		c.cert = cert
+		c.nextAttempt = time.Time{}
+	}()
+}
+
+// get returns the current certificate, starting its renewal if needed.
+func (c *proxyHTTPACMECertificate) get() (*tls.Certificate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.refreshLocked()
+
+	if c.cert == nil {
+		return nil, errors.New("ACME certificate hasn't been issued yet")
+	}
+
+	return c.cert, nil
+}
+
+// proxyHTTPListener represents a listener shared by the HTTP reverse proxy routes using the same listen address.
+// A listener is restricted to the routes of a single project so that projects can't take over each other's traffic.
+type proxyHTTPListener struct {
+	tls     bool
+	project string
+	server  *http.Server
+	routes  []*proxyHTTPRoute
+}
+
+// proxyHTTPListeners holds the HTTP reverse proxy listeners, indexed by listen address.
+var proxyHTTPListeners = map[string]*proxyHTTPListener{}
+
+var proxyHTTPListenersMu sync.Mutex
+
+// proxyHTTPNormalizeHost returns the lower case host name of a Host header or TLS server name, without port.
+func proxyHTTPNormalizeHost(host string) string {
+	hostName, _, err := net.SplitHostPort(host)
+	if err == nil {
+		host = hostName
+	}
+
+	return strings.TrimSuffix(strings.ToLower(strings.Trim(host, "[]")), ".")
+}
+
+// proxyHTTPHostScore returns how specifically the route hosts match the host name.
+// Exact matches rank above wildcard (`*.example.com`) matches, which rank above routes without hosts.
+// A negative score means that the route doesn't match the host name.
+func proxyHTTPHostScore(hosts []string, host string) int {
+	if len(hosts) == 0 {
+		return 0
+	}
+
+	score := -1
+	for _, routeHost := range hosts {
+		if routeHost == host {
+			return 2
+		}
+
+		suffix, ok := strings.CutPrefix(routeHost, "*")
+		if ok && strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+			score = 1
+		}
+	}
+
+	return score
+}
+
+// proxyHTTPPathMatches returns whether the request path is covered by the route path prefix.
+func proxyHTTPPathMatches(prefix string, path string) bool {
+	if prefix == "" || prefix == "/" || path == prefix {
+		return true
+	}
+
+	if strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(path, prefix)
+	}
+
+	return strings.HasPrefix(path, prefix+"/")
+}
+
+// proxyHTTPMatchRoute returns the most specific route for the host name and request path.
+// Host matches take precedence over path matches, longer path prefixes win amongst routes with the same host score.
+func proxyHTTPMatchRoute(routes []*proxyHTTPRoute, host string, path string) *proxyHTTPRoute {
+	var bestRoute *proxyHTTPRoute
+	bestHostScore := -1
+
+	for _, route := range routes {
+		hostScore := proxyHTTPHostScore(route.hosts, host)
+		if hostScore < 0 || !proxyHTTPPathMatches(route.path, path) {
+			continue
+		}
+
+		if bestRoute == nil || hostScore > bestHostScore || (hostScore == bestHostScore && len(route.path) > len(bestRoute.path)) {
+			bestRoute = route
+			bestHostScore = hostScore
+		}
+	}
+
+	return bestRoute
+}
+
+// ServeHTTP routes the request to the matching instance.
+func (l *proxyHTTPListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proxyHTTPListenersMu.Lock()
+	route := proxyHTTPMatchRoute(l.routes, proxyHTTPNormalizeHost(r.Host), r.URL.Path)
+	proxyHTTPListenersMu.Unlock()
+
+	if route == nil {
+		http.Error(w, "No route found for the requested host and path", http.StatusNotFound)
+		return
+	}
+
+	route.handler.ServeHTTP(w, r)
+}
+
+// getCertificate returns the certificate of the route whose hosts best match the TLS server name.
+// Clients not sending a server name get the certificate of the first route.
+func (l *proxyHTTPListener) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	serverName := proxyHTTPNormalizeHost(hello.ServerName)
+
+	proxyHTTPListenersMu.Lock()
+	var route *proxyHTTPRoute
+	bestHostScore := -1
+	for _, r := range l.routes {
+		hostScore := proxyHTTPHostScore(r.hosts, serverName)
+		if serverName == "" {
+			hostScore = 0
+		}
+
+		if hostScore > bestHostScore {
+			route = r
+			bestHostScore = hostScore
+		}
+	}
+
+	proxyHTTPListenersMu.Unlock()
+
+	if route == nil {
+		return nil, fmt.Errorf("No route found for server name %q", hello.ServerName)
+	}
+
+	return route.getCertificate()
+}
+
+// proxyHTTPAddRoute adds (or replaces) the route of its owner on the listen address, starting the listener if needed.
+func proxyHTTPAddRoute(listenAddress string, route *proxyHTTPRoute) error {
+	proxyHTTPListenersMu.Lock()
+	defer proxyHTTPListenersMu.Unlock()
+
+	useTLS := route.getCertificate != nil
+
+	l := proxyHTTPListeners[listenAddress]
+	if l != nil {
+		if l.project != route.project {
+			return fmt.Errorf("Listen address %q is already used by HTTP proxies of another project", listenAddress)
+		}
+
+		if l.tls != useTLS {
+			return fmt.Errorf("Listen address %q is already used by HTTP proxies with a different TLS configuration", listenAddress)
+		}
+
+		for _, r := range l.routes {
+			if r.owner == route.owner || r.path != route.path {
+				continue
+			}
+
+			if len(route.hosts) == 0 && len(r.hosts) == 0 {
+				return fmt.Errorf("Path %q on listen address %q is already used by another HTTP proxy", route.path, listenAddress)
+			}
+
+			for _, host := range route.hosts {
+				if slices.Contains(r.hosts, host) {
+					return fmt.Errorf("Host %q and path %q on listen address %q are already used by another HTTP proxy", host, route.path, listenAddress)
+				}
+			}
+		}
+
+		l.routes = slices.DeleteFunc(l.routes, func(r *proxyHTTPRoute) bool {
+			if r.owner == route.owner {
+				proxyHTTPCloseRoute(r)
+				return true
+			}
+
+			return false
+		})
+
+		l.routes = append(l.routes, route)
+
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return fmt.Errorf("Failed listening on %q: %w", listenAddress, err)
+	}
+
+	l = &proxyHTTPListener{
+		tls:     useTLS,
+		project: route.project,
+		routes:  []*proxyHTTPRoute{route},
+	}
+
+	l.server = &http.Server{
+		Handler:           l,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	if useTLS {
+		listener = tls.NewListener(listener, &tls.Config{
+			GetCertificate: l.getCertificate,
+			NextProtos:     []string{"h2", "http/1.1"},
+			MinVersion:     tls.VersionTLS12,
+		})
+	}
+
+	proxyHTTPListeners[listenAddress] = l
+
+	go func() {
+		err := l.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("HTTP proxy listener failed", logger.Ctx{"listen": listenAddress, "err": err})
+		}
+	}()
+
+	return nil
+}
+
+// proxyHTTPRemoveRoute removes the route of the owner on the listen address, stopping the listener once unused.
+func proxyHTTPRemoveRoute(listenAddress string, owner string) {
+	proxyHTTPListenersMu.Lock()
+	defer proxyHTTPListenersMu.Unlock()
+
+	l := proxyHTTPListeners[listenAddress]
+	if l == nil {
+		return
+	}
+
+	l.routes = slices.DeleteFunc(l.routes, func(r *proxyHTTPRoute) bool {
+		if r.owner == owner {
+			proxyHTTPCloseRoute(r)
+			return true
+		}
+
+		return false
+	})
+
+	if len(l.routes) > 0 {
+		return
+	}
+
+	delete(proxyHTTPListeners, listenAddress)
+	_ = l.server.Close()
+}
+
+// proxyHTTPCloseRoute releases the resources held by a route.
+// Requests in flight complete using their established connections, new connections can't be made anymore.
+func proxyHTTPCloseRoute(route *proxyHTTPRoute) {
+	if route.transport != nil {
+		route.transport.CloseIdleConnections()
+	}
+
+	if route.netns != nil {
+		route.netns.release()
+	}
+}
+
+// proxyHTTPHandler returns a reverse proxy handler sending requests to the target address, along with its transport.
+// The original Host header is kept and the X-Forwarded-For, X-Forwarded-Host and X-Forwarded-Proto headers are set.
+func proxyHTTPHandler(target string, dial func(ctx context.Context, network string, address string) (net.Conn, error)) (http.Handler, *http.Transport) {
+	targetURL := &url.URL{Scheme: "http", Host: target}
+
+	transport := &http.Transport{
+		DialContext:         dial,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(targetURL)
+			r.SetXForwarded()
+			r.Out.Host = r.In.Host
+		},
+		Transport: transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			logger.Debug("HTTP proxy request failed", logger.Ctx{"target": target, "host": r.Host, "err": err})
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}, transport
+}
+
+// proxyHTTPDialNetns connects to the address from within the network namespace referred to by netns.
+// The connection is established from a dedicated OS thread which is moved back to the host namespace afterwards.
+func proxyHTTPDialNetns(ctx context.Context, netns *proxyHTTPNetns, network string, address string) (net.Conn, error) {
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+
+	netnsFile, err := netns.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	resultCh := make(chan dialResult, 1)
+
+	go func() {
+		defer netns.release()
+
+		runtime.LockOSThread()
+
+		hostNetns, err := os.Open("/proc/thread-self/ns/net")
+		if err != nil {
+			runtime.UnlockOSThread()
+			resultCh <- dialResult{err: fmt.Errorf("Failed opening host network namespace: %w", err)}
+			return
+		}
+
+		defer func() { _ = hostNetns.Close() }()
+
+		err = unix.Setns(int(netnsFile.Fd()), unix.CLONE_NEWNET)
+		if err != nil {
+			runtime.UnlockOSThread()
+			resultCh <- dialResult{err: fmt.Errorf("Failed entering instance network namespace: %w", err)}
+			return
+		}
+
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, network, address)
+
+		// Leave the thread locked if it can't be restored so that it gets terminated with the goroutine.
+		if unix.Setns(int(hostNetns.Fd()), unix.CLONE_NEWNET) == nil {
+			runtime.UnlockOSThread()
+		}
+
+		resultCh <- dialResult{conn: conn, err: err}
+	}()
+
+	result := <-resultCh
+
+	return result.conn, result.err
+}
@@ -0,0 +1,190 @@
+package device
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+)
+
+func Test_proxyHTTPMatchRoute(t *testing.T) {
+	catchAll := &proxyHTTPRoute{owner: "catch-all", path: "/"}
+	exact := &proxyHTTPRoute{owner: "exact", hosts: []string{"www.example.com"}, path: "/"}
+	exactAPI := &proxyHTTPRoute{owner: "exact-api", hosts: []string{"www.example.com"}, path: "/api"}
+	wildcard := &proxyHTTPRoute{owner: "wildcard", hosts: []string{"*.example.com"}, path: "/"}
+	routes := []*proxyHTTPRoute{catchAll, exact, exactAPI, wildcard}
+
+	tests := []struct {
+		host string
+		path string
+		want *proxyHTTPRoute
+	}{
+		{"www.example.com", "/", exact},
+		{"www.example.com", "/index.html", exact},
+		{"www.example.com", "/api", exactAPI},
+		{"www.example.com", "/api/v1", exactAPI},
+		{"www.example.com", "/apis", exact},
+		{"blog.example.com", "/api", wildcard},
+		{"example.com", "/", catchAll},
+		{"other.org", "/", catchAll},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, proxyHTTPMatchRoute(routes, tt.host, tt.path), "%s%s", tt.host, tt.path)
+	}
+
+	assert.Nil(t, proxyHTTPMatchRoute([]*proxyHTTPRoute{exact}, "other.org", "/"))
+}
+
+func Test_proxyHTTPNormalizeHost(t *testing.T) {
+	assert.Equal(t, "www.example.com", proxyHTTPNormalizeHost("WWW.Example.com:8443"))
+	assert.Equal(t, "www.example.com", proxyHTTPNormalizeHost("www.example.com."))
+	assert.Equal(t, "2001:db8::1", proxyHTTPNormalizeHost("[2001:db8::1]:80"))
+}
+
+func Test_proxyHTTPAddRoute(t *testing.T) {
+	listenAddress := "127.0.0.1:0"
+	defer func() {
+		for _, owner := range []string{"p1_c1/web", "p1_c2/web", "p2_c3/web"} {
+			proxyHTTPRemoveRoute(listenAddress, owner)
+		}
+	}()
+
+	require.NoError(t, proxyHTTPAddRoute(listenAddress, &proxyHTTPRoute{owner: "p1_c1/web", project: "p1", hosts: []string{"a.example.com"}, path: "/"}))
+
+	// Routes of the same project share the listener.
+	assert.NoError(t, proxyHTTPAddRoute(listenAddress, &proxyHTTPRoute{owner: "p1_c2/web", project: "p1", hosts: []string{"b.example.com"}, path: "/"}))
+
+	// Routes of the same project can't use the same host and path.
+	assert.Error(t, proxyHTTPAddRoute(listenAddress, &proxyHTTPRoute{owner: "p1_c3/web", project: "p1", hosts: []string{"a.example.com"}, path: "/"}))
+
+	// Routes of other projects can't share the listener, even for other hosts.
+	assert.Error(t, proxyHTTPAddRoute(listenAddress, &proxyHTTPRoute{owner: "p2_c3/web", project: "p2", hosts: []string{"c.example.com"}, path: "/"}))
+
+	// The listener is released once its routes are removed.
+	proxyHTTPRemoveRoute(listenAddress, "p1_c1/web")
+	proxyHTTPRemoveRoute(listenAddress, "p1_c2/web")
+	assert.NoError(t, proxyHTTPAddRoute(listenAddress, &proxyHTTPRoute{owner: "p2_c3/web", project: "p2", hosts: []string{"c.example.com"}, path: "/"}))
+}
+
+func Test_proxyHTTPNetns(t *testing.T) {
+	file, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+
+	netns := newProxyHTTPNetns(file)
+
+	// A connection being established keeps the namespace open after the route is closed.
+	acquired, err := netns.acquire()
+	require.NoError(t, err)
+	assert.Equal(t, file, acquired)
+
+	proxyHTTPCloseRoute(&proxyHTTPRoute{netns: netns})
+	_, err = file.Stat()
+	assert.NoError(t, err)
+
+	// The namespace is closed once the last connection is established.
+	netns.release()
+	_, err = file.Stat()
+	assert.ErrorIs(t, err, os.ErrClosed)
+
+	_, err = netns.acquire()
+	assert.Error(t, err)
+}
+
+func Test_proxyHTTPACMECertificate(t *testing.T) {
+	issued := make(chan struct{})
+	cert := &tls.Certificate{Leaf: &x509.Certificate{NotAfter: time.Now().Add(90 * 24 * time.Hour)}}
+
+	var calls atomic.Int32
+	c := newProxyHTTPACMECertificate(func() (*tls.Certificate, error) {
+		calls.Add(1)
+		<-issued
+		return cert, nil
+	})
+
+	// Handshakes fail until the certificate is issued.
+	_, err := c.get()
+	assert.Error(t, err)
+
+	close(issued)
+	require.Eventually(t, func() bool {
+		got, err := c.get()
+		return err == nil && got == cert
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Failed attempts are only retried later.
+	calls.Store(0)
+	failing := newProxyHTTPACMECertificate(func() (*tls.Certificate, error) {
+		calls.Add(1)
+		return nil, errors.New("rate limited")
+	})
+
+	require.Eventually(t, func() bool {
+		failing.mu.Lock()
+		defer failing.mu.Unlock()
+
+		return !failing.updating
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = failing.get()
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_proxyInstanceStaticAddress(t *testing.T) {
+	devices := deviceConfig.Devices{
+		"eth0": deviceConfig.Device{"type": "nic", "ipv4.address": "10.0.0.2", "ipv6.address": "fd00::2"},
+		"eth1": deviceConfig.Device{"type": "nic", "network": "other"},
+		"root": deviceConfig.Device{"type": "disk", "ipv4.address": "10.0.0.3"},
+	}
+
+	assert.True(t, proxyInstanceStaticAddress(devices, net.ParseIP("10.0.0.2")))
+	assert.True(t, proxyInstanceStaticAddress(devices, net.ParseIP("fd00:0::2")))
+	assert.False(t, proxyInstanceStaticAddress(devices, net.ParseIP("10.0.0.3")))
+	assert.False(t, proxyInstanceStaticAddress(devices, net.ParseIP("127.0.0.1")))
+	assert.False(t, proxyInstanceStaticAddress(devices, net.ParseIP("169.254.169.254")))
+	assert.False(t, proxyInstanceStaticAddress(devices, nil))
+}
+
+func Test_validateHTTPTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), DNSNames: []string{"www.example.com"}}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	ecKeyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+
+	assert.NoError(t, validateHTTPTLSCertificate(cert))
+	assert.NoError(t, validateHTTPTLSCertificate(cert+cert))
+	assert.Error(t, validateHTTPTLSCertificate("not a certificate"))
+	assert.Error(t, validateHTTPTLSCertificate(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}))))
+
+	assert.NoError(t, validateHTTPTLSKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))))
+	assert.NoError(t, validateHTTPTLSKey(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecKeyDER}))))
+	assert.Error(t, validateHTTPTLSKey(cert))
+	assert.Error(t, validateHTTPTLSKey("not a key"))
+}
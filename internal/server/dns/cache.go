@@ -0,0 +1,147 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// zoneCacheLifetime is how long a rendered zone and the list of zones are used to answer queries before
+// being loaded again. Local changes invalidate the cache right away, this bounds the staleness of changes
+// made on other cluster members or to the instances and networks the zone records are generated from.
+const zoneCacheLifetime = 30 * time.Second
+
+// cachedZone is a rendered zone used to answer regular queries.
+type cachedZone struct {
+	info    api.NetworkZone
+	records []dns.RR
+	found   bool  // Whether the zone could be retrieved.
+	err     error // Failure to parse the zone records.
+	expiry  time.Time
+}
+
+// InvalidateZone drops the cached rendering of the zone.
+func (s *Server) InvalidateZone(name string) {
+	s.zonesMu.Lock()
+	defer s.zonesMu.Unlock()
+
+	delete(s.zones, name)
+}
+
+// InvalidateZones drops all cached zones as well as the list of existing zones.
+func (s *Server) InvalidateZones() {
+	s.zonesMu.Lock()
+	defer s.zonesMu.Unlock()
+
+	s.zones = nil
+	s.zoneNames = nil
+}
+
+// zoneNameSet returns the set of existing zone names, loading it from the database if needed.
+func (s *Server) zoneNameSet() (map[string]bool, error) {
+	s.zonesMu.RLock()
+	names := s.zoneNames
+	expired := time.Now().After(s.zoneNamesExpiry)
+	s.zonesMu.RUnlock()
+
+	if (names != nil && !expired) || s.db == nil {
+		return names, nil
+	}
+
+	names = map[string]bool{}
+	err := s.db.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		zones, err := dbCluster.GetNetworkZones(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, zone := range zones {
+			names[zone.Name] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.zonesMu.Lock()
+	s.zoneNames = names
+	s.zoneNamesExpiry = time.Now().Add(zoneCacheLifetime)
+	s.zonesMu.Unlock()
+
+	return names, nil
+}
+
+// queryZone returns the cached rendering of the most specific zone holding the name, rendering it if needed.
+// Only existing zones are looked up so that queries for unknown names don't hit the database.
+func (s *Server) queryZone(name string) (*cachedZone, error) {
+	names, err := s.zoneNameSet()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := dns.SplitDomainName(name)
+	for i := range labels {
+		zoneName := strings.Join(labels[i:], ".")
+		if names != nil && !names[zoneName] {
+			continue
+		}
+
+		zone := s.cachedZone(zoneName)
+		if zone == nil {
+			zone = s.renderZone(zoneName)
+		}
+
+		if zone.found {
+			return zone, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// cachedZone returns the cached rendering of the zone if still valid.
+func (s *Server) cachedZone(name string) *cachedZone {
+	s.zonesMu.RLock()
+	defer s.zonesMu.RUnlock()
+
+	zone := s.zones[name]
+	if zone == nil || time.Now().After(zone.expiry) {
+		return nil
+	}
+
+	return zone
+}
+
+// renderZone renders the zone and stores the result in the cache, including failures to render it.
+func (s *Server) renderZone(name string) *cachedZone {
+	zone := &cachedZone{expiry: time.Now().Add(zoneCacheLifetime)}
+
+	content, err := s.zoneRetriever(name, true)
+	if err == nil {
+		zone.found = true
+		zone.info = content.Info
+		zone.records, zone.err = parseZone(content.Content)
+		if zone.err != nil {
+			logger.Errorf("Bad DNS record in zone %q: %v", name, zone.err)
+		}
+	}
+
+	s.zonesMu.Lock()
+	if s.zones == nil {
+		s.zones = map[string]*cachedZone{}
+	}
+
+	s.zones[name] = zone
+	s.zonesMu.Unlock()
+
+	return zone
+}
@@ -0,0 +1,61 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestQueryZone(t *testing.T) {
+	retrieved := map[string]int{}
+	s := NewServer(nil, func(name string, full bool) (*Zone, error) {
+		retrieved[name]++
+
+		if name != "incus.example.net" {
+			return nil, errors.New("Zone not found")
+		}
+
+		return &Zone{Info: api.NetworkZone{Name: name}, Content: testZone}, nil
+	})
+
+	// The most specific existing zone is used.
+	zone, err := s.queryZone("c1.incus.example.net")
+	require.NoError(t, err)
+	require.NotNil(t, zone)
+	assert.Equal(t, "incus.example.net", zone.info.Name)
+	assert.NotEmpty(t, zone.records)
+	assert.Equal(t, map[string]int{"c1.incus.example.net": 1, "incus.example.net": 1}, retrieved)
+
+	// Further queries are answered from the cache.
+	for range 10 {
+		zone, err = s.queryZone("c1.incus.example.net")
+		require.NoError(t, err)
+		require.NotNil(t, zone)
+	}
+
+	assert.Equal(t, map[string]int{"c1.incus.example.net": 1, "incus.example.net": 1}, retrieved)
+
+	// Names outside of any zone aren't found.
+	zone, err = s.queryZone("example.com")
+	require.NoError(t, err)
+	assert.Nil(t, zone)
+
+	// Invalidated zones are rendered again.
+	s.InvalidateZone("incus.example.net")
+	_, err = s.queryZone("incus.example.net")
+	require.NoError(t, err)
+	assert.Equal(t, 2, retrieved["incus.example.net"])
+
+	// Only known zones are retrieved once the list of zones is loaded.
+	s.zonesMu.Lock()
+	s.zoneNames = map[string]bool{"incus.example.net": true}
+	s.zonesMu.Unlock()
+
+	_, err = s.queryZone("a.b.c.example.org")
+	require.NoError(t, err)
+	assert.Zero(t, retrieved["a.b.c.example.org"])
+}
@@ -0,0 +1,436 @@
+package dns
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// dnskeyTTL is the TTL of the DNSKEY records.
+const dnskeyTTL = 3600
+
+// signatureValidity is how long signatures remain valid after being generated.
+// Zones are signed on every transfer so this only needs to cover the secondaries' expiry.
+const signatureValidity = 14 * 24 * time.Hour
+
+// dnssecAlgorithms maps the supported DNSSEC algorithms to their key size.
+var dnssecAlgorithms = map[string]int{
+	"ECDSAP256SHA256": 256,
+	"ECDSAP384SHA384": 384,
+	"ED25519":         256,
+}
+
+// DNSSECAlgorithms returns the names of the supported DNSSEC algorithms.
+func DNSSECAlgorithms() []string {
+	algorithms := make([]string, 0, len(dnssecAlgorithms))
+	for algorithm := range dnssecAlgorithms {
+		algorithms = append(algorithms, algorithm)
+	}
+
+	slices.Sort(algorithms)
+
+	return algorithms
+}
+
+// Key represents a DNSSEC key of a zone.
+type Key struct {
+	// Public is the DNSKEY record in presentation format.
+	Public string
+
+	// Private is the private key in BIND format.
+	Private string
+
+	// Signing indicates whether the key is used to sign the zone (otherwise it's only published).
+	Signing bool
+}
+
+// signer is a parsed DNSSEC key used for signing.
+type signer struct {
+	key  *dns.DNSKEY
+	priv crypto.Signer
+}
+
+// GenerateKey generates a new DNSSEC key for the zone.
+// It returns the DNSKEY record and the private key in BIND format.
+func GenerateKey(zoneName string, algorithm string, ksk bool) (string, string, error) {
+	bits, ok := dnssecAlgorithms[algorithm]
+	if !ok {
+		return "", "", fmt.Errorf("Unsupported DNSSEC algorithm %q", algorithm)
+	}
+
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: dns.Fqdn(zoneName), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: dnskeyTTL},
+		Flags:     dns.ZONE,
+		Protocol:  3,
+		Algorithm: dns.StringToAlgorithm[algorithm],
+	}
+
+	if ksk {
+		key.Flags |= dns.SEP
+	}
+
+	priv, err := key.Generate(bits)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed generating DNSSEC key: %w", err)
+	}
+
+	return key.String(), key.PrivateKeyString(priv), nil
+}
+
+// parseDNSKEY parses a DNSKEY record in presentation format.
+func parseDNSKEY(public string) (*dns.DNSKEY, error) {
+	rr, err := dns.NewRR(public)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing DNSKEY record: %w", err)
+	}
+
+	key, ok := rr.(*dns.DNSKEY)
+	if !ok {
+		return nil, fmt.Errorf("Record %q isn't a DNSKEY record", public)
+	}
+
+	return key, nil
+}
+
+// KeyInfo returns the key tag and algorithm name of a DNSKEY record.
+func KeyInfo(public string) (uint16, string, error) {
+	key, err := parseDNSKEY(public)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return key.KeyTag(), dns.AlgorithmToString[key.Algorithm], nil
+}
+
+// DSRecord returns the DS record (SHA-256 digest) to publish in the parent zone for a DNSKEY record.
+func DSRecord(public string) (string, error) {
+	key, err := parseDNSKEY(public)
+	if err != nil {
+		return "", err
+	}
+
+	ds := key.ToDS(dns.SHA256)
+	if ds == nil {
+		return "", errors.New("Failed generating DS record")
+	}
+
+	return ds.String(), nil
+}
+
+// SignZone signs the zone content using the provided keys.
+// The DNSKEY records of all keys are added to the zone along with NSEC (or NSEC3) records.
+// The DNSKEY record set is signed by the active key signing keys (KSK) and all other record sets by the active zone signing keys (ZSK).
+// Like the unsigned content, the signed content starts and ends with the SOA record as expected for zone transfers.
+func SignZone(zoneName string, content string, keys []Key, nsec3 bool, now time.Time) (string, error) {
+	zoneName = dns.CanonicalName(zoneName)
+
+	records, err := parseZone(content)
+	if err != nil {
+		return "", err
+	}
+
+	// Split the SOA record from the rest of the zone.
+	var soa *dns.SOA
+	zoneRecords := []dns.RR{}
+	for _, rr := range records {
+		soaRecord, ok := rr.(*dns.SOA)
+		if ok {
+			if soa == nil {
+				soa = soaRecord
+			}
+
+			continue
+		}
+
+		zoneRecords = append(zoneRecords, rr)
+	}
+
+	if soa == nil {
+		return "", errors.New("Zone doesn't have a SOA record")
+	}
+
+	zoneRecords = append([]dns.RR{soa}, zoneRecords...)
+
+	// Publish the keys and prepare those used for signing.
+	var ksks []signer
+	var zsks []signer
+	for _, k := range keys {
+		key, err := parseDNSKEY(k.Public)
+		if err != nil {
+			return "", err
+		}
+
+		zoneRecords = append(zoneRecords, key)
+
+		if !k.Signing {
+			continue
+		}
+
+		priv, err := key.NewPrivateKey(k.Private)
+		if err != nil {
+			return "", fmt.Errorf("Failed parsing DNSSEC private key: %w", err)
+		}
+
+		privSigner, ok := priv.(crypto.Signer)
+		if !ok {
+			return "", errors.New("DNSSEC private key can't be used for signing")
+		}
+
+		if key.Flags&dns.SEP != 0 {
+			ksks = append(ksks, signer{key: key, priv: privSigner})
+		} else {
+			zsks = append(zsks, signer{key: key, priv: privSigner})
+		}
+	}
+
+	if len(ksks) == 0 || len(zsks) == 0 {
+		return "", errors.New("Zone needs both a key signing key and a zone signing key")
+	}
+
+	// Add the denial of existence records.
+	cuts := zoneCuts(zoneName, zoneRecords)
+	if nsec3 {
+		zoneRecords = append(zoneRecords, &dns.NSEC3PARAM{
+			Hdr:  dns.RR_Header{Name: zoneName, Rrtype: dns.TypeNSEC3PARAM, Class: dns.ClassINET, Ttl: soa.Minttl},
+			Hash: dns.SHA1,
+		})
+
+		zoneRecords = append(zoneRecords, buildNSEC3(zoneName, zoneRecords, cuts, soa.Minttl)...)
+	} else {
+		zoneRecords = append(zoneRecords, buildNSEC(zoneName, zoneRecords, cuts, soa.Minttl)...)
+	}
+
+	// Sign all authoritative record sets.
+	inception := uint32(now.Add(-time.Hour).Unix())
+	expiration := uint32(now.Add(signatureValidity).Unix())
+
+	signatures := []dns.RR{}
+	for _, rrset := range recordSets(zoneRecords) {
+		owner := dns.CanonicalName(rrset[0].Header().Name)
+		rrtype := rrset[0].Header().Rrtype
+
+		// Delegations and glue records aren't authoritative.
+		if !isAuthoritative(owner, rrtype, cuts) {
+			continue
+		}
+
+		signers := zsks
+		if rrtype == dns.TypeDNSKEY {
+			signers = ksks
+		}
+
+		for _, s := range signers {
+			sig := &dns.RRSIG{
+				Hdr:        dns.RR_Header{Ttl: rrset[0].Header().Ttl},
+				Algorithm:  s.key.Algorithm,
+				Expiration: expiration,
+				Inception:  inception,
+				KeyTag:     s.key.KeyTag(),
+				SignerName: zoneName,
+			}
+
+			err := sig.Sign(s.priv, rrset)
+			if err != nil {
+				return "", fmt.Errorf("Failed signing %s records of %q: %w", dns.TypeToString[rrtype], owner, err)
+			}
+
+			signatures = append(signatures, sig)
+		}
+	}
+
+	// Render the signed zone.
+	sb := &strings.Builder{}
+	for _, rr := range zoneRecords {
+		sb.WriteString(rr.String() + "\n")
+	}
+
+	for _, rr := range signatures {
+		sb.WriteString(rr.String() + "\n")
+	}
+
+	sb.WriteString(soa.String() + "\n")
+
+	return sb.String(), nil
+}
+
+// recordSets groups the records by owner name and type, keeping the order in which they first appear.
+func recordSets(records []dns.RR) [][]dns.RR {
+	sets := [][]dns.RR{}
+	index := map[string]int{}
+	for _, rr := range records {
+		key := dns.CanonicalName(rr.Header().Name) + "/" + dns.TypeToString[rr.Header().Rrtype]
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(sets)
+			sets = append(sets, []dns.RR{rr})
+			continue
+		}
+
+		sets[i] = append(sets[i], rr)
+	}
+
+	return sets
+}
+
+// zoneCuts returns the names below the zone apex which are delegated to other servers.
+func zoneCuts(zoneName string, records []dns.RR) []string {
+	cuts := []string{}
+	for _, rr := range records {
+		owner := dns.CanonicalName(rr.Header().Name)
+		if rr.Header().Rrtype == dns.TypeNS && owner != zoneName && !slices.Contains(cuts, owner) {
+			cuts = append(cuts, owner)
+		}
+	}
+
+	return cuts
+}
+
+// isAuthoritative returns whether the records of the given owner name and type are authoritative data of the zone.
+func isAuthoritative(owner string, rrtype uint16, cuts []string) bool {
+	for _, cut := range cuts {
+		if owner == cut {
+			return rrtype == dns.TypeDS || rrtype == dns.TypeNSEC
+		}
+
+		if dns.IsSubDomain(cut, owner) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isOccluded returns whether the owner name is below a delegation (glue records).
+func isOccluded(owner string, cuts []string) bool {
+	for _, cut := range cuts {
+		if owner != cut && dns.IsSubDomain(cut, owner) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ownerTypes returns the record types of every owner name in the zone (excluding names below delegations).
+func ownerTypes(records []dns.RR, cuts []string) map[string][]uint16 {
+	types := map[string][]uint16{}
+	for _, rr := range records {
+		owner := dns.CanonicalName(rr.Header().Name)
+		if isOccluded(owner, cuts) {
+			continue
+		}
+
+		if !slices.Contains(types[owner], rr.Header().Rrtype) {
+			types[owner] = append(types[owner], rr.Header().Rrtype)
+		}
+	}
+
+	return types
+}
+
+// buildNSEC returns the NSEC chain of the zone (RFC 4034).
+func buildNSEC(zoneName string, records []dns.RR, cuts []string, ttl uint32) []dns.RR {
+	types := ownerTypes(records, cuts)
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool { return canonicalLess(names[i], names[j]) })
+
+	nsecs := make([]dns.RR, 0, len(names))
+	for i, name := range names {
+		bitmap := append(slices.Clone(types[name]), dns.TypeRRSIG, dns.TypeNSEC)
+		slices.Sort(bitmap)
+
+		nsecs = append(nsecs, &dns.NSEC{
+			Hdr:        dns.RR_Header{Name: name, Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: ttl},
+			NextDomain: names[(i+1)%len(names)],
+			TypeBitMap: slices.Compact(bitmap),
+		})
+	}
+
+	return nsecs
+}
+
+// buildNSEC3 returns the NSEC3 chain of the zone (RFC 5155).
+// Following RFC 9276, no salt and no additional iterations are used.
+func buildNSEC3(zoneName string, records []dns.RR, cuts []string, ttl uint32) []dns.RR {
+	types := ownerTypes(records, cuts)
+
+	// Add the empty non-terminals.
+	for name := range types {
+		labels := dns.SplitDomainName(name)
+		for i := 1; i < len(labels); i++ {
+			parent := dns.Fqdn(strings.Join(labels[i:], "."))
+			if !dns.IsSubDomain(zoneName, parent) {
+				break
+			}
+
+			_, ok := types[parent]
+			if !ok {
+				types[parent] = []uint16{}
+			}
+		}
+	}
+
+	// Hash the names.
+	hashes := make([]string, 0, len(types))
+	hashNames := map[string]string{}
+	for name := range types {
+		hash := strings.ToLower(dns.HashName(name, dns.SHA1, 0, ""))
+		hashes = append(hashes, hash)
+		hashNames[hash] = name
+	}
+
+	slices.Sort(hashes)
+
+	nsec3s := make([]dns.RR, 0, len(hashes))
+	for i, hash := range hashes {
+		name := hashNames[hash]
+
+		// Insecure delegations and empty non-terminals don't have signed records.
+		bitmap := slices.Clone(types[name])
+		signed := isAuthoritative(name, dns.TypeA, cuts) || slices.Contains(bitmap, dns.TypeDS)
+		if len(bitmap) > 0 && signed {
+			bitmap = append(bitmap, dns.TypeRRSIG)
+		}
+
+		slices.Sort(bitmap)
+
+		nextHash := hashes[(i+1)%len(hashes)]
+		nsec3s = append(nsec3s, &dns.NSEC3{
+			Hdr:        dns.RR_Header{Name: hash + "." + zoneName, Rrtype: dns.TypeNSEC3, Class: dns.ClassINET, Ttl: ttl},
+			Hash:       dns.SHA1,
+			HashLength: uint8(len(nextHash) * 5 / 8),
+			NextDomain: nextHash,
+			TypeBitMap: slices.Compact(bitmap),
+		})
+	}
+
+	return nsec3s
+}
+
+// canonicalLess returns whether name a sorts before name b in the canonical DNS name order (RFC 4034, section 6.1).
+func canonicalLess(a string, b string) bool {
+	labelsA := dns.SplitDomainName(dns.CanonicalName(a))
+	labelsB := dns.SplitDomainName(dns.CanonicalName(b))
+
+	for i := 1; i <= min(len(labelsA), len(labelsB)); i++ {
+		labelA := labelsA[len(labelsA)-i]
+		labelB := labelsB[len(labelsB)-i]
+		if labelA != labelB {
+			return labelA < labelB
+		}
+	}
+
+	return len(labelsA) < len(labelsB)
+}
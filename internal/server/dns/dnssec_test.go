@@ -0,0 +1,190 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignZone(t *testing.T) {
+	for _, algorithm := range DNSSECAlgorithms() {
+		for _, nsec3 := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/nsec3=%v", algorithm, nsec3), func(t *testing.T) {
+				kskPublic, kskPrivate, err := GenerateKey("incus.example.net", algorithm, true)
+				require.NoError(t, err)
+
+				zskPublic, zskPrivate, err := GenerateKey("incus.example.net", algorithm, false)
+				require.NoError(t, err)
+
+				// The retired key is published but doesn't sign anything.
+				retiredPublic, _, err := GenerateKey("incus.example.net", algorithm, false)
+				require.NoError(t, err)
+
+				keys := []Key{
+					{Public: kskPublic, Private: kskPrivate, Signing: true},
+					{Public: zskPublic, Private: zskPrivate, Signing: true},
+					{Public: retiredPublic},
+				}
+
+				content, err := SignZone("incus.example.net", testZone, keys, nsec3, time.Now())
+				require.NoError(t, err)
+
+				records, err := parseZone(content)
+				require.NoError(t, err)
+
+				// The SOA record must be at the start and end of the zone.
+				assert.Equal(t, dns.TypeSOA, records[0].Header().Rrtype)
+				assert.Equal(t, dns.TypeSOA, records[len(records)-1].Header().Rrtype)
+				records = records[:len(records)-1]
+
+				ksk, err := parseDNSKEY(kskPublic)
+				require.NoError(t, err)
+
+				zsk, err := parseDNSKEY(zskPublic)
+				require.NoError(t, err)
+
+				// Every record set must be signed by the right key.
+				sets := map[string][]dns.RR{}
+				for _, rrset := range recordSets(records) {
+					sets[dns.CanonicalName(rrset[0].Header().Name)+"/"+dns.TypeToString[rrset[0].Header().Rrtype]] = rrset
+				}
+
+				countDNSKEY := 0
+				countDenial := 0
+				for key, rrset := range sets {
+					switch rrset[0].Header().Rrtype {
+					case dns.TypeRRSIG:
+						continue
+					case dns.TypeDNSKEY:
+						countDNSKEY = len(rrset)
+					case dns.TypeNSEC, dns.TypeNSEC3:
+						countDenial++
+					}
+
+					sigs := sets[strings.Split(key, "/")[0]+"/RRSIG"]
+
+					var sig *dns.RRSIG
+					for _, rr := range sigs {
+						if rr.(*dns.RRSIG).TypeCovered == rrset[0].Header().Rrtype {
+							sig = rr.(*dns.RRSIG)
+						}
+					}
+
+					require.NotNil(t, sig, "Missing signature for %s", key)
+
+					signingKey := zsk
+					if rrset[0].Header().Rrtype == dns.TypeDNSKEY {
+						signingKey = ksk
+					}
+
+					assert.NoError(t, sig.Verify(signingKey, rrset), "Bad signature for %s", key)
+					assert.True(t, sig.ValidityPeriod(time.Now()))
+				}
+
+				assert.Equal(t, 3, countDNSKEY)
+
+				// Apex, c1, www, ext, _http._tcp.svc (plus _tcp.svc and svc as empty non-terminals with NSEC3).
+				if nsec3 {
+					assert.Equal(t, 7, countDenial)
+				} else {
+					assert.Equal(t, 5, countDenial)
+				}
+			})
+		}
+	}
+}
+
+func TestSignZoneWithoutKeys(t *testing.T) {
+	zskPublic, zskPrivate, err := GenerateKey("incus.example.net", "ED25519", false)
+	require.NoError(t, err)
+
+	_, err = SignZone("incus.example.net", testZone, []Key{{Public: zskPublic, Private: zskPrivate, Signing: true}}, false, time.Now())
+	assert.Error(t, err)
+}
+
+func TestDSRecord(t *testing.T) {
+	public, _, err := GenerateKey("incus.example.net", "ECDSAP256SHA256", true)
+	require.NoError(t, err)
+
+	record, err := DSRecord(public)
+	require.NoError(t, err)
+
+	rr, err := dns.NewRR(record)
+	require.NoError(t, err)
+
+	ds, ok := rr.(*dns.DS)
+	require.True(t, ok)
+
+	tag, algorithm, err := KeyInfo(public)
+	require.NoError(t, err)
+	assert.Equal(t, "ECDSAP256SHA256", algorithm)
+
+	assert.Equal(t, "incus.example.net.", ds.Header().Name)
+	assert.Equal(t, tag, ds.KeyTag)
+	assert.Equal(t, dns.SHA256, ds.DigestType)
+}
+
+func TestLookupDNSSEC(t *testing.T) {
+	kskPublic, kskPrivate, err := GenerateKey("incus.example.net", "ED25519", true)
+	require.NoError(t, err)
+
+	zskPublic, zskPrivate, err := GenerateKey("incus.example.net", "ED25519", false)
+	require.NoError(t, err)
+
+	keys := []Key{
+		{Public: kskPublic, Private: kskPrivate, Signing: true},
+		{Public: zskPublic, Private: zskPrivate, Signing: true},
+	}
+
+	content, err := SignZone("incus.example.net", testZone, keys, false, time.Now())
+	require.NoError(t, err)
+
+	records, err := parseZone(content)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		qname     string
+		qtype     uint16
+		answers   []uint16
+		authority []uint16
+	}{
+		{name: "Signed answer", qname: "c1.incus.example.net.", qtype: dns.TypeA, answers: []uint16{dns.TypeA, dns.TypeRRSIG}},
+		{name: "Signed CNAME chain", qname: "www.incus.example.net.", qtype: dns.TypeA, answers: []uint16{dns.TypeCNAME, dns.TypeA, dns.TypeRRSIG, dns.TypeRRSIG}},
+		{name: "Keys", qname: "incus.example.net.", qtype: dns.TypeDNSKEY, answers: []uint16{dns.TypeDNSKEY, dns.TypeDNSKEY, dns.TypeRRSIG}},
+		{name: "No data", qname: "c1.incus.example.net.", qtype: dns.TypeMX, authority: []uint16{dns.TypeSOA, dns.TypeNSEC, dns.TypeRRSIG, dns.TypeRRSIG}},
+		{name: "Missing name", qname: "d.incus.example.net.", qtype: dns.TypeA, authority: []uint16{dns.TypeSOA, dns.TypeNSEC, dns.TypeNSEC, dns.TypeRRSIG, dns.TypeRRSIG, dns.TypeRRSIG}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer, authority, rcode := lookup("incus.example.net", records, tt.qname, tt.qtype)
+			answer, authority = addDNSSEC("incus.example.net", records, tt.qname, answer, authority, rcode)
+
+			types := func(rrs []dns.RR) []uint16 {
+				out := []uint16{}
+				for _, rr := range rrs {
+					out = append(out, rr.Header().Rrtype)
+				}
+
+				return out
+			}
+
+			if tt.answers == nil {
+				tt.answers = []uint16{}
+			}
+
+			if tt.authority == nil {
+				tt.authority = []uint16{}
+			}
+
+			assert.Equal(t, tt.answers, types(answer))
+			assert.Equal(t, tt.authority, types(authority))
+		})
+	}
+}
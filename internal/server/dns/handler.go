@@ -3,6 +3,7 @@ package dns
 import (
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,10 +18,6 @@ type dnsHandler struct {
 }
 
 func (d dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
-	// Don't allow concurrent queries.
-	d.server.mu.Lock()
-	defer d.server.mu.Unlock()
-
 	// Check if we're ready to serve queries.
 	if d.server.zoneRetriever == nil {
 		m := &dns.Msg{}
@@ -46,7 +43,9 @@ func (d dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 
 	// Check that it's a supported request type.
-	if r.Question[0].Qtype != dns.TypeAXFR && r.Question[0].Qtype != dns.TypeIXFR && r.Question[0].Qtype != dns.TypeSOA {
+	qtype := r.Question[0].Qtype
+	isTransfer := qtype == dns.TypeAXFR || qtype == dns.TypeIXFR
+	if !isTransfer && !slices.Contains(queryTypes, qtype) {
 		m := &dns.Msg{}
 		m.SetRcode(r, dns.RcodeNotImplemented)
 		err := w.WriteMsg(m)
@@ -71,13 +70,19 @@ func (d dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		return
 	}
 
+	// Regular queries are answered from the zone containing the name.
+	if !isTransfer {
+		d.serveQuery(w, r, name, ip)
+		return
+	}
+
 	// Prepare the response.
 	m := &dns.Msg{}
 	m.SetReply(r)
 	m.Authoritative = true
 
 	// Load the zone.
-	zone, err := d.server.zoneRetriever(name, r.Question[0].Qtype != dns.TypeSOA)
+	zone, err := d.server.zoneRetriever(name, true)
 	if err != nil {
 		// On failure, return NXDOMAIN.
 		m := &dns.Msg{}
@@ -103,30 +108,22 @@ func (d dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		return
 	}
 
-	zoneRR := dns.NewZoneParser(strings.NewReader(zone.Content), "", "")
-	for {
-		rr, ok := zoneRR.Next()
-		if !ok {
-			err := zoneRR.Err()
-			if err != nil {
-				logger.Errorf("Bad DNS record in zone %q: %v", name, err)
-
-				m := &dns.Msg{}
-				m.SetRcode(r, dns.RcodeFormatError)
-				err := w.WriteMsg(m)
-				if err != nil {
-					logger.Error("Unable to write message", logger.Ctx{"err": err})
-				}
-
-				return
-			}
+	records, err := parseZone(zone.Content)
+	if err != nil {
+		logger.Errorf("Bad DNS record in zone %q: %v", name, err)
 
-			break
+		m := &dns.Msg{}
+		m.SetRcode(r, dns.RcodeFormatError)
+		err := w.WriteMsg(m)
+		if err != nil {
+			logger.Error("Unable to write message", logger.Ctx{"err": err})
 		}
 
-		m.Answer = append(m.Answer, rr)
+		return
 	}
 
+	m.Answer = append(m.Answer, records...)
+
 	tsig := r.IsTsig()
 	if tsig != nil && w.TsigStatus() == nil {
 		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
@@ -138,14 +135,106 @@ func (d dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 }
 
-func isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool) bool {
-	type peer struct {
-		address string
-		key     string
+// serveQuery answers a regular query from the content of the zone holding the name.
+func (d dnsHandler) serveQuery(w dns.ResponseWriter, r *dns.Msg, name string, ip string) {
+	writeRcode := func(rcode int) {
+		m := &dns.Msg{}
+		m.SetRcode(r, rcode)
+		err := w.WriteMsg(m)
+		if err != nil {
+			logger.Error("Unable to write message", logger.Ctx{"err": err})
+		}
+	}
+
+	// Reject requests with an invalid TSIG signature.
+	tsig := r.IsTsig()
+	if tsig != nil && w.TsigStatus() != nil {
+		writeRcode(dns.RcodeNotAuth)
+		return
+	}
+
+	// Find the most specific zone holding the name.
+	zone, err := d.server.queryZone(name)
+	if err != nil {
+		logger.Error("Failed looking up DNS zone", logger.Ctx{"name": name, "err": err})
+		writeRcode(dns.RcodeServerFailure)
+		return
+	}
+
+	if zone == nil {
+		writeRcode(dns.RcodeRefused)
+		return
+	}
+
+	// Check access, peers can always check the zone serial ahead of a transfer.
+	switch zone.info.Config["dns.queries"] {
+	case "any":
+	case "peers":
+		if !isAllowed(zone.info, ip, tsig, tsig != nil) {
+			writeRcode(dns.RcodeRefused)
+			return
+		}
+
+	default:
+		if r.Question[0].Qtype != dns.TypeSOA || !isAllowed(zone.info, ip, tsig, tsig != nil) {
+			writeRcode(dns.RcodeRefused)
+			return
+		}
+	}
+
+	if zone.err != nil {
+		writeRcode(dns.RcodeServerFailure)
+		return
+	}
+
+	records := zone.records
+
+	// Prepare the response.
+	m := &dns.Msg{}
+	m.SetReply(r)
+	m.Authoritative = true
+	m.Answer, m.Ns, m.Rcode = lookup(zone.info.Name, records, r.Question[0].Name, r.Question[0].Qtype)
+
+	// Include the DNSSEC records when requested by the client.
+	opt := r.IsEdns0()
+	if opt != nil {
+		if opt.Do() {
+			m.Answer, m.Ns = addDNSSEC(zone.info.Name, records, r.Question[0].Name, m.Answer, m.Ns, m.Rcode)
+		}
+
+		m.SetEdns0(dns.DefaultMsgSize, opt.Do())
+	}
+
+	// Fit UDP responses within the size advertised by the client.
+	_, isUDP := w.RemoteAddr().(*net.UDPAddr)
+	if isUDP {
+		size := dns.MinMsgSize
+		if opt != nil {
+			size = int(opt.UDPSize())
+		}
+
+		m.Truncate(size)
 	}
 
-	// Build a list of peers.
-	peers := map[string]*peer{}
+	if tsig != nil {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+	}
+
+	err = w.WriteMsg(m)
+	if err != nil {
+		logger.Error("Unable to write message", logger.Ctx{"err": err})
+	}
+}
+
+// zonePeer represents a peer DNS server configured on a zone.
+type zonePeer struct {
+	address string
+	key     string
+}
+
+// zonePeers returns the peers configured on the zone, keyed by peer name.
+func zonePeers(zone api.NetworkZone) map[string]*zonePeer {
+	peers := map[string]*zonePeer{}
 	for k, v := range zone.Config {
 		if !strings.HasPrefix(k, "peers.") {
 			continue
@@ -160,7 +249,7 @@ func isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool)
 		peerName := fields[1]
 
 		if peers[peerName] == nil {
-			peers[peerName] = &peer{}
+			peers[peerName] = &zonePeer{}
 		}
 
 		// Add the correct validation rule for the dynamic field based on last part of key.
@@ -172,9 +261,18 @@ func isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool)
 		}
 	}
 
+	return peers
+}
+
+// zonePeerKeyName returns the TSIG key name used by a zone peer.
+func zonePeerKeyName(zoneName string, peerName string) string {
+	return fmt.Sprintf("%s_%s.", zoneName, peerName)
+}
+
+func isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool) bool {
 	// Validate access.
-	for peerName, peer := range peers {
-		peerKeyName := fmt.Sprintf("%s_%s.", zone.Name, peerName)
+	for peerName, peer := range zonePeers(zone) {
+		peerKeyName := zonePeerKeyName(zone.Name, peerName)
 
 		if peer.address != "" && ip != peer.address {
 			// Bad IP address.
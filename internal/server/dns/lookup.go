@@ -0,0 +1,256 @@
+package dns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// maxCNAMEChain is the maximum number of CNAME records followed within a zone.
+const maxCNAMEChain = 8
+
+// queryTypes lists the record types which are answered directly from the zone content.
+var queryTypes = []uint16{
+	dns.TypeA,
+	dns.TypeAAAA,
+	dns.TypeCNAME,
+	dns.TypeDNSKEY,
+	dns.TypeMX,
+	dns.TypeNS,
+	dns.TypePTR,
+	dns.TypeSOA,
+	dns.TypeSRV,
+	dns.TypeTXT,
+}
+
+// parseZone parses the zone content into its records.
+func parseZone(content string) ([]dns.RR, error) {
+	var records []dns.RR
+
+	zoneRR := dns.NewZoneParser(strings.NewReader(content), "", "")
+	for {
+		rr, ok := zoneRR.Next()
+		if !ok {
+			err := zoneRR.Err()
+			if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+
+		records = append(records, rr)
+	}
+
+	return records, nil
+}
+
+// lookup answers a query for the given name and type from the records of a zone.
+// It returns the answer and authority sections along with the response code.
+// CNAME records are followed as long as their target is within the zone.
+func lookup(zoneName string, records []dns.RR, qname string, qtype uint16) ([]dns.RR, []dns.RR, int) {
+	zoneName = dns.CanonicalName(zoneName)
+
+	// Index the records by owner name.
+	var soa dns.RR
+	owners := map[string][]dns.RR{}
+	for _, rr := range records {
+		if rr.Header().Rrtype == dns.TypeSOA {
+			// Zone content repeats the SOA record at its end.
+			if soa != nil {
+				continue
+			}
+
+			soa = rr
+		}
+
+		owner := dns.CanonicalName(rr.Header().Name)
+		owners[owner] = append(owners[owner], rr)
+	}
+
+	authority := []dns.RR{}
+	if soa != nil {
+		authority = append(authority, soa)
+	}
+
+	answer := []dns.RR{}
+	name := dns.CanonicalName(qname)
+	for range maxCNAMEChain {
+		// Stop following CNAME records once they leave the zone.
+		if !dns.IsSubDomain(zoneName, name) {
+			return answer, nil, dns.RcodeSuccess
+		}
+
+		rrs, ok := owners[name]
+		if !ok {
+			// Names with records below them exist even when they don't have any record themselves.
+			if hasSubdomain(owners, name) || name == zoneName {
+				return answer, authority, dns.RcodeSuccess
+			}
+
+			return answer, authority, dns.RcodeNameError
+		}
+
+		var cname *dns.CNAME
+		found := false
+		for _, rr := range rrs {
+			if rr.Header().Rrtype == qtype {
+				answer = append(answer, rr)
+				found = true
+			} else if rr.Header().Rrtype == dns.TypeCNAME {
+				cname, _ = rr.(*dns.CNAME)
+			}
+		}
+
+		if found {
+			return answer, nil, dns.RcodeSuccess
+		}
+
+		if cname == nil {
+			return answer, authority, dns.RcodeSuccess
+		}
+
+		answer = append(answer, cname)
+		name = dns.CanonicalName(cname.Target)
+	}
+
+	return answer, nil, dns.RcodeSuccess
+}
+
+// hasSubdomain returns whether any of the owner names is below the given name.
+func hasSubdomain(owners map[string][]dns.RR, name string) bool {
+	for owner := range owners {
+		if owner != name && dns.IsSubDomain(name, owner) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addDNSSEC adds the DNSSEC records proving the answer of a lookup in a signed zone.
+// The matching RRSIG records are added for every record set and, for negative answers in zones using NSEC, the NSEC records proving the non-existence.
+func addDNSSEC(zoneName string, records []dns.RR, qname string, answer []dns.RR, authority []dns.RR, rcode int) ([]dns.RR, []dns.RR) {
+	zoneName = dns.CanonicalName(zoneName)
+
+	// Index the signatures and NSEC records.
+	signatures := map[string][]dns.RR{}
+	nsecs := []*dns.NSEC{}
+	for _, rr := range records {
+		switch r := rr.(type) {
+		case *dns.RRSIG:
+			key := dns.CanonicalName(r.Header().Name) + "/" + dns.TypeToString[r.TypeCovered]
+			signatures[key] = append(signatures[key], rr)
+		case *dns.NSEC:
+			nsecs = append(nsecs, r)
+		}
+	}
+
+	addSignatures := func(section []dns.RR) []dns.RR {
+		signed := map[string]bool{}
+		out := slices.Clone(section)
+		for _, rr := range section {
+			key := dns.CanonicalName(rr.Header().Name) + "/" + dns.TypeToString[rr.Header().Rrtype]
+			if signed[key] {
+				continue
+			}
+
+			signed[key] = true
+			out = append(out, signatures[key]...)
+		}
+
+		return out
+	}
+
+	// Add the NSEC records proving the non-existence of the name or type.
+	if authority != nil && len(nsecs) > 0 {
+		// The negative answer applies to the last name of the CNAME chain.
+		name := dns.CanonicalName(qname)
+		if len(answer) > 0 {
+			cname, ok := answer[len(answer)-1].(*dns.CNAME)
+			if ok {
+				name = dns.CanonicalName(cname.Target)
+			}
+		}
+
+		proofs := []dns.RR{}
+		addProof := func(nsec *dns.NSEC) {
+			if nsec != nil && !slices.Contains(proofs, dns.RR(nsec)) {
+				proofs = append(proofs, nsec)
+			}
+		}
+
+		addProof(coveringNSEC(nsecs, name))
+
+		if rcode == dns.RcodeNameError {
+			// Also prove there isn't a wildcard at the closest encloser.
+			encloser := name
+			for encloser != zoneName {
+				encloser = parentName(encloser)
+				if matchingNSEC(nsecs, encloser) != nil || hasNSECBelow(nsecs, encloser) {
+					break
+				}
+			}
+
+			addProof(coveringNSEC(nsecs, "*."+encloser))
+		}
+
+		authority = append(authority, proofs...)
+	}
+
+	return addSignatures(answer), addSignatures(authority)
+}
+
+// parentName returns the parent of a domain name.
+func parentName(name string) string {
+	labels := dns.SplitDomainName(name)
+	if len(labels) <= 1 {
+		return "."
+	}
+
+	return dns.Fqdn(strings.Join(labels[1:], "."))
+}
+
+// matchingNSEC returns the NSEC record owned by the name.
+func matchingNSEC(nsecs []*dns.NSEC, name string) *dns.NSEC {
+	for _, nsec := range nsecs {
+		if dns.CanonicalName(nsec.Header().Name) == name {
+			return nsec
+		}
+	}
+
+	return nil
+}
+
+// hasNSECBelow returns whether any NSEC record is owned by a name below the given name.
+func hasNSECBelow(nsecs []*dns.NSEC, name string) bool {
+	for _, nsec := range nsecs {
+		owner := dns.CanonicalName(nsec.Header().Name)
+		if owner != name && dns.IsSubDomain(name, owner) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// coveringNSEC returns the NSEC record owned by the name or, if there's none, the one whose span covers it.
+func coveringNSEC(nsecs []*dns.NSEC, name string) *dns.NSEC {
+	nsec := matchingNSEC(nsecs, name)
+	if nsec != nil {
+		return nsec
+	}
+
+	for _, nsec := range nsecs {
+		owner := dns.CanonicalName(nsec.Header().Name)
+		next := dns.CanonicalName(nsec.NextDomain)
+
+		// The last record of the chain wraps around to the zone apex.
+		if canonicalLess(owner, name) && (canonicalLess(name, next) || !canonicalLess(owner, next)) {
+			return nsec
+		}
+	}
+
+	return nil
+}
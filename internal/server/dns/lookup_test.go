@@ -0,0 +1,74 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testZone = `
+incus.example.net. 3600 IN SOA incus.example.net. ns1.incus.example.net. 1669736788 120 60 86400 30
+incus.example.net. 300 IN NS ns1.incus.example.net.
+c1.incus.example.net. 300 IN A 192.0.2.10
+c1.incus.example.net. 300 IN AAAA 2001:db8::10
+www.incus.example.net. 300 IN CNAME c1.incus.example.net.
+ext.incus.example.net. 300 IN CNAME www.example.com.
+_http._tcp.svc.incus.example.net. 300 IN SRV 10 5 80 c1.incus.example.net.
+c1.incus.example.net. 300 IN TXT "hello"
+incus.example.net. 3600 IN SOA incus.example.net. ns1.incus.example.net. 1669736788 120 60 86400 30
+`
+
+func TestLookup(t *testing.T) {
+	records, err := parseZone(testZone)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		qname     string
+		qtype     uint16
+		answers   []uint16
+		authority bool
+		rcode     int
+	}{
+		{name: "A record", qname: "c1.incus.example.net.", qtype: dns.TypeA, answers: []uint16{dns.TypeA}, rcode: dns.RcodeSuccess},
+		{name: "Case insensitive", qname: "C1.Incus.Example.Net.", qtype: dns.TypeAAAA, answers: []uint16{dns.TypeAAAA}, rcode: dns.RcodeSuccess},
+		{name: "TXT record", qname: "c1.incus.example.net.", qtype: dns.TypeTXT, answers: []uint16{dns.TypeTXT}, rcode: dns.RcodeSuccess},
+		{name: "SRV record", qname: "_http._tcp.svc.incus.example.net.", qtype: dns.TypeSRV, answers: []uint16{dns.TypeSRV}, rcode: dns.RcodeSuccess},
+		{name: "CNAME chased in zone", qname: "www.incus.example.net.", qtype: dns.TypeA, answers: []uint16{dns.TypeCNAME, dns.TypeA}, rcode: dns.RcodeSuccess},
+		{name: "CNAME query", qname: "www.incus.example.net.", qtype: dns.TypeCNAME, answers: []uint16{dns.TypeCNAME}, rcode: dns.RcodeSuccess},
+		{name: "CNAME leaving zone", qname: "ext.incus.example.net.", qtype: dns.TypeA, answers: []uint16{dns.TypeCNAME}, rcode: dns.RcodeSuccess},
+		{name: "No data", qname: "c1.incus.example.net.", qtype: dns.TypeSRV, authority: true, rcode: dns.RcodeSuccess},
+		{name: "Empty non-terminal", qname: "_tcp.svc.incus.example.net.", qtype: dns.TypeA, authority: true, rcode: dns.RcodeSuccess},
+		{name: "Missing name", qname: "c2.incus.example.net.", qtype: dns.TypeA, authority: true, rcode: dns.RcodeNameError},
+		{name: "Apex NS", qname: "incus.example.net.", qtype: dns.TypeNS, answers: []uint16{dns.TypeNS}, rcode: dns.RcodeSuccess},
+		{name: "Apex SOA", qname: "incus.example.net.", qtype: dns.TypeSOA, answers: []uint16{dns.TypeSOA}, rcode: dns.RcodeSuccess},
+		{name: "SOA below apex", qname: "c1.incus.example.net.", qtype: dns.TypeSOA, authority: true, rcode: dns.RcodeSuccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer, authority, rcode := lookup("incus.example.net", records, tt.qname, tt.qtype)
+			assert.Equal(t, tt.rcode, rcode)
+
+			types := []uint16{}
+			for _, rr := range answer {
+				types = append(types, rr.Header().Rrtype)
+			}
+
+			if tt.answers == nil {
+				tt.answers = []uint16{}
+			}
+
+			assert.Equal(t, tt.answers, types)
+
+			if tt.authority {
+				require.Len(t, authority, 1)
+				assert.Equal(t, dns.TypeSOA, authority[0].Header().Rrtype)
+			} else {
+				assert.Empty(t, authority)
+			}
+		})
+	}
+}
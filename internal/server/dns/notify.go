@@ -0,0 +1,97 @@
+package dns
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// Notify sends a DNS NOTIFY for the zone to all of its peers which have an address configured.
+// Messages to peers with a TSIG key are signed using HMAC-SHA256.
+func (s *Server) Notify(zone api.NetworkZone) {
+	for peerName, peer := range zonePeers(zone) {
+		if peer.address == "" {
+			continue
+		}
+
+		go func() {
+			err := sendNotify(zone.Name, peerName, peer)
+			if err != nil {
+				logger.Warn("Failed sending DNS NOTIFY", logger.Ctx{"zone": zone.Name, "peer": peerName, "err": err})
+			}
+		}()
+	}
+}
+
+// NotifyIfChanged sends a DNS NOTIFY for the zone to its peers if its records changed since the last call.
+// The first call for a zone only records its current state. The cached zone is dropped on change.
+func (s *Server) NotifyIfChanged(zone api.NetworkZone, content string) error {
+	records, err := parseZone(content)
+	if err != nil {
+		return err
+	}
+
+	// Hash the records, ignoring the SOA and RRSIG records as they change on every render.
+	lines := make([]string, 0, len(records))
+	for _, rr := range records {
+		if rr.Header().Rrtype == dns.TypeSOA || rr.Header().Rrtype == dns.TypeRRSIG {
+			continue
+		}
+
+		lines = append(lines, rr.String())
+	}
+
+	slices.Sort(lines)
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(lines, "\n"))))
+
+	s.notifyMu.Lock()
+	if s.notifyHashes == nil {
+		s.notifyHashes = map[string]string{}
+	}
+
+	oldHash, found := s.notifyHashes[zone.Name]
+	s.notifyHashes[zone.Name] = hash
+	s.notifyMu.Unlock()
+
+	if !found || oldHash != hash {
+		s.InvalidateZone(zone.Name)
+	}
+
+	if found && oldHash != hash {
+		s.Notify(zone)
+	}
+
+	return nil
+}
+
+// sendNotify sends a DNS NOTIFY for the zone to a single peer.
+func sendNotify(zoneName string, peerName string, peer *zonePeer) error {
+	m := &dns.Msg{}
+	m.SetNotify(dns.Fqdn(zoneName))
+
+	client := &dns.Client{Net: "udp", Timeout: 5 * time.Second}
+	if peer.key != "" {
+		keyName := zonePeerKeyName(zoneName, peerName)
+		client.TsigSecret = map[string]string{keyName: peer.key}
+		m.SetTsig(keyName, dns.HmacSHA256, 300, time.Now().Unix())
+	}
+
+	resp, _, err := client.Exchange(m, net.JoinHostPort(peer.address, "53"))
+	if err != nil {
+		return err
+	}
+
+	if resp.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("Peer replied with %q", dns.RcodeToString[resp.Rcode])
+	}
+
+	return nil
+}
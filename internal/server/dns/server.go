@@ -34,6 +34,16 @@ type Server struct {
 	cmd chan serverCmdInfo
 
 	mu sync.Mutex
+
+	// Rendered zones used to answer regular queries.
+	zones           map[string]*cachedZone
+	zoneNames       map[string]bool
+	zoneNamesExpiry time.Time
+	zonesMu         sync.RWMutex
+
+	// Last notified zone records (to detect changes).
+	notifyHashes map[string]string
+	notifyMu     sync.Mutex
 }
 
 type serverCmd int
@@ -281,7 +291,7 @@ func (s *Server) updateTSIG() error {
 				}
 
 				// Format as a valid TSIG secret (encode domain name, key name and make valid FQDN).
-				secretKey := fmt.Sprintf("%s_%s.", zone.Name, fields[1])
+				secretKey := zonePeerKeyName(zone.Name, fields[1])
 				secrets[secretKey] = value
 			}
 		}
@@ -0,0 +1,57 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_nftParseACLRuleCounters(t *testing.T) {
+	output := `{"nftables": [
+{"metainfo": {"version": "1.0.9", "release_name": "Old Doc Yak #3", "json_schema_version": 1}},
+{"table": {"family": "inet", "name": "incus", "handle": 1}},
+{"rule": {"family": "inet", "table": "incus", "chain": "aclin.incusbr0", "handle": 10, "comment": "incus_acl5-ingress-0", "expr": [{"match": {"op": "==", "left": {"meta": {"key": "l4proto"}}, "right": "tcp"}}, {"counter": {"packets": 3, "bytes": 180}}, {"accept": null}]}},
+{"rule": {"family": "inet", "table": "incus", "chain": "aclin.incusbr1", "handle": 11, "comment": "incus_acl5-ingress-0", "expr": [{"counter": {"packets": 2, "bytes": 120}}, {"accept": null}]}},
+{"rule": {"family": "inet", "table": "incus", "chain": "aclout.incusbr0", "handle": 12, "comment": "incus_acl5-egress-1", "expr": [{"counter": {"packets": 0, "bytes": 0}}, {"drop": null}]}},
+{"rule": {"family": "inet", "table": "incus", "chain": "aclout.incusbr0", "handle": 13, "expr": [{"counter": {"packets": 7, "bytes": 700}}, {"reject": null}]}},
+{"rule": {"family": "inet", "table": "other", "chain": "input", "handle": 14, "comment": "incus_acl5-ingress-0", "expr": [{"counter": {"packets": 9, "bytes": 900}}, {"accept": null}]}}
+]}`
+
+	counters, err := nftParseACLRuleCounters(output)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]ACLRuleCounters{
+		"incus_acl5-ingress-0": {Packets: 5, Bytes: 300},
+		"incus_acl5-egress-1":  {Packets: 0, Bytes: 0},
+	}, counters)
+
+	_, err = nftParseACLRuleCounters("not json")
+	assert.Error(t, err)
+}
+
+func Test_xtablesParseACLRuleCounters(t *testing.T) {
+	output := `# Generated by iptables-save v1.8.10 on Thu Jan  1 00:00:00 2025
+*filter
+:INPUT ACCEPT [0:0]
+:incus_acl_incusbr0 - [0:0]
+[4:240] -A incus_acl_incusbr0 -o incusbr0 -p tcp -m multiport --dports 22 -m comment --comment incus_acl5-ingress-0 -j ACCEPT
+[1:60] -A incus_acl_incusbr0 -o incusbr0 -p tcp -m multiport --dports 22 -j LOG --log-prefix "incus_acl5-ingress-0 "
+[2:168] -A incus_acl_incusbr0 -i incusbr0 -m comment --comment "incus_acl5-egress-1" -j DROP
+[8:800] -A incus_acl_incusbr0 -i incusbr0 -j REJECT
+[9:900] -A INPUT -m comment --comment incus_acl5-ingress-0 -j ACCEPT
+COMMIT
+`
+
+	counters := map[string]ACLRuleCounters{
+		"incus_acl5-ingress-0": {Packets: 1, Bytes: 100},
+	}
+
+	err := xtablesParseACLRuleCounters(output, counters)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]ACLRuleCounters{
+		"incus_acl5-ingress-0": {Packets: 5, Bytes: 340},
+		"incus_acl5-egress-1":  {Packets: 2, Bytes: 168},
+	}, counters)
+}
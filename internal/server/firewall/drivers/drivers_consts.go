@@ -40,16 +40,46 @@ type ACLRule struct {
 	DestinationPort string
 	ICMPType        string
 	ICMPCode        string
+	CounterName     string // Name identifying the rule when retrieving its hit counters (optional).
+}
+
+// ACLRuleCounters represents the hit counters of an ACL rule.
+type ACLRuleCounters struct {
+	Packets int64
+	Bytes   int64
 }
 
 // AddressForward represents a NAT address forward.
 type AddressForward struct {
-	ListenAddress net.IP
-	TargetAddress net.IP
-	Protocol      string
-	ListenPorts   []uint64
-	TargetPorts   []uint64
-	SNAT          bool
+	ListenAddress        net.IP
+	TargetAddress        net.IP
+	Protocol             string
+	ListenPorts          []uint64
+	TargetPorts          []uint64
+	SNAT                 bool
+	MaxConnectionRate    int64 // Maximum number of new connections per second to the listen ports (optional).
+	MaxSourceConnections int64 // Maximum number of concurrent connections per source address (optional).
+}
+
+// LoadBalancerTarget represents a load balancer target address and port.
+type LoadBalancerTarget struct {
+	Address net.IP
+	Port    uint64
+}
+
+// LoadBalancerPort represents a load balancer listen port and the targets to distribute its connections to.
+type LoadBalancerPort struct {
+	ListenPort uint64
+	Targets    []LoadBalancerTarget
+}
+
+// LoadBalancer represents a set of load balanced listen ports.
+type LoadBalancer struct {
+	ListenAddress        net.IP
+	Protocol             string
+	Ports                []LoadBalancerPort
+	MaxConnectionRate    int64 // Maximum number of new connections per second to the listen ports (optional).
+	MaxSourceConnections int64 // Maximum number of concurrent connections per source address (optional).
 }
 
 // AddressSet represent an address set.
@@ -74,3 +104,32 @@ type NftSet struct {
 	Name   string `json:"name"`
 	Table  string `json:"table"`
 }
+
+// NftListRulesOutput structure to read JSON output of rule listing.
+type NftListRulesOutput struct {
+	Nftables []NftListRulesEntry `json:"nftables"`
+}
+
+// NftListRulesEntry structure to read JSON output of nft rule listing.
+type NftListRulesEntry struct {
+	Rule *NftRule `json:"rule,omitempty"`
+}
+
+// NftRule structure to parse the JSON of a rule returned by nft -j list ruleset.
+type NftRule struct {
+	Family  string        `json:"family"`
+	Table   string        `json:"table"`
+	Comment string        `json:"comment"`
+	Expr    []NftRuleExpr `json:"expr"`
+}
+
+// NftRuleExpr structure to parse the JSON of a rule expression returned by nft -j list ruleset.
+type NftRuleExpr struct {
+	Counter *NftCounter `json:"counter,omitempty"`
+}
+
+// NftCounter structure to parse the JSON of a counter statement returned by nft -j list ruleset.
+type NftCounter struct {
+	Packets int64 `json:"packets"`
+	Bytes   int64 `json:"bytes"`
+}
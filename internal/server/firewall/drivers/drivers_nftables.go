@@ -132,11 +132,11 @@ func (d Nftables) Compat() (bool, error) {
 
 // nftGenericItem represents some common fields amongst the different nftables types.
 type nftGenericItem struct {
-	ItemType string `json:"-"`      // Type of item (table, chain or rule). Populated by Incus.
+	ItemType string `json:"-"`      // Type of item (table, chain, set or rule). Populated by Incus.
 	Family   string `json:"family"` // Family of item (ip, ip6, bridge etc).
 	Table    string `json:"table"`  // Table the item belongs to (for chains and rules).
 	Chain    string `json:"chain"`  // Chain the item belongs to (for rules).
-	Name     string `json:"name"`   // Name of item (for tables and chains).
+	Name     string `json:"name"`   // Name of item (for tables, chains and sets).
 }
 
 // nftParseRuleset parses the ruleset and returns the generic parts as a slice of items.
@@ -169,6 +169,7 @@ func (d Nftables) nftParseRuleset() ([]nftGenericItem, error) {
 	for _, item := range v.Nftables {
 		rule, foundRule := item["rule"]
 		chain, foundChain := item["chain"]
+		set, foundSet := item["set"]
 		table, foundTable := item["table"]
 		if foundRule {
 			rule.ItemType = "rule"
@@ -176,6 +177,9 @@ func (d Nftables) nftParseRuleset() ([]nftGenericItem, error) {
 		} else if foundChain {
 			chain.ItemType = "chain"
 			items = append(items, chain)
+		} else if foundSet {
+			set.ItemType = "set"
+			items = append(items, set)
 		} else if foundTable {
 			table.ItemType = "table"
 			items = append(items, table)
@@ -377,7 +381,8 @@ func (d Nftables) NetworkClear(networkName string, _ bool, _ []uint) error {
 	removeChains := []string{
 		"fwd", "pstrt", "in", "out", // Chains used for network operation rules.
 		"aclin", "aclout", "aclfwd", "acl", // Chains used by ACL rules.
-		"fwdprert", "fwdout", "fwdpstrt", // Chains used by Address Forward rules.
+		"fwdprert", "fwdout", "fwdpstrt", "fwdlim", // Chains used by Address Forward rules.
+		"lbprert", "lbout", "lbpstrt", "lblim", // Chains used by Load Balancer rules.
 		"egress", // Chains added for limits.priority option
 	}
 
@@ -388,6 +393,14 @@ func (d Nftables) NetworkClear(networkName string, _ bool, _ []uint) error {
 		return fmt.Errorf("Failed clearing nftables rules for network %q: %w", networkName, err)
 	}
 
+	// Remove the sets used by the connection limits.
+	for _, chainPrefix := range []string{"fwd", "lb"} {
+		err = d.removeConnLimitSets(chainPrefix, networkName, nil)
+		if err != nil {
+			return fmt.Errorf("Failed clearing nftables connection limit sets for network %q: %w", networkName, err)
+		}
+	}
+
 	// Attempt to delete our address sets.
 	// This will fail so long as there are still rules referencing them (other networks).
 	_ = d.RemoveIncusAddressSets("bridge")
@@ -933,6 +946,43 @@ func (d Nftables) InstanceClearNetPrio(projectName string, instanceName string,
 	return nil
 }
 
+// InstanceSetupDSCP activates DSCP marking of the traffic coming from the specified instance device on the host interface.
+func (d Nftables) InstanceSetupDSCP(projectName string, instanceName string, deviceName string, dscp uint8) error {
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"family":         "netdev",
+		"chainSeparator": nftablesChainSeparator,
+		"deviceLabel":    deviceLabel,
+		"deviceName":     deviceName,
+		"dscp":           dscp,
+	}
+
+	err := d.applyNftConfig(nftablesInstanceDSCP, tplFields)
+	if err != nil {
+		return fmt.Errorf("Failed adding DSCP rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	return nil
+}
+
+// InstanceClearDSCP removes DSCP marking of the traffic coming from the specified instance device on the host interface.
+func (d Nftables) InstanceClearDSCP(projectName string, instanceName string, deviceName string) error {
+	if deviceName == "" {
+		return fmt.Errorf("Failed clearing DSCP rules for instance %q in project %q: device name is empty", projectName, instanceName)
+	}
+
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+	chainLabel := fmt.Sprintf("dscp%s%s", nftablesChainSeparator, deviceLabel)
+
+	err := d.removeChains([]string{"netdev"}, chainLabel, "ingress")
+	if err != nil {
+		return fmt.Errorf("Failed clearing DSCP rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	return nil
+}
+
 // NetworkApplyACLRules applies ACL rules to the existing firewall chains.
 func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) error {
 	completeNftRules := make([]string, 0)
@@ -987,6 +1037,47 @@ func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) erro
 	return nil
 }
 
+// NetworkACLRuleCounters returns the hit counters of the ACL rules, indexed by counter name.
+// The counters of all the rules sharing a counter name are added up.
+func (d Nftables) NetworkACLRuleCounters() (map[string]ACLRuleCounters, error) {
+	output, err := subprocess.RunCommand("nft", "-j", "list", "ruleset")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to execute nft command: %w", err)
+	}
+
+	return nftParseACLRuleCounters(output)
+}
+
+// nftParseACLRuleCounters parses the JSON output of "nft -j list ruleset" and returns the hit counters of the
+// Incus rules carrying a comment.
+func nftParseACLRuleCounters(output string) (map[string]ACLRuleCounters, error) {
+	var rulesOutput NftListRulesOutput
+	err := json.Unmarshal([]byte(output), &rulesOutput)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse nft command output: %w", err)
+	}
+
+	counters := map[string]ACLRuleCounters{}
+	for _, entry := range rulesOutput.Nftables {
+		if entry.Rule == nil || entry.Rule.Comment == "" || entry.Rule.Table != nftablesNamespace {
+			continue
+		}
+
+		for _, expr := range entry.Rule.Expr {
+			if expr.Counter == nil {
+				continue
+			}
+
+			counter := counters[entry.Rule.Comment]
+			counter.Packets += expr.Counter.Packets
+			counter.Bytes += expr.Counter.Bytes
+			counters[entry.Rule.Comment] = counter
+		}
+	}
+
+	return counters, nil
+}
+
 // buildRemainingRuleParts is a helper that returns the protocol, port, logging, and action parts of a rule.
 func (d Nftables) buildRemainingRuleParts(rule *ACLRule, ipVersion uint) (string, error) {
 	args := []string{}
@@ -1023,6 +1114,11 @@ func (d Nftables) buildRemainingRuleParts(rule *ACLRule, ipVersion uint) (string
 		}
 	}
 
+	// Handle counters.
+	if rule.CounterName != "" {
+		args = append(args, "counter")
+	}
+
 	// Handle logging.
 	if rule.Log {
 		args = append(args, "log")
@@ -1040,6 +1136,11 @@ func (d Nftables) buildRemainingRuleParts(rule *ACLRule, ipVersion uint) (string
 
 	args = append(args, action)
 
+	// Identify the counter through the rule comment.
+	if rule.CounterName != "" {
+		args = append(args, "comment", fmt.Sprintf(`"%s"`, rule.CounterName))
+	}
+
 	return strings.Join(args, " "), nil
 }
 
@@ -1312,6 +1413,7 @@ func (d Nftables) aclRulePortToACLMatch(direction string, portCriteria ...string
 func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForward) error {
 	var dnatRules []map[string]any
 	var snatRules []map[string]any
+	var limitRules []map[string]any
 
 	// Build up rules, ordering by port specific listen rules first, followed by default target rules.
 	// This is so the generated firewall rules will apply the port specific rules first.
@@ -1359,6 +1461,11 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 			targetAddressStr := rule.TargetAddress.String()
 
 			if rule.Protocol != "" {
+				limitRule := d.connLimitRule("fwd", networkName, len(limitRules), rule.ListenAddress, rule.Protocol, rule.ListenPorts, rule.MaxConnectionRate, rule.MaxSourceConnections)
+				if limitRule != nil {
+					limitRules = append(limitRules, limitRule)
+				}
+
 				targetPortRanges := portRangesFromSlice(rule.TargetPorts)
 
 				for _, targetPortRange := range targetPortRanges {
@@ -1456,6 +1563,219 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 		}
 	}
 
+	err := d.applyConnLimits("fwd", networkName, limitRules)
+	if err != nil {
+		return fmt.Errorf("Failed applying nftables forward connection limits for network %q: %w", networkName, err)
+	}
+
+	return nil
+}
+
+// NetworkApplyLoadBalancers apply network load balancers requests to network.
+// The new connections to each listen port are distributed randomly across the targets.
+func (d Nftables) NetworkApplyLoadBalancers(networkName string, rules []LoadBalancer) error {
+	var dnatRules []map[string]any
+	var snatRules []map[string]any
+	var limitRules []map[string]any
+	var snatTargets []string
+
+	for ruleIndex, rule := range rules {
+		if rule.ListenAddress == nil {
+			return fmt.Errorf("Invalid rule %d, listen address is required", ruleIndex)
+		}
+
+		if rule.Protocol == "" || len(rule.Ports) == 0 {
+			return fmt.Errorf("Invalid rule %d, protocol and listen ports are required", ruleIndex)
+		}
+
+		ipFamily := "ip"
+		if rule.ListenAddress.To4() == nil {
+			ipFamily = "ip6"
+		}
+
+		listenPorts := make([]uint64, 0, len(rule.Ports))
+		for _, port := range rule.Ports {
+			listenPorts = append(listenPorts, port.ListenPort)
+		}
+
+		limitRule := d.connLimitRule("lb", networkName, len(limitRules), rule.ListenAddress, rule.Protocol, listenPorts, rule.MaxConnectionRate, rule.MaxSourceConnections)
+		if limitRule != nil {
+			limitRules = append(limitRules, limitRule)
+		}
+
+		for _, port := range rule.Ports {
+			// Nothing to forward the traffic to (for example when all targets are offline).
+			if len(port.Targets) == 0 {
+				continue
+			}
+
+			targetMap := make([]string, 0, len(port.Targets))
+			for targetIndex, target := range port.Targets {
+				if target.Address == nil || target.Port == 0 {
+					return fmt.Errorf("Invalid rule %d, target address and port are required", ruleIndex)
+				}
+
+				targetMap = append(targetMap, fmt.Sprintf("%d : %s . %d", targetIndex, target.Address.String(), target.Port))
+
+				// Allow the targets to reach themselves through the listen address.
+				snatTarget := fmt.Sprintf("%s/%s/%d", rule.Protocol, target.Address.String(), target.Port)
+				if !slices.Contains(snatTargets, snatTarget) {
+					snatTargets = append(snatTargets, snatTarget)
+					snatRules = append(snatRules, map[string]any{
+						"ipFamily":    ipFamily,
+						"protocol":    rule.Protocol,
+						"targetHost":  target.Address.String(),
+						"targetPorts": target.Port,
+					})
+				}
+			}
+
+			dnatRules = append(dnatRules, map[string]any{
+				"ipFamily":      ipFamily,
+				"protocol":      rule.Protocol,
+				"listenAddress": rule.ListenAddress.String(),
+				"listenPort":    port.ListenPort,
+				"targetCount":   len(port.Targets),
+				"targetMap":     strings.Join(targetMap, ", "),
+			})
+		}
+	}
+
+	if len(dnatRules) > 0 {
+		tplFields := map[string]any{
+			"namespace":      nftablesNamespace,
+			"chainSeparator": nftablesChainSeparator,
+			"chainPrefix":    "lb",
+			"family":         "inet",
+			"label":          networkName,
+			"dnatRules":      dnatRules,
+			"snatRules":      snatRules,
+		}
+
+		config := &strings.Builder{}
+		err := nftablesNetLoadBalancers.Execute(config, tplFields)
+		if err != nil {
+			return fmt.Errorf("Failed running %q template: %w", nftablesNetLoadBalancers.Name(), err)
+		}
+
+		err = subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "nft", "-f", "-")
+		if err != nil {
+			return err
+		}
+	} else {
+		err := d.removeChains([]string{"inet"}, networkName, "lbprert", "lbout", "lbpstrt")
+		if err != nil {
+			return fmt.Errorf("Failed clearing nftables load balancer rules for network %q: %w", networkName, err)
+		}
+	}
+
+	err := d.applyConnLimits("lb", networkName, limitRules)
+	if err != nil {
+		return fmt.Errorf("Failed applying nftables load balancer connection limits for network %q: %w", networkName, err)
+	}
+
+	return nil
+}
+
+// connLimitRule returns the template fields of the connection limits of a set of listen ports.
+// Returns nil if no limit is set.
+func (d Nftables) connLimitRule(chainPrefix string, label string, index int, listenAddress net.IP, protocol string, listenPorts []uint64, connectionRate int64, sourceConnections int64) map[string]any {
+	if connectionRate <= 0 && sourceConnections <= 0 {
+		return nil
+	}
+
+	ipFamily := "ip"
+	setType := "ipv4_addr"
+	if listenAddress.To4() == nil {
+		ipFamily = "ip6"
+		setType = "ipv6_addr"
+	}
+
+	portRanges := []string{}
+	for _, portRange := range portRangesFromSlice(listenPorts) {
+		portRanges = append(portRanges, portRangeStr(portRange, "-"))
+	}
+
+	return map[string]any{
+		"ipFamily":          ipFamily,
+		"protocol":          protocol,
+		"listenAddress":     listenAddress.String(),
+		"listenPorts":       fmt.Sprintf("{ %s }", strings.Join(portRanges, ", ")),
+		"connectionRate":    max(connectionRate, 0),
+		"sourceConnections": max(sourceConnections, 0),
+		"setName":           fmt.Sprintf("%s%s%s%d", d.connLimitSetPrefix(chainPrefix, label), ipFamily, nftablesChainSeparator, index),
+		"setType":           setType,
+	}
+}
+
+// connLimitSetPrefix returns the name prefix of the sets used to track the connections per source address.
+func (d Nftables) connLimitSetPrefix(chainPrefix string, label string) string {
+	return fmt.Sprintf("%slim%s%s%s", chainPrefix, nftablesChainSeparator, label, nftablesChainSeparator)
+}
+
+// applyConnLimits applies the connection limit rules, removing the chain and sets when no limit is set.
+func (d Nftables) applyConnLimits(chainPrefix string, label string, limitRules []map[string]any) error {
+	if len(limitRules) == 0 {
+		err := d.removeChains([]string{"inet"}, label, chainPrefix+"lim")
+		if err != nil {
+			return err
+		}
+
+		return d.removeConnLimitSets(chainPrefix, label, nil)
+	}
+
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"chainSeparator": nftablesChainSeparator,
+		"chainPrefix":    chainPrefix,
+		"family":         "inet",
+		"label":          label,
+		"limitRules":     limitRules,
+	}
+
+	config := &strings.Builder{}
+	err := nftablesNetConnLimits.Execute(config, tplFields)
+	if err != nil {
+		return fmt.Errorf("Failed running %q template: %w", nftablesNetConnLimits.Name(), err)
+	}
+
+	err = subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "nft", "-f", "-")
+	if err != nil {
+		return err
+	}
+
+	// Remove the sets no longer referenced by the rules.
+	usedSets := make([]string, 0, len(limitRules))
+	for _, limitRule := range limitRules {
+		usedSets = append(usedSets, limitRule["setName"].(string))
+	}
+
+	return d.removeConnLimitSets(chainPrefix, label, usedSets)
+}
+
+// removeConnLimitSets removes the connection limit sets of the label, except for the ones listed in keep.
+func (d Nftables) removeConnLimitSets(chainPrefix string, label string, keep []string) error {
+	ruleset, err := d.nftParseRuleset()
+	if err != nil {
+		return err
+	}
+
+	setPrefix := d.connLimitSetPrefix(chainPrefix, label)
+	for _, item := range ruleset {
+		if item.ItemType != "set" || item.Family != "inet" || item.Table != nftablesNamespace {
+			continue
+		}
+
+		if !strings.HasPrefix(item.Name, setPrefix) || slices.Contains(keep, item.Name) {
+			continue
+		}
+
+		_, err = subprocess.RunCommand("nft", "delete", "set", item.Family, nftablesNamespace, item.Name)
+		if err != nil {
+			return fmt.Errorf("Failed deleting nftables set %q: %w", item.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -115,6 +115,64 @@ table {{.family}} {{.namespace}} {
 }
 `))
 
+var nftablesNetConnLimits = template.Must(template.New("nftablesNetConnLimits").Parse(`
+add table {{.family}} {{.namespace}}
+add chain {{.family}} {{.namespace}} {{.chainPrefix}}lim{{.chainSeparator}}{{.label}} {type filter hook prerouting priority -150; policy accept;}
+flush chain {{.family}} {{.namespace}} {{.chainPrefix}}lim{{.chainSeparator}}{{.label}}
+{{- range .limitRules }}
+{{- if .sourceConnections }}
+add set {{$.family}} {{$.namespace}} {{.setName}} { type {{.setType}}; size 65535; flags dynamic; }
+{{- end }}
+{{- end }}
+
+table {{.family}} {{.namespace}} {
+	chain {{.chainPrefix}}lim{{.chainSeparator}}{{.label}} {
+		type filter hook prerouting priority -150; policy accept;
+		{{- range .limitRules }}
+		{{- if .connectionRate }}
+		{{.ipFamily}} daddr {{.listenAddress}} {{.protocol}} dport {{.listenPorts}} ct state new limit rate over {{.connectionRate}}/second drop
+		{{- end }}
+		{{- if .sourceConnections }}
+		{{.ipFamily}} daddr {{.listenAddress}} {{.protocol}} dport {{.listenPorts}} ct state new add @{{.setName}} { {{.ipFamily}} saddr ct count over {{.sourceConnections}} } drop
+		{{- end }}
+		{{- end }}
+	}
+}
+`))
+
+var nftablesNetLoadBalancers = template.Must(template.New("nftablesNetLoadBalancers").Parse(`
+add table {{.family}} {{.namespace}}
+add chain {{.family}} {{.namespace}} {{.chainPrefix}}prert{{.chainSeparator}}{{.label}} {type nat hook prerouting priority -100; policy accept;}
+add chain {{.family}} {{.namespace}} {{.chainPrefix}}out{{.chainSeparator}}{{.label}} {type nat hook output priority -100; policy accept;}
+add chain {{.family}} {{.namespace}} {{.chainPrefix}}pstrt{{.chainSeparator}}{{.label}} {type nat hook postrouting priority 100; policy accept;}
+flush chain {{.family}} {{.namespace}} {{.chainPrefix}}prert{{.chainSeparator}}{{.label}}
+flush chain {{.family}} {{.namespace}} {{.chainPrefix}}out{{.chainSeparator}}{{.label}}
+flush chain {{.family}} {{.namespace}} {{.chainPrefix}}pstrt{{.chainSeparator}}{{.label}}
+
+table {{.family}} {{.namespace}} {
+	chain {{.chainPrefix}}prert{{.chainSeparator}}{{.label}} {
+		type nat hook prerouting priority -100; policy accept;
+		{{- range .dnatRules }}
+		{{.ipFamily}} daddr {{.listenAddress}} {{.protocol}} dport {{.listenPort}} dnat {{.ipFamily}} to numgen random mod {{.targetCount}} map { {{.targetMap}} }
+		{{- end }}
+	}
+
+	chain {{.chainPrefix}}out{{.chainSeparator}}{{.label}} {
+		type nat hook output priority -100; policy accept;
+		{{- range .dnatRules }}
+		{{.ipFamily}} daddr {{.listenAddress}} {{.protocol}} dport {{.listenPort}} dnat {{.ipFamily}} to numgen random mod {{.targetCount}} map { {{.targetMap}} }
+		{{- end }}
+	}
+
+	chain {{.chainPrefix}}pstrt{{.chainSeparator}}{{.label}} {
+		type nat hook postrouting priority 100; policy accept;
+		{{- range .snatRules }}
+		{{.ipFamily}} saddr {{.targetHost}} {{.ipFamily}} daddr {{.targetHost}} {{.protocol}} dport {{.targetPorts}} masquerade
+		{{- end }}
+	}
+}
+`))
+
 var nftablesNetACLSetup = template.Must(template.New("nftablesNetACLSetup").Parse(`
 add table {{.family}} {{.namespace}}
 add chain {{.family}} {{.namespace}} acl{{.chainSeparator}}{{.networkName}}
@@ -381,3 +439,12 @@ chain egress{{.chainSeparator}}netprio{{.chainSeparator}}{{.deviceLabel}} {
 	meta priority set "{{.netPrio}}"
 }
 `))
+
+// nftablesInstanceDSCP defines the rules to perform DSCP marking of the traffic coming from an instance.
+var nftablesInstanceDSCP = template.Must(template.New("nftablesInstanceDSCP").Parse(`
+chain ingress{{.chainSeparator}}dscp{{.chainSeparator}}{{.deviceLabel}} {
+	type filter hook ingress device "{{.deviceName}}" priority 0 ;
+	meta protocol ip ip dscp set {{.dscp}}
+	meta protocol ip6 ip6 dscp set {{.dscp}}
+}
+`))
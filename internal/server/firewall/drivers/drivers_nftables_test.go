@@ -0,0 +1,39 @@
+package drivers
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNftables_connLimitRule(t *testing.T) {
+	d := Nftables{}
+
+	// No limits.
+	assert.Nil(t, d.connLimitRule("fwd", "incusbr0", 0, net.ParseIP("192.0.2.1"), "tcp", []uint64{80}, 0, 0))
+
+	// IPv4 with both limits over port ranges.
+	assert.Equal(t, map[string]any{
+		"ipFamily":          "ip",
+		"protocol":          "tcp",
+		"listenAddress":     "192.0.2.1",
+		"listenPorts":       "{ 80-82, 443 }",
+		"connectionRate":    int64(100),
+		"sourceConnections": int64(10),
+		"setName":           "fwdlim.incusbr0.ip.0",
+		"setType":           "ipv4_addr",
+	}, d.connLimitRule("fwd", "incusbr0", 0, net.ParseIP("192.0.2.1"), "tcp", []uint64{80, 81, 82, 443}, 100, 10))
+
+	// IPv6 with only a connection rate.
+	assert.Equal(t, map[string]any{
+		"ipFamily":          "ip6",
+		"protocol":          "udp",
+		"listenAddress":     "2001:db8::1",
+		"listenPorts":       "{ 53 }",
+		"connectionRate":    int64(50),
+		"sourceConnections": int64(0),
+		"setName":           "lblim.incusbr0.ip6.1",
+		"setType":           "ipv6_addr",
+	}, d.connLimitRule("lb", "incusbr0", 1, net.ParseIP("2001:db8::1"), "udp", []uint64{53}, 50, 0))
+}
@@ -576,6 +576,64 @@ func (d Xtables) NetworkApplyACLRules(networkName string, rules []ACLRule) error
 	return nil
 }
 
+// NetworkACLRuleCounters returns the hit counters of the ACL rules, indexed by counter name.
+// The counters of all the rules sharing a counter name are added up.
+func (d Xtables) NetworkACLRuleCounters() (map[string]ACLRuleCounters, error) {
+	counters := map[string]ACLRuleCounters{}
+
+	for _, cmd := range []string{"iptables-save", "ip6tables-save"} {
+		output, err := subprocess.RunCommand(cmd, "-c", "-t", "filter")
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting %q rules: %w", cmd, err)
+		}
+
+		err = xtablesParseACLRuleCounters(output, counters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return counters, nil
+}
+
+// xtablesParseACLRuleCounters parses the output of "iptables-save -c" and adds the hit counters of the ACL
+// rules to the provided map.
+func xtablesParseACLRuleCounters(output string, counters map[string]ACLRuleCounters) error {
+	for _, line := range strings.Split(output, "\n") {
+		// Only consider the rules of the ACL chains.
+		if !strings.HasPrefix(line, "[") || !strings.Contains(line, fmt.Sprintf(" -A %s_", iptablesChainACLFilterPrefix)) {
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		counterName := ""
+		for i, field := range fields {
+			if field == "--comment" && i+1 < len(fields) {
+				counterName = strings.Trim(fields[i+1], "\"")
+				break
+			}
+		}
+
+		if counterName == "" {
+			continue
+		}
+
+		var packets, bytes int64
+		_, err := fmt.Sscanf(fields[0], "[%d:%d]", &packets, &bytes)
+		if err != nil {
+			return fmt.Errorf("Failed parsing counters of rule %q: %w", line, err)
+		}
+
+		counter := counters[counterName]
+		counter.Packets += packets
+		counter.Bytes += bytes
+		counters[counterName] = counter
+	}
+
+	return nil
+}
+
 // aclRuleCriteriaToArgs converts an ACL rule into an set of arguments for an xtables rule.
 // Returns the arguments to use for the action command and separately the arguments for logging if enabled.
 // Returns nil arguments if the rule is not appropriate for the ipVersion.
@@ -679,12 +737,19 @@ func (d Xtables) aclRuleCriteriaToArgs(networkName string, ipVersion uint, rule
 		action = "accept"
 	}
 
-	actionArgs := append(args, "-j", strings.ToUpper(action))
+	actionArgs := slices.Clone(args)
+
+	// Identify the counter through the rule comment.
+	if rule.CounterName != "" {
+		actionArgs = append(actionArgs, "-m", "comment", "--comment", rule.CounterName)
+	}
+
+	actionArgs = append(actionArgs, "-j", strings.ToUpper(action))
 
 	// Handle logging.
 	var logArgs []string
 	if rule.Log {
-		logArgs = append(args, "-j", "LOG")
+		logArgs = append(slices.Clone(args), "-j", "LOG")
 
 		if rule.LogName != "" {
 			// Add a trailing space to prefix for readability in logs.
@@ -1426,6 +1491,56 @@ func (d Xtables) InstanceClearNetPrio(projectName string, instanceName string, d
 	return nil
 }
 
+// InstanceSetupDSCP activates DSCP marking of the traffic coming from the specified instance device on the host interface.
+// Only routed traffic is marked.
+func (d Xtables) InstanceSetupDSCP(projectName string, instanceName string, deviceName string, dscp uint8) error {
+	comment := fmt.Sprintf("%s dscp", d.instanceDeviceIPTablesComment(projectName, instanceName, deviceName))
+	args := []string{
+		"-i", deviceName,
+		"-j", "DSCP",
+		"--set-dscp", fmt.Sprintf("%d", dscp),
+	}
+
+	// IPv4 filter.
+	err := d.iptablesPrepend(4, comment, "mangle", "PREROUTING", args...)
+	if err != nil {
+		return err
+	}
+
+	// IPv6 filter if IPv6 is enabled.
+	if util.PathExists("/proc/sys/net/ipv6") {
+		err = d.iptablesPrepend(6, comment, "mangle", "PREROUTING", args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InstanceClearDSCP removes DSCP marking of the traffic coming from the specified instance device on the host interface.
+func (d Xtables) InstanceClearDSCP(projectName string, instanceName string, deviceName string) error {
+	if deviceName == "" {
+		return fmt.Errorf("Failed clearing DSCP rules for instance %q in project %q: device name is empty", projectName, instanceName)
+	}
+
+	comment := fmt.Sprintf("%s dscp", d.instanceDeviceIPTablesComment(projectName, instanceName, deviceName))
+	errs := []error{}
+
+	for _, ipVersion := range []uint{4, 6} {
+		err := d.iptablesClear(ipVersion, []string{comment}, "mangle")
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to remove DSCP rules for %q: %v", deviceName, errs)
+	}
+
+	return nil
+}
+
 // iptablesChainExists checks whether a chain exists in a table, and whether it has any rules.
 func (d Xtables) iptablesChainExists(ipVersion uint, table string, chain string) (bool, bool, error) {
 	var cmd string
@@ -1505,6 +1620,16 @@ func (d Xtables) iptablesChainDelete(ipVersion uint, table string, chain string,
 	return nil
 }
 
+// NetworkApplyLoadBalancers apply network load balancers requests to network.
+func (d Xtables) NetworkApplyLoadBalancers(networkName string, rules []LoadBalancer) error {
+	// Nothing to clear as load balancers are never applied under xtables.
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return errors.New("Load balancers are not supported under xtables")
+}
+
 // NetworkApplyForwards apply network address forward rules to firewall.
 func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward) error {
 	// Validate all rules first.
@@ -1526,6 +1651,10 @@ func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward
 		if targetPortLen > 1 && targetPortLen != listenPortLen {
 			return fmt.Errorf("Invalid rule %d, mismatch between listen port(s) and target port(s) count", i)
 		}
+
+		if rule.MaxConnectionRate > 0 || rule.MaxSourceConnections > 0 {
+			return errors.New("Connection limits are not supported under xtables")
+		}
 	}
 
 	comment := d.networkForwardIPTablesComment(networkName)
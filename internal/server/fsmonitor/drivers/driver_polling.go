@@ -0,0 +1,159 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// DefaultPollInterval is the interval at which the polling driver scans the filesystem when none is provided.
+const DefaultPollInterval = 5 * time.Second
+
+type polling struct {
+	common
+
+	interval time.Duration
+
+	// entries holds all known paths below the prefix path and whether they are directories.
+	entries map[string]bool
+}
+
+func (d *polling) Name() string {
+	return "polling"
+}
+
+func (d *polling) load(ctx context.Context) error {
+	if !util.PathExists(d.prefixPath) {
+		return errors.New("Path doesn't exist")
+	}
+
+	if d.interval <= 0 {
+		d.interval = DefaultPollInterval
+	}
+
+	d.entries = d.scan()
+
+	go d.poll(ctx)
+
+	return nil
+}
+
+// poll rescans the filesystem tree at every interval until the context is done.
+func (d *polling) poll(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.refresh()
+		}
+	}
+}
+
+// scan returns all paths below the prefix path and whether they are directories.
+// Symlinks aren't followed.
+func (d *polling) scan() map[string]bool {
+	entries := map[string]bool{}
+
+	_ = filepath.WalkDir(d.prefixPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Entries may vanish while walking the tree and some may not be readable.
+			if !errors.Is(err, fs.ErrNotExist) && !os.IsPermission(err) {
+				d.logger.Warn("Error visiting path", logger.Ctx{"path": path, "err": err})
+			}
+
+			return nil
+		}
+
+		if path == d.prefixPath {
+			return nil
+		}
+
+		entries[filepath.Clean(path)] = entry.IsDir()
+
+		return nil
+	})
+
+	return entries
+}
+
+// refresh rescans the filesystem tree and calls the handlers for the paths which were added or removed.
+func (d *polling) refresh() {
+	entries := d.scan()
+
+	added := []string{}
+	for path := range entries {
+		_, ok := d.entries[path]
+		if !ok {
+			added = append(added, path)
+		}
+	}
+
+	removed := []string{}
+	for path := range d.entries {
+		_, ok := entries[path]
+		if !ok {
+			removed = append(removed, path)
+		}
+	}
+
+	oldEntries := d.entries
+	d.entries = entries
+
+	slices.Sort(added)
+	slices.Sort(removed)
+
+	// Like with the other drivers, only report the top-most path when a whole directory tree was added or removed.
+	// The handlers of paths within the directory are still called.
+	for _, path := range removed {
+		_, parentRemoved := slices.BinarySearch(removed, filepath.Dir(path))
+		if parentRemoved {
+			continue
+		}
+
+		d.handleEvent(path, oldEntries[path], Remove)
+	}
+
+	for _, path := range added {
+		_, parentAdded := slices.BinarySearch(added, filepath.Dir(path))
+		if parentAdded {
+			continue
+		}
+
+		d.handleEvent(path, entries[path], Add)
+	}
+}
+
+// handleEvent calls the handlers watching the path. For directories, the handlers watching any path within it are called too.
+func (d *polling) handleEvent(eventPath string, isDir bool, action Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for path := range d.watches {
+		if path != eventPath && (!isDir || !strings.HasPrefix(path, eventPath+"/")) {
+			continue
+		}
+
+		for identifier, f := range d.watches[path] {
+			ret := f(path, action.String())
+			if !ret {
+				delete(d.watches[path], identifier)
+
+				if len(d.watches[path]) == 0 {
+					delete(d.watches, path)
+				}
+			}
+		}
+	}
+}
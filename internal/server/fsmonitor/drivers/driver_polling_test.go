@@ -0,0 +1,173 @@
+package drivers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+type pollingEvent struct {
+	path  string
+	event string
+}
+
+// startPolling loads a polling driver on a new temporary directory.
+func startPolling(t *testing.T) (Driver, string) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+
+	d, err := LoadPolling(ctx, logger.Log, dir, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "polling", d.Name())
+
+	return d, dir
+}
+
+// watch adds a watch on the path which sends its events to the returned channel.
+func watch(t *testing.T, d Driver, path string, keep bool) chan pollingEvent {
+	t.Helper()
+
+	events := make(chan pollingEvent, 10)
+	err := d.Watch(path, "test", func(path string, event string) bool {
+		events <- pollingEvent{path: path, event: event}
+		return keep
+	})
+	require.NoError(t, err)
+
+	return events
+}
+
+// waitEvent waits for the next event on the channel.
+func waitEvent(t *testing.T, events chan pollingEvent) pollingEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for event")
+	}
+
+	return pollingEvent{}
+}
+
+// assertNoEvent checks that no event is received for a few polling intervals.
+func assertNoEvent(t *testing.T, events chan pollingEvent) {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		assert.Fail(t, "Unexpected event", "%s on %s", event.event, event.path)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPolling_CreateDelete(t *testing.T) {
+	d, dir := startPolling(t)
+
+	path := filepath.Join(dir, "dev0")
+	events := watch(t, d, path, true)
+
+	err := os.WriteFile(path, nil, 0o600)
+	require.NoError(t, err)
+	assert.Equal(t, pollingEvent{path: path, event: "add"}, waitEvent(t, events))
+
+	err = os.Remove(path)
+	require.NoError(t, err)
+	assert.Equal(t, pollingEvent{path: path, event: "remove"}, waitEvent(t, events))
+
+	assertNoEvent(t, events)
+}
+
+func TestPolling_ExistingEntries(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dev0")
+
+	err := os.WriteFile(path, nil, 0o600)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d, err := LoadPolling(ctx, logger.Log, dir, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	// Entries present when loading the driver don't trigger events.
+	events := watch(t, d, path, true)
+	assertNoEvent(t, events)
+
+	err = os.Remove(path)
+	require.NoError(t, err)
+	assert.Equal(t, pollingEvent{path: path, event: "remove"}, waitEvent(t, events))
+}
+
+func TestPolling_Recursive(t *testing.T) {
+	d, dir := startPolling(t)
+
+	// Watch a path in a directory tree which doesn't exist yet.
+	path := filepath.Join(dir, "bus", "usb", "001", "002")
+	events := watch(t, d, path, true)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	require.NoError(t, err)
+
+	err = os.WriteFile(path, nil, 0o600)
+	require.NoError(t, err)
+
+	// The whole tree is reported once, through its top-most directory.
+	assert.Equal(t, pollingEvent{path: path, event: "add"}, waitEvent(t, events))
+	assertNoEvent(t, events)
+
+	// Removing the tree reports the removal of the watched path.
+	err = os.RemoveAll(filepath.Join(dir, "bus"))
+	require.NoError(t, err)
+	assert.Equal(t, pollingEvent{path: path, event: "remove"}, waitEvent(t, events))
+	assertNoEvent(t, events)
+}
+
+func TestPolling_Unwatch(t *testing.T) {
+	d, dir := startPolling(t)
+
+	// Watches are removed when their handler returns false.
+	path := filepath.Join(dir, "dev0")
+	events := watch(t, d, path, false)
+
+	err := os.WriteFile(path, nil, 0o600)
+	require.NoError(t, err)
+	assert.Equal(t, pollingEvent{path: path, event: "add"}, waitEvent(t, events))
+
+	err = os.Remove(path)
+	require.NoError(t, err)
+	assertNoEvent(t, events)
+
+	// Watches can also be removed explicitly.
+	events = watch(t, d, path, true)
+
+	err = d.Unwatch(path, "test")
+	require.NoError(t, err)
+
+	err = os.WriteFile(path, nil, 0o600)
+	require.NoError(t, err)
+	assertNoEvent(t, events)
+}
+
+func TestPolling_InvalidPath(t *testing.T) {
+	d, _ := startPolling(t)
+
+	err := d.Watch("/elsewhere/dev0", "test", func(string, string) bool { return true })
+	assert.ErrorAs(t, err, new(*ErrInvalidPath))
+
+	_, err = LoadPolling(context.Background(), logger.Log, filepath.Join(t.TempDir(), "missing"), time.Second)
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package drivers
 
 import (
 	"context"
+	"time"
 
 	"github.com/lxc/incus/v6/shared/logger"
 )
@@ -9,6 +10,7 @@ import (
 var drivers = map[string]func() driver{
 	"inotify":  func() driver { return &inotify{} },
 	"fanotify": func() driver { return &fanotify{} },
+	"polling":  func() driver { return &polling{} },
 }
 
 // Load returns a Driver for an existing low-level FS monitor.
@@ -29,3 +31,18 @@ func Load(ctx context.Context, logger logger.Logger, driverName string, path str
 
 	return d, nil
 }
+
+// LoadPolling returns a Driver which scans the path for changes at the given interval.
+// It works on any filesystem, including those which don't deliver inotify or fanotify events.
+func LoadPolling(ctx context.Context, logger logger.Logger, path string, interval time.Duration) (Driver, error) {
+	d := &polling{interval: interval}
+
+	d.init(logger, path)
+
+	err := d.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
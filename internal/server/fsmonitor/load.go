@@ -3,6 +3,9 @@ package fsmonitor
 import (
 	"context"
 	"errors"
+	"time"
+
+	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/server/fsmonitor/drivers"
@@ -10,7 +13,8 @@ import (
 )
 
 // New creates a new FSMonitor instance.
-func New(ctx context.Context, path string) (FSMonitor, error) {
+// The polling driver, scanning the path at the given interval, is used when the kernel drivers can't be.
+func New(ctx context.Context, path string, pollInterval time.Duration) (FSMonitor, error) {
 	startMonitor := func(driverName string) (drivers.Driver, logger.Logger, error) {
 		logger := logger.AddContext(logger.Ctx{"driver": driverName})
 
@@ -26,13 +30,40 @@ func New(ctx context.Context, path string) (FSMonitor, error) {
 		return nil, errors.New("Path needs to be a mountpoint")
 	}
 
-	driver, monLogger, err := startMonitor("fanotify")
-	if err != nil {
-		logger.Warn("Failed to initialize fanotify, falling back on inotify", logger.Ctx{"err": err})
-		driver, monLogger, err = startMonitor("inotify")
+	startPolling := func() (drivers.Driver, logger.Logger, error) {
+		logger := logger.AddContext(logger.Ctx{"driver": "polling"})
+
+		driver, err := drivers.LoadPolling(ctx, logger, path, pollInterval)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return driver, logger, nil
+	}
+
+	var driver drivers.Driver
+	var monLogger logger.Logger
+	var err error
+
+	if needsPolling(path) {
+		// Filesystems like FUSE or network filesystems don't deliver fanotify or inotify events.
+		driver, monLogger, err = startPolling()
 		if err != nil {
 			return nil, err
 		}
+	} else {
+		driver, monLogger, err = startMonitor("fanotify")
+		if err != nil {
+			logger.Warn("Failed to initialize fanotify, falling back on inotify", logger.Ctx{"err": err})
+			driver, monLogger, err = startMonitor("inotify")
+			if err != nil {
+				logger.Warn("Failed to initialize inotify, falling back on polling", logger.Ctx{"err": err})
+				driver, monLogger, err = startPolling()
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 
 	logger.Info("Initialized filesystem monitor", logger.Ctx{"path": path, "driver": driver.Name()})
@@ -44,3 +75,18 @@ func New(ctx context.Context, path string) (FSMonitor, error) {
 
 	return &monitor, nil
 }
+
+// needsPolling returns whether the path is on a filesystem which doesn't deliver fanotify or inotify events.
+func needsPolling(path string) bool {
+	fs, err := linux.StatVFS(path)
+	if err != nil {
+		return false
+	}
+
+	switch uint32(fs.Type) {
+	case unix.FUSE_SUPER_MAGIC, unix.NFS_SUPER_MAGIC, unix.CIFS_SUPER_MAGIC, unix.SMB2_SUPER_MAGIC, unix.SMB_SUPER_MAGIC, unix.V9FS_MAGIC, unix.CEPH_SUPER_MAGIC:
+		return true
+	}
+
+	return false
+}
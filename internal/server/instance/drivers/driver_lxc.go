@@ -6681,6 +6681,7 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 			VolumeOnly:            !args.Snapshots,
 			ClusterMoveSourceName: args.ClusterMoveSourceName,
 			StoragePool:           args.StoragePool,
+			InfoReceived:          args.InfoReceived,
 		}
 
 		// At this point we have already figured out the parent container's root
@@ -2689,14 +2689,14 @@ func (d *qemu) deviceDetachBlockDevice(deviceName string, rawConfig deviceConfig
 func (d *qemu) deviceAttachNIC(deviceName string, configCopy map[string]string, runConf *deviceConfig.RunConfig) error {
 	devName := ""
 	for _, dev := range runConf.NetworkInterface {
-		if dev.Key == "link" {
+		if dev.Key == "link" || dev.Key == "vhostUserSocket" {
 			devName = dev.Value
 			break
 		}
 	}
 
 	if devName == "" {
-		return errors.New("Device didn't provide a link property to use")
+		return errors.New("Device didn't provide a link or socket property to use")
 	}
 
 	_, qemuBus, err := d.qemuArchConfig(d.architecture)
@@ -2967,6 +2967,12 @@ func (d *qemu) deviceDetachNIC(deviceName string) error {
 		}
 	}
 
+	// Remove the character device connecting vhost-user NICs to their socket (if any).
+	err = monitor.RemoveCharDevice(netDevID)
+	if err != nil {
+		return fmt.Errorf("Failed removing NIC character device: %w", err)
+	}
+
 	return nil
 }
 
@@ -4834,13 +4840,63 @@ func (d *qemu) addDriveConfig(qemuDev map[string]any, bootIndexes map[string]int
 	return monHook, nil
 }
 
+// qemuNICQueues sets the multi-queue configuration of the NIC device in qemuDev.
+// The number of queues is the one set on the NIC, or otherwise the number of vCPUs with a minimum of two.
+// Returns the number of queues to use with the NIC.
+func qemuNICQueues(busName string, queues string, cpuCount int, qemuDev map[string]any) int {
+	queueCount := max(cpuCount, 2)
+	if queues != "" {
+		queueCount, _ = strconv.Atoi(queues)
+	}
+
+	// Number of vectors is number of queues * 2 (RX/TX) + 2 (config/control MSI-X).
+	vectors := 2*queueCount + 2
+	if busName != "usb" {
+		qemuDev["mq"] = true
+		if slices.Contains([]string{"pcie", "pci"}, busName) {
+			qemuDev["vectors"] = vectors
+		}
+	}
+
+	return queueCount
+}
+
+// qemuVhostUserNetDev returns the character device connected to the vhost-user socket passed as fdName along
+// with the vhost-user network device using it.
+func qemuVhostUserNetDev(netDevID string, fdName string, queueCount int) (map[string]any, map[string]any) {
+	charDev := map[string]any{
+		"id": netDevID,
+		"backend": map[string]any{
+			"type": "socket",
+			"data": map[string]any{
+				"addr": map[string]any{
+					"type": "fd",
+					"data": map[string]any{
+						"str": fdName,
+					},
+				},
+				"server": false,
+			},
+		},
+	}
+
+	netDev := map[string]any{
+		"id":      netDevID,
+		"type":    "vhost-user",
+		"chardev": netDevID,
+		"queues":  queueCount,
+	}
+
+	return charDev, netDev
+}
+
 // addNetDevConfig adds the qemu config required for adding a network device.
 // The qemuDev map is expected to be preconfigured with the settings for an existing port to use for the device.
 func (d *qemu) addNetDevConfig(busName string, qemuDev map[string]any, bootIndexes map[string]int, nicConfig []deviceConfig.RunConfigItem) (monitorHook, error) {
 	reverter := revert.New()
 	defer reverter.Fail()
 
-	var devName, nicName, devHwaddr, pciSlotName, pciIOMMUGroup, vDPADevName, vhostVDPAPath, maxVQP string
+	var devName, nicName, devHwaddr, pciSlotName, pciIOMMUGroup, vDPADevName, vhostVDPAPath, maxVQP, vhostUserSocket, queues string
 	for _, nicItem := range nicConfig {
 		if nicItem.Key == "devName" {
 			devName = nicItem.Value
@@ -4858,6 +4914,10 @@ func (d *qemu) addNetDevConfig(busName string, qemuDev map[string]any, bootIndex
 			vhostVDPAPath = nicItem.Value
 		} else if nicItem.Key == "maxVQP" {
 			maxVQP = nicItem.Value
+		} else if nicItem.Key == "vhostUserSocket" {
+			vhostUserSocket = nicItem.Value
+		} else if nicItem.Key == "queues" {
+			queues = nicItem.Value
 		}
 	}
 
@@ -4873,22 +4933,11 @@ func (d *qemu) addNetDevConfig(busName string, qemuDev map[string]any, bootIndex
 
 	var monHook func(m *qmp.Monitor) error
 
-	// configureQueues modifies qemuDev with the queue configuration based on vCPUs.
+	// configureQueues modifies qemuDev with the queue configuration based on vCPUs, unless the number of
+	// queues is set by the device.
 	// Returns the number of queues to use with NIC.
 	configureQueues := func(cpuCount int) int {
-		// Number of queues is the same as number of vCPUs. Run with a minimum of two queues.
-		queueCount := max(cpuCount, 2)
-
-		// Number of vectors is number of vCPUs * 2 (RX/TX) + 2 (config/control MSI-X).
-		vectors := 2*queueCount + 2
-		if busName != "usb" {
-			qemuDev["mq"] = true
-			if slices.Contains([]string{"pcie", "pci"}, busName) {
-				qemuDev["vectors"] = vectors
-			}
-		}
-
-		return queueCount
+		return qemuNICQueues(busName, queues, cpuCount, qemuDev)
 	}
 
 	// tapMonHook is a helper function used as the monitor hook for macvtap and tap interfaces to open
@@ -5038,6 +5087,75 @@ func (d *qemu) addNetDevConfig(busName string, qemuDev map[string]any, bootIndex
 		}
 
 		monHook = tapMonHook(devFile)
+	} else if vhostUserSocket != "" {
+		// Connect to the vhost-user socket of the userspace switch and pass the connection to QEMU.
+		monHook = func(m *qmp.Monitor) error {
+			reverter := revert.New()
+			defer reverter.Fail()
+
+			cpus, err := m.QueryCPUs()
+			if err != nil {
+				return errors.New("Failed getting CPU list for NIC queues")
+			}
+
+			queueCount := configureQueues(len(cpus))
+
+			addr, err := net.ResolveUnixAddr("unix", vhostUserSocket)
+			if err != nil {
+				return err
+			}
+
+			vhostUserConn, err := net.DialUnix("unix", nil, addr)
+			if err != nil {
+				return fmt.Errorf("Error connecting to vhost-user socket %q: %w", vhostUserSocket, err)
+			}
+
+			defer func() { _ = vhostUserConn.Close() }() // Close file after device has been added.
+
+			vhostUserFile, err := vhostUserConn.File()
+			if err != nil {
+				return fmt.Errorf("Error opening vhost-user socket %q: %w", vhostUserSocket, err)
+			}
+
+			defer func() { _ = vhostUserFile.Close() }()
+
+			netDevID := fmt.Sprintf("%s%s", qemuNetDevIDPrefix, escapedDeviceName)
+			vhostUserFDName := fmt.Sprintf("%s.vhost-user", netDevID)
+			err = m.SendFile(vhostUserFDName, vhostUserFile)
+			if err != nil {
+				return fmt.Errorf("Failed to send vhost-user file descriptor: %w", err)
+			}
+
+			reverter.Add(func() { _ = m.CloseFile(vhostUserFDName) })
+
+			qemuCharDev, qemuNetDev := qemuVhostUserNetDev(netDevID, vhostUserFDName, queueCount)
+			err = m.AddCharDevice(qemuCharDev)
+			if err != nil {
+				return fmt.Errorf("Failed to add the character device: %w", err)
+			}
+
+			reverter.Add(func() { _ = m.RemoveCharDevice(netDevID) })
+
+			if slices.Contains([]string{"pcie", "pci"}, busName) {
+				qemuDev["driver"] = "virtio-net-pci"
+			} else if busName == "ccw" {
+				qemuDev["driver"] = "virtio-net-ccw"
+			} else {
+				return errors.New("vhost-user NICs can't be used on the USB bus")
+			}
+
+			qemuDev["netdev"] = netDevID
+			qemuDev["mac"] = devHwaddr
+
+			err = m.AddNIC(qemuNetDev, qemuDev)
+			if err != nil {
+				return fmt.Errorf("Failed setting up device %q: %w", devName, err)
+			}
+
+			reverter.Success()
+
+			return nil
+		}
 	} else if util.PathExists(vhostVDPAPath) {
 		monHook = func(m *qmp.Monitor) error {
 			reverter := revert.New()
@@ -8198,6 +8316,7 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 			VolumeOnly:            !args.Snapshots,
 			ClusterMoveSourceName: args.ClusterMoveSourceName,
 			StoragePool:           args.StoragePool,
+			InfoReceived:          args.InfoReceived,
 		}
 
 		// At this point we have already figured out the parent instances's root
@@ -8543,7 +8662,7 @@ func (d *qemu) Console(protocol string) (*os.File, chan error, error) {
 		return nil, nil, fmt.Errorf("Connect to console socket %q: %w", path, err)
 	}
 
-	file, err := (conn.(*net.UnixConn)).File()
+	file, err := conn.(*net.UnixConn).File()
 	if err != nil {
 		if protocol == instance.ConsoleTypeConsole {
 			_ = d.consoleSwapSocketWithRB()
@@ -64,3 +64,57 @@ func TestExtractTrailingNumber(t *testing.T) {
 		t.Errorf("unexpected error message: got %q, want %q", err.Error(), expectedErr)
 	}
 }
+
+// Test qemuNICQueues.
+func TestQemuNICQueues(t *testing.T) {
+	tests := []struct {
+		name     string
+		busName  string
+		queues   string
+		cpuCount int
+		want     int
+		wantQemu map[string]any
+	}{
+		{name: "vCPU count", busName: "pcie", cpuCount: 4, want: 4, wantQemu: map[string]any{"mq": true, "vectors": 10}},
+		{name: "Minimum of two queues", busName: "pci", cpuCount: 1, want: 2, wantQemu: map[string]any{"mq": true, "vectors": 6}},
+		{name: "Configured queues", busName: "pcie", queues: "8", cpuCount: 2, want: 8, wantQemu: map[string]any{"mq": true, "vectors": 18}},
+		{name: "CCW bus", busName: "ccw", queues: "3", cpuCount: 2, want: 3, wantQemu: map[string]any{"mq": true}},
+		{name: "USB bus", busName: "usb", cpuCount: 4, want: 4, wantQemu: map[string]any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qemuDev := map[string]any{}
+			assert.Equal(t, tt.want, qemuNICQueues(tt.busName, tt.queues, tt.cpuCount, qemuDev))
+			assert.Equal(t, tt.wantQemu, qemuDev)
+		})
+	}
+}
+
+// Test qemuVhostUserNetDev.
+func TestQemuVhostUserNetDev(t *testing.T) {
+	charDev, netDev := qemuVhostUserNetDev("incus_eth0", "incus_eth0.vhost-user", 4)
+
+	assert.Equal(t, map[string]any{
+		"id": "incus_eth0",
+		"backend": map[string]any{
+			"type": "socket",
+			"data": map[string]any{
+				"addr": map[string]any{
+					"type": "fd",
+					"data": map[string]any{
+						"str": "incus_eth0.vhost-user",
+					},
+				},
+				"server": false,
+			},
+		},
+	}, charDev)
+
+	assert.Equal(t, map[string]any{
+		"id":      "incus_eth0",
+		"type":    "vhost-user",
+		"chardev": "incus_eth0",
+		"queues":  4,
+	}, netDev)
+}
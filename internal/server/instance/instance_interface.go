@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v6/internal/server/metrics"
+	localMigration "github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/idmap"
@@ -252,4 +253,7 @@ type MigrateReceiveArgs struct {
 	InstanceOperation   *operationlock.InstanceOperation
 	Refresh             bool
 	RefreshExcludeOlder bool
+
+	// InfoReceived is called with the instance details received from the source.
+	InfoReceived func(info *localMigration.Info)
 }
@@ -19,6 +19,8 @@ type Class struct {
 type ClassHTB struct {
 	Class
 	Rate string
+	Ceil string
+	Prio uint32
 }
 
 // Add adds class to a node.
@@ -39,7 +41,9 @@ func (class *ClassHTB) Add() error {
 		Statistics: nil,
 	}
 
-	htbClassAttrs := netlink.HtbClassAttrs{}
+	htbClassAttrs := netlink.HtbClassAttrs{
+		Prio: class.Prio,
+	}
 
 	if class.Classid != "" {
 		handle, err := parseHandle(class.Classid)
@@ -59,6 +63,15 @@ func (class *ClassHTB) Add() error {
 		htbClassAttrs.Rate = uint64(rate)
 	}
 
+	if class.Ceil != "" {
+		ceil, err := units.ParseBitSizeString(class.Ceil)
+		if err != nil {
+			return fmt.Errorf("Invalid ceil %q: %w", class.Ceil, err)
+		}
+
+		htbClassAttrs.Ceil = uint64(ceil)
+	}
+
 	err = netlink.ClassAdd(netlink.NewHtbClass(classAttrs, htbClassAttrs))
 	if err != nil {
 		return fmt.Errorf("Failed to add htb class: %w", err)
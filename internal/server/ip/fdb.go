@@ -0,0 +1,92 @@
+package ip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// FDB represents arguments for bridge forwarding database manipulation.
+// Entries are added on the device itself (self), for example to point a MAC address at a remote VXLAN endpoint.
+// The all-zero MAC address is used for the default destinations of broadcast, unknown unicast and multicast.
+type FDB struct {
+	DevName string
+	MAC     net.HardwareAddr
+	Dst     net.IP
+}
+
+// Show lists the static forwarding database entries of the device.
+func (f *FDB) Show() ([]FDB, error) {
+	link, err := linkByName(f.DevName)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := netlink.NeighList(link.Attrs().Index, unix.AF_BRIDGE)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get forwarding database entries for link %q: %w", f.DevName, err)
+	}
+
+	entries := make([]FDB, 0, len(list))
+	for _, neigh := range list {
+		if neigh.Flags&unix.NTF_SELF == 0 || neigh.State&unix.NUD_PERMANENT == 0 {
+			continue
+		}
+
+		entries = append(entries, FDB{
+			DevName: f.DevName,
+			MAC:     neigh.HardwareAddr,
+			Dst:     neigh.IP,
+		})
+	}
+
+	return entries, nil
+}
+
+func (f *FDB) netlinkNeigh() (*netlink.Neigh, error) {
+	link, err := linkByName(f.DevName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &netlink.Neigh{
+		LinkIndex:    link.Attrs().Index,
+		Family:       unix.AF_BRIDGE,
+		Flags:        unix.NTF_SELF,
+		State:        unix.NUD_PERMANENT | unix.NUD_NOARP,
+		HardwareAddr: f.MAC,
+		IP:           f.Dst,
+	}, nil
+}
+
+// Append adds a forwarding database entry, allowing for multiple destinations for the same MAC address.
+func (f *FDB) Append() error {
+	neigh, err := f.netlinkNeigh()
+	if err != nil {
+		return err
+	}
+
+	err = netlink.NeighAppend(neigh)
+	if err != nil {
+		return fmt.Errorf("Failed to add forwarding database entry %v: %w", neigh, err)
+	}
+
+	return nil
+}
+
+// Delete removes a forwarding database entry.
+func (f *FDB) Delete() error {
+	neigh, err := f.netlinkNeigh()
+	if err != nil {
+		return err
+	}
+
+	err = netlink.NeighDel(neigh)
+	if err != nil {
+		return fmt.Errorf("Failed to delete forwarding database entry %v: %w", neigh, err)
+	}
+
+	return nil
+}
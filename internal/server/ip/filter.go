@@ -36,6 +36,34 @@ func (a *ActionPolice) toNetlink() (netlink.Action, error) {
 	return action, nil
 }
 
+// ActionSkbEdit represents an action of 'skbedit' type.
+type ActionSkbEdit struct {
+	Priority uint32 // skb->priority, i.e. the class ID (major:minor) used by classful qdiscs
+}
+
+func (a *ActionSkbEdit) toNetlink() (netlink.Action, error) {
+	action := netlink.NewSkbEditAction()
+
+	priority := a.Priority
+	action.Priority = &priority
+
+	return action, nil
+}
+
+// ActionMirred represents an action of 'mirred' type, redirecting packets to the egress of another device.
+type ActionMirred struct {
+	Dev string
+}
+
+func (a *ActionMirred) toNetlink() (netlink.Action, error) {
+	link, err := linkByName(a.Dev)
+	if err != nil {
+		return nil, err
+	}
+
+	return netlink.NewMirredAction(link.Attrs().Index), nil
+}
+
 // Filter represents filter object.
 type Filter struct {
 	Dev      string
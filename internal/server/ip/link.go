@@ -380,3 +380,12 @@ func (l *Link) BridgeLinkSetHairpin(hairpin bool) error {
 		},
 	}, hairpin)
 }
+
+// BridgeLinkSetLearning sets bridge 'learning' attribute on a port.
+func (l *Link) BridgeLinkSetLearning(learning bool) error {
+	return netlink.LinkSetLearning(&netlink.GenericLink{
+		LinkAttrs: netlink.LinkAttrs{
+			Name: l.Name,
+		},
+	}, learning)
+}
@@ -0,0 +1,22 @@
+package ip
+
+import (
+	"github.com/vishvananda/netlink"
+)
+
+// Ifb represents arguments for link device of type ifb.
+type Ifb struct {
+	Link
+}
+
+// Add adds new virtual link.
+func (i *Ifb) Add() error {
+	attrs, err := i.netlinkAttrs()
+	if err != nil {
+		return err
+	}
+
+	return i.addLink(&netlink.Ifb{
+		LinkAttrs: attrs,
+	})
+}
@@ -0,0 +1,196 @@
+package ip
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/lxc/incus/v6/shared/subprocess"
+)
+
+// Wireguard represents arguments for link device of type wireguard.
+type Wireguard struct {
+	Link
+}
+
+// WireguardPeer represents the configuration of a WireGuard peer.
+type WireguardPeer struct {
+	PublicKey           string
+	Endpoint            string
+	AllowedIPs          []string
+	PersistentKeepalive int
+}
+
+// WireguardPeerState represents the state of a WireGuard peer.
+type WireguardPeerState struct {
+	WireguardPeer
+	LatestHandshake time.Time
+	ReceivedBytes   int64
+	SentBytes       int64
+}
+
+// WireguardState represents the state of a WireGuard device.
+type WireguardState struct {
+	PublicKey  string
+	ListenPort int
+	Peers      []WireguardPeerState
+}
+
+// Add adds new virtual link.
+func (w *Wireguard) Add() error {
+	attrs, err := w.netlinkAttrs()
+	if err != nil {
+		return err
+	}
+
+	return w.addLink(&netlink.Wireguard{
+		LinkAttrs: attrs,
+	})
+}
+
+// SetConfig replaces the private key, listen port and peers of the device.
+func (w *Wireguard) SetConfig(privateKey string, listenPort int, peers []WireguardPeer) error {
+	var config strings.Builder
+
+	config.WriteString("[Interface]\n")
+	fmt.Fprintf(&config, "PrivateKey = %s\n", privateKey)
+	fmt.Fprintf(&config, "ListenPort = %d\n", listenPort)
+
+	for _, peer := range peers {
+		config.WriteString("\n[Peer]\n")
+		fmt.Fprintf(&config, "PublicKey = %s\n", peer.PublicKey)
+
+		if peer.Endpoint != "" {
+			fmt.Fprintf(&config, "Endpoint = %s\n", peer.Endpoint)
+		}
+
+		if len(peer.AllowedIPs) > 0 {
+			fmt.Fprintf(&config, "AllowedIPs = %s\n", strings.Join(peer.AllowedIPs, ", "))
+		}
+
+		if peer.PersistentKeepalive > 0 {
+			fmt.Fprintf(&config, "PersistentKeepalive = %d\n", peer.PersistentKeepalive)
+		}
+	}
+
+	// Pass the configuration through stdin to avoid exposing the private key.
+	err := subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "wg", "setconf", w.Name, "/dev/stdin")
+	if err != nil {
+		return fmt.Errorf("Failed configuring WireGuard device %q: %w", w.Name, err)
+	}
+
+	return nil
+}
+
+// Show returns the state of the device.
+func (w *Wireguard) Show() (*WireguardState, error) {
+	output, err := subprocess.RunCommand("wg", "show", w.Name, "dump")
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting state of WireGuard device %q: %w", w.Name, err)
+	}
+
+	return parseWireguardDump(output)
+}
+
+// parseWireguardDump parses the output of "wg show <device> dump".
+// The first line describes the device (private key, public key, listen port and firewall mark), each of the
+// following lines describes a peer (public key, preshared key, endpoint, allowed IPs, latest handshake,
+// received bytes, sent bytes and persistent keepalive).
+func parseWireguardDump(output string) (*WireguardState, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	fields := strings.Split(lines[0], "\t")
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("Invalid WireGuard device line %q", lines[0])
+	}
+
+	listenPort, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid WireGuard listen port %q: %w", fields[2], err)
+	}
+
+	state := &WireguardState{
+		PublicKey:  fields[1],
+		ListenPort: listenPort,
+		Peers:      []WireguardPeerState{},
+	}
+
+	for _, line := range lines[1:] {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 8 {
+			return nil, fmt.Errorf("Invalid WireGuard peer line %q", line)
+		}
+
+		peer := WireguardPeerState{
+			WireguardPeer: WireguardPeer{
+				PublicKey:  fields[0],
+				AllowedIPs: []string{},
+			},
+		}
+
+		if fields[2] != "(none)" {
+			peer.Endpoint = fields[2]
+		}
+
+		if fields[3] != "(none)" {
+			peer.AllowedIPs = strings.Split(fields[3], ",")
+		}
+
+		handshake, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid WireGuard latest handshake %q: %w", fields[4], err)
+		}
+
+		if handshake > 0 {
+			peer.LatestHandshake = time.Unix(handshake, 0)
+		}
+
+		peer.ReceivedBytes, err = strconv.ParseInt(fields[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid WireGuard received bytes %q: %w", fields[5], err)
+		}
+
+		peer.SentBytes, err = strconv.ParseInt(fields[6], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid WireGuard sent bytes %q: %w", fields[6], err)
+		}
+
+		if fields[7] != "off" {
+			peer.PersistentKeepalive, err = strconv.Atoi(fields[7])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid WireGuard persistent keepalive %q: %w", fields[7], err)
+			}
+		}
+
+		state.Peers = append(state.Peers, peer)
+	}
+
+	return state, nil
+}
+
+// WireguardGenerateKeyPair generates a new WireGuard private key and its public key, base64 encoded.
+func WireguardGenerateKeyPair() (string, string, error) {
+	privateKey := make([]byte, curve25519.ScalarSize)
+	_, err := rand.Read(privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed generating WireGuard private key: %w", err)
+	}
+
+	// Clamp the private key.
+	privateKey[0] &= 248
+	privateKey[31] = (privateKey[31] & 127) | 64
+
+	publicKey, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed computing WireGuard public key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(privateKey), base64.StdEncoding.EncodeToString(publicKey), nil
+}
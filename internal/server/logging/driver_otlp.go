@@ -0,0 +1,496 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// OTLP span kinds and status codes.
+const (
+	otlpSpanKindInternal = 1
+
+	otlpStatusCodeOk    = 1
+	otlpStatusCodeError = 2
+)
+
+// OTLPLogger represents an OpenTelemetry logger sending logs and traces over OTLP/HTTP.
+type OTLPLogger struct {
+	common
+	cfg     config
+	client  *http.Client
+	ctx     context.Context
+	quit    chan struct{}
+	once    sync.Once
+	entries chan otlpEntry
+	wg      sync.WaitGroup
+}
+
+// NewOTLPLogger returns a logger of otlp type.
+func NewOTLPLogger(s *state.State, name string) (*OTLPLogger, error) {
+	urlStr, username, password, caCert, instance, retry := s.GlobalConfig.LoggingConfigForOTLP(name)
+
+	// Set defaults.
+	if retry == 0 {
+		retry = 3
+	}
+
+	// Validate the URL.
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle standalone systems.
+	var location string
+	if !s.ServerClustered {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+
+		location = hostname
+		if instance == "" {
+			instance = hostname
+		}
+	} else if instance == "" {
+		instance = s.ServerName
+	}
+
+	loggerClient := OTLPLogger{
+		common: newCommonLogger(name, s.GlobalConfig),
+		cfg: config{
+			batchSize: 10 * 1024,
+			batchWait: 1 * time.Second,
+			caCert:    caCert,
+			username:  username,
+			password:  password,
+			instance:  instance,
+			location:  location,
+			retry:     retry,
+			timeout:   10 * time.Second,
+			url:       u,
+		},
+		client:  &http.Client{},
+		ctx:     s.ShutdownCtx,
+		entries: make(chan otlpEntry),
+		quit:    make(chan struct{}),
+	}
+
+	if caCert != "" {
+		tlsConfig, err := localtls.GetTLSConfigMem("", "", caCert, "", false)
+		if err != nil {
+			return nil, err
+		}
+
+		loggerClient.client.Transport = &http.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+	} else {
+		loggerClient.client = http.DefaultClient
+	}
+
+	return &loggerClient, nil
+}
+
+func (l *OTLPLogger) run() {
+	batch := newOTLPBatch()
+
+	minWaitCheckFrequency := 10 * time.Millisecond
+	maxWaitCheckFrequency := max(l.cfg.batchWait/10, minWaitCheckFrequency)
+
+	maxWaitCheck := time.NewTicker(maxWaitCheckFrequency)
+	defer maxWaitCheck.Stop()
+
+	defer func() {
+		// Send all pending batches
+		l.sendBatch(batch)
+		l.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+
+		case <-l.quit:
+			return
+
+		case e := <-l.entries:
+			// If adding the entry to the batch will increase the size over the max
+			// size allowed, we do send the current batch and then create a new one
+			if batch.sizeBytesAfter(e) > l.cfg.batchSize {
+				l.sendBatch(batch)
+
+				batch = newOTLPBatch(e)
+				break
+			}
+
+			// The max size of the batch isn't reached, so we can add the entry
+			batch.add(e)
+
+		case <-maxWaitCheck.C:
+			// Send batch if max wait time has been reached
+			if batch.age() < l.cfg.batchWait {
+				break
+			}
+
+			l.sendBatch(batch)
+			batch = newOTLPBatch()
+		}
+	}
+}
+
+func (l *OTLPLogger) sendBatch(batch *otlpBatch) {
+	if batch.empty() {
+		return
+	}
+
+	logs, traces, err := batch.encode()
+	if err != nil {
+		return
+	}
+
+	if logs != nil {
+		l.sendWithRetry("/v1/logs", logs)
+	}
+
+	if traces != nil {
+		l.sendWithRetry("/v1/traces", traces)
+	}
+}
+
+func (l *OTLPLogger) sendWithRetry(endpoint string, buf []byte) {
+	for range l.cfg.retry {
+		select {
+		case <-l.quit:
+			return
+		default:
+			// Try to send the message.
+			status, err := l.send(l.ctx, endpoint, buf)
+			if err == nil {
+				return
+			}
+
+			// Only retry 429s, 502s, 503s, 504s and connection-level errors as recommended by the OTLP specification.
+			if status > 0 && !slices.Contains([]int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}, status) {
+				return
+			}
+
+			// Retry every 10s.
+			time.Sleep(10 * time.Second)
+		}
+	}
+}
+
+func (l *OTLPLogger) send(ctx context.Context, endpoint string, buf []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.cfg.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(l.cfg.url.String(), "/")+endpoint, bytes.NewReader(buf))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	if l.cfg.username != "" && l.cfg.password != "" {
+		req.SetBasicAuth(l.cfg.username, l.cfg.password)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxErrMsgLen))
+		line := ""
+
+		if scanner.Scan() {
+			line = scanner.Text()
+		}
+
+		err = fmt.Errorf("server returned HTTP status %s (%d): %s", resp.Status, resp.StatusCode, line)
+	}
+
+	return resp.StatusCode, err
+}
+
+// Start starts the OTLP logger.
+func (l *OTLPLogger) Start() error {
+	l.wg.Add(1)
+	go l.run()
+
+	return nil
+}
+
+// Stop stops the client.
+func (l *OTLPLogger) Stop() {
+	l.once.Do(func() { close(l.quit) })
+	l.wg.Wait()
+}
+
+// Validate checks whether the logger configuration is correct.
+func (l *OTLPLogger) Validate() error {
+	if l.cfg.url.String() == "" {
+		return fmt.Errorf("%s: URL cannot be empty", l.name)
+	}
+
+	return nil
+}
+
+// HandleEvent handles the event received from the internal event listener.
+func (l *OTLPLogger) HandleEvent(event api.Event) {
+	var entry *otlpEntry
+
+	// Operations are only sent when explicitly requested.
+	if event.Type == api.EventTypeOperation {
+		if !contains(l.types, "operation") {
+			return
+		}
+
+		entry = l.operationEntry(event)
+	} else if l.processEvent(event) {
+		entry = l.logEntry(event)
+	}
+
+	if entry == nil {
+		return
+	}
+
+	select {
+	case l.entries <- *entry:
+	case <-l.quit:
+	case <-l.ctx.Done():
+	}
+}
+
+// resource returns the attributes of the resource which produced the event.
+func (l *OTLPLogger) resource(event api.Event, project string, instance string) []OTLPKeyValue {
+	// Support overriding the location field (used on standalone systems).
+	location := event.Location
+	if l.cfg.location != "" {
+		location = l.cfg.location
+	}
+
+	attributes := []OTLPKeyValue{
+		otlpAttribute("service.name", "incus"),
+		otlpAttribute("service.instance.id", l.cfg.instance),
+		otlpAttribute("incus.cluster.member", location),
+	}
+
+	if project != "" {
+		attributes = append(attributes, otlpAttribute("incus.project", project))
+	}
+
+	if instance != "" {
+		attributes = append(attributes, otlpAttribute("incus.instance", instance))
+	}
+
+	return attributes
+}
+
+// logEntry converts lifecycle and logging events to log records.
+func (l *OTLPLogger) logEntry(event api.Event) *otlpEntry {
+	timestamp := strconv.FormatInt(event.Timestamp.UnixNano(), 10)
+
+	record := &OTLPLogRecord{
+		TimeUnixNano:         timestamp,
+		ObservedTimeUnixNano: timestamp,
+		Attributes:           []OTLPKeyValue{otlpAttribute("incus.event.type", event.Type)},
+	}
+
+	var project string
+	var instance string
+
+	switch event.Type {
+	case api.EventTypeLifecycle:
+		lifecycleEvent := api.EventLifecycle{}
+
+		err := json.Unmarshal(event.Metadata, &lifecycleEvent)
+		if err != nil {
+			return nil
+		}
+
+		project = lifecycleEvent.Project
+		if strings.HasPrefix(lifecycleEvent.Action, "instance-") {
+			instance = lifecycleEvent.Name
+		}
+
+		record.SeverityNumber, record.SeverityText = otlpSeverity(logrus.InfoLevel.String())
+		record.Body.StringValue = lifecycleEvent.Action
+		record.Attributes = append(record.Attributes,
+			otlpAttribute("incus.lifecycle.action", lifecycleEvent.Action),
+			otlpAttribute("incus.lifecycle.source", lifecycleEvent.Source))
+
+		if lifecycleEvent.Requestor != nil {
+			record.Attributes = append(record.Attributes,
+				otlpAttribute("incus.requestor.address", lifecycleEvent.Requestor.Address),
+				otlpAttribute("incus.requestor.protocol", lifecycleEvent.Requestor.Protocol),
+				otlpAttribute("incus.requestor.username", lifecycleEvent.Requestor.Username))
+		}
+
+		record.Attributes = append(record.Attributes, otlpContextAttributes(buildNestedContext("", lifecycleEvent.Context))...)
+	case api.EventTypeLogging, api.EventTypeNetworkACL:
+		logEvent := api.EventLogging{}
+
+		err := json.Unmarshal(event.Metadata, &logEvent)
+		if err != nil {
+			return nil
+		}
+
+		project = logEvent.Context["project"]
+		instance = logEvent.Context["instance"]
+
+		record.SeverityNumber, record.SeverityText = otlpSeverity(logEvent.Level)
+		record.Body.StringValue = logEvent.Message
+		record.Attributes = append(record.Attributes, otlpContextAttributes(logEvent.Context)...)
+	default:
+		return nil
+	}
+
+	if project == "" {
+		project = event.Project
+	}
+
+	return &otlpEntry{
+		resource: l.resource(event, project, instance),
+		record:   record,
+	}
+}
+
+// operationEntry converts the event of a completed operation to a span.
+func (l *OTLPLogger) operationEntry(event api.Event) *otlpEntry {
+	op := api.Operation{}
+
+	err := json.Unmarshal(event.Metadata, &op)
+	if err != nil {
+		return nil
+	}
+
+	if !op.StatusCode.IsFinal() {
+		return nil
+	}
+
+	// Derive the trace and span IDs from the operation UUID.
+	id, err := uuid.Parse(op.ID)
+	if err != nil {
+		return nil
+	}
+
+	span := &OTLPSpan{
+		TraceID:           hex.EncodeToString(id[:]),
+		SpanID:            hex.EncodeToString(id[8:]),
+		Name:              op.Description,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(op.CreatedAt.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(op.UpdatedAt.UnixNano(), 10),
+		Attributes: []OTLPKeyValue{
+			otlpAttribute("incus.operation.id", op.ID),
+			otlpAttribute("incus.operation.class", op.Class),
+			otlpAttribute("incus.operation.status", op.Status),
+		},
+		Status: OTLPStatus{Code: otlpStatusCodeOk},
+	}
+
+	if op.StatusCode != api.Success {
+		span.Status.Code = otlpStatusCodeError
+		span.Status.Message = op.Err
+		if span.Status.Message == "" {
+			span.Status.Message = op.Status
+		}
+	}
+
+	resourceTypes := make([]string, 0, len(op.Resources))
+	for resourceType := range op.Resources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	slices.Sort(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		span.Attributes = append(span.Attributes, otlpAttribute("incus.operation.resources."+resourceType, strings.Join(op.Resources[resourceType], ",")))
+	}
+
+	// Only attribute the operation to an instance when it's the only one affected.
+	var instance string
+	if len(op.Resources["instances"]) == 1 {
+		u, err := url.Parse(op.Resources["instances"][0])
+		if err == nil {
+			instance = path.Base(u.Path)
+		}
+	}
+
+	return &otlpEntry{
+		resource: l.resource(event, event.Project, instance),
+		span:     span,
+	}
+}
+
+// otlpAttribute returns a string attribute.
+func otlpAttribute(key string, value string) OTLPKeyValue {
+	return OTLPKeyValue{Key: key, Value: OTLPAnyValue{StringValue: value}}
+}
+
+// otlpContextAttributes converts the event context to attributes sorted by key.
+func otlpContextAttributes(ctx map[string]string) []OTLPKeyValue {
+	keys := make([]string, 0, len(ctx))
+	for k := range ctx {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	attributes := make([]OTLPKeyValue, 0, len(keys))
+	for _, k := range keys {
+		attributes = append(attributes, otlpAttribute("incus.context."+k, ctx[k]))
+	}
+
+	return attributes
+}
+
+// otlpSeverity returns the OTLP severity number and text matching the log level.
+func otlpSeverity(level string) (int, string) {
+	l, err := logrus.ParseLevel(level)
+	if err != nil {
+		return 0, ""
+	}
+
+	switch l {
+	case logrus.TraceLevel:
+		return 1, "TRACE"
+	case logrus.DebugLevel:
+		return 5, "DEBUG"
+	case logrus.InfoLevel:
+		return 9, "INFO"
+	case logrus.WarnLevel:
+		return 13, "WARN"
+	case logrus.ErrorLevel:
+		return 17, "ERROR"
+	default:
+		return 21, "FATAL"
+	}
+}
@@ -0,0 +1,168 @@
+package logging
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+)
+
+// otlpScopeName is the instrumentation scope of all log records and spans.
+const otlpScopeName = "incus"
+
+// otlpEntry is a log record or a span along with the attributes of the resource which produced it.
+type otlpEntry struct {
+	resource []OTLPKeyValue
+	record   *OTLPLogRecord
+	span     *OTLPSpan
+}
+
+// size returns an estimate of the encoded size of the entry.
+func (e otlpEntry) size() int {
+	size := 0
+
+	var attributes []OTLPKeyValue
+	if e.record != nil {
+		size += len(e.record.Body.StringValue)
+		attributes = e.record.Attributes
+	} else if e.span != nil {
+		size += len(e.span.Name) + len(e.span.Status.Message)
+		attributes = e.span.Attributes
+	}
+
+	for _, attribute := range attributes {
+		size += len(attribute.Key) + len(attribute.Value.StringValue)
+	}
+
+	return size
+}
+
+// otlpBatchResource holds the pending log records and spans of a single resource.
+type otlpBatchResource struct {
+	attributes []OTLPKeyValue
+	records    []OTLPLogRecord
+	spans      []OTLPSpan
+}
+
+// otlpBatch holds pending log records and spans waiting to be sent to the OTLP collector, grouped by resource.
+type otlpBatch struct {
+	resources map[string]*otlpBatchResource
+	bytes     int
+	createdAt time.Time
+}
+
+func newOTLPBatch(entries ...otlpEntry) *otlpBatch {
+	b := &otlpBatch{
+		resources: map[string]*otlpBatchResource{},
+		bytes:     0,
+		createdAt: time.Now(),
+	}
+
+	// Add entries to the batch
+	for _, entry := range entries {
+		b.add(entry)
+	}
+
+	return b
+}
+
+// add an entry to the batch.
+func (b *otlpBatch) add(entry otlpEntry) {
+	b.bytes += entry.size()
+
+	key := otlpResourceKey(entry.resource)
+
+	resource, ok := b.resources[key]
+	if !ok {
+		resource = &otlpBatchResource{attributes: entry.resource}
+		b.resources[key] = resource
+	}
+
+	if entry.record != nil {
+		resource.records = append(resource.records, *entry.record)
+	}
+
+	if entry.span != nil {
+		resource.spans = append(resource.spans, *entry.span)
+	}
+}
+
+// sizeBytesAfter returns the size of the batch after the input entry
+// will be added to the batch itself.
+func (b *otlpBatch) sizeBytesAfter(entry otlpEntry) int {
+	return b.bytes + entry.size()
+}
+
+// age of the batch since its creation.
+func (b *otlpBatch) age() time.Duration {
+	return time.Since(b.createdAt)
+}
+
+// empty returns true if the batch holds no entries.
+func (b *otlpBatch) empty() bool {
+	return len(b.resources) == 0
+}
+
+// encode the batch as logs and traces export requests. A nil slice is returned for requests which would be empty.
+func (b *otlpBatch) encode() ([]byte, []byte, error) {
+	logsReq := OTLPLogsRequest{ResourceLogs: []*OTLPResourceLogs{}}
+	tracesReq := OTLPTracesRequest{ResourceSpans: []*OTLPResourceSpans{}}
+
+	// Keep the resources in a stable order.
+	keys := make([]string, 0, len(b.resources))
+	for key := range b.resources {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		resource := b.resources[key]
+
+		if len(resource.records) > 0 {
+			logsReq.ResourceLogs = append(logsReq.ResourceLogs, &OTLPResourceLogs{
+				Resource:  OTLPResource{Attributes: resource.attributes},
+				ScopeLogs: []OTLPScopeLogs{{Scope: OTLPScope{Name: otlpScopeName}, LogRecords: resource.records}},
+			})
+		}
+
+		if len(resource.spans) > 0 {
+			tracesReq.ResourceSpans = append(tracesReq.ResourceSpans, &OTLPResourceSpans{
+				Resource:   OTLPResource{Attributes: resource.attributes},
+				ScopeSpans: []OTLPScopeSpans{{Scope: OTLPScope{Name: otlpScopeName}, Spans: resource.spans}},
+			})
+		}
+	}
+
+	var logs []byte
+	var traces []byte
+	var err error
+
+	if len(logsReq.ResourceLogs) > 0 {
+		logs, err = json.Marshal(logsReq)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(tracesReq.ResourceSpans) > 0 {
+		traces, err = json.Marshal(tracesReq)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return logs, traces, nil
+}
+
+// otlpResourceKey returns a string uniquely identifying the resource attributes.
+func otlpResourceKey(attributes []OTLPKeyValue) string {
+	pairs := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		pairs = append(pairs, attribute.Key+"="+attribute.Value.StringValue)
+	}
+
+	slices.Sort(pairs)
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,243 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// otlpCollector is a minimal OTLP/HTTP collector recording the export requests it receives.
+type otlpCollector struct {
+	*httptest.Server
+
+	logs   chan OTLPLogsRequest
+	traces chan OTLPTracesRequest
+}
+
+func newOTLPCollector(t *testing.T) *otlpCollector {
+	t.Helper()
+
+	c := &otlpCollector{
+		logs:   make(chan OTLPLogsRequest, 10),
+		traces: make(chan OTLPTracesRequest, 10),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/logs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		req := OTLPLogsRequest{}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		assert.NoError(t, err)
+
+		c.logs <- req
+	})
+
+	mux.HandleFunc("POST /v1/traces", func(w http.ResponseWriter, r *http.Request) {
+		req := OTLPTracesRequest{}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		assert.NoError(t, err)
+
+		c.traces <- req
+	})
+
+	c.Server = httptest.NewServer(mux)
+	t.Cleanup(c.Close)
+
+	return c
+}
+
+// newTestOTLPLogger starts an OTLP logger sending the given event types to the collector.
+func newTestOTLPLogger(t *testing.T, collector *otlpCollector, types ...string) *OTLPLogger {
+	t.Helper()
+
+	u, err := url.Parse(collector.URL)
+	require.NoError(t, err)
+
+	l := &OTLPLogger{
+		common: common{
+			loggingLevel: "info",
+			name:         "otlp01",
+			types:        types,
+		},
+		cfg: config{
+			batchSize: 10 * 1024,
+			batchWait: 50 * time.Millisecond,
+			instance:  "cluster01",
+			retry:     1,
+			timeout:   time.Second,
+			url:       u,
+		},
+		client:  http.DefaultClient,
+		ctx:     context.Background(),
+		entries: make(chan otlpEntry),
+		quit:    make(chan struct{}),
+	}
+
+	require.NoError(t, l.Validate())
+	require.NoError(t, l.Start())
+	t.Cleanup(l.Stop)
+
+	return l
+}
+
+func newTestEvent(t *testing.T, eventType string, metadata any) api.Event {
+	t.Helper()
+
+	data, err := json.Marshal(metadata)
+	require.NoError(t, err)
+
+	return api.Event{
+		Type:      eventType,
+		Timestamp: time.Unix(1700000000, 0),
+		Metadata:  data,
+		Location:  "server01",
+		Project:   "default",
+	}
+}
+
+// attributes converts a list of attributes to a map.
+func attributes(kvs []OTLPKeyValue) map[string]string {
+	result := map[string]string{}
+	for _, kv := range kvs {
+		result[kv.Key] = kv.Value.StringValue
+	}
+
+	return result
+}
+
+func TestOTLPLogger_Logs(t *testing.T) {
+	collector := newOTLPCollector(t)
+	l := newTestOTLPLogger(t, collector, "lifecycle", "logging")
+
+	l.HandleEvent(newTestEvent(t, api.EventTypeLifecycle, api.EventLifecycle{
+		Action:  "instance-started",
+		Source:  "/1.0/instances/c1",
+		Project: "foo",
+		Name:    "c1",
+		Context: map[string]any{"command": "start"},
+	}))
+
+	l.HandleEvent(newTestEvent(t, api.EventTypeLogging, api.EventLogging{
+		Message: "Failed to start",
+		Level:   "error",
+		Context: map[string]string{"instance": "c2", "project": "bar"},
+	}))
+
+	// Below the configured level.
+	l.HandleEvent(newTestEvent(t, api.EventTypeLogging, api.EventLogging{
+		Message: "Debugging",
+		Level:   "debug",
+	}))
+
+	var req OTLPLogsRequest
+	select {
+	case req = <-collector.logs:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for logs")
+	}
+
+	// Both records are sent in a single batch, one per resource.
+	require.Len(t, req.ResourceLogs, 2)
+
+	records := map[string]OTLPLogRecord{}
+	for _, resourceLogs := range req.ResourceLogs {
+		resource := attributes(resourceLogs.Resource.Attributes)
+		assert.Equal(t, "incus", resource["service.name"])
+		assert.Equal(t, "cluster01", resource["service.instance.id"])
+		assert.Equal(t, "server01", resource["incus.cluster.member"])
+
+		require.Len(t, resourceLogs.ScopeLogs, 1)
+		require.Len(t, resourceLogs.ScopeLogs[0].LogRecords, 1)
+
+		records[resource["incus.project"]+"/"+resource["incus.instance"]] = resourceLogs.ScopeLogs[0].LogRecords[0]
+	}
+
+	lifecycle, ok := records["foo/c1"]
+	require.True(t, ok)
+	assert.Equal(t, "instance-started", lifecycle.Body.StringValue)
+	assert.Equal(t, "INFO", lifecycle.SeverityText)
+	assert.Equal(t, "1700000000000000000", lifecycle.TimeUnixNano)
+	assert.Equal(t, "start", attributes(lifecycle.Attributes)["incus.context.command"])
+
+	logging, ok := records["bar/c2"]
+	require.True(t, ok)
+	assert.Equal(t, "Failed to start", logging.Body.StringValue)
+	assert.Equal(t, 17, logging.SeverityNumber)
+	assert.Equal(t, "ERROR", logging.SeverityText)
+
+	select {
+	case <-collector.traces:
+		assert.Fail(t, "Unexpected traces")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestOTLPLogger_Traces(t *testing.T) {
+	collector := newOTLPCollector(t)
+	l := newTestOTLPLogger(t, collector, "operation")
+
+	op := api.Operation{
+		ID:          "6916c8a6-9b7d-4abd-90b3-aedfec7ec7da",
+		Class:       "task",
+		Description: "Starting instance",
+		CreatedAt:   time.Unix(1700000000, 0),
+		UpdatedAt:   time.Unix(1700000002, 0),
+		Status:      api.Running.String(),
+		StatusCode:  api.Running,
+		Resources:   map[string][]string{"instances": {"/1.0/instances/c1?project=foo"}},
+	}
+
+	// Running operations aren't sent.
+	l.HandleEvent(newTestEvent(t, api.EventTypeOperation, op))
+
+	op.Status = api.Failure.String()
+	op.StatusCode = api.Failure
+	op.Err = "Failed to start"
+	event := newTestEvent(t, api.EventTypeOperation, op)
+	event.Project = "foo"
+	l.HandleEvent(event)
+
+	// Lifecycle events aren't enabled.
+	l.HandleEvent(newTestEvent(t, api.EventTypeLifecycle, api.EventLifecycle{Action: "instance-started"}))
+
+	var req OTLPTracesRequest
+	select {
+	case req = <-collector.traces:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for traces")
+	}
+
+	require.Len(t, req.ResourceSpans, 1)
+
+	resource := attributes(req.ResourceSpans[0].Resource.Attributes)
+	assert.Equal(t, "foo", resource["incus.project"])
+	assert.Equal(t, "c1", resource["incus.instance"])
+
+	require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+	require.Len(t, req.ResourceSpans[0].ScopeSpans[0].Spans, 1)
+
+	span := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "6916c8a69b7d4abd90b3aedfec7ec7da", span.TraceID)
+	assert.Equal(t, "90b3aedfec7ec7da", span.SpanID)
+	assert.Equal(t, "Starting instance", span.Name)
+	assert.Equal(t, "1700000000000000000", span.StartTimeUnixNano)
+	assert.Equal(t, "1700000002000000000", span.EndTimeUnixNano)
+	assert.Equal(t, OTLPStatus{Code: otlpStatusCodeError, Message: "Failed to start"}, span.Status)
+	assert.Equal(t, "/1.0/instances/c1?project=foo", attributes(span.Attributes)["incus.operation.resources.instances"])
+
+	select {
+	case <-collector.logs:
+		assert.Fail(t, "Unexpected logs")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,86 @@
+package logging
+
+// This follows the JSON encoding of the OTLP/HTTP protocol, see https://opentelemetry.io/docs/specs/otlp/.
+
+// OTLPLogsRequest models an OTLP logs export request.
+type OTLPLogsRequest struct {
+	ResourceLogs []*OTLPResourceLogs `json:"resourceLogs"`
+}
+
+// OTLPTracesRequest models an OTLP traces export request.
+type OTLPTracesRequest struct {
+	ResourceSpans []*OTLPResourceSpans `json:"resourceSpans"`
+}
+
+// OTLPResourceLogs represents the log records of a single resource.
+type OTLPResourceLogs struct {
+	Resource  OTLPResource    `json:"resource"`
+	ScopeLogs []OTLPScopeLogs `json:"scopeLogs"`
+}
+
+// OTLPResourceSpans represents the spans of a single resource.
+type OTLPResourceSpans struct {
+	Resource   OTLPResource     `json:"resource"`
+	ScopeSpans []OTLPScopeSpans `json:"scopeSpans"`
+}
+
+// OTLPResource represents the entity producing the telemetry.
+type OTLPResource struct {
+	Attributes []OTLPKeyValue `json:"attributes"`
+}
+
+// OTLPScope represents the instrumentation scope.
+type OTLPScope struct {
+	Name string `json:"name"`
+}
+
+// OTLPScopeLogs represents the log records of an instrumentation scope.
+type OTLPScopeLogs struct {
+	Scope      OTLPScope       `json:"scope"`
+	LogRecords []OTLPLogRecord `json:"logRecords"`
+}
+
+// OTLPScopeSpans represents the spans of an instrumentation scope.
+type OTLPScopeSpans struct {
+	Scope OTLPScope  `json:"scope"`
+	Spans []OTLPSpan `json:"spans"`
+}
+
+// OTLPLogRecord represents a log record.
+type OTLPLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 OTLPAnyValue   `json:"body"`
+	Attributes           []OTLPKeyValue `json:"attributes,omitempty"`
+}
+
+// OTLPSpan represents a span. Trace and span IDs are hex encoded.
+type OTLPSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []OTLPKeyValue `json:"attributes,omitempty"`
+	Status            OTLPStatus     `json:"status"`
+}
+
+// OTLPStatus represents the status of a span.
+type OTLPStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// OTLPKeyValue is a key/value pair used for attributes.
+type OTLPKeyValue struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+// OTLPAnyValue represents an attribute or body value. Only strings are used.
+type OTLPAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
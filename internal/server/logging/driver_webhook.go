@@ -76,6 +76,11 @@ func NewWebhookLogger(s *state.State, name string) (*WebhookLogger, error) {
 
 // HandleEvent handles the event received from the internal event listener.
 func (c *WebhookLogger) HandleEvent(event api.Event) {
+	// Operations are only sent when explicitly requested.
+	if event.Type == api.EventTypeOperation && !contains(c.types, "operation") {
+		return
+	}
+
 	// JSON data.
 	data, err := json.Marshal(event)
 	if err != nil {
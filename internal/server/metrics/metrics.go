@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -65,6 +66,11 @@ func (m *MetricSet) AddSamples(metricType MetricType, samples ...Sample) {
 	m.set[metricType] = append(m.set[metricType], samples...)
 }
 
+// Samples returns the samples of the type metricType.
+func (m *MetricSet) Samples(metricType MetricType) []Sample {
+	return slices.Clone(m.set[metricType])
+}
+
 // AddRaw allows for adding extra metrics directly to the output without having to parse them first.
 func (m *MetricSet) AddRaw(rawData []byte) {
 	m.suffix = append(m.suffix, rawData...)
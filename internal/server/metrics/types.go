@@ -91,6 +91,10 @@ const (
 	NetworkTransmitErrsTotal
 	// NetworkTransmitPacketsTotal represents the amount of transmitted packets on a given interface.
 	NetworkTransmitPacketsTotal
+	// NetworkACLRuleBytesTotal represents the amount of bytes matched by a network ACL rule.
+	NetworkACLRuleBytesTotal
+	// NetworkACLRulePacketsTotal represents the amount of packets matched by a network ACL rule.
+	NetworkACLRulePacketsTotal
 	// ProcsTotal represents the number of running processes.
 	ProcsTotal
 	// OperationsTotal represents the number of running operations.
@@ -201,6 +205,8 @@ var MetricNames = map[MetricType]string{
 	MemoryUnevictableBytes:      "incus_memory_Unevictable_bytes",
 	MemoryWritebackBytes:        "incus_memory_Writeback_bytes",
 	MemoryOOMKillsTotal:         "incus_memory_OOM_kills_total",
+	NetworkACLRuleBytesTotal:    "incus_network_acl_rule_bytes_total",
+	NetworkACLRulePacketsTotal:  "incus_network_acl_rule_packets_total",
 	NetworkReceiveBytesTotal:    "incus_network_receive_bytes_total",
 	NetworkReceiveDropTotal:     "incus_network_receive_drop_total",
 	NetworkReceiveErrsTotal:     "incus_network_receive_errs_total",
@@ -269,6 +275,8 @@ var MetricHeaders = map[MetricType]string{
 	MemoryUnevictableBytes:      "# HELP incus_memory_Unevictable_bytes The amount of unevictable memory.",
 	MemoryWritebackBytes:        "# HELP incus_memory_Writeback_bytes The amount of memory queued for syncing to disk.",
 	MemoryOOMKillsTotal:         "# HELP incus_memory_OOM_kills_total The number of out of memory kills.",
+	NetworkACLRuleBytesTotal:    "# HELP incus_network_acl_rule_bytes_total The amount of bytes matched by a network ACL rule.",
+	NetworkACLRulePacketsTotal:  "# HELP incus_network_acl_rule_packets_total The amount of packets matched by a network ACL rule.",
 	NetworkReceiveBytesTotal:    "# HELP incus_network_receive_bytes_total The amount of received bytes on a given interface.",
 	NetworkReceiveDropTotal:     "# HELP incus_network_receive_drop_total The amount of received dropped bytes on a given interface.",
 	NetworkReceiveErrsTotal:     "# HELP incus_network_receive_errs_total The amount of received errors on a given interface.",
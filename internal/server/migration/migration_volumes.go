@@ -81,6 +81,7 @@ type VolumeTargetArgs struct {
 	VolumeOnly            bool
 	ClusterMoveSourceName string
 	StoragePool           string
+	InfoReceived          func(info *Info) // Called with the index header received from the source.
 }
 
 // TypesToHeader converts one or more Types to a MigrationHeader. It uses the first type argument
@@ -0,0 +1,201 @@
+package acl
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// RuleCounterKey identifies an ACL rule in the hit counters.
+type RuleCounterKey struct {
+	ACLID     int64
+	Direction string
+	Rule      int
+}
+
+// ruleCounterName returns the name identifying the hit counters of an ACL rule.
+func ruleCounterName(aclID int64, direction string, ruleIndex int) string {
+	return fmt.Sprintf("%s-%s-%d", OVNACLPortGroupNamePrefix(aclID), direction, ruleIndex)
+}
+
+// parseRuleCounterName returns the ACL rule identified by a counter name.
+func parseRuleCounterName(name string) (*RuleCounterKey, error) {
+	after, ok := strings.CutPrefix(name, ovnACLPortGroupPrefix)
+	fields := strings.Split(after, "-")
+	if !ok || len(fields) != 3 {
+		return nil, fmt.Errorf("Invalid ACL rule counter name %q", name)
+	}
+
+	aclID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid ACL ID in rule counter name %q: %w", name, err)
+	}
+
+	if fields[1] != string(ruleDirectionIngress) && fields[1] != string(ruleDirectionEgress) {
+		return nil, fmt.Errorf("Invalid direction in rule counter name %q", name)
+	}
+
+	ruleIndex, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid rule index in rule counter name %q: %w", name, err)
+	}
+
+	return &RuleCounterKey{ACLID: aclID, Direction: fields[1], Rule: ruleIndex}, nil
+}
+
+// localRuleCountersLifetime is how long the local ACL rule hit counters are reused, avoiding dumping the
+// firewall rules and OpenFlow flows on every request or metrics scrape.
+const localRuleCountersLifetime = 10 * time.Second
+
+var (
+	localRuleCountersCache  map[RuleCounterKey]api.NetworkACLRuleCounters
+	localRuleCountersExpiry time.Time
+	localRuleCountersMu     sync.Mutex
+)
+
+// LocalRuleCounters returns the hit counters of the ACL rules applied on the local server.
+// This combines the firewall rules of bridge networks and the OVN flows handled by the local chassis.
+func LocalRuleCounters(s *state.State) (map[RuleCounterKey]api.NetworkACLRuleCounters, error) {
+	localRuleCountersMu.Lock()
+	defer localRuleCountersMu.Unlock()
+
+	if localRuleCountersCache == nil || time.Now().After(localRuleCountersExpiry) {
+		counters, err := localRuleCounters(s)
+		if err != nil {
+			return nil, err
+		}
+
+		localRuleCountersCache = counters
+		localRuleCountersExpiry = time.Now().Add(localRuleCountersLifetime)
+	}
+
+	return maps.Clone(localRuleCountersCache), nil
+}
+
+// localRuleCounters gathers the hit counters of the ACL rules applied on the local server.
+func localRuleCounters(s *state.State) (map[RuleCounterKey]api.NetworkACLRuleCounters, error) {
+	counters := map[RuleCounterKey]api.NetworkACLRuleCounters{}
+
+	addCounters := func(name string, packets int64, bytes int64) {
+		key, err := parseRuleCounterName(name)
+		if err != nil {
+			return
+		}
+
+		counter := counters[*key]
+		counter.Packets += packets
+		counter.Bytes += bytes
+		counters[*key] = counter
+	}
+
+	// Get the firewall counters.
+	firewallCounters, err := s.Firewall.NetworkACLRuleCounters()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting firewall ACL rule counters: %w", err)
+	}
+
+	for name, counter := range firewallCounters {
+		addCounters(name, counter.Packets, counter.Bytes)
+	}
+
+	// Only query OVN if it's in use.
+	var hasOVN bool
+	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		projectNetworks, err := tx.GetCreatedNetworks(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, networks := range projectNetworks {
+			for _, network := range networks {
+				if network.Type == "ovn" {
+					hasOVN = true
+					return nil
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading networks: %w", err)
+	}
+
+	if !hasOVN {
+		return counters, nil
+	}
+
+	ovnCounters, err := ovnRuleCounters(s)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN ACL rule counters: %w", err)
+	}
+
+	for name, counter := range ovnCounters {
+		addCounters(name, counter.Packets, counter.Bytes)
+	}
+
+	return counters, nil
+}
+
+// ovnRuleCounters returns the hit counters of the OVN ACL rules on the local chassis, indexed by counter name.
+// The OVN ACL rules are mapped to their logical flows and then to the OpenFlow flows of the integration bridge.
+func ovnRuleCounters(s *state.State) (map[string]api.NetworkACLRuleCounters, error) {
+	ovnnb, ovnsb, err := s.OVN()
+	if err != nil {
+		return nil, err
+	}
+
+	vswitch, err := s.OVS()
+	if err != nil {
+		return nil, err
+	}
+
+	ruleUUIDs, err := ovnnb.GetACLRuleCounterUUIDs(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	aclUUIDs := []string{}
+	for _, uuids := range ruleUUIDs {
+		aclUUIDs = append(aclUUIDs, uuids...)
+	}
+
+	cookies, err := ovnsb.GetACLFlowCookies(context.TODO(), aclUUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bridgeName, err := vswitch.GetOVNIntegrationBridge(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	flowStatistics, err := vswitch.GetFlowStatistics(context.TODO(), bridgeName)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := map[string]api.NetworkACLRuleCounters{}
+	for counterName, uuids := range ruleUUIDs {
+		counter := api.NetworkACLRuleCounters{}
+
+		for _, aclUUID := range uuids {
+			for _, cookie := range cookies[aclUUID] {
+				counter.Packets += flowStatistics[cookie].Packets
+				counter.Bytes += flowStatistics[cookie].Bytes
+			}
+		}
+
+		counters[counterName] = counter
+	}
+
+	return counters, nil
+}
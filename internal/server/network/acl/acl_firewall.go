@@ -31,7 +31,7 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 	var allowStatelessRules []firewallDrivers.ACLRule
 
 	// convertACLRules converts the ACL rules to Firewall ACL rules.
-	convertACLRules := func(direction string, logPrefix string, rules ...api.NetworkACLRule) error {
+	convertACLRules := func(aclID int64, direction string, logPrefix string, rules ...api.NetworkACLRule) error {
 		for ruleIndex, rule := range rules {
 			if rule.State == "disabled" {
 				continue
@@ -47,6 +47,7 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 				DestinationPort: rule.DestinationPort,
 				ICMPType:        rule.ICMPType,
 				ICMPCode:        rule.ICMPCode,
+				CounterName:     ruleCounterName(aclID, direction, ruleIndex),
 			}
 
 			if rule.State == "logged" {
@@ -76,12 +77,13 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 
 	// Load ACLs specified by network.
 	for _, aclName := range util.SplitNTrimSpace(config["security.acls"], ",", -1, true) {
+		var aclID int
 		var aclInfo *api.NetworkACL
 
 		err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 			var err error
 
-			_, aclInfo, err = dbCluster.GetNetworkACLAPI(ctx, tx.Tx(), aclProjectName, aclName)
+			aclID, aclInfo, err = dbCluster.GetNetworkACLAPI(ctx, tx.Tx(), aclProjectName, aclName)
 
 			return err
 		})
@@ -89,12 +91,12 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 			return nil, fmt.Errorf("Failed loading ACL %q for network %q: %w", aclName, aclDeviceName, err)
 		}
 
-		err = convertACLRules("ingress", logPrefix, aclInfo.Ingress...)
+		err = convertACLRules(int64(aclID), "ingress", logPrefix, aclInfo.Ingress...)
 		if err != nil {
 			return nil, fmt.Errorf("Failed converting ACL %q ingress rules for network %q: %w", aclInfo.Name, aclDeviceName, err)
 		}
 
-		err = convertACLRules("egress", logPrefix, aclInfo.Egress...)
+		err = convertACLRules(int64(aclID), "egress", logPrefix, aclInfo.Egress...)
 		if err != nil {
 			return nil, fmt.Errorf("Failed converting ACL %q egress rules for network %q: %w", aclInfo.Name, aclDeviceName, err)
 		}
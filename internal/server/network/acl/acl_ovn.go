@@ -2,12 +2,16 @@ package acl
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
+	"net/http"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -577,6 +581,8 @@ func ovnApplyToPortGroup(s *state.State, l logger.Logger, client *ovn.NB, aclInf
 				ovnACLRule.LogName = fmt.Sprintf("%s-%s-%d", portGroupName, direction, ruleIndex)
 			}
 
+			ovnACLRule.CounterName = ruleCounterName(aclNameIDs[aclName], direction, ruleIndex)
+
 			if networkSpecific {
 				networkRules = append(networkRules, ovnACLRule)
 			} else if isAllRule {
@@ -1312,36 +1318,57 @@ func OVNApplyInstanceNICDefaultRules(client *ovn.NB, switchPortGroup ovn.OVNPort
 	return nil
 }
 
-// ovnLogEntry is the type used for the JSON encoded entries on the log endpoint (when coming from OVN).
+// ovnLogACLRuleName matches the log name of the OVN ACL rules (<port group>-<direction>-<rule index>).
+var ovnLogACLRuleName = regexp.MustCompile(`^` + ovnACLPortGroupPrefix + `([0-9]+)_[a-z0-9_]+-(ingress|egress)-([0-9]+)$`)
+
+// ovnLogNICDefaultRuleName matches the log name of the instance NIC default rules (<instance UUID>-<device>-<direction>).
+var ovnLogNICDefaultRuleName = regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})-(.+)-(ingress|egress)$`)
+
+// ovnLogEntry is a parsed OVN ACL log entry.
 type ovnLogEntry struct {
-	Time     string `json:"time"`
-	Proto    string `json:"proto"`
-	Src      string `json:"src"`
-	Dst      string `json:"dst"`
-	SrcPort  string `json:"src_port,omitempty"`
-	DstPort  string `json:"dst_port,omitempty"`
-	ICMPType string `json:"icmp_type,omitempty"`
-	ICMPCode string `json:"icmp_code,omitempty"`
-	Action   string `json:"action"`
+	api.NetworkACLLogEntry
+
+	name   string // Log name of the matching OVN ACL rule.
+	srcMAC string
+	dstMAC string
 }
 
-// ovnParseLogEntry takes a log line and expected ACL prefix and returns a re-formated log entry if matching.
-func ovnParseLogEntry(input string, prefix string) string {
+// ovnParseLogEntry takes a log line and returns the parsed ACL log entry, or nil if not an ACL log entry.
+func ovnParseLogEntry(input string) *ovnLogEntry {
 	fields := strings.Split(input, "|")
 
 	// Skip unknown formatting.
 	if len(fields) != 5 {
-		return ""
+		return nil
 	}
 
 	// We only care about ACLs.
 	if !strings.HasPrefix(fields[2], "acl_log") {
-		return ""
+		return nil
 	}
 
+	// Parse the timestamp.
+	logTime, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return nil
+	}
+
+	entry := ovnParseLogMessage(fields[4])
+	if entry == nil {
+		return nil
+	}
+
+	entry.Time = logTime.UTC().Format(time.RFC3339)
+
+	return entry
+}
+
+// ovnParseLogMessage takes the message part of an ACL log line and returns the parsed ACL log entry (without
+// time), or nil if the message can't be parsed.
+func ovnParseLogMessage(message string) *ovnLogEntry {
 	// Parse the ACL log entry.
 	aclEntry := map[string]string{}
-	for _, entry := range util.SplitNTrimSpace(fields[4], ",", -1, true) {
+	for _, entry := range util.SplitNTrimSpace(message, ",", -1, true) {
 		pair := strings.Split(entry, "=")
 		if len(pair) != 2 {
 			continue
@@ -1350,21 +1377,14 @@ func ovnParseLogEntry(input string, prefix string) string {
 		aclEntry[strings.Trim(pair[0], "\"")] = strings.Trim(pair[1], "\"")
 	}
 
-	// Filter for our ACL.
-	if !strings.HasPrefix(aclEntry["name"], prefix) {
-		return ""
-	}
-
-	// Parse the timestamp.
-	logTime, err := time.Parse(time.RFC3339, fields[0])
-	if err != nil {
-		return ""
+	if aclEntry["name"] == "" {
+		return nil
 	}
 
 	// Get the protocol.
 	directionFields := strings.Split(aclEntry["direction"], " ")
 	if len(directionFields) != 2 {
-		return ""
+		return nil
 	}
 
 	protocol := directionFields[1]
@@ -1374,7 +1394,7 @@ func ovnParseLogEntry(input string, prefix string) string {
 	if !ok {
 		srcAddr, ok = aclEntry["ipv6_src"]
 		if !ok {
-			return ""
+			return nil
 		}
 	}
 
@@ -1382,38 +1402,271 @@ func ovnParseLogEntry(input string, prefix string) string {
 	if !ok {
 		dstAddr, ok = aclEntry["ipv6_dst"]
 		if !ok {
-			return ""
+			return nil
 		}
 	}
 
 	// Prepare the core log entry.
 	newEntry := ovnLogEntry{
-		Time:     logTime.UTC().Format(time.RFC3339),
-		Proto:    protocol,
-		Src:      srcAddr,
-		Dst:      dstAddr,
-		ICMPType: aclEntry["icmp_type"],
-		ICMPCode: aclEntry["icmp_code"],
-		Action:   aclEntry["verdict"],
+		NetworkACLLogEntry: api.NetworkACLLogEntry{
+			Proto:    protocol,
+			Src:      srcAddr,
+			Dst:      dstAddr,
+			SrcPort:  aclEntry["tp_src"],
+			DstPort:  aclEntry["tp_dst"],
+			ICMPType: aclEntry["icmp_type"],
+			ICMPCode: aclEntry["icmp_code"],
+			Action:   aclEntry["verdict"],
+		},
+		name:   aclEntry["name"],
+		srcMAC: aclEntry["dl_src"],
+		dstMAC: aclEntry["dl_dst"],
+	}
+
+	return &newEntry
+}
+
+// ovnLogEventResolverLifetime is how long the resolver of the network ACL events is kept before being replaced,
+// bounding the staleness of the cached ACL and instance details.
+const ovnLogEventResolverLifetime = time.Minute
+
+// ovnLogEventResolver is the resolver shared by all the network ACL events.
+var (
+	ovnLogEventResolver       *ovnLogResolver
+	ovnLogEventResolverExpiry time.Time
+	ovnLogEventResolverMu     sync.Mutex
+)
+
+// ovnLogResolver fills in the ACL and instance details of parsed OVN ACL log entries.
+// The database lookups, including failed ones, are cached for the lifetime of the resolver.
+type ovnLogResolver struct {
+	state *state.State
+
+	acls      map[int64][]string // ACL ID to project and name.
+	nics      map[string][]string
+	instances map[string][]string
+}
+
+// newOVNLogResolver returns a new OVN ACL log entry resolver.
+func newOVNLogResolver(s *state.State) *ovnLogResolver {
+	return &ovnLogResolver{
+		state:     s,
+		acls:      map[int64][]string{},
+		nics:      map[string][]string{},
+		instances: map[string][]string{},
 	}
+}
+
+// resolve fills in the ACL, rule, direction and instance NIC of the entry from the name of the matching OVN ACL
+// rule. Returns the ID of the ACL, or 0 if the entry comes from an instance NIC default rule.
+func (r *ovnLogResolver) resolve(entry *ovnLogEntry) (int64, error) {
+	match := ovnLogACLRuleName.FindStringSubmatch(entry.name)
+	if match != nil {
+		aclID, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return -1, err
+		}
+
+		ruleIndex, err := strconv.Atoi(match[3])
+		if err != nil {
+			return -1, err
+		}
+
+		entry.Direction = match[2]
+		entry.Rule = &ruleIndex
+
+		acl, err := r.acl(aclID)
+		if err != nil {
+			return -1, err
+		}
+
+		entry.Project = acl[0]
+		entry.ACL = acl[1]
+
+		// The instance NIC is the destination of ingress traffic and the source of egress traffic.
+		mac := entry.srcMAC
+		if entry.Direction == string(ruleDirectionIngress) {
+			mac = entry.dstMAC
+		}
 
-	// Add the source and destination ports.
-	srcPort, ok := aclEntry["tp_src"]
+		nic, err := r.nic(mac)
+		if err == nil {
+			entry.Project = nic[0]
+			entry.Instance = nic[1]
+			entry.Device = nic[2]
+		}
+
+		return aclID, nil
+	}
+
+	match = ovnLogNICDefaultRuleName.FindStringSubmatch(entry.name)
+	if match != nil {
+		entry.Device = match[2]
+		entry.Direction = match[3]
+
+		inst, err := r.instance(match[1])
+		if err == nil {
+			entry.Project = inst[0]
+			entry.Instance = inst[1]
+		}
+
+		return 0, nil
+	}
+
+	return -1, fmt.Errorf("Unknown OVN ACL rule %q", entry.name)
+}
+
+// acl returns the project and name of an ACL.
+func (r *ovnLogResolver) acl(aclID int64) ([]string, error) {
+	acl, ok := r.acls[aclID]
 	if ok {
-		newEntry.SrcPort = srcPort
+		if acl == nil {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Network ACL not found")
+		}
+
+		return acl, nil
 	}
 
-	dstPort, ok := aclEntry["tp_dst"]
+	err := r.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id := int(aclID)
+		acls, err := cluster.GetNetworkACLs(ctx, tx.Tx(), cluster.NetworkACLFilter{ID: &id})
+		if err != nil {
+			return err
+		}
+
+		if len(acls) != 1 {
+			return api.StatusErrorf(http.StatusNotFound, "Network ACL not found")
+		}
+
+		acl = []string{acls[0].Project, acls[0].Name}
+
+		return nil
+	})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			r.acls[aclID] = nil
+		}
+
+		return nil, err
+	}
+
+	r.acls[aclID] = acl
+
+	return acl, nil
+}
+
+// nic returns the project, instance and device name of the instance NIC using a MAC address.
+func (r *ovnLogResolver) nic(hwaddr string) ([]string, error) {
+	nic, ok := r.nics[hwaddr]
 	if ok {
-		newEntry.DstPort = dstPort
+		if nic == nil {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Instance NIC not found")
+		}
+
+		return nic, nil
 	}
 
-	out, err := json.Marshal(&newEntry)
+	err := r.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		projectName, instanceName, deviceName, err := tx.GetInstanceNICByHwaddr(ctx, hwaddr)
+		if err != nil {
+			return err
+		}
+
+		nic = []string{projectName, instanceName, deviceName}
+
+		return nil
+	})
 	if err != nil {
-		return ""
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			r.nics[hwaddr] = nil
+		}
+
+		return nil, err
 	}
 
-	return string(out)
+	r.nics[hwaddr] = nic
+
+	return nic, nil
+}
+
+// instance returns the project and name of an instance using its volatile UUID.
+func (r *ovnLogResolver) instance(uuid string) ([]string, error) {
+	inst, ok := r.instances[uuid]
+	if ok {
+		if inst == nil {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Instance not found")
+		}
+
+		return inst, nil
+	}
+
+	err := r.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		projectName, instanceName, err := tx.GetInstanceProjectAndNameByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		inst = []string{projectName, instanceName}
+
+		return nil
+	})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			r.instances[uuid] = nil
+		}
+
+		return nil, err
+	}
+
+	r.instances[uuid] = inst
+
+	return inst, nil
+}
+
+// OVNLogEventContext parses the message of an OVN ACL log line and returns its fields for use as the context
+// of a network ACL event. Returns nil if the message can't be parsed.
+func OVNLogEventContext(s *state.State, message string) map[string]string {
+	entry := ovnParseLogMessage(message)
+	if entry == nil {
+		return nil
+	}
+
+	ovnLogEventResolverMu.Lock()
+	if ovnLogEventResolver == nil || ovnLogEventResolver.state != s || time.Now().After(ovnLogEventResolverExpiry) {
+		ovnLogEventResolver = newOVNLogResolver(s)
+		ovnLogEventResolverExpiry = time.Now().Add(ovnLogEventResolverLifetime)
+	}
+
+	_, err := ovnLogEventResolver.resolve(entry)
+	ovnLogEventResolverMu.Unlock()
+	if err != nil {
+		return nil
+	}
+
+	ctx := map[string]string{
+		"acl":       entry.ACL,
+		"project":   entry.Project,
+		"instance":  entry.Instance,
+		"device":    entry.Device,
+		"direction": entry.Direction,
+		"proto":     entry.Proto,
+		"src":       entry.Src,
+		"dst":       entry.Dst,
+		"src_port":  entry.SrcPort,
+		"dst_port":  entry.DstPort,
+		"icmp_type": entry.ICMPType,
+		"icmp_code": entry.ICMPCode,
+		"action":    entry.Action,
+	}
+
+	if entry.Rule != nil {
+		ctx["rule"] = strconv.Itoa(*entry.Rule)
+	}
+
+	// Skip empty fields.
+	maps.DeleteFunc(ctx, func(_ string, v string) bool { return v == "" })
+
+	return ctx
 }
 
 func addPortGroupDefaultAction(portGroupName ovn.OVNPortGroup, portGroupRules []ovn.OVNACLRule) []ovn.OVNACLRule {
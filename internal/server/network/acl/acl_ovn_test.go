@@ -0,0 +1,124 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func Test_ovnParseLogEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *ovnLogEntry
+	}{
+		{
+			name:  "TCP entry",
+			input: `2024-01-02T03:04:05.678Z|00001|acl_log(ovn_pinctrl0)|INFO|name="incus_acl12_ovn3-ingress-2", verdict=drop, severity=info, direction=to-lport: tcp,vlan_tci=0x0000,dl_src=00:16:3e:00:00:01,dl_dst=00:16:3e:00:00:02,nw_src=10.0.0.1,nw_dst=10.0.0.2,nw_tos=0,nw_ecn=0,nw_ttl=64,tp_src=1234,tp_dst=22,tcp_flags=syn`,
+			want: &ovnLogEntry{
+				NetworkACLLogEntry: api.NetworkACLLogEntry{Time: "2024-01-02T03:04:05Z", Proto: "tcp", Src: "10.0.0.1", Dst: "10.0.0.2", SrcPort: "1234", DstPort: "22", Action: "drop"},
+				name:               "incus_acl12_ovn3-ingress-2",
+				srcMAC:             "00:16:3e:00:00:01",
+				dstMAC:             "00:16:3e:00:00:02",
+			},
+		},
+		{
+			name:  "ICMPv6 entry",
+			input: `2024-01-02T03:04:05.678Z|00002|acl_log(ovn_pinctrl0)|INFO|name="incus_acl12_ovn3-egress-0", verdict=allow, severity=info, direction=from-lport: icmp6,vlan_tci=0x0000,dl_src=00:16:3e:00:00:01,dl_dst=00:16:3e:00:00:02,ipv6_src=fd00::1,ipv6_dst=fd00::2,icmp_type=128,icmp_code=0`,
+			want: &ovnLogEntry{
+				NetworkACLLogEntry: api.NetworkACLLogEntry{Time: "2024-01-02T03:04:05Z", Proto: "icmp6", Src: "fd00::1", Dst: "fd00::2", ICMPType: "128", ICMPCode: "0", Action: "allow"},
+				name:               "incus_acl12_ovn3-egress-0",
+				srcMAC:             "00:16:3e:00:00:01",
+				dstMAC:             "00:16:3e:00:00:02",
+			},
+		},
+		{
+			name:  "Not an ACL entry",
+			input: `2024-01-02T03:04:05.678Z|00003|binding|INFO|Claiming lport`,
+		},
+		{
+			name:  "Invalid time",
+			input: `yesterday|00004|acl_log(ovn_pinctrl0)|INFO|name="incus_acl12_ovn3-egress-0", verdict=allow, direction=from-lport: tcp,nw_src=10.0.0.1,nw_dst=10.0.0.2`,
+		},
+		{
+			name:  "Missing addresses",
+			input: `2024-01-02T03:04:05.678Z|00005|acl_log(ovn_pinctrl0)|INFO|name="incus_acl12_ovn3-egress-0", verdict=allow, direction=from-lport: arp`,
+		},
+		{
+			name:  "Missing name",
+			input: `2024-01-02T03:04:05.678Z|00006|acl_log(ovn_pinctrl0)|INFO|verdict=allow, direction=from-lport: tcp,nw_src=10.0.0.1,nw_dst=10.0.0.2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ovnParseLogEntry(tt.input))
+		})
+	}
+}
+
+func Test_ovnLogResolver(t *testing.T) {
+	// Pre-populate the caches so that no database lookup takes place.
+	r := newOVNLogResolver(nil)
+	r.acls[12] = []string{"p1", "web"}
+	r.acls[13] = nil
+	r.nics["00:16:3e:00:00:02"] = []string{"p2", "c1", "eth0"}
+	r.nics["00:16:3e:00:00:03"] = nil
+	r.instances["c5a8a5a4-4d8c-4a9b-a6c6-7b7e6f5a4b3c"] = []string{"p2", "c1"}
+
+	rule := 2
+
+	tests := []struct {
+		name    string
+		entry   ovnLogEntry
+		wantID  int64
+		want    api.NetworkACLLogEntry
+		wantErr bool
+	}{
+		{
+			name:   "Ingress rule with known NIC",
+			entry:  ovnLogEntry{name: "incus_acl12_ovn3-ingress-2", srcMAC: "00:16:3e:00:00:01", dstMAC: "00:16:3e:00:00:02"},
+			wantID: 12,
+			want:   api.NetworkACLLogEntry{Project: "p2", ACL: "web", Instance: "c1", Device: "eth0", Direction: "ingress", Rule: &rule},
+		},
+		{
+			name:   "Egress rule with unknown NIC",
+			entry:  ovnLogEntry{name: "incus_acl12_ovn3-egress-2", srcMAC: "00:16:3e:00:00:03", dstMAC: "00:16:3e:00:00:02"},
+			wantID: 12,
+			want:   api.NetworkACLLogEntry{Project: "p1", ACL: "web", Direction: "egress", Rule: &rule},
+		},
+		{
+			name:    "Deleted ACL",
+			entry:   ovnLogEntry{name: "incus_acl13_ovn3-egress-2"},
+			wantErr: true,
+		},
+		{
+			name:   "Instance NIC default rule",
+			entry:  ovnLogEntry{name: "c5a8a5a4-4d8c-4a9b-a6c6-7b7e6f5a4b3c-eth0-ingress"},
+			wantID: 0,
+			want:   api.NetworkACLLogEntry{Project: "p2", Instance: "c1", Device: "eth0", Direction: "ingress"},
+		},
+		{
+			name:    "Unknown rule",
+			entry:   ovnLogEntry{name: "something-else"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aclID, err := r.resolve(&tt.entry)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, aclID)
+			assert.Equal(t, tt.want, tt.entry.NetworkACLLogEntry)
+		})
+	}
+}
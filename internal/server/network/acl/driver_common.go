@@ -3,6 +3,7 @@ package acl
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -856,14 +857,26 @@ func (d *common) GetLog(clientType request.ClientType) (string, error) {
 	defer func() { _ = logFile.Close() }()
 
 	logEntries := []string{}
+	resolver := newOVNLogResolver(d.state)
 	scanner := bufio.NewScanner(logFile)
 	for scanner.Scan() {
-		logEntry := ovnParseLogEntry(scanner.Text(), fmt.Sprintf("incus_acl%d-", d.id))
-		if logEntry == "" {
+		entry := ovnParseLogEntry(scanner.Text())
+		if entry == nil {
 			continue
 		}
 
-		logEntries = append(logEntries, logEntry)
+		// Filter for our ACL.
+		aclID, err := resolver.resolve(entry)
+		if err != nil || aclID != d.id {
+			continue
+		}
+
+		logEntry, err := json.Marshal(&entry.NetworkACLLogEntry)
+		if err != nil {
+			continue
+		}
+
+		logEntries = append(logEntries, string(logEntry))
 	}
 
 	err = scanner.Err()
@@ -926,3 +939,75 @@ func (d *common) GetLog(clientType request.ClientType) (string, error) {
 
 	return strings.Join(logEntries, "\n") + "\n", nil
 }
+
+// GetState gets the hit counters of the ACL rules.
+func (d *common) GetState(clientType request.ClientType) (*api.NetworkACLState, error) {
+	aclState := &api.NetworkACLState{
+		Ingress: make([]api.NetworkACLRuleCounters, len(d.info.Ingress)),
+		Egress:  make([]api.NetworkACLRuleCounters, len(d.info.Egress)),
+	}
+
+	// addCounters adds the counters of a rule to the state, ignoring rules that don't exist anymore.
+	addCounters := func(direction string, ruleIndex int, counters api.NetworkACLRuleCounters) {
+		rules := aclState.Ingress
+		if direction == string(ruleDirectionEgress) {
+			rules = aclState.Egress
+		}
+
+		if ruleIndex < 0 || ruleIndex >= len(rules) {
+			return
+		}
+
+		rules[ruleIndex].Packets += counters.Packets
+		rules[ruleIndex].Bytes += counters.Bytes
+	}
+
+	counters, err := LocalRuleCounters(d.state)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, ruleCounters := range counters {
+		if key.ACLID != d.id {
+			continue
+		}
+
+		addCounters(key.Direction, key.Rule, ruleCounters)
+	}
+
+	// Aggregates the counters from the rest of the cluster.
+	if clientType == request.ClientTypeNormal {
+		// Setup notifier to reach the rest of the cluster.
+		notifier, err := cluster.NewNotifier(d.state, d.state.Endpoints.NetworkCert(), d.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return nil, err
+		}
+
+		mu := sync.Mutex{}
+		err = notifier(func(client incus.InstanceServer) error {
+			memberState, err := client.UseProject(d.projectName).GetNetworkACLState(d.info.Name)
+			if err != nil {
+				return err
+			}
+
+			// Prevent concurrent writes to the counters.
+			mu.Lock()
+			defer mu.Unlock()
+
+			for i, ruleCounters := range memberState.Ingress {
+				addCounters(string(ruleDirectionIngress), i, ruleCounters)
+			}
+
+			for i, ruleCounters := range memberState.Egress {
+				addCounters(string(ruleDirectionEgress), i, ruleCounters)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return aclState, nil
+}
@@ -73,9 +73,14 @@ func OVNEnsureAddressSets(s *state.State, l logger.Logger, client *ovn.NB, proje
 
 		asInfo := addrSet.Info()
 
+		addresses, err := setAddresses(s, projectName, asInfo)
+		if err != nil {
+			return nil, err
+		}
+
 		// Convert addresses into net.IPNet slices.
 		var ipNets []net.IPNet
-		for _, addr := range asInfo.Addresses {
+		for _, addr := range addresses {
 			// Try to parse as IP or CIDR.
 			if strings.Contains(addr, "/") {
 				_, ipnet, err := net.ParseCIDR(addr)
@@ -0,0 +1,416 @@
+package addressset
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/lxc/incus/v6/internal/server/cluster/request"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// NetworkLeases returns the leases of a project's instances on a managed network.
+// It is set by the network package, which can't be imported here without causing circular imports.
+var NetworkLeases func(s *state.State, networkProjectName string, networkName string, instanceProjectName string) ([]api.NetworkLease, error)
+
+// selectorResult is what was last applied for an address set using selectors.
+type selectorResult struct {
+	addresses []string
+	instances []string
+}
+
+// selectorResults holds the instance addresses last applied for each address set using selectors, indexed by ID.
+var selectorResults = map[int]selectorResult{}
+
+var selectorResultsMu sync.Mutex
+
+// instancePowerStateRunning matches the power state recorded in the instance configuration when running.
+const instancePowerStateRunning = "RUNNING"
+
+// HasSelectors returns whether the address set configuration selects instances.
+func HasSelectors(config map[string]string) bool {
+	for k := range config {
+		if strings.HasPrefix(k, "selector.") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectorMatches returns whether an instance with the expanded config and profiles matches the selectors.
+func selectorMatches(config map[string]string, instanceConfig map[string]string, instanceProfiles []string) bool {
+	for _, profileName := range util.SplitNTrimSpace(config["selector.profiles"], ",", -1, true) {
+		if !slices.Contains(instanceProfiles, profileName) {
+			return false
+		}
+	}
+
+	for k, v := range config {
+		key, ok := strings.CutPrefix(k, "selector.config.")
+		if !ok {
+			continue
+		}
+
+		if instanceConfig[key] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selectorInstance is a running instance considered by the address set selectors.
+type selectorInstance struct {
+	name     string
+	config   map[string]string // Expanded configuration.
+	profiles []string
+	nics     []selectorNIC
+}
+
+// selectorNIC is a NIC of an instance considered by the address set selectors.
+type selectorNIC struct {
+	network string
+	hwaddr  string
+	config  map[string]string
+}
+
+// selectorCache holds the instances and network leases loaded while evaluating the selectors of address sets,
+// so that they're only loaded once when refreshing several address sets.
+type selectorCache struct {
+	instances map[string][]selectorInstance
+	leases    map[string][]api.NetworkLease
+}
+
+// newSelectorCache returns an empty selector cache.
+func newSelectorCache() *selectorCache {
+	return &selectorCache{
+		instances: map[string][]selectorInstance{},
+		leases:    map[string][]api.NetworkLease{},
+	}
+}
+
+// selectorInstanceProject returns the project of the instances selected by the address set.
+func selectorInstanceProject(projectName string, config map[string]string) string {
+	if config["selector.project"] != "" {
+		return config["selector.project"]
+	}
+
+	return projectName
+}
+
+// runningInstances returns the running instances of the project which use the networks of the network project.
+func (c *selectorCache) runningInstances(s *state.State, networkProjectName string, instanceProjectName string) ([]selectorInstance, error) {
+	cacheKey := networkProjectName + "/" + instanceProjectName
+	instances, found := c.instances[cacheKey]
+	if found {
+		return instances, nil
+	}
+
+	instances = []selectorInstance{}
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
+			// Only consider instances using the networks of the address set's project.
+			if project.NetworkProjectFromRecord(&p) != networkProjectName {
+				return nil
+			}
+
+			if inst.Config["volatile.last_state.power"] != instancePowerStateRunning {
+				return nil
+			}
+
+			selected := selectorInstance{
+				name:     inst.Name,
+				config:   db.ExpandInstanceConfig(inst.Config, inst.Profiles),
+				profiles: make([]string, 0, len(inst.Profiles)),
+			}
+
+			for _, profile := range inst.Profiles {
+				selected.profiles = append(selected.profiles, profile.Name)
+			}
+
+			for devName, devConfig := range db.ExpandInstanceDevices(inst.Devices.Clone(), inst.Profiles) {
+				if devConfig["type"] != "nic" {
+					continue
+				}
+
+				hwaddr := devConfig["hwaddr"]
+				if hwaddr == "" {
+					hwaddr = inst.Config[fmt.Sprintf("volatile.%s.hwaddr", devName)]
+				}
+
+				selected.nics = append(selected.nics, selectorNIC{
+					network: devConfig["network"],
+					hwaddr:  hwaddr,
+					config:  devConfig,
+				})
+			}
+
+			instances = append(instances, selected)
+
+			return nil
+		}, dbCluster.InstanceFilter{Project: &instanceProjectName})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.instances[cacheKey] = instances
+
+	return instances, nil
+}
+
+// networkLeases returns the leases of the project's instances on a managed network.
+func (c *selectorCache) networkLeases(s *state.State, networkProjectName string, networkName string, instanceProjectName string) ([]api.NetworkLease, error) {
+	if NetworkLeases == nil {
+		return nil, nil
+	}
+
+	cacheKey := networkProjectName + "/" + networkName + "/" + instanceProjectName
+	leases, found := c.leases[cacheKey]
+	if found {
+		return leases, nil
+	}
+
+	leases, err := NetworkLeases(s, networkProjectName, networkName, instanceProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	c.leases[cacheKey] = leases
+
+	return leases, nil
+}
+
+// selected returns the addresses and names of the running instances matching the selectors of an address set.
+func (c *selectorCache) selected(s *state.State, projectName string, config map[string]string) ([]string, []string, error) {
+	instanceProjectName := selectorInstanceProject(projectName, config)
+
+	instances, err := c.runningInstances(s, projectName, instanceProjectName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return selectedAddresses(config, instances, func(networkName string) ([]api.NetworkLease, error) {
+		return c.networkLeases(s, projectName, networkName, instanceProjectName)
+	})
+}
+
+// setAddresses returns the addresses of the set, including those of the running instances matching its selectors.
+func setAddresses(s *state.State, projectName string, set *api.NetworkAddressSet) ([]string, error) {
+	if !HasSelectors(set.Config) {
+		return set.Addresses, nil
+	}
+
+	selected, _, err := newSelectorCache().selected(s, projectName, set.Config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting addresses of instances selected by address set %q: %w", set.Name, err)
+	}
+
+	addresses := slices.Clone(set.Addresses)
+	for _, address := range selected {
+		if !slices.Contains(addresses, address) {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses, nil
+}
+
+// selectedAddresses returns the sorted IP addresses and the names of the running instances matching the selectors.
+// The addresses come from the static addresses of the instance NICs and from the leases of the managed networks
+// they are connected to, as returned by the leases function.
+func selectedAddresses(config map[string]string, instances []selectorInstance, leases func(networkName string) ([]api.NetworkLease, error)) ([]string, []string, error) {
+	var addresses []string
+	addAddress := func(address string) {
+		ip := net.ParseIP(address)
+		if ip != nil && !slices.Contains(addresses, ip.String()) {
+			addresses = append(addresses, ip.String())
+		}
+	}
+
+	var instanceNames []string
+	for _, inst := range instances {
+		if !selectorMatches(config, inst.config, inst.profiles) {
+			continue
+		}
+
+		instanceNames = append(instanceNames, inst.name)
+
+		for _, nic := range inst.nics {
+			// Static addresses (routed NICs accept lists of addresses).
+			for _, key := range []string{"ipv4.address", "ipv6.address"} {
+				for _, address := range util.SplitNTrimSpace(nic.config[key], ",", -1, true) {
+					addAddress(address)
+				}
+			}
+
+			if nic.network == "" {
+				continue
+			}
+
+			// Dynamic addresses.
+			networkLeases, err := leases(nic.network)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Failed getting leases of network %q: %w", nic.network, err)
+			}
+
+			hwaddr, _ := net.ParseMAC(nic.hwaddr)
+			for _, lease := range networkLeases {
+				if lease.Type != "static" && lease.Type != "dynamic" {
+					continue
+				}
+
+				// DHCPv6 leases can't be tracked down to a MAC, so rely on the host name for those.
+				if (hwaddr != nil && lease.Hwaddr == hwaddr.String()) || (lease.Hwaddr == "" && lease.Hostname == inst.name) {
+					addAddress(lease.Address)
+				}
+			}
+		}
+	}
+
+	slices.Sort(addresses)
+	slices.Sort(instanceNames)
+
+	return addresses, instanceNames, nil
+}
+
+// validateSelectorProject checks that the instances of the selected project use the networks of the address set's project.
+func (d *common) validateSelectorProject(projectName string) error {
+	if d.state == nil {
+		return nil
+	}
+
+	networkProjectName, _, err := project.NetworkProject(d.state.DB.Cluster, projectName)
+	if err != nil {
+		return fmt.Errorf("Failed loading project %q: %w", projectName, err)
+	}
+
+	if networkProjectName != d.projectName {
+		return fmt.Errorf("Instances of project %q don't use the networks of project %q", projectName, d.projectName)
+	}
+
+	return nil
+}
+
+// RefreshSelectors re-applies the address sets using selectors whose instance addresses changed since last applied
+// on this member. Normal requests also update OVN and notify the other cluster members.
+// When an instance project is given, only the address sets selecting instances of that project are considered and
+// when instance names are also given, only those which selected or may now select one of those instances.
+func RefreshSelectors(s *state.State, clientType request.ClientType, instanceProjectName string, instanceNames []string) error {
+	type selectorSet struct {
+		id          int
+		projectName string
+		info        *api.NetworkAddressSet
+	}
+
+	var sets []selectorSet
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbSets, err := dbCluster.GetNetworkAddressSets(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, dbSet := range dbSets {
+			info, err := dbSet.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			sets = append(sets, selectorSet{id: dbSet.ID, projectName: dbSet.Project, info: info})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading address sets: %w", err)
+	}
+
+	selectorResultsMu.Lock()
+	defer selectorResultsMu.Unlock()
+
+	cache := newSelectorCache()
+	for _, set := range sets {
+		if !HasSelectors(set.info.Config) {
+			delete(selectorResults, set.id)
+			continue
+		}
+
+		lastResult, found := selectorResults[set.id]
+
+		if found && instanceProjectName != "" {
+			if selectorInstanceProject(set.projectName, set.info.Config) != instanceProjectName {
+				continue
+			}
+
+			if instanceNames != nil {
+				affected, err := cache.affected(s, set.projectName, set.info.Config, lastResult, instanceNames)
+				if err != nil {
+					logger.Warn("Failed getting instances selected by address set", logger.Ctx{"project": set.projectName, "addressSet": set.info.Name, "err": err})
+					continue
+				}
+
+				if !affected {
+					continue
+				}
+			}
+		}
+
+		addresses, selectedInstances, err := cache.selected(s, set.projectName, set.info.Config)
+		if err != nil {
+			logger.Warn("Failed getting addresses of instances selected by address set", logger.Ctx{"project": set.projectName, "addressSet": set.info.Name, "err": err})
+			continue
+		}
+
+		if found && slices.Equal(addresses, lastResult.addresses) {
+			selectorResults[set.id] = selectorResult{addresses: addresses, instances: selectedInstances}
+			continue
+		}
+
+		addrSet := &common{}
+		addrSet.init(s, set.id, set.projectName, set.info)
+
+		err = addrSet.apply(clientType)
+		if err != nil {
+			logger.Warn("Failed applying address set", logger.Ctx{"project": set.projectName, "addressSet": set.info.Name, "err": err})
+			continue
+		}
+
+		selectorResults[set.id] = selectorResult{addresses: addresses, instances: selectedInstances}
+	}
+
+	return nil
+}
+
+// affected returns whether any of the instances was selected by the address set when last applied or is now
+// running and matching its selectors.
+func (c *selectorCache) affected(s *state.State, projectName string, config map[string]string, lastResult selectorResult, instanceNames []string) (bool, error) {
+	for _, instanceName := range instanceNames {
+		if slices.Contains(lastResult.instances, instanceName) {
+			return true, nil
+		}
+	}
+
+	instances, err := c.runningInstances(s, projectName, selectorInstanceProject(projectName, config))
+	if err != nil {
+		return false, err
+	}
+
+	for _, inst := range instances {
+		if slices.Contains(instanceNames, inst.name) && selectorMatches(config, inst.config, inst.profiles) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
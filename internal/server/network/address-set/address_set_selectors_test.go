@@ -0,0 +1,148 @@
+package addressset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func Test_selectorMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]string
+		profiles []string
+		want     bool
+	}{
+		{
+			name:   "No selectors",
+			config: map[string]string{},
+			want:   true,
+		},
+		{
+			name:     "Matching profiles",
+			config:   map[string]string{"selector.profiles": "web, default"},
+			profiles: []string{"default", "web"},
+			want:     true,
+		},
+		{
+			name:     "Missing profile",
+			config:   map[string]string{"selector.profiles": "web,db"},
+			profiles: []string{"default", "web"},
+			want:     false,
+		},
+		{
+			name:   "Matching config",
+			config: map[string]string{"selector.config.user.role": "web"},
+			want:   true,
+		},
+		{
+			name:   "Different config",
+			config: map[string]string{"selector.config.user.role": "db"},
+			want:   false,
+		},
+		{
+			name:   "Unset config",
+			config: map[string]string{"selector.config.user.tier": "front"},
+			want:   false,
+		},
+		{
+			name:     "Profiles and config",
+			config:   map[string]string{"selector.profiles": "web", "selector.config.user.role": "web", "selector.project": "other"},
+			profiles: []string{"web"},
+			want:     true,
+		},
+	}
+
+	instanceConfig := map[string]string{"user.role": "web"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectorMatches(tt.config, instanceConfig, tt.profiles))
+		})
+	}
+}
+
+func Test_selectedAddresses(t *testing.T) {
+	instances := []selectorInstance{
+		{
+			name:     "web1",
+			config:   map[string]string{"user.role": "web"},
+			profiles: []string{"default"},
+			nics: []selectorNIC{
+				{network: "br0", hwaddr: "00:16:3E:00:00:01", config: map[string]string{"ipv4.address": "10.0.0.10"}},
+			},
+		},
+		{
+			name:     "web2",
+			config:   map[string]string{"user.role": "web"},
+			profiles: []string{"default"},
+			nics: []selectorNIC{
+				{network: "br0", hwaddr: "00:16:3e:00:00:02", config: map[string]string{}},
+				{config: map[string]string{"ipv4.address": "192.0.2.1, 192.0.2.2", "ipv6.address": "2001:db8::0:1"}},
+			},
+		},
+		{
+			name:     "db1",
+			config:   map[string]string{"user.role": "db"},
+			profiles: []string{"default"},
+			nics: []selectorNIC{
+				{network: "br0", hwaddr: "00:16:3e:00:00:03", config: map[string]string{}},
+			},
+		},
+	}
+
+	leases := []api.NetworkLease{
+		{Hostname: "web1", Hwaddr: "00:16:3e:00:00:01", Address: "10.0.0.10", Type: "static"},
+		{Hostname: "web2", Hwaddr: "00:16:3e:00:00:02", Address: "10.0.0.20", Type: "dynamic"},
+		{Hostname: "web2", Address: "fd42::20", Type: "dynamic"},
+		{Hostname: "db1", Hwaddr: "00:16:3e:00:00:03", Address: "10.0.0.30", Type: "dynamic"},
+		{Hostname: "br0.gw", Address: "10.0.0.1", Type: "gateway"},
+	}
+
+	leasesFunc := func(networkName string) ([]api.NetworkLease, error) {
+		if networkName != "br0" {
+			return nil, errors.New("Unknown network")
+		}
+
+		return leases, nil
+	}
+
+	tests := []struct {
+		name          string
+		config        map[string]string
+		wantAddresses []string
+		wantInstances []string
+	}{
+		{
+			name:          "Selected by config",
+			config:        map[string]string{"selector.config.user.role": "web"},
+			wantAddresses: []string{"10.0.0.10", "10.0.0.20", "192.0.2.1", "192.0.2.2", "2001:db8::1", "fd42::20"},
+			wantInstances: []string{"web1", "web2"},
+		},
+		{
+			name:          "Selected by profile",
+			config:        map[string]string{"selector.profiles": "default"},
+			wantAddresses: []string{"10.0.0.10", "10.0.0.20", "10.0.0.30", "192.0.2.1", "192.0.2.2", "2001:db8::1", "fd42::20"},
+			wantInstances: []string{"db1", "web1", "web2"},
+		},
+		{
+			name:   "Nothing selected",
+			config: map[string]string{"selector.profiles": "web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addresses, instanceNames, err := selectedAddresses(tt.config, instances, leasesFunc)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAddresses, addresses)
+			assert.Equal(t, tt.wantInstances, instanceNames)
+		})
+	}
+
+	_, _, err := selectedAddresses(map[string]string{}, []selectorInstance{{name: "c1", nics: []selectorNIC{{network: "missing"}}}}, leasesFunc)
+	assert.EqualError(t, err, `Failed getting leases of network "missing": Unknown network`)
+}
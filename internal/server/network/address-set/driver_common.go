@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/validate"
 )
 
 // common represents a network address set.
@@ -164,7 +165,22 @@ func (d *common) validateConfig(config *api.NetworkAddressSetPut) error {
 	}
 
 	// Validate the configuration.
-	configKeys := map[string]func(value string) error{}
+	configKeys := map[string]func(value string) error{
+		// gendoc:generate(entity=network_address_set, group=common, key=selector.project)
+		// The instances of that project must use the networks of the address set's project, and the user must be allowed to view that project.
+		// ---
+		//  type: string
+		//  defaultdesc: The address set's project
+		//  shortdesc: Project of the instances selected by the address set
+		"selector.project": validate.Optional(d.validateSelectorProject),
+
+		// gendoc:generate(entity=network_address_set, group=common, key=selector.profiles)
+		//
+		// ---
+		//  type: string
+		//  shortdesc: Comma-separated list of profiles that the selected instances must use
+		"selector.profiles": validate.Optional(validate.IsListOf(validate.IsAny)),
+	}
 
 	for k, v := range config.Config {
 		// User keys are free for all.
@@ -178,6 +194,16 @@ func (d *common) validateConfig(config *api.NetworkAddressSetPut) error {
 			continue
 		}
 
+		// gendoc:generate(entity=network_address_set, group=common, key=selector.config.*)
+		// For example, `selector.config.user.role=web` selects the instances having `user.role` set to `web`
+		// (directly or through their profiles).
+		// ---
+		//  type: string
+		//  shortdesc: Value of an instance configuration key that the selected instances must have
+		if strings.HasPrefix(k, "selector.config.") {
+			continue
+		}
+
 		validator, ok := configKeys[k]
 		if !ok {
 			return fmt.Errorf("Invalid network integration configuration key %q", k)
@@ -268,9 +294,24 @@ func (d *common) Update(config *api.NetworkAddressSetPut, clientType request.Cli
 		})
 	}
 
+	err = d.apply(clientType)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// apply applies the address set to the networks using it through ACLs.
+// The address set is only updated in OVN and on the other cluster members for normal requests.
+func (d *common) apply(clientType request.ClientType) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
 	// Get a list of networks that indirectly reference this address set via ACLs.
 	asNets := map[string]AddressSetUsage{}
-	err = AddressSetNetworkUsage(d.state, d.projectName, d.info.Name, d.info.Addresses, asNets)
+	err := AddressSetNetworkUsage(d.state, d.projectName, d.info.Name, d.info.Addresses, asNets)
 	if err != nil {
 		return fmt.Errorf("Failed getting address set network usage: %w", err)
 	}
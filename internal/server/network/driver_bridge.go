@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/mdlayher/netx/eui64"
+	"golang.org/x/sys/unix"
 
 	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/server/apparmor"
@@ -29,6 +30,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/dnsmasq"
 	"github.com/lxc/incus/v6/internal/server/dnsmasq/dhcpalloc"
 	firewallDrivers "github.com/lxc/incus/v6/internal/server/firewall/drivers"
+	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/ip"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
 	addressset "github.com/lxc/incus/v6/internal/server/network/address-set"
@@ -41,6 +43,7 @@ import (
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
@@ -48,6 +51,23 @@ import (
 // Default MTU for bridge interface.
 const bridgeMTUDefault = 1500
 
+// bridgeVolatileDelegatedAddress records the bridge address picked from the delegated IPv6 prefix.
+const bridgeVolatileDelegatedAddress = "volatile.ipv6.delegation.address"
+
+// BridgeIPv6Address returns the IPv6 address (in CIDR notation) of a bridge network with the given config.
+// When using IPv6 prefix delegation, this is the address picked from the delegated prefix rather than "ipv6.address".
+func BridgeIPv6Address(config map[string]string) string {
+	if config["ipv6.delegation.interface"] == "" {
+		return config["ipv6.address"]
+	}
+
+	if config[bridgeVolatileDelegatedAddress] == "" {
+		return "none"
+	}
+
+	return config[bridgeVolatileDelegatedAddress]
+}
+
 // bridge represents a bridge network.
 type bridge struct {
 	common
@@ -62,6 +82,7 @@ func (n *bridge) DBType() db.NetworkType {
 func (n *bridge) Info() Info {
 	info := n.common.Info()
 	info.AddressForwards = true
+	info.LoadBalancers = true
 
 	return info
 }
@@ -107,6 +128,11 @@ func (n *bridge) FillConfig(config map[string]string) error {
 		config["ipv4.nat"] = "true"
 	}
 
+	// The IPv6 subnet is set once a prefix gets delegated.
+	if config["ipv6.address"] == "" && config["ipv6.delegation.interface"] != "" {
+		config["ipv6.address"] = "none"
+	}
+
 	if config["ipv6.address"] == "" {
 		content, err := os.ReadFile("/proc/sys/net/ipv6/conf/default/disable_ipv6")
 		if err == nil && string(content) == "0\n" {
@@ -466,6 +492,35 @@ func (n *bridge) Validate(config map[string]string, clientType request.ClientTyp
 		//  shortdesc: Comma-separated list of IPv6 ranges to use for child OVN network routers (FIRST-LAST format)
 		"ipv6.ovn.ranges": validate.Optional(validate.IsListOf(validate.IsNetworkRangeV6)),
 
+		// gendoc:generate(entity=network_bridge, group=common, key=ipv6.delegation.interface)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  default: -
+		//  shortdesc: Upstream interface on which to request a delegated IPv6 prefix (using DHCPv6) for the bridge
+		"ipv6.delegation.interface": validate.Optional(validate.IsInterfaceName),
+
+		// gendoc:generate(entity=network_bridge, group=common, key=ipv6.delegation.subnet)
+		//
+		// ---
+		//  type: integer
+		//  condition: IPv6 prefix delegation
+		//  default: `0`
+		//  shortdesc: Index of the `/64` subnet of the delegated prefix to use for the bridge
+		"ipv6.delegation.subnet":       validate.Optional(validate.IsUint32),
+		networkVolatileDelegatedPrefix: validate.Optional(validate.IsNetworkV6),
+		bridgeVolatileDelegatedAddress: validate.Optional(validate.IsNetworkAddressCIDRV6),
+
+		// gendoc:generate(entity=network_bridge, group=common, key=qos.rate)
+		//
+		// ---
+		//  type: string
+		//  condition: QoS classes
+		//  default: sum of the ceils of the QoS classes
+		//  shortdesc: Total egress bandwidth shared by the QoS classes, in bit/s (various suffixes supported, see {ref}`instances-limit-units`)
+		"qos.rate": validate.Optional(validate.IsBitSize),
+
 		// gendoc:generate(entity=network_bridge, group=common, key=dns.nameservers)
 		//
 		// ---
@@ -675,6 +730,58 @@ func (n *bridge) Validate(config map[string]string, clientType request.ClientTyp
 				rules[k] = validate.Optional(validate.IsUint8)
 			}
 		}
+
+		// QoS class keys have the class name in their name, extract the suffix.
+		if strings.HasPrefix(k, "qos.classes.") {
+			// Validate class name in key.
+			fields := strings.Split(k, ".")
+			if len(fields) != 4 {
+				return fmt.Errorf("Invalid network configuration key: %s", k)
+			}
+
+			// Add the correct validation rule for the dynamic field based on last part of key.
+			switch fields[3] {
+			case "rate":
+				// gendoc:generate(entity=network_bridge, group=common, key=qos.classes.NAME.rate)
+				//
+				// ---
+				//  type: string
+				//  condition: -
+				//  default: -
+				//  shortdesc: Egress bandwidth guaranteed to the instances in the QoS class, in bit/s (various suffixes supported, see {ref}`instances-limit-units`)
+				rules[k] = validate.Optional(validate.IsBitSize)
+			case "ceil":
+				// gendoc:generate(entity=network_bridge, group=common, key=qos.classes.NAME.ceil)
+				//
+				// ---
+				//  type: string
+				//  condition: QoS class
+				//  default: rate of the class
+				//  shortdesc: Maximum egress bandwidth of the instances in the QoS class when borrowing unused bandwidth, in bit/s
+				rules[k] = validate.Optional(validate.IsBitSize)
+			case "priority":
+				// gendoc:generate(entity=network_bridge, group=common, key=qos.classes.NAME.priority)
+				//
+				// ---
+				//  type: integer
+				//  condition: QoS class
+				//  default: `0`
+				//  shortdesc: Priority of the QoS class when borrowing unused bandwidth (`0` to `7`, lower values first)
+				rules[k] = validate.Optional(validate.IsInRange(0, 7))
+			case "dscp":
+				// gendoc:generate(entity=network_bridge, group=common, key=qos.classes.NAME.dscp)
+				//
+				// ---
+				//  type: string
+				//  condition: QoS class
+				//  default: -
+				//  shortdesc: DSCP value to mark the traffic of the instances in the QoS class with (`0` to `63` or a name like `EF`, `AF41` or `CS1`)
+				rules[k] = validate.Optional(func(value string) error {
+					_, err := ParseDSCP(value)
+					return err
+				})
+			}
+		}
 	}
 
 	// gendoc:generate(entity=network_bridge, group=bgp, key=bgp.peers.NAME.address)
@@ -760,6 +867,20 @@ func (n *bridge) Validate(config map[string]string, clientType request.ClientTyp
 		}
 	}
 
+	// Check QoS classes.
+	err = qosValidate(n.name, config)
+	if err != nil {
+		return err
+	}
+
+	// Check IPv6 prefix delegation.
+	if config["ipv6.delegation.interface"] != "" {
+		err = n.prefixDelegationValidate(config)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Check using same MAC address on every cluster node is safe.
 	if config["bridge.hwaddr"] != "" {
 		err = n.checkClusterWideMACSafe(config)
@@ -1105,7 +1226,7 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 	}
 
 	// IPv6 bridge configuration.
-	if !util.IsNoneOrEmpty(n.config["ipv6.address"]) {
+	if !util.IsNoneOrEmpty(BridgeIPv6Address(n.config)) {
 		if !util.PathExists("/proc/sys/net/ipv6") {
 			return errors.New("Network has ipv6.address but kernel IPv6 support is missing")
 		}
@@ -1528,7 +1649,7 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 	}
 
 	// Configure IPv6.
-	if !util.IsNoneOrEmpty(n.config["ipv6.address"]) {
+	if !util.IsNoneOrEmpty(BridgeIPv6Address(n.config)) {
 		// Enable IPv6 for the subnet.
 		err := localUtil.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/disable_ipv6", n.name), "0")
 		if err != nil {
@@ -1536,7 +1657,7 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		}
 
 		// Parse the subnet.
-		ipAddress, subnet, err := net.ParseCIDR(n.config["ipv6.address"])
+		ipAddress, subnet, err := net.ParseCIDR(BridgeIPv6Address(n.config))
 		if err != nil {
 			return fmt.Errorf("Failed parsing ipv6.address: %w", err)
 		}
@@ -2018,12 +2139,37 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		return err
 	}
 
+	// Setup network load balancers.
+	err = n.loadBalancerSetupFirewall()
+	if err != nil {
+		return err
+	}
+
 	// Setup BGP.
 	err = n.bgpSetup(oldConfig)
 	if err != nil {
 		return err
 	}
 
+	err = n.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		return fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
+	}
+
+	// Setup QoS classes.
+	err = n.qosSetup()
+	if err != nil {
+		return fmt.Errorf("Failed setting up QoS classes: %w", err)
+	}
+
+	// Re-apply the changed QoS classes to the NICs of the running instances.
+	if oldConfig != nil && qosConfigChanged(oldConfig, n.config) {
+		n.qosReloadNICs()
+	}
+
+	// Setup IPv6 prefix delegation.
+	n.prefixDelegationSetup()
+
 	reverter.Success()
 
 	return nil
@@ -2037,6 +2183,20 @@ func (n *bridge) Stop() error {
 		return nil
 	}
 
+	// Stop the load balancer health checks.
+	loadBalancerHealthMonitorStop(n.id)
+
+	// Stop following the delegated IPv6 prefix.
+	prefixDelegationUnsubscribe(n.id)
+
+	// Remove the QoS interface.
+	if InterfaceExists(QoSInterfaceName(n.name)) {
+		err := InterfaceRemove(QoSInterfaceName(n.name))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Clear BGP.
 	err := n.bgpClear(n.config)
 	if err != nil {
@@ -2120,6 +2280,14 @@ func (n *bridge) Update(newNetwork api.NetworkPut, targetNode string, clientType
 		return nil // Nothing changed.
 	}
 
+	// Don't remove the QoS classes still used by instance NICs.
+	if clientType == request.ClientTypeNormal && slices.ContainsFunc(changedKeys, func(k string) bool { return strings.HasPrefix(k, "qos.classes.") }) {
+		err = n.qosValidateUsage(newNetwork.Config)
+		if err != nil {
+			return err
+		}
+	}
+
 	// If the network as a whole has not had any previous creation attempts, or the node itself is still
 	// pending, then don't apply the new settings to the node, just to the database record (ready for the
 	// actual global create request to be initiated).
@@ -2294,7 +2462,7 @@ func (n *bridge) hasIPv4Firewall() bool {
 // hasIPv6Firewall indicates whether the network has IPv6 firewall enabled.
 func (n *bridge) hasIPv6Firewall() bool {
 	// IPv6 firewall is only enabled if there is a bridge ipv6.address and ipv6.firewall enabled.
-	if !util.IsNoneOrEmpty(n.config["ipv6.address"]) && util.IsTrueOrEmpty(n.config["ipv6.firewall"]) {
+	if !util.IsNoneOrEmpty(BridgeIPv6Address(n.config)) && util.IsTrueOrEmpty(n.config["ipv6.firewall"]) {
 		return true
 	}
 
@@ -2336,7 +2504,7 @@ func (n *bridge) DHCPv6Subnet() *net.IPNet {
 		return nil
 	}
 
-	_, subnet, err := net.ParseCIDR(n.config["ipv6.address"])
+	_, subnet, err := net.ParseCIDR(BridgeIPv6Address(n.config))
 	if err != nil {
 		return nil
 	}
@@ -2363,6 +2531,9 @@ func (n *bridge) forwardConvertToFirewallForwards(listenAddress net.IP, defaultT
 			ListenPorts:   portMap.listenPorts,
 			TargetPorts:   portMap.target.ports,
 			SNAT:          portMap.snat,
+
+			MaxConnectionRate:    portMap.limits.maxConnectionRate,
+			MaxSourceConnections: portMap.limits.maxSourceConnections,
 		})
 	}
 
@@ -2832,46 +3003,7 @@ func (n *bridge) ForwardCreate(forward api.NetworkForwardsPost, clientType reque
 
 			// If we are the first forward on this bridge, enable hairpin mode on active NIC ports.
 			if len(listenAddresses) <= 1 {
-				filter := dbCluster.InstanceFilter{Node: &n.state.ServerName}
-
-				err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-					return tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
-						// Get the instance's effective network project name.
-						instNetworkProject := project.NetworkProjectFromRecord(&p)
-
-						if instNetworkProject != api.ProjectDefaultName {
-							return nil // Managed bridge networks can only exist in default project.
-						}
-
-						devices := db.ExpandInstanceDevices(inst.Devices.Clone(), inst.Profiles)
-
-						// Iterate through each of the instance's devices, looking for bridged NICs
-						// that are linked to this network.
-						for devName, devConfig := range devices {
-							if devConfig["type"] != "nic" {
-								continue
-							}
-
-							// Check whether the NIC device references our network..
-							if !NICUsesNetwork(devConfig, &api.Network{Name: n.Name()}) {
-								continue
-							}
-
-							hostName := inst.Config[fmt.Sprintf("volatile.%s.host_name", devName)]
-							if InterfaceExists(hostName) {
-								link := &ip.Link{Name: hostName}
-								err = link.BridgeLinkSetHairpin(true)
-								if err != nil {
-									return fmt.Errorf("Error enabling hairpin mode on bridge port %q: %w", link.Name, err)
-								}
-
-								n.logger.Debug("Enabled hairpin mode on NIC bridge port", logger.Ctx{"inst": inst.Name, "project": inst.Project, "device": devName, "dev": link.Name})
-							}
-						}
-
-						return nil
-					}, filter)
-				})
+				err = n.enableNICHairpinMode()
 				if err != nil {
 					return err
 				}
@@ -2890,6 +3022,56 @@ func (n *bridge) ForwardCreate(forward api.NetworkForwardsPost, clientType reque
 	return nil
 }
 
+// enableNICHairpinMode enables hairpin mode on the bridge ports of the active NICs connected to the network.
+// This allows the instances to connect to the forwards and load balancers targeting themselves.
+func (n *bridge) enableNICHairpinMode() error {
+	filter := dbCluster.InstanceFilter{Node: &n.state.ServerName}
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
+			// Get the instance's effective network project name.
+			instNetworkProject := project.NetworkProjectFromRecord(&p)
+
+			if instNetworkProject != api.ProjectDefaultName {
+				return nil // Managed bridge networks can only exist in default project.
+			}
+
+			devices := db.ExpandInstanceDevices(inst.Devices.Clone(), inst.Profiles)
+
+			// Iterate through each of the instance's devices, looking for bridged NICs
+			// that are linked to this network.
+			for devName, devConfig := range devices {
+				if devConfig["type"] != "nic" {
+					continue
+				}
+
+				// Check whether the NIC device references our network..
+				if !NICUsesNetwork(devConfig, &api.Network{Name: n.Name()}) {
+					continue
+				}
+
+				hostName := inst.Config[fmt.Sprintf("volatile.%s.host_name", devName)]
+				if InterfaceExists(hostName) {
+					link := &ip.Link{Name: hostName}
+					err := link.BridgeLinkSetHairpin(true)
+					if err != nil {
+						return fmt.Errorf("Error enabling hairpin mode on bridge port %q: %w", link.Name, err)
+					}
+
+					n.logger.Debug("Enabled hairpin mode on NIC bridge port", logger.Ctx{"inst": inst.Name, "project": inst.Project, "device": devName, "dev": link.Name})
+				}
+			}
+
+			return nil
+		}, filter)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ForwardUpdate updates a network forward.
 func (n *bridge) ForwardUpdate(listenAddress string, req api.NetworkForwardPut, clientType request.ClientType) error {
 	var curForwardID int64
@@ -3226,6 +3408,953 @@ func (n *bridge) forwardSetupFirewall() error {
 	return nil
 }
 
+// LoadBalancerCreate creates a network load balancer.
+func (n *bridge) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	if clientType == request.ClientTypeNormal {
+		err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			// Check if there is an existing load balancer using the same listen address.
+			_, err := dbCluster.GetNetworkLoadBalancer(ctx, tx.Tx(), n.ID(), loadBalancer.ListenAddress)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		})
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "A load balancer for that listen address already exists")
+		}
+
+		// Convert listen address to subnet so we can check its valid and can be used.
+		listenAddressNet, err := ParseIPToNet(loadBalancer.ListenAddress)
+		if err != nil {
+			return fmt.Errorf("Failed parsing load balancer listen address %q: %w", loadBalancer.ListenAddress, err)
+		}
+
+		_, err = n.loadBalancerValidate(listenAddressNet.IP, &loadBalancer.NetworkLoadBalancerPut)
+		if err != nil {
+			return err
+		}
+
+		externalSubnetsInUse, err := n.getExternalSubnetInUse()
+		if err != nil {
+			return err
+		}
+
+		// Check the listen address subnet doesn't fall within any existing network external subnets.
+		for _, externalSubnetUser := range externalSubnetsInUse {
+			// Check if usage is from our own network.
+			if externalSubnetUser.networkProject == n.project && externalSubnetUser.networkName == n.name {
+				// Skip checking conflict with our own network's subnet or SNAT address.
+				// But do not allow other conflict with other usage types within our own network.
+				if externalSubnetUser.usageType == subnetUsageNetwork || externalSubnetUser.usageType == subnetUsageNetworkSNAT {
+					continue
+				}
+			}
+
+			if SubnetContains(&externalSubnetUser.subnet, listenAddressNet) || SubnetContains(listenAddressNet, &externalSubnetUser.subnet) {
+				// This error is purposefully vague so that it doesn't reveal any names of
+				// resources potentially outside of the network.
+				return fmt.Errorf("Load balancer listen address %q overlaps with another network or NIC", listenAddressNet.String())
+			}
+		}
+
+		var loadBalancerID int64
+
+		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			// Create load balancer DB record.
+			lb := dbCluster.NetworkLoadBalancer{
+				NetworkID:     n.ID(),
+				ListenAddress: loadBalancer.ListenAddress,
+				Description:   loadBalancer.Description,
+				Backends:      loadBalancer.Backends,
+				Ports:         loadBalancer.Ports,
+			}
+
+			loadBalancerID, err = dbCluster.CreateNetworkLoadBalancer(ctx, tx.Tx(), lb)
+			if err != nil {
+				return err
+			}
+
+			// Save the load balancer configuration.
+			err = dbCluster.CreateNetworkLoadBalancerConfig(ctx, tx.Tx(), loadBalancerID, loadBalancer.Config)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() {
+			_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return dbCluster.DeleteNetworkLoadBalancer(ctx, tx.Tx(), n.ID(), loadBalancerID)
+			})
+
+			_ = n.loadBalancerSetupFirewall()
+			_ = n.loadBalancerBGPSetupPrefixes()
+		})
+
+		// Notify all other members to apply the load balancer.
+		notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		err = notifier(func(client incus.InstanceServer) error {
+			return client.UseProject(n.project).CreateNetworkLoadBalancer(n.name, loadBalancer)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := n.loadBalancerSetupFirewall()
+	if err != nil {
+		return err
+	}
+
+	// Enable hairpin mode on active NIC ports in case the instances connect to their own load balancer.
+	if n.config["bridge.driver"] != "openvswitch" {
+		for _, ipVersion := range []uint{4, 6} {
+			if BridgeNetfilterEnabled(ipVersion) == nil {
+				err = n.enableNICHairpinMode()
+				if err != nil {
+					return err
+				}
+
+				break
+			}
+		}
+	}
+
+	// Refresh exported BGP prefixes on local member.
+	err = n.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		return fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// LoadBalancerUpdate updates a network load balancer.
+func (n *bridge) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalancerPut, clientType request.ClientType) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	if clientType == request.ClientTypeNormal {
+		var curLoadBalancer *api.NetworkLoadBalancer
+		var curLoadBalancerID int64
+
+		err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			networkID := n.ID()
+
+			// Get the load balancer.
+			dbLoadBalancers, err := dbCluster.GetNetworkLoadBalancers(ctx, tx.Tx(), dbCluster.NetworkLoadBalancerFilter{
+				NetworkID:     &networkID,
+				ListenAddress: &listenAddress,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(dbLoadBalancers) != 1 {
+				return api.StatusErrorf(http.StatusNotFound, "Network load balancer not found")
+			}
+
+			// Get the API struct.
+			curLoadBalancer, err = dbLoadBalancers[0].ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			curLoadBalancerID = dbLoadBalancers[0].ID
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = n.loadBalancerValidate(net.ParseIP(curLoadBalancer.ListenAddress), &req)
+		if err != nil {
+			return err
+		}
+
+		curEtagHash, err := localUtil.EtagHash(curLoadBalancer.Etag())
+		if err != nil {
+			return err
+		}
+
+		newLoadBalancer := api.NetworkLoadBalancer{
+			ListenAddress:          curLoadBalancer.ListenAddress,
+			NetworkLoadBalancerPut: req,
+		}
+
+		newLoadBalancerEtagHash, err := localUtil.EtagHash(newLoadBalancer.Etag())
+		if err != nil {
+			return err
+		}
+
+		if curEtagHash == newLoadBalancerEtagHash {
+			return nil // Nothing has changed.
+		}
+
+		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			lb := dbCluster.NetworkLoadBalancer{
+				NetworkID:     n.ID(),
+				ListenAddress: listenAddress,
+				Description:   newLoadBalancer.Description,
+				Backends:      newLoadBalancer.Backends,
+				Ports:         newLoadBalancer.Ports,
+			}
+
+			err = dbCluster.UpdateNetworkLoadBalancer(ctx, tx.Tx(), n.ID(), listenAddress, lb)
+			if err != nil {
+				return err
+			}
+
+			err = dbCluster.UpdateNetworkLoadBalancerConfig(ctx, tx.Tx(), curLoadBalancerID, newLoadBalancer.Config)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() {
+			_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				lb := dbCluster.NetworkLoadBalancer{
+					NetworkID:     n.ID(),
+					ListenAddress: listenAddress,
+					Description:   curLoadBalancer.Description,
+					Backends:      curLoadBalancer.Backends,
+					Ports:         curLoadBalancer.Ports,
+				}
+
+				err = dbCluster.UpdateNetworkLoadBalancer(ctx, tx.Tx(), n.ID(), listenAddress, lb)
+				if err != nil {
+					return err
+				}
+
+				err = dbCluster.UpdateNetworkLoadBalancerConfig(ctx, tx.Tx(), curLoadBalancerID, curLoadBalancer.Config)
+				if err != nil {
+					return err
+				}
+
+				return nil
+			})
+
+			_ = n.loadBalancerSetupFirewall()
+		})
+
+		// Notify all other members to apply the load balancer.
+		notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		err = notifier(func(client incus.InstanceServer) error {
+			return client.UseProject(n.project).UpdateNetworkLoadBalancer(n.name, curLoadBalancer.ListenAddress, req, "")
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := n.loadBalancerSetupFirewall()
+	if err != nil {
+		return err
+	}
+
+	// Refresh exported BGP prefixes on local member.
+	err = n.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		return fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// LoadBalancerState returns the state of a network load balancer.
+// The backend health is the one observed by the local server.
+func (n *bridge) LoadBalancerState(lb api.NetworkLoadBalancer) (*api.NetworkLoadBalancerState, error) {
+	lbState := &api.NetworkLoadBalancerState{}
+
+	if !util.IsTrue(lb.Config["healthcheck"]) {
+		return lbState, nil
+	}
+
+	portMaps, err := n.loadBalancerValidate(net.ParseIP(lb.ListenAddress), &lb.NetworkLoadBalancerPut)
+	if err != nil {
+		return nil, err
+	}
+
+	lbState.BackendHealth = map[string]api.NetworkLoadBalancerStateBackendHealth{}
+
+	for _, backend := range lb.Backends {
+		backendHealth := api.NetworkLoadBalancerStateBackendHealth{
+			Address: backend.TargetAddress,
+			Ports:   []api.NetworkLoadBalancerStateBackendHealthPort{},
+		}
+
+		for portSpecID, lbPort := range lb.Ports {
+			if !slices.Contains(lbPort.TargetBackend, backend.Name) {
+				continue
+			}
+
+			portMap := portMaps[portSpecID]
+			backendIndex := slices.Index(lbPort.TargetBackend, backend.Name)
+			target := portMap.targets[backendIndex]
+
+			for i, listenPort := range portMap.listenPorts {
+				targetPort := loadBalancerTargetPort(target, listenPort, i)
+
+				status := loadBalancerHealthUnknown
+				if portMap.protocol == "tcp" {
+					status = loadBalancerHealthStatus(n.id, loadBalancerHealthTarget{
+						listenAddress: lb.ListenAddress,
+						address:       target.address.String(),
+						port:          targetPort,
+					})
+				}
+
+				backendHealth.Ports = append(backendHealth.Ports, api.NetworkLoadBalancerStateBackendHealthPort{
+					Protocol: portMap.protocol,
+					Port:     int(targetPort),
+					Status:   status,
+				})
+			}
+		}
+
+		lbState.BackendHealth[backend.Name] = backendHealth
+	}
+
+	return lbState, nil
+}
+
+// LoadBalancerDelete deletes a network load balancer.
+func (n *bridge) LoadBalancerDelete(listenAddress string, clientType request.ClientType) error {
+	if clientType == request.ClientTypeNormal {
+		var lb *dbCluster.NetworkLoadBalancer
+
+		err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			networkID := n.ID()
+
+			// Get the load balancer.
+			dbLoadBalancers, err := dbCluster.GetNetworkLoadBalancers(ctx, tx.Tx(), dbCluster.NetworkLoadBalancerFilter{
+				NetworkID:     &networkID,
+				ListenAddress: &listenAddress,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(dbLoadBalancers) != 1 {
+				return api.StatusErrorf(http.StatusNotFound, "Network load balancer not found")
+			}
+
+			lb = &dbLoadBalancers[0]
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Delete the database records.
+		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return dbCluster.DeleteNetworkLoadBalancer(ctx, tx.Tx(), n.ID(), lb.ID)
+		})
+		if err != nil {
+			return err
+		}
+
+		// Notify all other members to remove the load balancer.
+		notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		err = notifier(func(client incus.InstanceServer) error {
+			return client.UseProject(n.project).DeleteNetworkLoadBalancer(n.name, lb.ListenAddress)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := n.loadBalancerSetupFirewall()
+	if err != nil {
+		return err
+	}
+
+	// Refresh exported BGP prefixes on local member.
+	err = n.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		return fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
+	}
+
+	return nil
+}
+
+// loadBalancerTargetPort returns the target port of a load balancer backend for the listen port at the index.
+func loadBalancerTargetPort(target forwardTarget, listenPort uint64, index int) uint64 {
+	if len(target.ports) == 1 {
+		// If a single target port is specified, forward all listen ports to it.
+		return target.ports[0]
+	} else if len(target.ports) > 1 {
+		// If more than 1 target port specified, use listen port index to get the target port to use.
+		return target.ports[index]
+	}
+
+	// Default to using same port as listen port for target port.
+	return listenPort
+}
+
+// loadBalancerHealthCheck returns the health check settings of the load balancer (nil if disabled).
+func (n *bridge) loadBalancerHealthCheck(loadBalancer api.NetworkLoadBalancerPut) (*loadBalancerHealthCheck, error) {
+	if !util.IsTrue(loadBalancer.Config["healthcheck"]) {
+		return nil, nil
+	}
+
+	// Parse the healthcheck options, using the same defaults as OVN.
+	values := map[string]int{
+		"interval":      10,
+		"timeout":       30,
+		"success_count": 3,
+		"failure_count": 3,
+	}
+
+	for key := range values {
+		value := loadBalancer.Config[fmt.Sprintf("healthcheck.%s", key)]
+		if value == "" {
+			continue
+		}
+
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for healthcheck.%s: %w", key, err)
+		}
+
+		if parsed > 0 {
+			values[key] = parsed
+		}
+	}
+
+	return &loadBalancerHealthCheck{
+		interval:     time.Duration(values["interval"]) * time.Second,
+		timeout:      time.Duration(values["timeout"]) * time.Second,
+		successCount: values["success_count"],
+		failureCount: values["failure_count"],
+	}, nil
+}
+
+// loadBalancerSetupFirewall applies all network load balancers to the firewall and updates the backend health
+// checks. Backend ports reported offline by the health checks are left out until they're back online.
+func (n *bridge) loadBalancerSetupFirewall() error {
+	var loadBalancers []*api.NetworkLoadBalancer
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		networkID := n.ID()
+		dbLoadBalancers, err := dbCluster.GetNetworkLoadBalancers(ctx, tx.Tx(), dbCluster.NetworkLoadBalancerFilter{
+			NetworkID: &networkID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, dbLoadBalancer := range dbLoadBalancers {
+			lb, err := dbLoadBalancer.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			loadBalancers = append(loadBalancers, lb)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading network load balancers: %w", err)
+	}
+
+	var fwLoadBalancers []firewallDrivers.LoadBalancer
+	healthTargets := map[loadBalancerHealthTarget]loadBalancerHealthCheck{}
+
+	for _, lb := range loadBalancers {
+		listenAddress := net.ParseIP(lb.ListenAddress)
+
+		portMaps, err := n.loadBalancerValidate(listenAddress, &lb.NetworkLoadBalancerPut)
+		if err != nil {
+			return fmt.Errorf("Failed validating load balancer for listen address %q: %w", lb.ListenAddress, err)
+		}
+
+		healthCheck, err := n.loadBalancerHealthCheck(lb.NetworkLoadBalancerPut)
+		if err != nil {
+			return fmt.Errorf("Failed parsing health check of load balancer for listen address %q: %w", lb.ListenAddress, err)
+		}
+
+		for _, portMap := range portMaps {
+			fwLoadBalancer := firewallDrivers.LoadBalancer{
+				ListenAddress:        listenAddress,
+				Protocol:             portMap.protocol,
+				MaxConnectionRate:    portMap.limits.maxConnectionRate,
+				MaxSourceConnections: portMap.limits.maxSourceConnections,
+			}
+
+			for i, listenPort := range portMap.listenPorts {
+				fwPort := firewallDrivers.LoadBalancerPort{ListenPort: listenPort}
+
+				for _, target := range portMap.targets {
+					targetPort := loadBalancerTargetPort(target, listenPort, i)
+
+					// Only TCP backends are checked.
+					if healthCheck != nil && portMap.protocol == "tcp" {
+						healthTarget := loadBalancerHealthTarget{
+							listenAddress: lb.ListenAddress,
+							address:       target.address.String(),
+							port:          targetPort,
+						}
+
+						healthTargets[healthTarget] = *healthCheck
+
+						// Keep using the backends until they're known to be offline.
+						if loadBalancerHealthStatus(n.id, healthTarget) == loadBalancerHealthOffline {
+							continue
+						}
+					}
+
+					fwPort.Targets = append(fwPort.Targets, firewallDrivers.LoadBalancerTarget{
+						Address: target.address,
+						Port:    targetPort,
+					})
+				}
+
+				fwLoadBalancer.Ports = append(fwLoadBalancer.Ports, fwPort)
+			}
+
+			fwLoadBalancers = append(fwLoadBalancers, fwLoadBalancer)
+		}
+	}
+
+	err = n.state.Firewall.NetworkApplyLoadBalancers(n.name, fwLoadBalancers)
+	if err != nil {
+		return fmt.Errorf("Failed applying firewall load balancers: %w", err)
+	}
+
+	// Re-apply the load balancers whenever a backend goes offline or comes back online.
+	loadBalancerHealthMonitorUpdate(n.id, healthTargets, n.loadBalancerHealthChanged)
+
+	return nil
+}
+
+// loadBalancerHealthChanged re-applies the load balancers after a change of backend health.
+func (n *bridge) loadBalancerHealthChanged() {
+	// Reload the network in case its configuration changed since the health checks were started.
+	network, err := LoadByName(n.state, n.project, n.name)
+	if err != nil {
+		n.logger.Warn("Failed loading network to apply load balancer backend health", logger.Ctx{"err": err})
+		return
+	}
+
+	bridgeNet, ok := network.(*bridge)
+	if !ok || !bridgeNet.isRunning() {
+		return
+	}
+
+	err = bridgeNet.loadBalancerSetupFirewall()
+	if err != nil {
+		n.logger.Warn("Failed applying load balancer backend health", logger.Ctx{"err": err})
+	}
+
+	err = bridgeNet.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		n.logger.Warn("Failed applying BGP prefixes for load balancers", logger.Ctx{"err": err})
+	}
+}
+
+// qosSetup sets up the interface shaping the egress traffic of the instances in the network's QoS classes.
+// The instance NICs redirect their traffic to this interface, with the packets' priority set to their class ID.
+func (n *bridge) qosSetup() error {
+	classes, err := qosClasses(n.config)
+	if err != nil {
+		return err
+	}
+
+	ifName := QoSInterfaceName(n.name)
+
+	if len(classes) == 0 {
+		if InterfaceExists(ifName) {
+			return InterfaceRemove(ifName)
+		}
+
+		return nil
+	}
+
+	if !InterfaceExists(ifName) {
+		ifb := &ip.Ifb{Link: ip.Link{Name: ifName}}
+		err = ifb.Add()
+		if err != nil {
+			return err
+		}
+
+		err = ifb.SetUp()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Recreate the classes from scratch.
+	qdiscHTB := &ip.QdiscHTB{Qdisc: ip.Qdisc{Dev: ifName, Handle: fmt.Sprintf("%x:0", qosHandleMajor), Parent: "root"}}
+	err = qdiscHTB.Delete()
+	if err != nil && !errors.Is(err, unix.ENOENT) {
+		return err
+	}
+
+	err = qdiscHTB.Add()
+	if err != nil {
+		return err
+	}
+
+	// Unless set, the total rate is high enough for all the classes to use their ceil at once.
+	rate := n.config["qos.rate"]
+	if rate == "" {
+		var totalCeil int64
+		for _, class := range classes {
+			ceil, err := units.ParseBitSizeString(class.ceil)
+			if err != nil {
+				return err
+			}
+
+			totalCeil += ceil
+		}
+
+		rate = fmt.Sprintf("%dbit", totalCeil)
+	}
+
+	rootClassID := fmt.Sprintf("%x:1", qosHandleMajor)
+	rootClass := &ip.ClassHTB{Class: ip.Class{Dev: ifName, Parent: fmt.Sprintf("%x:0", qosHandleMajor), Classid: rootClassID}, Rate: rate}
+	err = rootClass.Add()
+	if err != nil {
+		return err
+	}
+
+	for _, class := range classes {
+		minor := qosClassMinor(class.name)
+		classID := fmt.Sprintf("%x:%x", qosHandleMajor, minor)
+
+		htbClass := &ip.ClassHTB{Class: ip.Class{Dev: ifName, Parent: rootClassID, Classid: classID}, Rate: class.rate, Ceil: class.ceil, Prio: class.priority}
+		err = htbClass.Add()
+		if err != nil {
+			return fmt.Errorf("Failed adding QoS class %q: %w", class.name, err)
+		}
+
+		fqCodel := &ip.QdiscFqCodel{Qdisc: ip.Qdisc{Dev: ifName, Handle: fmt.Sprintf("%x:0", minor), Parent: classID}}
+		err = fqCodel.Add()
+		if err != nil {
+			return fmt.Errorf("Failed adding queue of QoS class %q: %w", class.name, err)
+		}
+	}
+
+	return nil
+}
+
+// qosValidateUsage checks that the QoS classes used by instance NICs are defined in the new config.
+func (n *bridge) qosValidateUsage(config map[string]string) error {
+	return UsedByInstanceDevices(n.state, n.project, n.name, n.netType, func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
+		className := nicConfig["qos.class"]
+		if className != "" && !QoSClassExists(config, className) {
+			return fmt.Errorf("QoS class %q is still used by device %q of instance %q in project %q", className, nicName, inst.Name, inst.Project)
+		}
+
+		return nil
+	})
+}
+
+// qosReloadNICs re-applies the QoS class of the NICs of the local running instances using the network's classes.
+// Failures are logged so that a single NIC doesn't prevent the others from being updated.
+func (n *bridge) qosReloadNICs() {
+	type qosNIC struct {
+		projectName  string
+		instanceName string
+		nicName      string
+	}
+
+	var nics []qosNIC
+
+	filter := dbCluster.InstanceFilter{}
+	if n.state.ServerName != "" {
+		filter.Node = &n.state.ServerName
+	}
+
+	err := UsedByInstanceDevices(n.state, n.project, n.name, n.netType, func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
+		if nicConfig["qos.class"] != "" {
+			nics = append(nics, qosNIC{projectName: inst.Project, instanceName: inst.Name, nicName: nicName})
+		}
+
+		return nil
+	}, filter)
+	if err != nil {
+		n.logger.Error("Failed listing instance NICs using QoS classes", logger.Ctx{"err": err})
+		return
+	}
+
+	for _, nic := range nics {
+		l := n.logger.AddContext(logger.Ctx{"project": nic.projectName, "instance": nic.instanceName, "device": nic.nicName})
+
+		inst, err := instance.LoadByProjectAndName(n.state, nic.projectName, nic.instanceName)
+		if err != nil {
+			l.Error("Failed loading instance to apply QoS classes", logger.Ctx{"err": err})
+			continue
+		}
+
+		if !inst.IsRunning() {
+			continue
+		}
+
+		err = inst.ReloadDevice(nic.nicName)
+		if err != nil {
+			l.Error("Failed applying QoS classes to instance NIC", logger.Ctx{"err": err})
+		}
+	}
+}
+
+// prefixDelegationValidate checks the IPv6 prefix delegation settings.
+func (n *bridge) prefixDelegationValidate(config map[string]string) error {
+	if config["ipv6.delegation.interface"] == n.name {
+		return errors.New(`"ipv6.delegation.interface" must be an upstream interface`)
+	}
+
+	for _, key := range []string{"ipv6.dhcp.ranges", "ipv6.ovn.ranges"} {
+		if config[key] != "" {
+			return fmt.Errorf(`%q can't be used in conjunction with "ipv6.delegation.interface"`, key)
+		}
+	}
+
+	// The bridge address is picked from the delegated prefix instead.
+	if !util.IsNoneOrEmpty(config["ipv6.address"]) {
+		return errors.New(`"ipv6.address" must be set to "none" when using "ipv6.delegation.interface"`)
+	}
+
+	// Check that no other network uses the same subnet of the delegated prefix.
+	var err error
+	var projectNetworks map[string]map[int64]api.Network
+
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		projectNetworks, err = tx.GetCreatedNetworks(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to load all networks: %w", err)
+	}
+
+	subnetIndex := func(config map[string]string) string {
+		if config["ipv6.delegation.subnet"] == "" {
+			return "0"
+		}
+
+		return config["ipv6.delegation.subnet"]
+	}
+
+	for projectName, networks := range projectNetworks {
+		for _, network := range networks {
+			if projectName == n.project && network.Name == n.name {
+				continue // Ignore our own DB record.
+			}
+
+			if network.Config["ipv6.delegation.interface"] == config["ipv6.delegation.interface"] && subnetIndex(network.Config) == subnetIndex(config) {
+				return fmt.Errorf("Subnet %s of the prefix delegated on %q is already used by network %q in project %q", subnetIndex(config), config["ipv6.delegation.interface"], network.Name, projectName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// prefixDelegationSetup follows the prefix delegated on the upstream interface (if any).
+func (n *bridge) prefixDelegationSetup() {
+	ifName := n.config["ipv6.delegation.interface"]
+	if ifName == "" {
+		prefixDelegationUnsubscribe(n.id)
+		return
+	}
+
+	prefix := prefixDelegationSubscribe(ifName, n.id, n.prefixDelegationChanged)
+	if prefix != nil {
+		// Apply the current prefix in the background as it may require updating the network.
+		go n.prefixDelegationChanged(prefix)
+	}
+}
+
+// prefixDelegationChanged renumbers the network after a change of the prefix delegated on its upstream interface.
+// IPv6 is turned off on the network while no prefix is delegated.
+func (n *bridge) prefixDelegationChanged(prefix *net.IPNet) {
+	// Reload the network in case its configuration changed since subscribing.
+	network, err := LoadByName(n.state, n.project, n.name)
+	if err != nil {
+		n.logger.Warn("Failed loading network to apply delegated prefix", logger.Ctx{"err": err})
+		return
+	}
+
+	bridgeNet, ok := network.(*bridge)
+	if !ok || bridgeNet.config["ipv6.delegation.interface"] == "" {
+		return
+	}
+
+	newConfig := maps.Clone(bridgeNet.config)
+	delete(newConfig, bridgeVolatileDelegatedAddress)
+
+	var oldPrefix *net.IPNet
+	if prefix != nil {
+		subnetIndex, _ := strconv.ParseUint(newConfig["ipv6.delegation.subnet"], 10, 32)
+
+		subnet, err := prefixDelegationSubnet(prefix, subnetIndex)
+		if err != nil {
+			n.logger.Warn("Failed picking subnet from delegated prefix", logger.Ctx{"err": err})
+		} else {
+			subnet.IP[net.IPv6len-1] = 1
+			newConfig[bridgeVolatileDelegatedAddress] = fmt.Sprintf("%s/64", subnet.IP.String())
+		}
+
+		_, oldPrefix, _ = net.ParseCIDR(newConfig[networkVolatileDelegatedPrefix])
+		newConfig[networkVolatileDelegatedPrefix] = prefix.String()
+	}
+
+	if maps.Equal(newConfig, bridgeNet.config) {
+		return
+	}
+
+	n.logger.Info("Renumbering network after delegated prefix change", logger.Ctx{"address": BridgeIPv6Address(newConfig)})
+
+	err = bridgeNet.prefixDelegationApply(newConfig, oldPrefix, prefix)
+	prefixDelegationReport(n.state, n.logger, n.project, n.id, err)
+}
+
+// prefixDelegationApply updates the network with the config derived from the delegated prefix and renumbers the
+// instance NICs which were using the previous prefix.
+func (n *bridge) prefixDelegationApply(newConfig map[string]string, oldPrefix *net.IPNet, newPrefix *net.IPNet) error {
+	// Each cluster member gets its own delegated prefix, so only update the local member.
+	targetNode := ""
+	if n.state.ServerClustered {
+		targetNode = n.state.ServerName
+	}
+
+	err := n.Update(api.NetworkPut{Description: n.description, Config: newConfig}, targetNode, request.ClientTypeNormal)
+	if err != nil {
+		return fmt.Errorf("Failed updating network: %w", err)
+	}
+
+	if oldPrefix == nil || prefixDelegationEqual(oldPrefix, newPrefix) {
+		return nil
+	}
+
+	return prefixDelegationRenumberInstances(n.state, oldPrefix, newPrefix)
+}
+
+// loadBalancerBGPSetupPrefixes exports external load balancer addresses as prefixes.
+// Load balancers whose backends are all known to be offline are not exported.
+func (n *bridge) loadBalancerBGPSetupPrefixes() error {
+	var loadBalancers []*api.NetworkLoadBalancer
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		networkID := n.ID()
+		dbLoadBalancers, err := dbCluster.GetNetworkLoadBalancers(ctx, tx.Tx(), dbCluster.NetworkLoadBalancerFilter{
+			NetworkID: &networkID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, dbLoadBalancer := range dbLoadBalancers {
+			lb, err := dbLoadBalancer.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			loadBalancers = append(loadBalancers, lb)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading network load balancers: %w", err)
+	}
+
+	// Use load balancer specific owner string (different from the network prefixes) so that these can be
+	// reapplied independently of the network's own prefixes.
+	bgpOwner := fmt.Sprintf("network_%d_load_balancer", n.id)
+
+	// Clear existing load balancer prefixes for network.
+	err = n.state.BGP.RemovePrefixByOwner(bgpOwner)
+	if err != nil {
+		return err
+	}
+
+	for _, lb := range loadBalancers {
+		listenAddr := net.ParseIP(lb.ListenAddress)
+		if listenAddr == nil {
+			continue
+		}
+
+		ipVersion := uint(4)
+		routeSubnetSize := 32
+		if listenAddr.To4() == nil {
+			ipVersion = 6
+			routeSubnetSize = 128
+		}
+
+		// Don't export internal load balancers (those inside the NAT enabled network's subnet).
+		_, netSubnet, _ := net.ParseCIDR(n.config[fmt.Sprintf("ipv%d.address", ipVersion)])
+		if util.IsTrue(n.config[fmt.Sprintf("ipv%d.nat", ipVersion)]) && netSubnet != nil && netSubnet.Contains(listenAddr) {
+			continue
+		}
+
+		// Check health of load balancer (if enabled).
+		if util.IsTrue(lb.Config["healthcheck"]) {
+			state, err := n.LoadBalancerState(*lb)
+			if err != nil {
+				return err
+			}
+
+			online := false
+			for _, backendHealth := range state.BackendHealth {
+				for _, port := range backendHealth.Ports {
+					if port.Status != loadBalancerHealthOffline {
+						online = true
+						break
+					}
+				}
+			}
+
+			if !online {
+				continue
+			}
+		}
+
+		_, ipRouteSubnet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", listenAddr.String(), routeSubnetSize))
+		if err != nil {
+			return err
+		}
+
+		err = n.state.BGP.AddPrefix(*ipRouteSubnet, n.bgpNextHopAddress(ipVersion), bgpOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Leases returns a list of leases for the bridged network. It will reach out to other cluster members as needed.
 // The projectName passed here refers to the initial project from the API request which may differ from the network's project.
 func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]api.NetworkLease, error) {
@@ -3238,7 +4367,7 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 		// If requested project matches network's project then include gateway and downstream uplink IPs.
 		if projectName == n.project {
 			// Add our own gateway IPs.
-			for _, addr := range []string{n.config["ipv4.address"], n.config["ipv6.address"]} {
+			for _, addr := range []string{n.config["ipv4.address"], BridgeIPv6Address(n.config)} {
 				ip, _, _ := net.ParseCIDR(addr)
 				if ip != nil {
 					leases = append(leases, api.NetworkLease{
@@ -3319,7 +4448,7 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 			}
 
 			// Add EUI64 records.
-			_, netIP6, _ := net.ParseCIDR(n.config["ipv6.address"])
+			_, netIP6, _ := net.ParseCIDR(BridgeIPv6Address(n.config))
 			if netIP6 != nil && hwAddr != nil && util.IsFalseOrEmpty(n.config["ipv6.dhcp.stateful"]) {
 				eui64IP6, err := eui64.ParseMAC(netIP6.IP, hwAddr)
 				if err == nil {
@@ -3432,7 +4561,7 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 // UsesDNSMasq indicates if network's config indicates if it needs to use dnsmasq.
 func (n *bridge) UsesDNSMasq() bool {
 	// Skip dnsmasq when no connectivity is configured.
-	if util.IsNoneOrEmpty(n.config["ipv4.address"]) && util.IsNoneOrEmpty(n.config["ipv6.address"]) {
+	if util.IsNoneOrEmpty(n.config["ipv4.address"]) && util.IsNoneOrEmpty(BridgeIPv6Address(n.config)) {
 		return false
 	}
 
@@ -3442,8 +4571,8 @@ func (n *bridge) UsesDNSMasq() bool {
 	}
 
 	// Start dnsmassq if IPv6 is used (needed for SLAAC or DHCPv6).
-	if !util.IsNoneOrEmpty(n.config["ipv6.address"]) {
-		ipAddress, _, err := net.ParseCIDR(n.config["ipv6.address"])
+	if !util.IsNoneOrEmpty(BridgeIPv6Address(n.config)) {
+		ipAddress, _, err := net.ParseCIDR(BridgeIPv6Address(n.config))
 		if err != nil {
 			return true
 		}
@@ -54,12 +54,20 @@ type forwardPortMap struct {
 	protocol    string
 	target      forwardTarget
 	snat        bool
+	limits      forwardConnectionLimits
 }
 
 type loadBalancerPortMap struct {
 	listenPorts []uint64
 	protocol    string
 	targets     []forwardTarget
+	limits      forwardConnectionLimits
+}
+
+// forwardConnectionLimits represents the limits of new connections to the listen port(s) of a port specification.
+type forwardConnectionLimits struct {
+	maxConnectionRate    int64
+	maxSourceConnections int64
 }
 
 // subnetUsageType indicates the type of use for a subnet.
@@ -688,6 +696,12 @@ func (n *common) bgpClear(config map[string]string) error {
 		return err
 	}
 
+	// Clear existing load balancer prefixes for network.
+	err = n.state.BGP.RemovePrefixByOwner(fmt.Sprintf("network_%d_load_balancer", n.id))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -747,7 +761,13 @@ func (n *common) bgpSetupPeers(oldConfig map[string]string) error {
 			}
 		}
 
-		err = n.state.BGP.AddPeer(net.ParseIP(fields[0]), uint32(asn), fields[2], holdTime)
+		// VXLAN networks exchange their EVPN routes with their peers.
+		if n.netType == "vxlan" {
+			err = n.state.BGP.AddEVPNPeer(net.ParseIP(fields[0]), uint32(asn), fields[2], holdTime)
+		} else {
+			err = n.state.BGP.AddPeer(net.ParseIP(fields[0]), uint32(asn), fields[2], holdTime)
+		}
+
 		if err != nil {
 			return err
 		}
@@ -1020,6 +1040,11 @@ func (n *common) forwardValidate(listenAddress net.IP, forward *api.NetworkForwa
 			return nil, errors.New("SNAT can only be used with bridge networks")
 		}
 
+		portMap.limits, err = n.forwardConnectionLimitsValidate(portSpecID, portSpec.Protocol, portSpec.MaxConnectionRate, portSpec.MaxSourceConnections)
+		if err != nil {
+			return nil, err
+		}
+
 		// Check valid target port(s) supplied.
 		targetPortRanges := util.SplitNTrimSpace(portSpec.TargetPort, ",", -1, true)
 
@@ -1053,6 +1078,39 @@ func (n *common) forwardValidate(listenAddress net.IP, forward *api.NetworkForwa
 	return portMaps, err
 }
 
+// forwardConnectionLimitsValidate validates the connection limits of a forward or load balancer port specification.
+func (n *common) forwardConnectionLimitsValidate(portSpecID int, protocol string, maxConnectionRate int64, maxSourceConnections int64) (forwardConnectionLimits, error) {
+	limits := forwardConnectionLimits{
+		maxConnectionRate:    maxConnectionRate,
+		maxSourceConnections: maxSourceConnections,
+	}
+
+	if maxConnectionRate < 0 {
+		return limits, fmt.Errorf("Invalid maximum connection rate in port specification %d, must be positive", portSpecID)
+	}
+
+	if maxSourceConnections < 0 {
+		return limits, fmt.Errorf("Invalid maximum connections per source in port specification %d, must be positive", portSpecID)
+	}
+
+	// Tracking the connections of each source address requires the firewall, OVN has no equivalent.
+	if maxSourceConnections > 0 && n.netType != "bridge" {
+		return limits, fmt.Errorf("Maximum connections per source in port specification %d is only supported on bridge networks (%s networks can't count the connections of each source address)", portSpecID, n.netType)
+	}
+
+	// The limits of bridge networks are enforced through nftables.
+	if (maxConnectionRate > 0 || maxSourceConnections > 0) && n.netType == "bridge" && n.state.Firewall.String() != "nftables" {
+		return limits, fmt.Errorf("Connection limits in port specification %d require the nftables firewall driver", portSpecID)
+	}
+
+	// OVN can only detect new connections through the TCP flags.
+	if maxConnectionRate > 0 && n.netType == "ovn" && protocol != "tcp" {
+		return limits, fmt.Errorf("Maximum connection rate can only be used with the tcp protocol on ovn networks in port specification %d", portSpecID)
+	}
+
+	return limits, nil
+}
+
 // ForwardCreate returns ErrNotImplemented for drivers that do not support forwards.
 func (n *common) ForwardCreate(forward api.NetworkForwardsPost, clientType request.ClientType) error {
 	return ErrNotImplemented
@@ -1470,6 +1528,11 @@ func (n *common) loadBalancerValidate(listenAddress net.IP, forward *api.Network
 			}
 		}
 
+		portMap.limits, err = n.forwardConnectionLimitsValidate(portSpecID, portSpec.Protocol, portSpec.MaxConnectionRate, portSpec.MaxSourceConnections)
+		if err != nil {
+			return nil, err
+		}
+
 		// Check each of the backends specified are compatible with the listen ports.
 		for _, backendName := range portSpec.TargetBackend {
 			// Check backend exists.
@@ -1521,7 +1584,7 @@ func (n *common) Leases(projectName string, clientType request.ClientType) ([]ap
 }
 
 // PeerCrete returns ErrNotImplemented for drivers that do not support forwards.
-func (n *common) PeerCreate(forward api.NetworkPeersPost) error {
+func (n *common) PeerCreate(forward api.NetworkPeersPost, clientType request.ClientType) error {
 	return ErrNotImplemented
 }
 
@@ -1531,7 +1594,7 @@ func (n *common) PeerUpdate(peerName string, newPeer api.NetworkPeerPut) error {
 }
 
 // PeerDelete returns ErrNotImplemented for drivers that do not support forwards.
-func (n *common) PeerDelete(peerName string) error {
+func (n *common) PeerDelete(peerName string, clientType request.ClientType) error {
 	return ErrNotImplemented
 }
 
@@ -1547,11 +1610,30 @@ func (n *common) peerValidate(peerName string, peer *api.NetworkPeerPut) error {
 	}
 
 	// Look for any unknown config fields.
-	for k := range peer.Config {
+	for k, v := range peer.Config {
 		if k == "target_address" {
 			continue
 		}
 
+		// Router addresses on the VLAN of bridged VLAN gateway integrations.
+		if k == "vlan.ipv4.address" {
+			err := validate.IsNetworkAddressV4(v)
+			if err != nil {
+				return fmt.Errorf("Invalid value for option %q: %w", k, err)
+			}
+
+			continue
+		}
+
+		if k == "vlan.ipv6.address" {
+			err := validate.IsNetworkAddressV6(v)
+			if err != nil {
+				return fmt.Errorf("Invalid value for option %q: %w", k, err)
+			}
+
+			continue
+		}
+
 		// User keys are not validated.
 		if internalInstance.IsUserConfig(k) {
 			continue
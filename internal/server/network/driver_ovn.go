@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/flosch/pongo2/v6"
@@ -25,6 +26,7 @@ import (
 
 	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/iprange"
+	"github.com/lxc/incus/v6/internal/server/bgp"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -61,6 +63,19 @@ const (
 	ovnRouterPolicyPeerDropPriority  = 500
 )
 
+// ovnBGPIntegrationPeer represents a BGP peer of a BGP network integration.
+type ovnBGPIntegrationPeer struct {
+	address  string
+	asn      uint32
+	password string
+	holdTime uint64
+}
+
+// ovnBGPIntegrationPeers holds the BGP peers added for the BGP integrations of each running OVN network.
+var ovnBGPIntegrationPeers = map[int64][]ovnBGPIntegrationPeer{}
+
+var ovnBGPIntegrationPeersMu sync.Mutex
+
 // ovnUplinkVars OVN object variables derived from uplink network.
 type ovnUplinkVars struct {
 	// Router.
@@ -3740,6 +3755,11 @@ func (n *ovn) Start() error {
 		return fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
 	}
 
+	err = n.bgpIntegrationSetup()
+	if err != nil {
+		return fmt.Errorf("Failed setting up BGP peers for network integrations: %w", err)
+	}
+
 	// Setup event handler for monitored services.
 	handler := networkOVN.EventHandler{
 		Tables: []string{"Service_Monitor", "Port_Binding"},
@@ -3879,6 +3899,11 @@ func (n *ovn) Stop() error {
 		return err
 	}
 
+	err = n.bgpIntegrationClear()
+	if err != nil {
+		return err
+	}
+
 	// Clear event handler for monitored services.
 	err = networkOVN.RemoveOVNSBHandler(fmt.Sprintf("network_%d", n.id))
 	if err != nil {
@@ -5696,6 +5721,31 @@ func (n *ovn) forwardFlattenVIPs(listenAddress net.IP, defaultTargetAddress net.
 	return vips
 }
 
+// forwardFlattenConnectionLimits flattens the forward connection limits into format compatible with OVN.
+func (n *ovn) forwardFlattenConnectionLimits(listenAddress net.IP, portMaps []*forwardPortMap) []networkOVN.OVNLoadBalancerConnectionLimit {
+	var limits []networkOVN.OVNLoadBalancerConnectionLimit
+
+	for _, portMap := range portMaps {
+		if portMap.limits.maxConnectionRate <= 0 {
+			continue
+		}
+
+		limit := networkOVN.OVNLoadBalancerConnectionLimit{
+			ListenAddress:     listenAddress,
+			ListenPorts:       portMap.listenPorts,
+			MaxConnectionRate: portMap.limits.maxConnectionRate,
+		}
+
+		for _, vip := range n.forwardFlattenVIPs(listenAddress, nil, []*forwardPortMap{portMap}) {
+			limit.Targets = append(limit.Targets, vip.Targets...)
+		}
+
+		limits = append(limits, limit)
+	}
+
+	return limits
+}
+
 // ForwardCreate creates a network forward.
 func (n *ovn) ForwardCreate(forward api.NetworkForwardsPost, clientType request.ClientType) error {
 	if n.config["network"] == "none" {
@@ -5834,6 +5884,7 @@ func (n *ovn) ForwardCreate(forward api.NetworkForwardsPost, clientType request.
 				return dbCluster.DeleteNetworkForward(ctx, tx.Tx(), n.ID(), forwardID)
 			})
 
+			_ = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(forward.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName())
 			_ = n.ovnnb.DeleteLoadBalancer(context.TODO(), n.getLoadBalancerName(forward.ListenAddress))
 			_ = n.forwardBGPSetupPrefixes()
 		})
@@ -5845,6 +5896,12 @@ func (n *ovn) ForwardCreate(forward api.NetworkForwardsPost, clientType request.
 			return fmt.Errorf("Failed applying OVN load balancer: %w", err)
 		}
 
+		limits := n.forwardFlattenConnectionLimits(net.ParseIP(forward.ListenAddress), portMaps)
+		err = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(forward.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName(), limits...)
+		if err != nil {
+			return fmt.Errorf("Failed applying OVN load balancer connection limits: %w", err)
+		}
+
 		// Add internal static route to the network forward (helps with OVN IC).
 		var nexthop net.IP
 		if listenAddressNet.IP.To4() == nil {
@@ -5959,11 +6016,19 @@ func (n *ovn) ForwardUpdate(listenAddress string, req api.NetworkForwardPut, cli
 			portMaps, err := n.forwardValidate(net.ParseIP(curForward.ListenAddress), &curForward.NetworkForwardPut)
 			if err == nil {
 				vips := n.forwardFlattenVIPs(net.ParseIP(curForward.ListenAddress), net.ParseIP(curForward.Config["target_address"]), portMaps)
+				limits := n.forwardFlattenConnectionLimits(net.ParseIP(curForward.ListenAddress), portMaps)
 				_ = n.ovnnb.CreateLoadBalancer(context.TODO(), n.getLoadBalancerName(curForward.ListenAddress), n.getRouterName(), n.getIntSwitchName(), vips...)
+				_ = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(curForward.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName(), limits...)
 				_ = n.forwardBGPSetupPrefixes()
 			}
 		})
 
+		limits := n.forwardFlattenConnectionLimits(net.ParseIP(newForward.ListenAddress), portMaps)
+		err = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(newForward.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName(), limits...)
+		if err != nil {
+			return fmt.Errorf("Failed applying OVN load balancer connection limits: %w", err)
+		}
+
 		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 			fwd := dbCluster.NetworkForward{
 				NetworkID:     n.ID(),
@@ -6063,6 +6128,11 @@ func (n *ovn) ForwardDelete(listenAddress string, clientType request.ClientType)
 		}
 
 		// Delete the network forward itself.
+		err = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(forward.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName())
+		if err != nil {
+			return fmt.Errorf("Failed deleting OVN load balancer connection limits: %w", err)
+		}
+
 		err = n.ovnnb.DeleteLoadBalancer(context.TODO(), n.getLoadBalancerName(forward.ListenAddress))
 		if err != nil {
 			return fmt.Errorf("Failed deleting OVN load balancer: %w", err)
@@ -6145,6 +6215,31 @@ func (n *ovn) loadBalancerFlattenVIPs(listenAddress net.IP, portMaps []*loadBala
 	return vips
 }
 
+// loadBalancerFlattenConnectionLimits flattens the load balancer connection limits into format compatible with OVN.
+func (n *ovn) loadBalancerFlattenConnectionLimits(listenAddress net.IP, portMaps []*loadBalancerPortMap) []networkOVN.OVNLoadBalancerConnectionLimit {
+	var limits []networkOVN.OVNLoadBalancerConnectionLimit
+
+	for _, portMap := range portMaps {
+		if portMap.limits.maxConnectionRate <= 0 {
+			continue
+		}
+
+		limit := networkOVN.OVNLoadBalancerConnectionLimit{
+			ListenAddress:     listenAddress,
+			ListenPorts:       portMap.listenPorts,
+			MaxConnectionRate: portMap.limits.maxConnectionRate,
+		}
+
+		for _, vip := range n.loadBalancerFlattenVIPs(listenAddress, []*loadBalancerPortMap{portMap}) {
+			limit.Targets = append(limit.Targets, vip.Targets...)
+		}
+
+		limits = append(limits, limit)
+	}
+
+	return limits
+}
+
 // LoadBalancerCreate creates a network load balancer.
 func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) error {
 	if n.config["network"] == "none" {
@@ -6276,6 +6371,7 @@ func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clie
 				return dbCluster.DeleteNetworkLoadBalancer(ctx, tx.Tx(), n.ID(), loadBalancerID)
 			})
 
+			_ = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(loadBalancer.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName())
 			_ = n.ovnnb.DeleteLoadBalancer(context.TODO(), n.getLoadBalancerName(loadBalancer.ListenAddress))
 			_ = n.loadBalancerBGPSetupPrefixes()
 		})
@@ -6299,6 +6395,12 @@ func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clie
 			return fmt.Errorf("Failed applying OVN load balancer: %w", err)
 		}
 
+		limits := n.loadBalancerFlattenConnectionLimits(net.ParseIP(loadBalancer.ListenAddress), portMaps)
+		err = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(loadBalancer.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName(), limits...)
+		if err != nil {
+			return fmt.Errorf("Failed applying OVN load balancer connection limits: %w", err)
+		}
+
 		// Add internal static route to the load-balancer (helps with OVN IC).
 		var nexthop net.IP
 		if listenAddressNet.IP.To4() == nil {
@@ -6435,11 +6537,19 @@ func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalanc
 			portMaps, err := n.loadBalancerValidate(net.ParseIP(curLoadBalancer.ListenAddress), &curLoadBalancer.NetworkLoadBalancerPut)
 			if err == nil {
 				vips := n.loadBalancerFlattenVIPs(net.ParseIP(curLoadBalancer.ListenAddress), portMaps)
+				limits := n.loadBalancerFlattenConnectionLimits(net.ParseIP(curLoadBalancer.ListenAddress), portMaps)
 				_ = n.ovnnb.CreateLoadBalancer(context.TODO(), n.getLoadBalancerName(curLoadBalancer.ListenAddress), n.getRouterName(), n.getIntSwitchName(), vips...)
+				_ = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(curLoadBalancer.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName(), limits...)
 				_ = n.forwardBGPSetupPrefixes()
 			}
 		})
 
+		limits := n.loadBalancerFlattenConnectionLimits(net.ParseIP(newLoadBalancer.ListenAddress), portMaps)
+		err = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(newLoadBalancer.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName(), limits...)
+		if err != nil {
+			return fmt.Errorf("Failed applying OVN load balancer connection limits: %w", err)
+		}
+
 		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 			lb := dbCluster.NetworkLoadBalancer{
 				NetworkID:     n.ID(),
@@ -6597,6 +6707,11 @@ func (n *ovn) LoadBalancerDelete(listenAddress string, clientType request.Client
 		}
 
 		// Delete the load balancer itself.
+		err = n.ovnnb.UpdateLoadBalancerConnectionLimits(context.TODO(), n.getLoadBalancerName(lb.ListenAddress), n.getIntSwitchName(), n.getIntSwitchRouterPortName())
+		if err != nil {
+			return fmt.Errorf("Failed deleting OVN load balancer connection limits: %w", err)
+		}
+
 		err = n.ovnnb.DeleteLoadBalancer(context.TODO(), n.getLoadBalancerName(lb.ListenAddress))
 		if err != nil {
 			return fmt.Errorf("Failed deleting OVN load balancer: %w", err)
@@ -6902,6 +7017,18 @@ func (n *ovn) remotePeerCreate(peer api.NetworkPeersPost) error {
 		return fmt.Errorf("Failed to load network integration %q: %w", peer.TargetIntegration, err)
 	}
 
+	// The router addresses on the VLAN are only used by bridged VLAN gateway integrations.
+	if integration.Type != "vlan" && (peer.Config["vlan.ipv4.address"] != "" || peer.Config["vlan.ipv6.address"] != "") {
+		return fmt.Errorf("VLAN addresses can't be used with integrations of type %q", integration.Type)
+	}
+
+	switch integration.Type {
+	case "bgp":
+		return n.remoteBGPPeerCreate(peer)
+	case "vlan":
+		return n.remoteVLANPeerCreate(peer, integration)
+	}
+
 	// Get ICNB.
 	icnb, err := networkOVN.NewICNB(integration.Config["ovn.northbound_connection"], integration.Config["ovn.ca_cert"], integration.Config["ovn.client_cert"], integration.Config["ovn.client_key"])
 	if err != nil {
@@ -7045,8 +7172,365 @@ func (n *ovn) remotePeerCreate(peer api.NetworkPeersPost) error {
 	return nil
 }
 
+// getVLANPeerSwitchName returns the name of the logical switch connected to the VLAN of a bridged VLAN gateway peer.
+func (n *ovn) getVLANPeerSwitchName(peerName string) networkOVN.OVNSwitch {
+	return networkOVN.OVNSwitch(fmt.Sprintf("%s-ls-vlan-%s", n.getNetworkPrefix(), peerName))
+}
+
+// getVLANPeerRouterPortName returns the name of the logical router port connected to a bridged VLAN gateway peer.
+func (n *ovn) getVLANPeerRouterPortName(peerName string) networkOVN.OVNRouterPort {
+	return networkOVN.OVNRouterPort(fmt.Sprintf("%s-lrp-vlan-%s", n.getRouterName(), peerName))
+}
+
+// vlanPeerRouting returns the router port addresses and the static routes of a bridged VLAN gateway peer.
+func (n *ovn) vlanPeerRouting(peerName string, peerConfig map[string]string, integrationConfig map[string]string) ([]*net.IPNet, []networkOVN.OVNRouterRoute, error) {
+	routerAddresses := []*net.IPNet{}
+	gateways := map[uint]net.IP{}
+
+	for _, ipVersion := range []uint{4, 6} {
+		gatewayKey := fmt.Sprintf("vlan.ipv%d.gateway", ipVersion)
+		addressKey := fmt.Sprintf("vlan.ipv%d.address", ipVersion)
+
+		if integrationConfig[gatewayKey] == "" {
+			if peerConfig[addressKey] != "" {
+				return nil, nil, fmt.Errorf("The %q option requires the integration to have %q set", addressKey, gatewayKey)
+			}
+
+			continue
+		}
+
+		gatewayIP, gatewaySubnet, err := net.ParseCIDR(integrationConfig[gatewayKey])
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed parsing %q: %w", gatewayKey, err)
+		}
+
+		routerIP := net.ParseIP(peerConfig[addressKey])
+		if routerIP == nil {
+			return nil, nil, fmt.Errorf("The %q option is required", addressKey)
+		}
+
+		if !gatewaySubnet.Contains(routerIP) || routerIP.Equal(gatewayIP) {
+			return nil, nil, fmt.Errorf("The %q option must be an unused address of %q", addressKey, gatewaySubnet.String())
+		}
+
+		routerAddresses = append(routerAddresses, &net.IPNet{IP: routerIP, Mask: gatewaySubnet.Mask})
+		gateways[ipVersion] = gatewayIP
+	}
+
+	routes := []networkOVN.OVNRouterRoute{}
+	for _, route := range util.SplitNTrimSpace(integrationConfig["vlan.routes"], ",", -1, true) {
+		_, routeSubnet, err := net.ParseCIDR(route)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed parsing route %q: %w", route, err)
+		}
+
+		ipVersion := uint(6)
+		if routeSubnet.IP.To4() != nil {
+			ipVersion = 4
+		}
+
+		gatewayIP, ok := gateways[ipVersion]
+		if !ok {
+			return nil, nil, fmt.Errorf("No IPv%d gateway available for route %q", ipVersion, route)
+		}
+
+		routes = append(routes, networkOVN.OVNRouterRoute{
+			Prefix:  *routeSubnet,
+			NextHop: gatewayIP,
+			Port:    n.getVLANPeerRouterPortName(peerName),
+		})
+	}
+
+	return routerAddresses, routes, nil
+}
+
+// remoteVLANPeerCreate connects the network's router to a gateway on a VLAN of the uplink network.
+func (n *ovn) remoteVLANPeerCreate(peer api.NetworkPeersPost, integration *api.NetworkIntegration) error {
+	ctx := context.TODO()
+
+	if n.config["network"] == "none" {
+		return errors.New("Isolated OVN network cannot be peered with a VLAN gateway")
+	}
+
+	vlanID, err := strconv.ParseUint(integration.Config["vlan.id"], 10, 16)
+	if err != nil {
+		return fmt.Errorf("Invalid VLAN ID %q: %w", integration.Config["vlan.id"], err)
+	}
+
+	routerAddresses, routes, err := n.vlanPeerRouting(peer.Name, peer.Config, integration.Config)
+	if err != nil {
+		return err
+	}
+
+	routerPortName := n.getVLANPeerRouterPortName(peer.Name)
+
+	routerMAC, err := n.getRouterMAC()
+	if err != nil {
+		return err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Create the logical switch connected to the VLAN.
+	switchName := n.getVLANPeerSwitchName(peer.Name)
+	err = n.ovnnb.CreateLogicalSwitch(ctx, switchName, false)
+	if err != nil {
+		return fmt.Errorf("Failed adding VLAN switch: %w", err)
+	}
+
+	reverter.Add(func() { _ = n.ovnnb.DeleteLogicalSwitch(ctx, switchName) })
+
+	// Create the logical router port, scheduled on the same chassis as the uplink port.
+	err = n.ovnnb.CreateLogicalRouterPort(ctx, n.getRouterName(), routerPortName, routerMAC, n.getBridgeMTU(), routerAddresses, n.getChassisGroupName(), false)
+	if err != nil {
+		return fmt.Errorf("Failed adding VLAN router port: %w", err)
+	}
+
+	reverter.Add(func() { _ = n.ovnnb.DeleteLogicalRouterPort(ctx, n.getRouterName(), routerPortName) })
+
+	// Create the switch port and link it to the router port.
+	switchRouterPortName := networkOVN.OVNSwitchPort(fmt.Sprintf("%s-lsp-router", switchName))
+	err = n.ovnnb.CreateLogicalSwitchPort(ctx, switchName, switchRouterPortName, nil, false)
+	if err != nil {
+		return fmt.Errorf("Failed adding VLAN switch router port: %w", err)
+	}
+
+	err = n.ovnnb.UpdateLogicalSwitchPortLinkRouter(ctx, switchRouterPortName, routerPortName)
+	if err != nil {
+		return fmt.Errorf("Failed linking VLAN router port to VLAN switch port: %w", err)
+	}
+
+	// Create the switch port and link it to the VLAN of the uplink network.
+	switchProviderPortName := networkOVN.OVNSwitchPort(fmt.Sprintf("%s-lsp-provider", switchName))
+	err = n.ovnnb.CreateLogicalSwitchPort(ctx, switchName, switchProviderPortName, nil, false)
+	if err != nil {
+		return fmt.Errorf("Failed adding VLAN switch provider port: %w", err)
+	}
+
+	err = n.ovnnb.UpdateLogicalSwitchPortLinkProviderNetworkVLAN(ctx, switchProviderPortName, n.config["network"], uint16(vlanID))
+	if err != nil {
+		return fmt.Errorf("Failed linking VLAN switch provider port to uplink network: %w", err)
+	}
+
+	// Route the subnets behind the gateway.
+	if len(routes) > 0 {
+		err = n.ovnnb.CreateLogicalRouterRoute(ctx, n.getRouterName(), false, routes...)
+		if err != nil {
+			return fmt.Errorf("Failed adding VLAN gateway routes: %w", err)
+		}
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// remoteVLANPeerDelete disconnects the network's router from a bridged VLAN gateway.
+func (n *ovn) remoteVLANPeerDelete(peer *api.NetworkPeer, integration *api.NetworkIntegration) error {
+	ctx := context.TODO()
+
+	_, routes, err := n.vlanPeerRouting(peer.Name, peer.Config, integration.Config)
+	if err != nil {
+		return err
+	}
+
+	prefixes := make([]net.IPNet, 0, len(routes))
+	for _, route := range routes {
+		prefixes = append(prefixes, route.Prefix)
+	}
+
+	if len(prefixes) > 0 {
+		err = n.ovnnb.DeleteLogicalRouterRoute(ctx, n.getRouterName(), prefixes...)
+		if err != nil {
+			return fmt.Errorf("Failed deleting VLAN gateway routes: %w", err)
+		}
+	}
+
+	err = n.ovnnb.DeleteLogicalRouterPort(ctx, n.getRouterName(), n.getVLANPeerRouterPortName(peer.Name))
+	if err != nil {
+		return fmt.Errorf("Failed deleting VLAN router port: %w", err)
+	}
+
+	err = n.ovnnb.DeleteLogicalSwitch(ctx, n.getVLANPeerSwitchName(peer.Name))
+	if err != nil && !errors.Is(err, networkOVN.ErrNotFound) {
+		return fmt.Errorf("Failed deleting VLAN switch: %w", err)
+	}
+
+	return nil
+}
+
+// remoteBGPPeerCreate peers the network with an external BGP router from all cluster members.
+func (n *ovn) remoteBGPPeerCreate(peer api.NetworkPeersPost) error {
+	if n.config["network"] == "none" {
+		return errors.New("Isolated OVN network cannot be peered with a BGP router")
+	}
+
+	err := n.bgpIntegrationSetup()
+	if err != nil {
+		return err
+	}
+
+	// Notify all other members to add the BGP peer.
+	notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+	if err != nil {
+		return err
+	}
+
+	err = notifier(func(client incus.InstanceServer) error {
+		return client.UseProject(n.project).CreateNetworkPeer(n.name, peer)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ovnBGPIntegrationPeerFromConfig returns the BGP peer defined by the configuration of a BGP network integration.
+func ovnBGPIntegrationPeerFromConfig(config map[string]string) (ovnBGPIntegrationPeer, error) {
+	peer := ovnBGPIntegrationPeer{
+		address:  config["bgp.peer.address"],
+		password: config["bgp.peer.password"],
+	}
+
+	asn, err := strconv.ParseUint(config["bgp.peer.asn"], 10, 32)
+	if err != nil {
+		return peer, fmt.Errorf("Invalid BGP peer ASN %q: %w", config["bgp.peer.asn"], err)
+	}
+
+	peer.asn = uint32(asn)
+
+	if config["bgp.peer.holdtime"] != "" {
+		peer.holdTime, err = strconv.ParseUint(config["bgp.peer.holdtime"], 10, 32)
+		if err != nil {
+			return peer, fmt.Errorf("Invalid BGP peer hold time %q: %w", config["bgp.peer.holdtime"], err)
+		}
+	}
+
+	return peer, nil
+}
+
+// bgpIntegrationGetPeers returns the BGP peers of the BGP integrations the network is peered with.
+func (n *ovn) bgpIntegrationGetPeers() ([]ovnBGPIntegrationPeer, error) {
+	peers := []ovnBGPIntegrationPeer{}
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		netID := n.ID()
+		peerType := dbCluster.NetworkPeerTypeRemote
+		dbPeers, err := dbCluster.GetNetworkPeers(ctx, tx.Tx(), dbCluster.NetworkPeerFilter{NetworkID: &netID, Type: &peerType})
+		if err != nil {
+			return fmt.Errorf("Failed loading network peers: %w", err)
+		}
+
+		for _, dbPeer := range dbPeers {
+			if !dbPeer.TargetNetworkIntegrationID.Valid {
+				continue
+			}
+
+			integrationID := int(dbPeer.TargetNetworkIntegrationID.Int64)
+			integrations, err := dbCluster.GetNetworkIntegrations(ctx, tx.Tx(), dbCluster.NetworkIntegrationFilter{ID: &integrationID})
+			if err != nil {
+				return fmt.Errorf("Failed loading network integration: %w", err)
+			}
+
+			if len(integrations) != 1 || integrations[0].Type != dbCluster.NetworkIntegrationTypeBGP {
+				continue
+			}
+
+			config, err := dbCluster.GetNetworkIntegrationConfig(ctx, tx.Tx(), integrationID)
+			if err != nil {
+				return fmt.Errorf("Failed loading network integration configuration: %w", err)
+			}
+
+			peer, err := ovnBGPIntegrationPeerFromConfig(config)
+			if err != nil {
+				return err
+			}
+
+			if !slices.Contains(peers, peer) {
+				peers = append(peers, peer)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return peers, nil
+}
+
+// bgpIntegrationSetup refreshes the BGP peers of the BGP integrations the network is peered with.
+// The network's prefixes are exported to all BGP peers of the server.
+func (n *ovn) bgpIntegrationSetup() error {
+	newPeers, err := n.bgpIntegrationGetPeers()
+	if err != nil {
+		return err
+	}
+
+	ovnBGPIntegrationPeersMu.Lock()
+	defer ovnBGPIntegrationPeersMu.Unlock()
+
+	oldPeers := ovnBGPIntegrationPeers[n.id]
+
+	// Remove old peers.
+	for _, peer := range oldPeers {
+		if slices.Contains(newPeers, peer) {
+			continue
+		}
+
+		err := n.state.BGP.RemovePeer(net.ParseIP(peer.address))
+		if err != nil && !errors.Is(err, bgp.ErrPeerNotFound) {
+			return err
+		}
+	}
+
+	// Add new peers.
+	for _, peer := range newPeers {
+		if slices.Contains(oldPeers, peer) {
+			continue
+		}
+
+		err = n.state.BGP.AddPeer(net.ParseIP(peer.address), peer.asn, peer.password, peer.holdTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(newPeers) > 0 {
+		ovnBGPIntegrationPeers[n.id] = newPeers
+	} else {
+		delete(ovnBGPIntegrationPeers, n.id)
+	}
+
+	return nil
+}
+
+// bgpIntegrationClear removes the BGP peers of the BGP integrations the network is peered with.
+func (n *ovn) bgpIntegrationClear() error {
+	ovnBGPIntegrationPeersMu.Lock()
+	defer ovnBGPIntegrationPeersMu.Unlock()
+
+	for _, peer := range ovnBGPIntegrationPeers[n.id] {
+		err := n.state.BGP.RemovePeer(net.ParseIP(peer.address))
+		if err != nil && !errors.Is(err, bgp.ErrPeerNotFound) {
+			return err
+		}
+	}
+
+	delete(ovnBGPIntegrationPeers, n.id)
+
+	return nil
+}
+
 // PeerCreate creates a network peering.
-func (n *ovn) PeerCreate(peer api.NetworkPeersPost) error {
+func (n *ovn) PeerCreate(peer api.NetworkPeersPost, clientType request.ClientType) error {
+	// The peer has already been created by the notifying member, only refresh the local BGP peers.
+	if clientType == request.ClientTypeNotifier {
+		return n.bgpIntegrationSetup()
+	}
+
 	reverter := revert.New()
 	defer reverter.Fail()
 
@@ -7213,6 +7697,11 @@ func (n *ovn) PeerCreate(peer api.NetworkPeersPost) error {
 			return err
 		}
 
+		err = dbCluster.CreateNetworkPeerConfig(ctx, tx.Tx(), peerID, peer.Config)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -7411,6 +7900,13 @@ func (n *ovn) PeerUpdate(peerName string, req api.NetworkPeerPut) error {
 		return err
 	}
 
+	// The router addresses on the VLAN are applied when the peering is created.
+	for _, k := range []string{"vlan.ipv4.address", "vlan.ipv6.address"} {
+		if curPeer.Config[k] != req.Config[k] {
+			return fmt.Errorf("The %q option cannot be changed", k)
+		}
+	}
+
 	curPeerEtagHash, err := localUtil.EtagHash(curPeer.Etag())
 	if err != nil {
 		return err
@@ -7513,6 +8009,14 @@ func (n *ovn) remotePeerDelete(peer *api.NetworkPeer) error {
 		return fmt.Errorf("Failed to load network integration %q: %w", peer.TargetIntegration, err)
 	}
 
+	switch integration.Type {
+	case "bgp":
+		// The BGP peers are refreshed once the peer is removed from the database.
+		return nil
+	case "vlan":
+		return n.remoteVLANPeerDelete(peer, integration)
+	}
+
 	// Get ICNB.
 	icnb, err := networkOVN.NewICNB(integration.Config["ovn.northbound_connection"], integration.Config["ovn.ca_cert"], integration.Config["ovn.client_cert"], integration.Config["ovn.client_key"])
 	if err != nil {
@@ -7596,7 +8100,12 @@ func (n *ovn) remotePeerDelete(peer *api.NetworkPeer) error {
 }
 
 // PeerDelete deletes a network peering.
-func (n *ovn) PeerDelete(peerName string) error {
+func (n *ovn) PeerDelete(peerName string, clientType request.ClientType) error {
+	// The peer has already been deleted by the notifying member, only refresh the local BGP peers.
+	if clientType == request.ClientTypeNotifier {
+		return n.bgpIntegrationSetup()
+	}
+
 	var peerID int64
 	var peer *api.NetworkPeer
 
@@ -7671,6 +8180,26 @@ func (n *ovn) PeerDelete(peerName string) error {
 		return err
 	}
 
+	// Refresh the BGP peers of the integrations on all members.
+	if peer.Type == "remote" {
+		err = n.bgpIntegrationSetup()
+		if err != nil {
+			return err
+		}
+
+		notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		err = notifier(func(client incus.InstanceServer) error {
+			return client.UseProject(n.project).DeleteNetworkPeer(n.name, peerName)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,136 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	networkOVN "github.com/lxc/incus/v6/internal/server/network/ovn"
+)
+
+func Test_ovnBGPIntegrationPeerFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]string
+		want    ovnBGPIntegrationPeer
+		wantErr string
+	}{
+		{
+			name:   "Address and ASN",
+			config: map[string]string{"bgp.peer.address": "192.0.2.1", "bgp.peer.asn": "65000"},
+			want:   ovnBGPIntegrationPeer{address: "192.0.2.1", asn: 65000},
+		},
+		{
+			name:   "Password with separators and hold time",
+			config: map[string]string{"bgp.peer.address": "2001:db8::1", "bgp.peer.asn": "65001", "bgp.peer.password": "a,b,c", "bgp.peer.holdtime": "90"},
+			want:   ovnBGPIntegrationPeer{address: "2001:db8::1", asn: 65001, password: "a,b,c", holdTime: 90},
+		},
+		{
+			name:    "Invalid ASN",
+			config:  map[string]string{"bgp.peer.address": "192.0.2.1", "bgp.peer.asn": "foo"},
+			wantErr: `Invalid BGP peer ASN "foo"`,
+		},
+		{
+			name:    "Invalid hold time",
+			config:  map[string]string{"bgp.peer.address": "192.0.2.1", "bgp.peer.asn": "65000", "bgp.peer.holdtime": "-1"},
+			wantErr: `Invalid BGP peer hold time "-1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peer, err := ovnBGPIntegrationPeerFromConfig(tt.config)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, peer)
+		})
+	}
+}
+
+func Test_ovnVLANPeerRouting(t *testing.T) {
+	n := &ovn{common: common{id: 1}}
+	routerPort := networkOVN.OVNRouterPort("incus-net1-lr-lrp-vlan-gw")
+
+	tests := []struct {
+		name              string
+		peerConfig        map[string]string
+		integrationConfig map[string]string
+		wantAddresses     []string
+		wantRoutes        []networkOVN.OVNRouterRoute
+		wantErr           string
+	}{
+		{
+			name:              "Dual-stack with routes",
+			peerConfig:        map[string]string{"vlan.ipv4.address": "192.0.2.10", "vlan.ipv6.address": "2001:db8::10"},
+			integrationConfig: map[string]string{"vlan.ipv4.gateway": "192.0.2.1/24", "vlan.ipv6.gateway": "2001:db8::1/64", "vlan.routes": "198.51.100.0/24, 2001:db8:1::/48"},
+			wantAddresses:     []string{"192.0.2.10/24", "2001:db8::10/64"},
+			wantRoutes: []networkOVN.OVNRouterRoute{
+				{Prefix: net.IPNet{IP: net.ParseIP("198.51.100.0").To4(), Mask: net.CIDRMask(24, 32)}, NextHop: net.ParseIP("192.0.2.1"), Port: routerPort},
+				{Prefix: net.IPNet{IP: net.ParseIP("2001:db8:1::"), Mask: net.CIDRMask(48, 128)}, NextHop: net.ParseIP("2001:db8::1"), Port: routerPort},
+			},
+		},
+		{
+			name:              "IPv4 only",
+			peerConfig:        map[string]string{"vlan.ipv4.address": "192.0.2.10"},
+			integrationConfig: map[string]string{"vlan.ipv4.gateway": "192.0.2.1/24"},
+			wantAddresses:     []string{"192.0.2.10/24"},
+			wantRoutes:        []networkOVN.OVNRouterRoute{},
+		},
+		{
+			name:              "Missing address",
+			peerConfig:        map[string]string{},
+			integrationConfig: map[string]string{"vlan.ipv4.gateway": "192.0.2.1/24"},
+			wantErr:           `The "vlan.ipv4.address" option is required`,
+		},
+		{
+			name:              "Address without gateway",
+			peerConfig:        map[string]string{"vlan.ipv4.address": "192.0.2.10", "vlan.ipv6.address": "2001:db8::10"},
+			integrationConfig: map[string]string{"vlan.ipv4.gateway": "192.0.2.1/24"},
+			wantErr:           `The "vlan.ipv6.address" option requires the integration to have "vlan.ipv6.gateway" set`,
+		},
+		{
+			name:              "Address outside of the gateway subnet",
+			peerConfig:        map[string]string{"vlan.ipv4.address": "198.51.100.10"},
+			integrationConfig: map[string]string{"vlan.ipv4.gateway": "192.0.2.1/24"},
+			wantErr:           `The "vlan.ipv4.address" option must be an unused address of "192.0.2.0/24"`,
+		},
+		{
+			name:              "Gateway address",
+			peerConfig:        map[string]string{"vlan.ipv4.address": "192.0.2.1"},
+			integrationConfig: map[string]string{"vlan.ipv4.gateway": "192.0.2.1/24"},
+			wantErr:           `The "vlan.ipv4.address" option must be an unused address of "192.0.2.0/24"`,
+		},
+		{
+			name:              "Route without gateway",
+			peerConfig:        map[string]string{"vlan.ipv4.address": "192.0.2.10"},
+			integrationConfig: map[string]string{"vlan.ipv4.gateway": "192.0.2.1/24", "vlan.routes": "2001:db8:1::/48"},
+			wantErr:           `No IPv6 gateway available for route "2001:db8:1::/48"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addresses, routes, err := n.vlanPeerRouting("gw", tt.peerConfig, tt.integrationConfig)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			addressStrings := []string{}
+			for _, address := range addresses {
+				addressStrings = append(addressStrings, address.String())
+			}
+
+			assert.Equal(t, tt.wantAddresses, addressStrings)
+			assert.Equal(t, tt.wantRoutes, routes)
+		})
+	}
+}
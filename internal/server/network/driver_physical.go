@@ -146,6 +146,16 @@ func (n *physical) Validate(config map[string]string, clientType request.ClientT
 		// shortdesc: Allow the overlapping routes to be used on multiple networks/NIC at the same time
 		"ipv6.routes.anycast": validate.Optional(validate.IsBool),
 
+		// gendoc:generate(entity=network_physical, group=ipv6, key=ipv6.delegation)
+		//
+		// ---
+		// type: bool
+		// condition: standard mode
+		// defaultdesc: `false`
+		// shortdesc: Whether to request a delegated IPv6 prefix (using DHCPv6) on the parent interface
+		"ipv6.delegation":              validate.Optional(validate.IsBool),
+		networkVolatileDelegatedPrefix: validate.Optional(validate.IsNetworkV6),
+
 		// gendoc:generate(entity=network_physical, group=dns, key=dns.nameservers)
 		//
 		// ---
@@ -212,6 +222,10 @@ func (n *physical) Validate(config map[string]string, clientType request.ClientT
 		return err
 	}
 
+	if util.IsTrue(config["ipv6.delegation"]) && config["parent"] == "none" {
+		return errors.New(`"ipv6.delegation" requires a parent interface`)
+	}
+
 	return nil
 }
 
@@ -371,6 +385,8 @@ func (n *physical) setup(oldConfig map[string]string) error {
 		return err
 	}
 
+	n.prefixDelegationSetup()
+
 	reverter.Success()
 	return nil
 }
@@ -379,6 +395,8 @@ func (n *physical) setup(oldConfig map[string]string) error {
 func (n *physical) Stop() error {
 	n.logger.Debug("Stop")
 
+	prefixDelegationUnsubscribe(n.id)
+
 	// Clear BGP.
 	err := n.bgpClear(n.config)
 	if err != nil {
@@ -443,6 +461,11 @@ func (n *physical) Update(newNetwork api.NetworkPut, targetNode string, clientTy
 
 	hostNameChanged := slices.Contains(changedKeys, "vlan") || slices.Contains(changedKeys, "parent")
 
+	// Forget the delegated prefix when no longer requesting one.
+	if util.IsFalseOrEmpty(newNetwork.Config["ipv6.delegation"]) {
+		delete(newNetwork.Config, networkVolatileDelegatedPrefix)
+	}
+
 	// We only need to check in the database once, not on every clustered node.
 	if clientType == request.ClientTypeNormal {
 		if hostNameChanged {
@@ -545,3 +568,63 @@ func (n *physical) DHCPv6Subnet() *net.IPNet {
 
 	return subnet
 }
+
+// prefixDelegationSetup follows the prefix delegated on the parent interface (if enabled).
+func (n *physical) prefixDelegationSetup() {
+	if util.IsFalseOrEmpty(n.config["ipv6.delegation"]) {
+		prefixDelegationUnsubscribe(n.id)
+		return
+	}
+
+	prefix := prefixDelegationSubscribe(GetHostDevice(n.config["parent"], n.config["vlan"]), n.id, n.prefixDelegationChanged)
+	if prefix != nil {
+		// Apply the current prefix in the background as it may require renumbering instances.
+		go n.prefixDelegationChanged(prefix)
+	}
+}
+
+// prefixDelegationChanged records the prefix delegated on the parent interface and renumbers the instance NICs
+// which were using the previous one. The last prefix is kept when it's lost so that the instances can be
+// renumbered once a new prefix gets delegated.
+func (n *physical) prefixDelegationChanged(prefix *net.IPNet) {
+	if prefix == nil {
+		return
+	}
+
+	// Reload the network in case its configuration changed since subscribing.
+	network, err := LoadByName(n.state, n.project, n.name)
+	if err != nil {
+		n.logger.Warn("Failed loading network to apply delegated prefix", logger.Ctx{"err": err})
+		return
+	}
+
+	physicalNet, ok := network.(*physical)
+	if !ok || util.IsFalseOrEmpty(physicalNet.config["ipv6.delegation"]) || physicalNet.config[networkVolatileDelegatedPrefix] == prefix.String() {
+		return
+	}
+
+	_, oldPrefix, _ := net.ParseCIDR(physicalNet.config[networkVolatileDelegatedPrefix])
+
+	n.logger.Info("Recording delegated prefix", logger.Ctx{"prefix": prefix.String()})
+
+	err = physicalNet.prefixDelegationApply(oldPrefix, prefix)
+	prefixDelegationReport(n.state, n.logger, n.project, n.id, err)
+}
+
+// prefixDelegationApply records the delegated prefix and renumbers the instance NICs which were using the previous one.
+func (n *physical) prefixDelegationApply(oldPrefix *net.IPNet, newPrefix *net.IPNet) error {
+	// The volatile key is specific to the local cluster member, so only its config gets updated.
+	n.config[networkVolatileDelegatedPrefix] = newPrefix.String()
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateNetwork(ctx, n.project, n.name, n.description, n.config)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed saving delegated prefix: %w", err)
+	}
+
+	if oldPrefix == nil {
+		return nil
+	}
+
+	return prefixDelegationRenumberInstances(n.state, oldPrefix, newPrefix)
+}
@@ -0,0 +1,690 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/lxc/incus/v6/internal/server/bgp"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/cluster/request"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/ip"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// vxlanMTUDefault is the default MTU of VXLAN networks, leaving room for the encapsulation overhead.
+const vxlanMTUDefault = 1450
+
+// vxlanPortDefault is the default VXLAN UDP port.
+const vxlanPortDefault = 4789
+
+// vxlanClusterPeers holds the cluster member addresses peered with for each running VXLAN network.
+var vxlanClusterPeers = map[int64][]string{}
+
+var vxlanClusterPeersMu sync.Mutex
+
+// EVPNNetwork is implemented by networks relying on BGP EVPN to distribute the location of the NICs
+// connected to them.
+type EVPNNetwork interface {
+	EVPNAdvertiseNIC(owner string, mac net.HardwareAddr, ips []net.IP) error
+}
+
+// vxlan represents a VXLAN network using BGP EVPN as its control plane.
+type vxlan struct {
+	common
+}
+
+// DBType returns the network type DB ID.
+func (n *vxlan) DBType() db.NetworkType {
+	return db.NetworkTypeVXLAN
+}
+
+// ValidateName validates network name.
+func (n *vxlan) ValidateName(name string) error {
+	err := validate.IsInterfaceName(name)
+	if err != nil {
+		return err
+	}
+
+	// Apply common name validation that applies to all network types.
+	return n.common.ValidateName(name)
+}
+
+// Validate network config.
+func (n *vxlan) Validate(config map[string]string, clientType request.ClientType) error {
+	rules := map[string]func(value string) error{
+		// gendoc:generate(entity=network_vxlan, group=common, key=vxlan.id)
+		//
+		// ---
+		//  type: integer
+		//  condition: -
+		//  shortdesc: VXLAN network identifier (VNI), shared by all cluster members
+		"vxlan.id": validate.Required(validate.IsInRange(1, 16777215)),
+
+		// gendoc:generate(entity=network_vxlan, group=common, key=vxlan.port)
+		//
+		// ---
+		//  type: integer
+		//  condition: -
+		//  default: `4789`
+		//  shortdesc: UDP port used for the VXLAN traffic
+		"vxlan.port": validate.Optional(validate.IsNetworkPort),
+
+		// gendoc:generate(entity=network_vxlan, group=common, key=vxlan.local)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  default: `core.bgp_routerid`
+		//  shortdesc: Local tunnel endpoint (VTEP) address (can be specified per cluster member)
+		"vxlan.local": validate.Optional(validate.IsNetworkAddress),
+
+		// gendoc:generate(entity=network_vxlan, group=common, key=vxlan.interface)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  shortdesc: Interface to send the VXLAN traffic through (can be specified per cluster member)
+		"vxlan.interface": validate.Optional(validate.IsInterfaceName),
+
+		// gendoc:generate(entity=network_vxlan, group=common, key=mtu)
+		//
+		// ---
+		//  type: integer
+		//  condition: -
+		//  default: `1450`
+		//  shortdesc: MTU of the network
+		"mtu": validate.Optional(validate.IsNetworkMTU),
+
+		// gendoc:generate(entity=network_vxlan, group=common, key=user.*)
+		//
+		// ---
+		//  type: string
+		//  shortdesc: User-provided free-form key/value pairs
+	}
+
+	// gendoc:generate(entity=network_vxlan, group=bgp, key=bgp.peers.NAME.address)
+	//
+	// ---
+	//  type: string
+	//  condition: BGP server
+	//  shortdesc: Peer address (IPv4 or IPv6), in addition to the other cluster members
+
+	// gendoc:generate(entity=network_vxlan, group=bgp, key=bgp.peers.NAME.asn)
+	//
+	// ---
+	//  type: integer
+	//  condition: BGP server
+	//  shortdesc: Peer AS number
+
+	// gendoc:generate(entity=network_vxlan, group=bgp, key=bgp.peers.NAME.password)
+	//
+	// ---
+	//  type: string
+	//  condition: BGP server
+	//  default: - (no password)
+	//  shortdesc: Peer session password (optional)
+
+	// gendoc:generate(entity=network_vxlan, group=bgp, key=bgp.peers.NAME.holdtime)
+	//
+	// ---
+	//  type: integer
+	//  condition: BGP server
+	//  default: `180`
+	//  shortdesc: Peer session hold time (in seconds; optional)
+	bgpRules, err := n.bgpValidationRules(config)
+	if err != nil {
+		return err
+	}
+
+	maps.Copy(rules, bgpRules)
+
+	err = n.validate(config, rules)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isRunning returns whether the network is up.
+func (n *vxlan) isRunning() bool {
+	return InterfaceExists(n.name)
+}
+
+// Delete deletes a network.
+func (n *vxlan) Delete(clientType request.ClientType) error {
+	n.logger.Debug("Delete", logger.Ctx{"clientType": clientType})
+
+	if n.isRunning() {
+		err := n.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	return n.delete(clientType)
+}
+
+// Rename renames a network.
+func (n *vxlan) Rename(newName string) error {
+	n.logger.Debug("Rename", logger.Ctx{"newName": newName})
+
+	if InterfaceExists(newName) {
+		return fmt.Errorf("Network interface %q already exists", newName)
+	}
+
+	// Bring the network down.
+	if n.isRunning() {
+		err := n.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Rename common steps.
+	err := n.rename(newName)
+	if err != nil {
+		return err
+	}
+
+	// Bring the network up.
+	err = n.Start()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// vni returns the VXLAN network identifier.
+func (n *vxlan) vni() uint32 {
+	vni, _ := strconv.ParseUint(n.config["vxlan.id"], 10, 32)
+
+	return uint32(vni)
+}
+
+// vxlanDevice returns the name of the VXLAN device connected to the network bridge.
+func (n *vxlan) vxlanDevice() string {
+	return fmt.Sprintf("vxlan%d", n.vni())
+}
+
+// evpnOwner returns the owner of the EVPN routes of the network itself.
+func (n *vxlan) evpnOwner() string {
+	return fmt.Sprintf("network_%d_evpn", n.id)
+}
+
+// vtepAddress returns the address of the local tunnel endpoint.
+func (n *vxlan) vtepAddress() (net.IP, error) {
+	address := n.config["vxlan.local"]
+	if address == "" {
+		address = n.state.LocalConfig.BGPRouterID()
+	}
+
+	vtep := net.ParseIP(address)
+	if vtep == nil {
+		return nil, errors.New("No local tunnel endpoint address, set either vxlan.local or core.bgp_routerid")
+	}
+
+	return vtep, nil
+}
+
+// Start starts the network.
+func (n *vxlan) Start() error {
+	n.logger.Debug("Start")
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() { n.setUnavailable() })
+
+	err := n.setup()
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	// Ensure network is marked as available now its started.
+	n.setAvailable()
+
+	return nil
+}
+
+// setup creates the bridge and VXLAN devices, announces the local tunnel endpoint and peers with the other
+// cluster members.
+func (n *vxlan) setup() error {
+	n.logger.Debug("Setting up network")
+
+	if n.state.LocalConfig.BGPAddress() == "" || n.state.GlobalConfig.BGPASN() == 0 {
+		return errors.New("VXLAN networks require the BGP server to be configured (core.bgp_address and core.bgp_asn)")
+	}
+
+	vtep, err := n.vtepAddress()
+	if err != nil {
+		return err
+	}
+
+	if n.config["vxlan.interface"] != "" && !InterfaceExists(n.config["vxlan.interface"]) {
+		return fmt.Errorf("Interface %q not found", n.config["vxlan.interface"])
+	}
+
+	mtu := uint32(vxlanMTUDefault)
+	if n.config["mtu"] != "" {
+		mtuInt, err := strconv.ParseUint(n.config["mtu"], 10, 32)
+		if err != nil {
+			return fmt.Errorf("Invalid MTU %q: %w", n.config["mtu"], err)
+		}
+
+		mtu = uint32(mtuInt)
+	}
+
+	port := vxlanPortDefault
+	if n.config["vxlan.port"] != "" {
+		port, err = strconv.Atoi(n.config["vxlan.port"])
+		if err != nil {
+			return fmt.Errorf("Invalid port %q: %w", n.config["vxlan.port"], err)
+		}
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Create the bridge.
+	if !InterfaceExists(n.name) {
+		bridge := &ip.Bridge{Link: ip.Link{Name: n.name, MTU: mtu}}
+		err = bridge.Add()
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = bridge.Delete() })
+	} else {
+		bridge := &ip.Link{Name: n.name}
+		err = bridge.SetMTU(mtu)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Re-create the VXLAN device so it reflects the current configuration.
+	vxlanName := n.vxlanDevice()
+	if InterfaceExists(vxlanName) {
+		info, err := ip.LinkByName(vxlanName)
+		if err != nil {
+			return err
+		}
+
+		if info.Master != n.name {
+			return fmt.Errorf("Interface %q is already in use", vxlanName)
+		}
+
+		err = info.Delete()
+		if err != nil {
+			return err
+		}
+	}
+
+	vxlanLink := &ip.Vxlan{
+		Link:    ip.Link{Name: vxlanName, MTU: mtu, Master: n.name},
+		VxlanID: int(n.vni()),
+		DevName: n.config["vxlan.interface"],
+		Local:   vtep,
+		DstPort: port,
+	}
+
+	err = vxlanLink.Add()
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = vxlanLink.Delete() })
+
+	// Forwarding is driven by the EVPN routes rather than by learning from the received traffic.
+	err = vxlanLink.BridgeLinkSetLearning(false)
+	if err != nil {
+		return err
+	}
+
+	err = vxlanLink.SetUp()
+	if err != nil {
+		return err
+	}
+
+	err = (&ip.Link{Name: n.name}).SetUp()
+	if err != nil {
+		return err
+	}
+
+	// Announce the local tunnel endpoint.
+	err = n.state.BGP.AddEVPNInclusiveMulticast(n.vni(), vtep, n.evpnOwner())
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = n.state.BGP.RemoveEVPNByOwner(n.evpnOwner()) })
+
+	// Peer with the other cluster members and any additional peers.
+	var members []db.NodeInfo
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		members, err = tx.GetNodes(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	addresses := make([]string, 0, len(members))
+	for _, member := range members {
+		addresses = append(addresses, member.Address)
+	}
+
+	err = n.setupClusterPeers(addresses)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = n.clearClusterPeers() })
+
+	err = n.bgpSetupPeers(nil)
+	if err != nil {
+		return fmt.Errorf("Failed setting up BGP peers: %w", err)
+	}
+
+	// Keep the forwarding database in sync with the EVPN routes.
+	n.state.BGP.WatchEVPN(n.evpnOwner(), func() {
+		err := n.syncFDB()
+		if err != nil {
+			n.logger.Warn("Failed synchronizing forwarding database with EVPN routes", logger.Ctx{"err": err})
+		}
+	})
+
+	err = n.syncFDB()
+	if err != nil {
+		n.state.BGP.UnwatchEVPN(n.evpnOwner())
+		return err
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// setupClusterPeers updates the BGP peers of the network to match the given cluster member addresses,
+// skipping the local member.
+func (n *vxlan) setupClusterPeers(addresses []string) error {
+	vxlanClusterPeersMu.Lock()
+	defer vxlanClusterPeersMu.Unlock()
+
+	localAddress := n.state.LocalConfig.ClusterAddress()
+	asn := uint32(n.state.GlobalConfig.BGPASN())
+
+	newPeers := []string{}
+	for _, address := range addresses {
+		if address == localAddress {
+			continue
+		}
+
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+
+		peerAddress := net.ParseIP(host)
+		if peerAddress == nil || peerAddress.IsUnspecified() {
+			continue
+		}
+
+		newPeers = append(newPeers, peerAddress.String())
+	}
+
+	oldPeers := vxlanClusterPeers[n.id]
+
+	// Remove old peers.
+	for _, peer := range oldPeers {
+		if slices.Contains(newPeers, peer) {
+			continue
+		}
+
+		err := n.state.BGP.RemovePeer(net.ParseIP(peer))
+		if err != nil && !errors.Is(err, bgp.ErrPeerNotFound) {
+			return err
+		}
+	}
+
+	// Add new peers.
+	for _, peer := range newPeers {
+		if slices.Contains(oldPeers, peer) {
+			continue
+		}
+
+		err := n.state.BGP.AddEVPNPeer(net.ParseIP(peer), asn, "", 0)
+		if err != nil {
+			return fmt.Errorf("Failed adding cluster member %q as BGP peer: %w", peer, err)
+		}
+	}
+
+	vxlanClusterPeers[n.id] = newPeers
+
+	return nil
+}
+
+// clearClusterPeers removes the BGP peers of the network towards the other cluster members.
+func (n *vxlan) clearClusterPeers() error {
+	vxlanClusterPeersMu.Lock()
+	defer vxlanClusterPeersMu.Unlock()
+
+	for _, peer := range vxlanClusterPeers[n.id] {
+		err := n.state.BGP.RemovePeer(net.ParseIP(peer))
+		if err != nil && !errors.Is(err, bgp.ErrPeerNotFound) {
+			return err
+		}
+	}
+
+	delete(vxlanClusterPeers, n.id)
+
+	return nil
+}
+
+// syncFDB updates the forwarding database of the VXLAN device to match the EVPN routes received from peers.
+// Inclusive multicast routes result in flooding entries (all-zero MAC address) towards each remote VTEP
+// and MAC/IP routes in unicast entries towards the VTEP hosting the MAC address.
+func (n *vxlan) syncFDB() error {
+	routes, err := n.state.BGP.EVPNRoutes(n.vni())
+	if err != nil {
+		return err
+	}
+
+	fdbKey := func(mac net.HardwareAddr, dst net.IP) string {
+		return mac.String() + "/" + dst.String()
+	}
+
+	wanted := map[string]ip.FDB{}
+	for _, route := range routes {
+		mac := route.MAC
+		if mac == nil {
+			mac = net.HardwareAddr{0, 0, 0, 0, 0, 0}
+		}
+
+		wanted[fdbKey(mac, route.VTEP)] = ip.FDB{DevName: n.vxlanDevice(), MAC: mac, Dst: route.VTEP}
+	}
+
+	fdb := &ip.FDB{DevName: n.vxlanDevice()}
+	entries, err := fdb.Show()
+	if err != nil {
+		return err
+	}
+
+	// Remove stale entries.
+	for _, entry := range entries {
+		// Skip entries which aren't pointing at a remote VTEP.
+		if entry.Dst == nil {
+			continue
+		}
+
+		key := fdbKey(entry.MAC, entry.Dst)
+		_, ok := wanted[key]
+		if ok {
+			delete(wanted, key)
+			continue
+		}
+
+		err = entry.Delete()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Add missing entries.
+	for _, entry := range wanted {
+		err = entry.Append()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the network.
+func (n *vxlan) Stop() error {
+	n.logger.Debug("Stop")
+
+	n.state.BGP.UnwatchEVPN(n.evpnOwner())
+
+	err := n.state.BGP.RemoveEVPNByOwner(n.evpnOwner())
+	if err != nil {
+		return err
+	}
+
+	err = n.clearClusterPeers()
+	if err != nil {
+		return err
+	}
+
+	err = n.bgpClearPeers(n.config)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{n.vxlanDevice(), n.name} {
+		if !InterfaceExists(name) {
+			continue
+		}
+
+		err = (&ip.Link{Name: name}).Delete()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates the network. Accepts notification boolean indicating if this update request is coming from a
+// cluster notification, in which case do not update the database, just apply local changes needed.
+func (n *vxlan) Update(newNetwork api.NetworkPut, targetNode string, clientType request.ClientType) error {
+	n.logger.Debug("Update", logger.Ctx{"clientType": clientType, "newNetwork": newNetwork})
+
+	dbUpdateNeeded, changedKeys, oldNetwork, err := n.configChanged(newNetwork)
+	if err != nil {
+		return err
+	}
+
+	if !dbUpdateNeeded {
+		return nil // Nothing changed.
+	}
+
+	// If the network as a whole has not had any previous creation attempts, or the node itself is still
+	// pending, then don't apply the new settings to the node, just to the database record (ready for the
+	// actual global create request to be initiated).
+	if n.Status() == api.NetworkStatusPending || n.LocalStatus() == api.NetworkStatusPending {
+		return n.update(newNetwork, targetNode, clientType)
+	}
+
+	// Any configuration change requires re-creating the devices and routes.
+	restart := len(changedKeys) > 0 && n.isRunning()
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Define a function which reverts everything.
+	reverter.Add(func() {
+		// Reset changes to all nodes and database.
+		_ = n.update(oldNetwork, targetNode, clientType)
+
+		if restart {
+			_ = n.Start()
+		}
+	})
+
+	// Bring the network down using the old config before applying the changes.
+	if restart {
+		err = n.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Apply changes to all nodes and database.
+	err = n.update(newNetwork, targetNode, clientType)
+	if err != nil {
+		return err
+	}
+
+	if restart {
+		err = n.Start()
+		if err != nil {
+			return err
+		}
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// HandleHeartbeat updates the BGP peers of the network when the cluster members change.
+func (n *vxlan) HandleHeartbeat(heartbeatData *cluster.APIHeartbeat) error {
+	if !n.isRunning() {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(heartbeatData.Members))
+	for _, member := range heartbeatData.Members {
+		addresses = append(addresses, member.Address)
+	}
+
+	return n.setupClusterPeers(addresses)
+}
+
+// EVPNAdvertiseNIC advertises the MAC address (and IP addresses) of a NIC connected to the network on
+// the local tunnel endpoint.
+func (n *vxlan) EVPNAdvertiseNIC(owner string, mac net.HardwareAddr, ips []net.IP) error {
+	vtep, err := n.vtepAddress()
+	if err != nil {
+		return err
+	}
+
+	if len(ips) == 0 {
+		return n.state.BGP.AddEVPNMACIP(n.vni(), mac, nil, vtep, owner)
+	}
+
+	for _, address := range ips {
+		err = n.state.BGP.AddEVPNMACIP(n.vni(), mac, address, vtep, owner)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,1072 @@
+package network
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/cluster/request"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/ip"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// wireguardPortDefault is the default WireGuard UDP port.
+const wireguardPortDefault = 51820
+
+// wireguardMTUDefault is the default MTU of WireGuard networks, leaving room for the encapsulation overhead.
+const wireguardMTUDefault = 1420
+
+// wireguardKeepaliveDefault is the default interval (in seconds) of the keepalive packets sent to peers.
+const wireguardKeepaliveDefault = 25
+
+// KeyRotationNetwork is implemented by networks holding keys which can be replaced on demand.
+type KeyRotationNetwork interface {
+	RotateKeys(clientType request.ClientType) error
+}
+
+// InstanceRoutesNetwork is implemented by networks routing the addresses of the instance NICs using them as uplink.
+type InstanceRoutesNetwork interface {
+	RefreshInstanceRoutes() error
+}
+
+// WireguardBridgeName returns the name of the bridge that bridged NICs using the WireGuard network connect to.
+func WireguardBridgeName(networkID int64) string {
+	return fmt.Sprintf("incuswg%d", networkID)
+}
+
+// wireguard represents a WireGuard network.
+type wireguard struct {
+	common
+}
+
+// wireguardPeer represents a WireGuard peer along with its name.
+type wireguardPeer struct {
+	ip.WireguardPeer
+	name string
+}
+
+// DBType returns the network type DB ID.
+func (n *wireguard) DBType() db.NetworkType {
+	return db.NetworkTypeWireguard
+}
+
+// ValidateName validates network name.
+func (n *wireguard) ValidateName(name string) error {
+	err := validate.IsInterfaceName(name)
+	if err != nil {
+		return err
+	}
+
+	// Apply common name validation that applies to all network types.
+	return n.common.ValidateName(name)
+}
+
+// Validate network config.
+func (n *wireguard) Validate(config map[string]string, clientType request.ClientType) error {
+	rules := map[string]func(value string) error{
+		// gendoc:generate(entity=network_wireguard, group=common, key=wireguard.port)
+		//
+		// ---
+		//  type: integer
+		//  condition: -
+		//  default: `51820`
+		//  shortdesc: UDP port used by WireGuard on all cluster members
+		"wireguard.port": validate.Optional(validate.IsNetworkPort),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=wireguard.keepalive)
+		//
+		// ---
+		//  type: integer
+		//  condition: -
+		//  default: `25`
+		//  shortdesc: Interval in seconds between keepalive packets sent to peers (`0` to disable)
+		"wireguard.keepalive": validate.Optional(validate.IsInRange(0, 65535)),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=wireguard.ipv4.address)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  shortdesc: IPv4 address of the cluster member on the network, in CIDR notation (can be specified per cluster member)
+		"wireguard.ipv4.address": validate.Optional(validate.IsNetworkAddressCIDRV4),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=wireguard.ipv6.address)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  shortdesc: IPv6 address of the cluster member on the network, in CIDR notation (can be specified per cluster member)
+		"wireguard.ipv6.address": validate.Optional(validate.IsNetworkAddressCIDRV6),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=wireguard.routes)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  shortdesc: Comma-separated list of subnets routed to the cluster member by the other members (can be specified per cluster member)
+		"wireguard.routes": validate.Optional(validate.IsListOf(validate.IsNetwork)),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=bridge.ipv4.address)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  shortdesc: IPv4 address of the bridge for bridged NICs on the cluster member, in CIDR notation (can be specified per cluster member)
+		"bridge.ipv4.address": validate.Optional(validate.IsNetworkAddressCIDRV4),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=bridge.ipv6.address)
+		//
+		// ---
+		//  type: string
+		//  condition: -
+		//  shortdesc: IPv6 address of the bridge for bridged NICs on the cluster member, in CIDR notation (can be specified per cluster member)
+		"bridge.ipv6.address": validate.Optional(validate.IsNetworkAddressCIDRV6),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=mtu)
+		//
+		// ---
+		//  type: integer
+		//  condition: -
+		//  default: `1420`
+		//  shortdesc: MTU of the network
+		"mtu": validate.Optional(validate.IsNetworkMTU),
+
+		// gendoc:generate(entity=network_wireguard, group=common, key=user.*)
+		//
+		// ---
+		//  type: string
+		//  shortdesc: User-provided free-form key/value pairs
+	}
+
+	// gendoc:generate(entity=network_wireguard, group=peers, key=peers.NAME.public_key)
+	//
+	// ---
+	//  type: string
+	//  condition: -
+	//  shortdesc: Public key of the external peer
+
+	// gendoc:generate(entity=network_wireguard, group=peers, key=peers.NAME.endpoint)
+	//
+	// ---
+	//  type: string
+	//  condition: -
+	//  default: - (peer connects to the cluster members)
+	//  shortdesc: Address and port of the external peer
+
+	// gendoc:generate(entity=network_wireguard, group=peers, key=peers.NAME.allowed_ips)
+	//
+	// ---
+	//  type: string
+	//  condition: -
+	//  shortdesc: Comma-separated list of addresses and subnets routed to the external peer
+	peerNames := []string{}
+	for k := range config {
+		if !strings.HasPrefix(k, "peers.") {
+			continue
+		}
+
+		// Validate peer name in key.
+		fields := strings.Split(k, ".")
+		if len(fields) != 3 {
+			return fmt.Errorf("Invalid network configuration key: %q", k)
+		}
+
+		switch fields[2] {
+		case "public_key":
+			rules[k] = validate.Required(wireguardValidateKey)
+			peerNames = append(peerNames, fields[1])
+		case "endpoint":
+			rules[k] = validate.Optional(validate.IsListenAddress(true, false, true))
+		case "allowed_ips":
+			rules[k] = validate.Optional(validate.IsListOf(validate.IsNetwork))
+		}
+	}
+
+	err := n.validate(config, rules)
+	if err != nil {
+		return err
+	}
+
+	// Check that all peers have a public key.
+	for k := range config {
+		if !strings.HasPrefix(k, "peers.") {
+			continue
+		}
+
+		peerName := strings.Split(k, ".")[1]
+		if !slices.Contains(peerNames, peerName) {
+			return fmt.Errorf("Missing public key for peer %q", peerName)
+		}
+	}
+
+	return nil
+}
+
+// wireguardValidateKey checks that the value is a base64 encoded WireGuard key.
+func wireguardValidateKey(value string) error {
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(key) != 32 {
+		return errors.New("Invalid WireGuard key")
+	}
+
+	return nil
+}
+
+// Create generates the WireGuard keys of the local member.
+func (n *wireguard) Create(clientType request.ClientType) error {
+	n.logger.Debug("Create", logger.Ctx{"clientType": clientType, "config": n.config})
+
+	// Joining members don't have access to the cluster database yet, their keys are generated once the
+	// network is started after the join.
+	if clientType == request.ClientTypeJoiner {
+		return nil
+	}
+
+	return n.ensureKeyPair()
+}
+
+// ensureKeyPair generates and records the WireGuard keys of the local member if missing.
+func (n *wireguard) ensureKeyPair() error {
+	localMemberID := n.state.DB.Cluster.GetNodeID()
+
+	return n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		keyPairs, err := dbCluster.GetNetworkWireguardKeyPairs(ctx, tx.Tx(), dbCluster.NetworkWireguardKeyPairFilter{NetworkID: &n.id})
+		if err != nil {
+			return fmt.Errorf("Failed getting WireGuard keys: %w", err)
+		}
+
+		for _, keyPair := range keyPairs {
+			if keyPair.NodeID == localMemberID {
+				return nil
+			}
+		}
+
+		privateKey, publicKey, err := ip.WireguardGenerateKeyPair()
+		if err != nil {
+			return err
+		}
+
+		_, err = dbCluster.CreateNetworkWireguardKeyPair(ctx, tx.Tx(), dbCluster.NetworkWireguardKeyPair{NetworkID: n.id, NodeID: localMemberID, PrivateKey: privateKey, PublicKey: publicKey})
+		if err != nil {
+			return fmt.Errorf("Failed recording WireGuard keys: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// isRunning returns whether the network is up.
+func (n *wireguard) isRunning() bool {
+	return InterfaceExists(n.name)
+}
+
+// Delete deletes a network.
+func (n *wireguard) Delete(clientType request.ClientType) error {
+	n.logger.Debug("Delete", logger.Ctx{"clientType": clientType})
+
+	if n.isRunning() {
+		err := n.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	return n.delete(clientType)
+}
+
+// Rename renames a network.
+func (n *wireguard) Rename(newName string) error {
+	n.logger.Debug("Rename", logger.Ctx{"newName": newName})
+
+	if InterfaceExists(newName) {
+		return fmt.Errorf("Network interface %q already exists", newName)
+	}
+
+	// Bring the network down.
+	if n.isRunning() {
+		err := n.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Rename common steps.
+	err := n.rename(newName)
+	if err != nil {
+		return err
+	}
+
+	// Bring the network up.
+	err = n.Start()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Start starts the network.
+func (n *wireguard) Start() error {
+	n.logger.Debug("Start")
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() { n.setUnavailable() })
+
+	err := n.setup()
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	// Ensure network is marked as available now its started.
+	n.setAvailable()
+
+	return nil
+}
+
+// setup creates the WireGuard device, configures its addresses and applies the keys and peers.
+func (n *wireguard) setup() error {
+	n.logger.Debug("Setting up network")
+
+	mtu := uint32(wireguardMTUDefault)
+	if n.config["mtu"] != "" {
+		mtuInt, err := strconv.ParseUint(n.config["mtu"], 10, 32)
+		if err != nil {
+			return fmt.Errorf("Invalid MTU %q: %w", n.config["mtu"], err)
+		}
+
+		mtu = uint32(mtuInt)
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	device := &ip.Wireguard{Link: ip.Link{Name: n.name, MTU: mtu}}
+	if !n.isRunning() {
+		err := device.Add()
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = device.Delete() })
+	} else {
+		err := device.SetMTU(mtu)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Apply the addresses.
+	for _, family := range []ip.Family{ip.FamilyV4, ip.FamilyV6} {
+		addr := &ip.Addr{DevName: n.name, Scope: "global", Family: family}
+		err := addr.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range []string{"wireguard.ipv4.address", "wireguard.ipv6.address"} {
+		if n.config[key] == "" {
+			continue
+		}
+
+		address, err := ParseIPCIDRToNet(n.config[key])
+		if err != nil {
+			return err
+		}
+
+		addr := &ip.Addr{DevName: n.name, Address: address, Family: ip.FamilyV4}
+		if address.IP.To4() == nil {
+			addr.Family = ip.FamilyV6
+		}
+
+		err = addr.Add()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := device.SetUp()
+	if err != nil {
+		return err
+	}
+
+	// Generate the keys of members which joined the cluster after the network was created.
+	err = n.ensureKeyPair()
+	if err != nil {
+		return err
+	}
+
+	// Apply the keys, peers and routes.
+	err = n.applyPeers()
+	if err != nil {
+		return err
+	}
+
+	err = n.setupBridge(mtu)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// setupBridge creates the bridge that bridged NICs connect to when the local member has a bridge address,
+// and removes it otherwise. The traffic of the bridge is forwarded to the WireGuard device.
+func (n *wireguard) setupBridge(mtu uint32) error {
+	bridgeName := WireguardBridgeName(n.id)
+
+	if n.config["bridge.ipv4.address"] == "" && n.config["bridge.ipv6.address"] == "" {
+		if InterfaceExists(bridgeName) {
+			return (&ip.Link{Name: bridgeName}).Delete()
+		}
+
+		return nil
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	bridge := &ip.Bridge{Link: ip.Link{Name: bridgeName, MTU: mtu}}
+	if !InterfaceExists(bridgeName) {
+		err := bridge.Add()
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = bridge.Delete() })
+	} else {
+		err := bridge.SetMTU(mtu)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, family := range []ip.Family{ip.FamilyV4, ip.FamilyV6} {
+		addr := &ip.Addr{DevName: bridgeName, Scope: "global", Family: family}
+		err := addr.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.config["bridge.ipv4.address"] != "" {
+		address, err := ParseIPCIDRToNet(n.config["bridge.ipv4.address"])
+		if err != nil {
+			return err
+		}
+
+		err = (&ip.Addr{DevName: bridgeName, Address: address, Family: ip.FamilyV4}).Add()
+		if err != nil {
+			return err
+		}
+
+		err = localUtil.SysctlSet("net/ipv4/ip_forward", "1")
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.config["bridge.ipv6.address"] != "" {
+		err := localUtil.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/disable_ipv6", bridgeName), "0")
+		if err != nil {
+			return err
+		}
+
+		address, err := ParseIPCIDRToNet(n.config["bridge.ipv6.address"])
+		if err != nil {
+			return err
+		}
+
+		err = (&ip.Addr{DevName: bridgeName, Address: address, Family: ip.FamilyV6}).Add()
+		if err != nil {
+			return err
+		}
+
+		for _, devName := range []string{bridgeName, n.name} {
+			err = localUtil.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/forwarding", devName), "1")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := bridge.SetUp()
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// peers returns the local private key and the peers of the network: the other cluster members and the
+// external peers. Cluster members without keys yet are skipped, they get picked up on the next heartbeat.
+func (n *wireguard) peers() (string, []wireguardPeer, error) {
+	port := strconv.Itoa(wireguardPortDefault)
+	if n.config["wireguard.port"] != "" {
+		port = n.config["wireguard.port"]
+	}
+
+	keepalive := wireguardKeepaliveDefault
+	if n.config["wireguard.keepalive"] != "" {
+		keepalive, _ = strconv.Atoi(n.config["wireguard.keepalive"])
+	}
+
+	instanceAllowedIPs, err := n.instanceAllowedIPs()
+	if err != nil {
+		return "", nil, err
+	}
+
+	localMemberID := n.state.DB.Cluster.GetNodeID()
+
+	var privateKey string
+	peers := []wireguardPeer{}
+
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		members, err := tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		keyPairs, err := dbCluster.GetNetworkWireguardKeyPairs(ctx, tx.Tx(), dbCluster.NetworkWireguardKeyPairFilter{NetworkID: &n.id})
+		if err != nil {
+			return fmt.Errorf("Failed getting WireGuard keys: %w", err)
+		}
+
+		publicKeys := make(map[int64]string, len(keyPairs))
+		for _, keyPair := range keyPairs {
+			publicKeys[keyPair.NodeID] = keyPair.PublicKey
+			if keyPair.NodeID == localMemberID {
+				privateKey = keyPair.PrivateKey
+			}
+		}
+
+		membersConfig, err := tx.GetNetworkMembersConfig(ctx, n.id)
+		if err != nil {
+			return fmt.Errorf("Failed getting member specific configuration: %w", err)
+		}
+
+		for _, member := range members {
+			_, ok := publicKeys[member.ID]
+			if !ok || member.ID == localMemberID {
+				continue
+			}
+
+			host, _, err := net.SplitHostPort(member.Address)
+			if err != nil {
+				host = member.Address
+			}
+
+			peer := wireguardPeer{
+				name: member.Name,
+				WireguardPeer: ip.WireguardPeer{
+					PublicKey:           publicKeys[member.ID],
+					Endpoint:            net.JoinHostPort(host, port),
+					AllowedIPs:          wireguardMemberAllowedIPs(membersConfig[member.ID], instanceAllowedIPs[member.Name]),
+					PersistentKeepalive: keepalive,
+				},
+			}
+
+			peers = append(peers, peer)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if privateKey == "" {
+		return "", nil, errors.New("The WireGuard keys of the local member are missing")
+	}
+
+	// Add the external peers.
+	peerNames := []string{}
+	for k := range n.config {
+		fields := strings.Split(k, ".")
+		if len(fields) == 3 && fields[0] == "peers" && fields[2] == "public_key" {
+			peerNames = append(peerNames, fields[1])
+		}
+	}
+
+	sort.Strings(peerNames)
+
+	for _, peerName := range peerNames {
+		peers = append(peers, wireguardPeer{
+			name: peerName,
+			WireguardPeer: ip.WireguardPeer{
+				PublicKey:           n.config["peers."+peerName+".public_key"],
+				Endpoint:            n.config["peers."+peerName+".endpoint"],
+				AllowedIPs:          util.SplitNTrimSpace(n.config["peers."+peerName+".allowed_ips"], ",", -1, true),
+				PersistentKeepalive: keepalive,
+			},
+		})
+	}
+
+	return privateKey, peers, nil
+}
+
+// instanceAllowedIPs returns the addresses and subnets of the routed NICs using the network as their parent,
+// grouped by the name of the cluster member running the instance.
+func (n *wireguard) instanceAllowedIPs() (map[string][]string, error) {
+	allowedIPs := map[string][]string{}
+
+	err := UsedByInstanceDevices(n.state, n.project, n.name, n.netType, func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
+		allowedIPs[inst.Node] = append(allowedIPs[inst.Node], wireguardNICAllowedIPs(nicConfig)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting instance NICs using the network: %w", err)
+	}
+
+	return allowedIPs, nil
+}
+
+// wireguardNICAllowedIPs returns the addresses and subnets of a routed NIC. Other NICs using the network are
+// bridged and their addresses are part of the subnet of the bridge.
+func wireguardNICAllowedIPs(nicConfig map[string]string) []string {
+	allowedIPs := []string{}
+
+	if nicConfig["nictype"] != "routed" {
+		return allowedIPs
+	}
+
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		for _, address := range util.SplitNTrimSpace(nicConfig[keyPrefix+".address"], ",", -1, true) {
+			addressIP := net.ParseIP(address)
+			if addressIP == nil {
+				continue
+			}
+
+			if addressIP.To4() != nil {
+				allowedIPs = append(allowedIPs, addressIP.String()+"/32")
+			} else {
+				allowedIPs = append(allowedIPs, addressIP.String()+"/128")
+			}
+		}
+
+		allowedIPs = append(allowedIPs, util.SplitNTrimSpace(nicConfig[keyPrefix+".routes"], ",", -1, true)...)
+	}
+
+	return allowedIPs
+}
+
+// wireguardMemberAllowedIPs returns the addresses and subnets routed to a cluster member based on its
+// member specific configuration and on the addresses of the routed NICs of its instances.
+func wireguardMemberAllowedIPs(config map[string]string, instanceAllowedIPs []string) []string {
+	allowedIPs := []string{}
+
+	for _, key := range []string{"wireguard.ipv4.address", "wireguard.ipv6.address"} {
+		address, _, err := net.ParseCIDR(config[key])
+		if err != nil {
+			continue
+		}
+
+		if address.To4() != nil {
+			allowedIPs = append(allowedIPs, address.String()+"/32")
+		} else {
+			allowedIPs = append(allowedIPs, address.String()+"/128")
+		}
+	}
+
+	allowedIPs = append(allowedIPs, util.SplitNTrimSpace(config["wireguard.routes"], ",", -1, true)...)
+
+	// Route the subnets of the bridge.
+	for _, key := range []string{"bridge.ipv4.address", "bridge.ipv6.address"} {
+		_, subnet, err := net.ParseCIDR(config[key])
+		if err != nil {
+			continue
+		}
+
+		allowedIPs = append(allowedIPs, subnet.String())
+	}
+
+	for _, allowedIP := range instanceAllowedIPs {
+		if !slices.Contains(allowedIPs, allowedIP) {
+			allowedIPs = append(allowedIPs, allowedIP)
+		}
+	}
+
+	return allowedIPs
+}
+
+// applyPeers applies the local key and the peers to the WireGuard device and routes the allowed addresses
+// of the peers through it.
+func (n *wireguard) applyPeers() error {
+	privateKey, peers, err := n.peers()
+	if err != nil {
+		return err
+	}
+
+	port := wireguardPortDefault
+	if n.config["wireguard.port"] != "" {
+		port, err = strconv.Atoi(n.config["wireguard.port"])
+		if err != nil {
+			return fmt.Errorf("Invalid port %q: %w", n.config["wireguard.port"], err)
+		}
+	}
+
+	devicePeers := make([]ip.WireguardPeer, 0, len(peers))
+	routes := map[string]*net.IPNet{}
+	for _, peer := range peers {
+		devicePeers = append(devicePeers, peer.WireguardPeer)
+
+		for _, allowedIP := range peer.AllowedIPs {
+			_, subnet, err := net.ParseCIDR(allowedIP)
+			if err != nil {
+				return fmt.Errorf("Invalid allowed IP %q of peer %q: %w", allowedIP, peer.name, err)
+			}
+
+			routes[subnet.String()] = subnet
+		}
+	}
+
+	device := &ip.Wireguard{Link: ip.Link{Name: n.name}}
+	err = device.SetConfig(privateKey, port, devicePeers)
+	if err != nil {
+		return err
+	}
+
+	// Remove stale routes.
+	for _, family := range []ip.Family{ip.FamilyV4, ip.FamilyV6} {
+		r := &ip.Route{DevName: n.name, Proto: "static", Family: family}
+		existing, err := r.List()
+		if err != nil {
+			return err
+		}
+
+		for _, route := range existing {
+			if route.Route == nil {
+				continue
+			}
+
+			_, ok := routes[route.Route.String()]
+			if ok {
+				continue
+			}
+
+			err = route.Delete()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Route the allowed addresses of the peers through the device.
+	for _, subnet := range routes {
+		r := &ip.Route{DevName: n.name, Route: subnet, Proto: "static", Family: ip.FamilyV4}
+		if subnet.IP.To4() == nil {
+			r.Family = ip.FamilyV6
+		}
+
+		err = r.Replace()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the network.
+func (n *wireguard) Stop() error {
+	n.logger.Debug("Stop")
+
+	if !n.isRunning() {
+		return nil
+	}
+
+	bridgeName := WireguardBridgeName(n.id)
+	if InterfaceExists(bridgeName) {
+		err := (&ip.Link{Name: bridgeName}).Delete()
+		if err != nil {
+			return err
+		}
+	}
+
+	return (&ip.Link{Name: n.name}).Delete()
+}
+
+// Update updates the network. Accepts notification boolean indicating if this update request is coming from a
+// cluster notification, in which case do not update the database, just apply local changes needed.
+func (n *wireguard) Update(newNetwork api.NetworkPut, targetNode string, clientType request.ClientType) error {
+	n.logger.Debug("Update", logger.Ctx{"clientType": clientType, "newNetwork": newNetwork})
+
+	dbUpdateNeeded, changedKeys, oldNetwork, err := n.configChanged(newNetwork)
+	if err != nil {
+		return err
+	}
+
+	if !dbUpdateNeeded {
+		// Another member notified of a change to its member specific configuration.
+		if clientType == request.ClientTypeNotifier && n.isRunning() {
+			return n.applyPeers()
+		}
+
+		return nil // Nothing changed.
+	}
+
+	// If the network as a whole has not had any previous creation attempts, or the node itself is still
+	// pending, then don't apply the new settings to the node, just to the database record (ready for the
+	// actual global create request to be initiated).
+	if n.Status() == api.NetworkStatusPending || n.LocalStatus() == api.NetworkStatusPending {
+		return n.update(newNetwork, targetNode, clientType)
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Define a function which reverts everything.
+	reverter.Add(func() {
+		// Reset changes to all nodes and database.
+		_ = n.update(oldNetwork, targetNode, clientType)
+
+		// Reset any change that was made to the network.
+		_ = n.setup()
+	})
+
+	// Apply changes to all nodes and database.
+	err = n.update(newNetwork, targetNode, clientType)
+	if err != nil {
+		return err
+	}
+
+	if len(changedKeys) > 0 {
+		err = n.setup()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Let the other members pick up the changes to the member specific configuration.
+	if targetNode != "" && clientType == request.ClientTypeNormal {
+		notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		sendNetwork := api.NetworkPut{
+			Description: newNetwork.Description,
+			Config:      db.StripNodeSpecificNetworkConfig(newNetwork.Config),
+		}
+
+		err = notifier(func(client incus.InstanceServer) error {
+			return client.UseProject(n.project).UpdateNetwork(n.name, sendNetwork, "")
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// HandleHeartbeat updates the peers of the network when the cluster members change.
+func (n *wireguard) HandleHeartbeat(heartbeatData *cluster.APIHeartbeat) error {
+	if !n.isRunning() {
+		return nil
+	}
+
+	return n.applyPeers()
+}
+
+// RefreshInstanceRoutes has the other cluster members apply the current addresses of the routed NICs using the
+// network, for example after an instance was started on the local member.
+func (n *wireguard) RefreshInstanceRoutes() error {
+	notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return err
+	}
+
+	// An update without changes makes the members apply their peers again.
+	sendNetwork := api.NetworkPut{
+		Description: n.description,
+		Config:      db.StripNodeSpecificNetworkConfig(n.config),
+	}
+
+	return notifier(func(client incus.InstanceServer) error {
+		return client.UseProject(n.project).UpdateNetwork(n.name, sendNetwork, "")
+	})
+}
+
+// RotateKeys replaces the WireGuard keys of all cluster members with newly generated ones.
+// All members must be reachable, the previous keys are restored if any of them fails to apply the new ones.
+func (n *wireguard) RotateKeys(clientType request.ClientType) error {
+	n.logger.Debug("RotateKeys", logger.Ctx{"clientType": clientType})
+
+	// The member handling the request generates the new keys, the other members only apply them.
+	if clientType == request.ClientTypeNotifier {
+		if !n.isRunning() {
+			return nil
+		}
+
+		return n.applyPeers()
+	}
+
+	// Check that all members are reachable before changing the keys.
+	notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+	if err != nil {
+		return err
+	}
+
+	notify := func() error {
+		return notifier(func(client incus.InstanceServer) error {
+			return client.UseProject(n.project).RotateNetworkKeys(n.name)
+		})
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	var oldKeyPairs []dbCluster.NetworkWireguardKeyPair
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		oldKeyPairs, err = dbCluster.GetNetworkWireguardKeyPairs(ctx, tx.Tx(), dbCluster.NetworkWireguardKeyPairFilter{NetworkID: &n.id})
+		if err != nil {
+			return fmt.Errorf("Failed getting WireGuard keys: %w", err)
+		}
+
+		for _, keyPair := range oldKeyPairs {
+			privateKey, publicKey, err := ip.WireguardGenerateKeyPair()
+			if err != nil {
+				return err
+			}
+
+			err = dbCluster.UpdateNetworkWireguardKeyPair(ctx, tx.Tx(), n.id, keyPair.NodeID, privateKey, publicKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() {
+		err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			for _, keyPair := range oldKeyPairs {
+				err := dbCluster.UpdateNetworkWireguardKeyPair(ctx, tx.Tx(), n.id, keyPair.NodeID, keyPair.PrivateKey, keyPair.PublicKey)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			n.logger.Error("Failed restoring WireGuard keys", logger.Ctx{"err": err})
+			return
+		}
+
+		if n.isRunning() {
+			_ = n.applyPeers()
+		}
+
+		// Have the other members go back to the previous keys too.
+		_ = notify()
+	})
+
+	// Apply the new keys locally, then on the other members.
+	if n.isRunning() {
+		err = n.applyPeers()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = notify()
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// State returns the network state, including the state of the WireGuard peers.
+func (n *wireguard) State() (*api.NetworkState, error) {
+	if !n.isRunning() {
+		return &api.NetworkState{
+			Addresses: []api.NetworkStateAddress{},
+			State:     "down",
+			Type:      "point-to-point",
+		}, nil
+	}
+
+	state, err := n.common.State()
+	if err != nil {
+		return nil, err
+	}
+
+	device := &ip.Wireguard{Link: ip.Link{Name: n.name}}
+	deviceState, err := device.Show()
+	if err != nil {
+		return nil, err
+	}
+
+	_, peers, err := n.peers()
+	if err != nil {
+		return nil, err
+	}
+
+	peerNames := make(map[string]string, len(peers))
+	for _, peer := range peers {
+		peerNames[peer.PublicKey] = peer.name
+	}
+
+	state.Wireguard = &api.NetworkStateWireguard{
+		PublicKey:  deviceState.PublicKey,
+		ListenPort: deviceState.ListenPort,
+		Peers:      make([]api.NetworkStateWireguardPeer, 0, len(deviceState.Peers)),
+	}
+
+	for _, peer := range deviceState.Peers {
+		state.Wireguard.Peers = append(state.Wireguard.Peers, api.NetworkStateWireguardPeer{
+			Name:            peerNames[peer.PublicKey],
+			PublicKey:       peer.PublicKey,
+			Endpoint:        peer.Endpoint,
+			AllowedIPs:      peer.AllowedIPs,
+			LatestHandshake: peer.LatestHandshake,
+			BytesReceived:   peer.ReceivedBytes,
+			BytesSent:       peer.SentBytes,
+		})
+	}
+
+	return state, nil
+}
+
+// Info returns the network driver info.
+func (n *wireguard) Info() Info {
+	info := n.common.Info()
+	info.NodeSpecificConfig = false
+
+	return info
+}
@@ -0,0 +1,74 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_wireguardNICAllowedIPs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   []string
+	}{
+		{
+			name:   "Routed NIC with addresses and routes",
+			config: map[string]string{"nictype": "routed", "ipv4.address": "192.0.2.10, 192.0.2.11", "ipv6.address": "2001:db8::10", "ipv4.routes": "198.51.100.0/24", "ipv6.routes": "2001:db8:1::/48"},
+			want:   []string{"192.0.2.10/32", "192.0.2.11/32", "198.51.100.0/24", "2001:db8::10/128", "2001:db8:1::/48"},
+		},
+		{
+			name:   "Routed NIC without addresses",
+			config: map[string]string{"nictype": "routed"},
+			want:   []string{},
+		},
+		{
+			name:   "Bridged NIC",
+			config: map[string]string{"nictype": "bridged", "ipv4.address": "192.0.2.10"},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wireguardNICAllowedIPs(tt.config))
+		})
+	}
+}
+
+func Test_wireguardMemberAllowedIPs(t *testing.T) {
+	tests := []struct {
+		name               string
+		config             map[string]string
+		instanceAllowedIPs []string
+		want               []string
+	}{
+		{
+			name:   "Addresses and routes",
+			config: map[string]string{"wireguard.ipv4.address": "10.200.0.1/24", "wireguard.ipv6.address": "fd00::1/64", "wireguard.routes": "10.0.1.0/24"},
+			want:   []string{"10.200.0.1/32", "fd00::1/128", "10.0.1.0/24"},
+		},
+		{
+			name:   "Bridge subnets",
+			config: map[string]string{"bridge.ipv4.address": "10.0.2.1/24", "bridge.ipv6.address": "fd00:2::1/64"},
+			want:   []string{"10.0.2.0/24", "fd00:2::/64"},
+		},
+		{
+			name:               "Routed NICs",
+			config:             map[string]string{"wireguard.routes": "10.0.1.0/24"},
+			instanceAllowedIPs: []string{"192.0.2.10/32", "10.0.1.0/24"},
+			want:               []string{"10.0.1.0/24", "192.0.2.10/32"},
+		},
+		{
+			name:   "No configuration",
+			config: map[string]string{},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wireguardMemberAllowedIPs(tt.config, tt.instanceAllowedIPs))
+		})
+	}
+}
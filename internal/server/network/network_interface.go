@@ -70,8 +70,8 @@ type Network interface {
 	LoadBalancerDelete(listenAddress string, clientType request.ClientType) error
 
 	// Peerings.
-	PeerCreate(forward api.NetworkPeersPost) error
+	PeerCreate(forward api.NetworkPeersPost, clientType request.ClientType) error
 	PeerUpdate(peerName string, newPeer api.NetworkPeerPut) error
-	PeerDelete(peerName string) error
+	PeerDelete(peerName string, clientType request.ClientType) error
 	PeerUsedBy(peerName string) ([]string, error)
 }
@@ -5,17 +5,21 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
+	addressset "github.com/lxc/incus/v6/internal/server/network/address-set"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
 )
 
 var drivers = map[string]func() Network{
-	"bridge":   func() Network { return &bridge{} },
-	"macvlan":  func() Network { return &macvlan{} },
-	"sriov":    func() Network { return &sriov{} },
-	"ovn":      func() Network { return &ovn{} },
-	"physical": func() Network { return &physical{} },
+	"bridge":    func() Network { return &bridge{} },
+	"macvlan":   func() Network { return &macvlan{} },
+	"sriov":     func() Network { return &sriov{} },
+	"ovn":       func() Network { return &ovn{} },
+	"physical":  func() Network { return &physical{} },
+	"vxlan":     func() Network { return &vxlan{} },
+	"wireguard": func() Network { return &wireguard{} },
 }
 
 // ProjectNetwork is a composite type of project name and network name.
@@ -29,6 +33,18 @@ var (
 	unavailableNetworksMu = sync.Mutex{}
 )
 
+func init() {
+	// Expose the network leases to the address set package, to avoid circular imports.
+	addressset.NetworkLeases = func(s *state.State, networkProjectName string, networkName string, instanceProjectName string) ([]api.NetworkLease, error) {
+		n, err := LoadByName(s, networkProjectName, networkName)
+		if err != nil {
+			return nil, err
+		}
+
+		return n.Leases(instanceProjectName, request.ClientTypeNormal)
+	}
+}
+
 // LoadByType loads a network by driver type.
 func LoadByType(driverType string) (Type, error) {
 	driverFunc, ok := drivers[driverType]
@@ -0,0 +1,166 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// Load balancer backend health status values (matching those reported by OVN).
+const (
+	loadBalancerHealthUnknown = "unknown"
+	loadBalancerHealthOnline  = "online"
+	loadBalancerHealthOffline = "offline"
+)
+
+// loadBalancerHealthTarget identifies a load balancer backend port checked by the health monitor.
+type loadBalancerHealthTarget struct {
+	listenAddress string
+	address       string
+	port          uint64
+}
+
+// loadBalancerHealthCheck represents the health check settings of a load balancer.
+type loadBalancerHealthCheck struct {
+	interval     time.Duration
+	timeout      time.Duration
+	successCount int
+	failureCount int
+}
+
+// loadBalancerHealthRunner represents the checks running against a load balancer backend port.
+type loadBalancerHealthRunner struct {
+	check  loadBalancerHealthCheck
+	cancel context.CancelFunc
+	status string
+}
+
+// loadBalancerHealthMonitors holds the health check runners of each network, indexed by network ID.
+var loadBalancerHealthMonitors = map[int64]map[loadBalancerHealthTarget]*loadBalancerHealthRunner{}
+
+var loadBalancerHealthMonitorsMu sync.Mutex
+
+// loadBalancerHealthMonitorUpdate replaces the backend ports checked for the network.
+// The checks of ports whose settings didn't change keep running (and keep their status), the others are
+// (re)started with an unknown status. The onChange function is called whenever the status of a port changes.
+func loadBalancerHealthMonitorUpdate(networkID int64, targets map[loadBalancerHealthTarget]loadBalancerHealthCheck, onChange func()) {
+	loadBalancerHealthMonitorsMu.Lock()
+	defer loadBalancerHealthMonitorsMu.Unlock()
+
+	runners := loadBalancerHealthMonitors[networkID]
+	if runners == nil {
+		runners = map[loadBalancerHealthTarget]*loadBalancerHealthRunner{}
+	}
+
+	// Stop the checks which are no longer needed or whose settings changed.
+	for target, runner := range runners {
+		check, found := targets[target]
+		if found && check == runner.check {
+			continue
+		}
+
+		runner.cancel()
+		delete(runners, target)
+	}
+
+	// Start the new checks.
+	for target, check := range targets {
+		_, found := runners[target]
+		if found {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runner := &loadBalancerHealthRunner{
+			check:  check,
+			cancel: cancel,
+			status: loadBalancerHealthUnknown,
+		}
+
+		runners[target] = runner
+
+		go loadBalancerHealthRun(ctx, networkID, target, runner, onChange)
+	}
+
+	if len(runners) == 0 {
+		delete(loadBalancerHealthMonitors, networkID)
+		return
+	}
+
+	loadBalancerHealthMonitors[networkID] = runners
+}
+
+// loadBalancerHealthMonitorStop stops all the checks of the network.
+func loadBalancerHealthMonitorStop(networkID int64) {
+	loadBalancerHealthMonitorUpdate(networkID, nil, nil)
+}
+
+// loadBalancerHealthStatus returns the health status of a load balancer backend port.
+// Ports which aren't being checked have an unknown status.
+func loadBalancerHealthStatus(networkID int64, target loadBalancerHealthTarget) string {
+	loadBalancerHealthMonitorsMu.Lock()
+	defer loadBalancerHealthMonitorsMu.Unlock()
+
+	runner, found := loadBalancerHealthMonitors[networkID][target]
+	if !found {
+		return loadBalancerHealthUnknown
+	}
+
+	return runner.status
+}
+
+// loadBalancerHealthRun periodically checks that a TCP connection can be established to the backend port.
+// The port is considered online (or offline) after the configured number of consecutive successful (or failed)
+// connection attempts.
+func loadBalancerHealthRun(ctx context.Context, networkID int64, target loadBalancerHealthTarget, runner *loadBalancerHealthRunner, onChange func()) {
+	var successes, failures int
+
+	address := net.JoinHostPort(target.address, fmt.Sprintf("%d", target.port))
+	dialer := net.Dialer{Timeout: runner.check.timeout}
+
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			_ = conn.Close()
+			successes++
+			failures = 0
+		} else {
+			failures++
+			successes = 0
+		}
+
+		status := ""
+		if successes >= runner.check.successCount {
+			status = loadBalancerHealthOnline
+		} else if failures >= runner.check.failureCount {
+			status = loadBalancerHealthOffline
+		}
+
+		loadBalancerHealthMonitorsMu.Lock()
+		changed := status != "" && status != runner.status && ctx.Err() == nil
+		if changed {
+			runner.status = status
+		}
+
+		loadBalancerHealthMonitorsMu.Unlock()
+
+		if changed {
+			logger.Info("Load balancer backend health changed", logger.Ctx{"networkID": networkID, "listenAddress": target.listenAddress, "backend": address, "status": status})
+			onChange()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(runner.check.interval):
+		}
+	}
+}
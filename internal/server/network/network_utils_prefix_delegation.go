@@ -0,0 +1,601 @@
+package network
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv6"
+	"github.com/insomniacslk/dhcp/dhcpv6/nclient6"
+	"github.com/insomniacslk/dhcp/iana"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// networkVolatileDelegatedPrefix records the IPv6 prefix last delegated to a network (used to renumber instances).
+const networkVolatileDelegatedPrefix = "volatile.ipv6.delegation.prefix"
+
+// prefixDelegationRetryInterval is how long to wait before retrying a failed prefix delegation exchange.
+const prefixDelegationRetryInterval = 30 * time.Second
+
+// prefixDelegationTimeout is how long to wait for the replies of the upstream DHCPv6 servers.
+const prefixDelegationTimeout = 30 * time.Second
+
+// prefixDelegationLease represents a prefix delegated by an upstream DHCPv6 server.
+type prefixDelegationLease struct {
+	prefix   *net.IPNet
+	serverID dhcpv6.DUID
+	renewAt  time.Time
+	expireAt time.Time
+}
+
+// prefixDelegationClient represents the DHCPv6 prefix delegation client running on an upstream interface.
+type prefixDelegationClient struct {
+	cancel      context.CancelFunc
+	prefix      *net.IPNet
+	subscribers map[int64]func(prefix *net.IPNet)
+}
+
+// prefixDelegationClients holds the prefix delegation clients, indexed by upstream interface name.
+var prefixDelegationClients = map[string]*prefixDelegationClient{}
+
+var prefixDelegationClientsMu sync.Mutex
+
+// prefixDelegationSubscribe registers the network to be notified of the changes of the prefix delegated on the
+// upstream interface, starting the client for the interface if needed. The network is unsubscribed from any other
+// interface first. Returns the currently delegated prefix (nil if none yet).
+func prefixDelegationSubscribe(ifName string, networkID int64, onChange func(prefix *net.IPNet)) *net.IPNet {
+	prefixDelegationClientsMu.Lock()
+	defer prefixDelegationClientsMu.Unlock()
+
+	prefixDelegationUnsubscribeLocked(networkID, ifName)
+
+	client := prefixDelegationClients[ifName]
+	if client == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		client = &prefixDelegationClient{
+			cancel:      cancel,
+			subscribers: map[int64]func(prefix *net.IPNet){},
+		}
+
+		prefixDelegationClients[ifName] = client
+
+		go prefixDelegationRun(ctx, ifName, client)
+	}
+
+	client.subscribers[networkID] = onChange
+
+	return client.prefix
+}
+
+// prefixDelegationUnsubscribe unregisters the network, stopping the clients which are no longer needed.
+func prefixDelegationUnsubscribe(networkID int64) {
+	prefixDelegationClientsMu.Lock()
+	defer prefixDelegationClientsMu.Unlock()
+
+	prefixDelegationUnsubscribeLocked(networkID, "")
+}
+
+// prefixDelegationUnsubscribeLocked unregisters the network from all interfaces but keepIfName.
+// Must be called with prefixDelegationClientsMu held.
+func prefixDelegationUnsubscribeLocked(networkID int64, keepIfName string) {
+	for ifName, client := range prefixDelegationClients {
+		if ifName == keepIfName {
+			continue
+		}
+
+		delete(client.subscribers, networkID)
+
+		if len(client.subscribers) == 0 {
+			client.cancel()
+			delete(prefixDelegationClients, ifName)
+		}
+	}
+}
+
+// prefixDelegationSet records the prefix delegated on the interface and notifies the subscribed networks if it changed.
+func prefixDelegationSet(ctx context.Context, ifName string, client *prefixDelegationClient, prefix *net.IPNet) {
+	prefixDelegationClientsMu.Lock()
+
+	if ctx.Err() != nil || prefixDelegationEqual(client.prefix, prefix) {
+		prefixDelegationClientsMu.Unlock()
+		return
+	}
+
+	client.prefix = prefix
+	subscribers := slices.Collect(maps.Values(client.subscribers))
+
+	prefixDelegationClientsMu.Unlock()
+
+	if prefix != nil {
+		logger.Info("Delegated IPv6 prefix changed", logger.Ctx{"interface": ifName, "prefix": prefix.String()})
+	} else {
+		logger.Warn("Delegated IPv6 prefix lost", logger.Ctx{"interface": ifName})
+	}
+
+	for _, onChange := range subscribers {
+		onChange(prefix)
+	}
+}
+
+// prefixDelegationEqual returns whether both prefixes are the same.
+func prefixDelegationEqual(a *net.IPNet, b *net.IPNet) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.String() == b.String()
+}
+
+// prefixDelegationRun requests a prefix on the interface and keeps renewing it until the context is cancelled.
+// The lease isn't released when stopping so that the upstream server keeps delegating the same prefix to us.
+func prefixDelegationRun(ctx context.Context, ifName string, client *prefixDelegationClient) {
+	var lease *prefixDelegationLease
+
+	for {
+		newLease, err := prefixDelegationExchange(ctx, ifName, lease)
+		if ctx.Err() != nil {
+			return
+		}
+
+		wait := prefixDelegationRetryInterval
+
+		if err != nil {
+			logger.Warn("Failed DHCPv6 prefix delegation exchange", logger.Ctx{"interface": ifName, "err": err})
+
+			// Keep using the current prefix until it expires.
+			if lease != nil && !time.Now().Before(lease.expireAt) {
+				lease = nil
+				prefixDelegationSet(ctx, ifName, client, nil)
+			}
+
+			if lease != nil {
+				wait = min(wait, time.Until(lease.expireAt))
+			}
+		} else {
+			lease = newLease
+			prefixDelegationSet(ctx, ifName, client, lease.prefix)
+			wait = time.Until(lease.renewAt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// prefixDelegationExchange requests a new prefix on the interface (when lease is nil) or renews the existing lease.
+// A DUID based on the interface MAC address is used so that the prefix remains stable across restarts.
+func prefixDelegationExchange(ctx context.Context, ifName string, lease *prefixDelegationLease) (*prefixDelegationLease, error) {
+	iface, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting interface %q: %w", ifName, err)
+	}
+
+	if len(iface.HardwareAddr) < 4 {
+		return nil, fmt.Errorf("Interface %q has no usable MAC address", ifName)
+	}
+
+	duid := &dhcpv6.DUIDLL{HWType: iana.HWTypeEthernet, LinkLayerAddr: iface.HardwareAddr}
+
+	var iaid [4]byte
+	copy(iaid[:], iface.HardwareAddr[len(iface.HardwareAddr)-4:])
+
+	client, err := nclient6.New(ifName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed setting up DHCPv6 client: %w", err)
+	}
+
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, prefixDelegationTimeout)
+	defer cancel()
+
+	msgType := dhcpv6.MessageTypeRenew
+	var serverID dhcpv6.DUID
+	var prefixes []*dhcpv6.OptIAPrefix
+
+	if lease == nil {
+		solicit, err := prefixDelegationMessage(dhcpv6.MessageTypeSolicit, duid, nil, iaid, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		advertise, err := client.SendAndRead(ctx, nclient6.AllDHCPRelayAgentsAndServers, solicit, nclient6.IsMessageType(dhcpv6.MessageTypeAdvertise))
+		if err != nil {
+			return nil, fmt.Errorf("Failed soliciting prefix: %w", err)
+		}
+
+		advertised, err := prefixDelegationLeaseFromReply(advertise)
+		if err != nil {
+			return nil, err
+		}
+
+		msgType = dhcpv6.MessageTypeRequest
+		serverID = advertised.serverID
+		prefixes = []*dhcpv6.OptIAPrefix{{Prefix: advertised.prefix}}
+	} else {
+		serverID = lease.serverID
+		prefixes = []*dhcpv6.OptIAPrefix{{Prefix: lease.prefix}}
+	}
+
+	request, err := prefixDelegationMessage(msgType, duid, serverID, iaid, prefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := client.SendAndRead(ctx, nclient6.AllDHCPRelayAgentsAndServers, request, nclient6.IsMessageType(dhcpv6.MessageTypeReply))
+	if err != nil {
+		return nil, fmt.Errorf("Failed requesting prefix: %w", err)
+	}
+
+	return prefixDelegationLeaseFromReply(reply)
+}
+
+// prefixDelegationMessage returns a DHCPv6 message requesting a prefix delegation (without any address assignment).
+func prefixDelegationMessage(msgType dhcpv6.MessageType, duid dhcpv6.DUID, serverID dhcpv6.DUID, iaid [4]byte, prefixes []*dhcpv6.OptIAPrefix) (*dhcpv6.Message, error) {
+	modifiers := []dhcpv6.Modifier{
+		dhcpv6.WithClientID(duid),
+		dhcpv6.WithOption(dhcpv6.OptElapsedTime(0)),
+		dhcpv6.WithIAPD(iaid, prefixes...),
+	}
+
+	if serverID != nil {
+		modifiers = append(modifiers, dhcpv6.WithServerID(serverID))
+	}
+
+	msg, err := dhcpv6.NewMessage(modifiers...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed preparing DHCPv6 message: %w", err)
+	}
+
+	msg.MessageType = msgType
+
+	return msg, nil
+}
+
+// prefixDelegationLeaseFromReply returns the lease of the first usable prefix delegated by the DHCPv6 message.
+// The renewal time defaults to half the preferred lifetime of the prefix when the server doesn't specify it.
+func prefixDelegationLeaseFromReply(msg *dhcpv6.Message) (*prefixDelegationLease, error) {
+	status := msg.Options.Status()
+	if status != nil && status.StatusCode != iana.StatusSuccess {
+		return nil, fmt.Errorf("DHCPv6 server returned an error: %s", status.String())
+	}
+
+	iapd := msg.Options.OneIAPD()
+	if iapd == nil {
+		return nil, errors.New("DHCPv6 server didn't delegate any prefix")
+	}
+
+	status = iapd.Options.Status()
+	if status != nil && status.StatusCode != iana.StatusSuccess {
+		return nil, fmt.Errorf("DHCPv6 server didn't delegate any prefix: %s", status.String())
+	}
+
+	serverID := msg.Options.ServerID()
+	if serverID == nil {
+		return nil, errors.New("DHCPv6 server didn't identify itself")
+	}
+
+	for _, iaPrefix := range iapd.Options.Prefixes() {
+		if iaPrefix.Prefix == nil || iaPrefix.ValidLifetime <= 0 {
+			continue
+		}
+
+		ones, bits := iaPrefix.Prefix.Mask.Size()
+		if bits != 128 || ones > 64 {
+			continue
+		}
+
+		renew := iapd.T1
+		if renew <= 0 || renew > iaPrefix.ValidLifetime {
+			renew = iaPrefix.PreferredLifetime / 2
+		}
+
+		now := time.Now()
+
+		return &prefixDelegationLease{
+			prefix:   &net.IPNet{IP: iaPrefix.Prefix.IP.Mask(iaPrefix.Prefix.Mask), Mask: iaPrefix.Prefix.Mask},
+			serverID: serverID,
+			renewAt:  now.Add(renew),
+			expireAt: now.Add(iaPrefix.ValidLifetime),
+		}, nil
+	}
+
+	return nil, errors.New("DHCPv6 server didn't delegate any prefix of /64 or larger")
+}
+
+// prefixDelegationSubnet returns the /64 subnet with the given index within the delegated prefix.
+func prefixDelegationSubnet(prefix *net.IPNet, index uint64) (*net.IPNet, error) {
+	ones, bits := prefix.Mask.Size()
+	if bits != 128 || ones > 64 {
+		return nil, fmt.Errorf("Delegated prefix %q is too small to hold /64 subnets", prefix.String())
+	}
+
+	if ones > 0 && index >= 1<<(64-ones) {
+		return nil, fmt.Errorf("Subnet index %d is out of the range of delegated prefix %q", index, prefix.String())
+	}
+
+	subnet := make(net.IP, net.IPv6len)
+	copy(subnet, prefix.IP.Mask(prefix.Mask).To16())
+	binary.BigEndian.PutUint64(subnet[:8], binary.BigEndian.Uint64(subnet[:8])|index)
+
+	return &net.IPNet{IP: subnet, Mask: net.CIDRMask(64, 128)}, nil
+}
+
+// prefixDelegationRenumber returns the address moved from the old delegated prefix into the new one, keeping its
+// host bits. Returns nil if the address isn't part of the old prefix or if the new prefix is smaller than the old one.
+func prefixDelegationRenumber(address net.IP, oldPrefix *net.IPNet, newPrefix *net.IPNet) net.IP {
+	oldOnes, _ := oldPrefix.Mask.Size()
+	newOnes, _ := newPrefix.Mask.Size()
+	if address.To4() != nil || !oldPrefix.Contains(address) || newOnes > oldOnes {
+		return nil
+	}
+
+	oldMask := net.IP(oldPrefix.Mask).To16()
+	newIP := newPrefix.IP.Mask(newPrefix.Mask).To16()
+	address = address.To16()
+
+	renumbered := make(net.IP, net.IPv6len)
+	for i := range renumbered {
+		renumbered[i] = newIP[i] | (address[i] &^ oldMask[i])
+	}
+
+	return renumbered
+}
+
+// prefixDelegationRenumberList renumbers the addresses and subnets of a comma separated list, leaving the entries
+// outside of the old delegated prefix untouched. The value is returned as is when nothing needs renumbering.
+func prefixDelegationRenumberList(value string, oldPrefix *net.IPNet, newPrefix *net.IPNet) string {
+	changed := false
+	entries := util.SplitNTrimSpace(value, ",", -1, true)
+	for i, entry := range entries {
+		address, subnet, err := net.ParseCIDR(entry)
+		if err != nil {
+			address = net.ParseIP(entry)
+			if address == nil {
+				continue
+			}
+		}
+
+		renumbered := prefixDelegationRenumber(address, oldPrefix, newPrefix)
+		if renumbered == nil {
+			continue
+		}
+
+		changed = true
+
+		if subnet != nil {
+			ones, _ := subnet.Mask.Size()
+			entries[i] = fmt.Sprintf("%s/%d", renumbered.String(), ones)
+		} else {
+			entries[i] = renumbered.String()
+		}
+	}
+
+	if !changed {
+		return value
+	}
+
+	return strings.Join(entries, ",")
+}
+
+// prefixDelegationRenumberDevices moves the static IPv6 addresses and routes of the NIC devices from the old
+// delegated prefix into the new one. Returns whether any device was changed.
+func prefixDelegationRenumberDevices(devices deviceConfig.Devices, oldPrefix *net.IPNet, newPrefix *net.IPNet) bool {
+	changed := false
+
+	for _, devConfig := range devices {
+		if devConfig["type"] != "nic" {
+			continue
+		}
+
+		for _, key := range []string{"ipv6.address", "ipv6.routes", "ipv6.routes.external"} {
+			value := prefixDelegationRenumberList(devConfig[key], oldPrefix, newPrefix)
+			if value != devConfig[key] {
+				devConfig[key] = value
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+// prefixDelegationProfile identifies a profile renumbered after a delegated prefix change.
+type prefixDelegationProfile struct {
+	project string
+	name    string
+}
+
+// prefixDelegationRenumberProfiles renumbers the NIC devices of the profiles of all projects.
+// Returns the previous configuration of the profiles which changed.
+func prefixDelegationRenumberProfiles(s *state.State, oldPrefix *net.IPNet, newPrefix *net.IPNet) (map[prefixDelegationProfile]api.ProfilePut, error) {
+	oldProfiles := map[prefixDelegationProfile]api.ProfilePut{}
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		profiles, err := dbCluster.GetProfiles(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		profileConfigs, err := dbCluster.GetAllProfileConfigs(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		profileDevices, err := dbCluster.GetAllProfileDevices(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			apiProfile, err := profile.ToAPI(ctx, tx.Tx(), profileConfigs, profileDevices)
+			if err != nil {
+				return err
+			}
+
+			devices := deviceConfig.NewDevices(apiProfile.Devices)
+			if !prefixDelegationRenumberDevices(devices, oldPrefix, newPrefix) {
+				continue
+			}
+
+			dbDevices, err := dbCluster.APIToDevices(devices.CloneNative())
+			if err != nil {
+				return err
+			}
+
+			err = dbCluster.UpdateProfileDevices(ctx, tx.Tx(), int64(profile.ID), dbDevices)
+			if err != nil {
+				return fmt.Errorf("Failed updating profile %q in project %q: %w", profile.Name, profile.Project, err)
+			}
+
+			oldProfiles[prefixDelegationProfile{project: profile.Project, name: profile.Name}] = apiProfile.ProfilePut
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return oldProfiles, nil
+}
+
+// prefixDelegationRenumberInstances moves the static IPv6 addresses and routes of the NICs from the old delegated
+// prefix into the new one. This covers the NICs of the local instances, whatever network they use, as well as the
+// NICs defined in profiles. The other cluster members are notified of the profile changes so that they apply them
+// to their own instances. Returns the errors of all the failed updates.
+func prefixDelegationRenumberInstances(s *state.State, oldPrefix *net.IPNet, newPrefix *net.IPNet) error {
+	// Load the instances before renumbering the profiles so that the profile changes get applied to them.
+	insts, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	oldProfiles, err := prefixDelegationRenumberProfiles(s, oldPrefix, newPrefix)
+	if err != nil {
+		return fmt.Errorf("Failed renumbering profiles: %w", err)
+	}
+
+	var errs []error
+
+	for _, inst := range insts {
+		if inst.IsSnapshot() {
+			continue
+		}
+
+		devices := inst.LocalDevices().Clone()
+		changed := prefixDelegationRenumberDevices(devices, oldPrefix, newPrefix)
+
+		profileNames := make([]string, 0, len(inst.Profiles()))
+		for _, profile := range inst.Profiles() {
+			profileNames = append(profileNames, profile.Name)
+
+			_, ok := oldProfiles[prefixDelegationProfile{project: profile.Project, name: profile.Name}]
+			if ok {
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		// Supply the new profile config so that the profile changes get detected.
+		var profiles []api.Profile
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			profiles, err = tx.GetProfiles(ctx, inst.Project().Name, profileNames)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed loading profiles of instance %q in project %q: %w", inst.Name(), inst.Project().Name, err))
+			continue
+		}
+
+		args := db.InstanceArgs{
+			Architecture: inst.Architecture(),
+			Config:       inst.LocalConfig(),
+			Description:  inst.Description(),
+			Devices:      devices,
+			Ephemeral:    inst.IsEphemeral(),
+			Profiles:     profiles,
+			Project:      inst.Project().Name,
+			Type:         inst.Type(),
+			Snapshot:     inst.IsSnapshot(),
+		}
+
+		err = inst.Update(args, true)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed renumbering instance %q in project %q: %w", inst.Name(), inst.Project().Name, err))
+		}
+	}
+
+	if len(oldProfiles) > 0 {
+		notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		err = notifier(func(client incus.InstanceServer) error {
+			var notifyErrs []error
+
+			for profile, oldProfile := range oldProfiles {
+				err := client.UseProject(profile.project).UpdateProfile(profile.name, oldProfile, "")
+				if err != nil {
+					notifyErrs = append(notifyErrs, fmt.Errorf("Failed notifying cluster member of the renumbering of profile %q in project %q: %w", profile.name, profile.project, err))
+				}
+			}
+
+			return errors.Join(notifyErrs...)
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// prefixDelegationReport records a warning on the network when applying a change of its delegated prefix failed,
+// and resolves it once a change got applied.
+func prefixDelegationReport(s *state.State, l logger.Logger, projectName string, networkID int64, applyErr error) {
+	if applyErr != nil {
+		l.Error("Failed applying delegated prefix change", logger.Ctx{"err": applyErr})
+
+		err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpsertWarningLocalNode(ctx, projectName, dbCluster.TypeNetwork, int(networkID), warningtype.PrefixDelegationFailure, applyErr.Error())
+		})
+		if err != nil {
+			l.Warn("Failed to create warning", logger.Ctx{"err": err})
+		}
+
+		return
+	}
+
+	err := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, projectName, warningtype.PrefixDelegationFailure, dbCluster.TypeNetwork, int(networkID))
+	if err != nil {
+		l.Warn("Failed to resolve warning", logger.Ctx{"err": err})
+	}
+}
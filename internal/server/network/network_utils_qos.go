@@ -0,0 +1,214 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/units"
+)
+
+// qosHandleMajor is the major number of the HTB qdisc shaping the traffic of the QoS classes.
+const qosHandleMajor = 1
+
+// qosDSCPNames maps the DSCP names to their value.
+var qosDSCPNames = map[string]uint8{
+	"CS0": 0, "CS1": 8, "CS2": 16, "CS3": 24, "CS4": 32, "CS5": 40, "CS6": 48, "CS7": 56,
+	"AF11": 10, "AF12": 12, "AF13": 14,
+	"AF21": 18, "AF22": 20, "AF23": 22,
+	"AF31": 26, "AF32": 28, "AF33": 30,
+	"AF41": 34, "AF42": 36, "AF43": 38,
+	"EF": 46, "LE": 1,
+}
+
+// qosClass represents a QoS class defined on a network.
+type qosClass struct {
+	name     string
+	rate     string
+	ceil     string
+	priority uint32
+}
+
+// QoSInterfaceName returns the name of the interface shaping the traffic of the network's QoS classes.
+func QoSInterfaceName(networkName string) string {
+	return fmt.Sprintf("%s-qos", networkName)
+}
+
+// qosClassMinor returns the minor number of the class ID of a QoS class.
+// It is derived from the class name so that it remains stable when other classes are added or removed.
+func qosClassMinor(className string) uint16 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(className))
+
+	// Keep minor numbers 0x0 to 0xf for internal use.
+	return uint16(0x10 + h.Sum32()%(0xffff-0x10))
+}
+
+// QoSClassPriority returns the skb priority to set on packets to put them into a QoS class.
+// This is the class ID (major:minor) of the class in the HTB qdisc.
+func QoSClassPriority(className string) uint32 {
+	return qosHandleMajor<<16 | uint32(qosClassMinor(className))
+}
+
+// ParseDSCP parses a DSCP value, either numeric (0-63) or named (such as `EF`, `AF41` or `CS1`).
+func ParseDSCP(value string) (uint8, error) {
+	dscp, found := qosDSCPNames[strings.ToUpper(value)]
+	if found {
+		return dscp, nil
+	}
+
+	number, err := strconv.ParseUint(value, 10, 8)
+	if err != nil || number > 63 {
+		return 0, fmt.Errorf("Invalid DSCP value %q", value)
+	}
+
+	return uint8(number), nil
+}
+
+// QoSClassExists returns whether the network config defines the QoS class.
+func QoSClassExists(netConfig map[string]string, className string) bool {
+	return netConfig[fmt.Sprintf("qos.classes.%s.rate", className)] != ""
+}
+
+// QoSClassDSCP returns the DSCP value to mark the traffic of the QoS class with, or -1 if none.
+func QoSClassDSCP(netConfig map[string]string, className string) (int, error) {
+	value := netConfig[fmt.Sprintf("qos.classes.%s.dscp", className)]
+	if value == "" {
+		return -1, nil
+	}
+
+	dscp, err := ParseDSCP(value)
+	if err != nil {
+		return -1, err
+	}
+
+	return int(dscp), nil
+}
+
+// qosClasses returns the QoS classes defined in the network config, sorted by name.
+func qosClasses(config map[string]string) ([]qosClass, error) {
+	var classes []qosClass
+
+	for k := range config {
+		className, ok := strings.CutPrefix(k, "qos.classes.")
+		if !ok {
+			continue
+		}
+
+		className, ok = strings.CutSuffix(className, ".rate")
+		if !ok {
+			continue
+		}
+
+		class := qosClass{
+			name: className,
+			rate: config[k],
+			ceil: config[fmt.Sprintf("qos.classes.%s.ceil", className)],
+		}
+
+		if class.ceil == "" {
+			class.ceil = class.rate
+		}
+
+		priority := config[fmt.Sprintf("qos.classes.%s.priority", className)]
+		if priority != "" {
+			value, err := strconv.ParseUint(priority, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid priority for QoS class %q: %w", className, err)
+			}
+
+			class.priority = uint32(value)
+		}
+
+		classes = append(classes, class)
+	}
+
+	slices.SortFunc(classes, func(a qosClass, b qosClass) int { return strings.Compare(a.name, b.name) })
+
+	return classes, nil
+}
+
+// qosValidate checks the QoS classes of a network config.
+func qosValidate(networkName string, config map[string]string) error {
+	for k := range config {
+		fields := strings.Split(k, ".")
+		if len(fields) != 4 || fields[0] != "qos" || fields[1] != "classes" {
+			continue
+		}
+
+		className := fields[2]
+		if !QoSClassExists(config, className) {
+			return fmt.Errorf("QoS class %q requires %q to be set", className, fmt.Sprintf("qos.classes.%s.rate", className))
+		}
+	}
+
+	classes, err := qosClasses(config)
+	if err != nil {
+		return err
+	}
+
+	if len(classes) > 0 && len(QoSInterfaceName(networkName)) > 15 {
+		return fmt.Errorf("Network name too long for QoS interface: %s", QoSInterfaceName(networkName))
+	}
+
+	minors := map[uint16]string{}
+	var totalRate int64
+
+	for _, class := range classes {
+		rate, err := units.ParseBitSizeString(class.rate)
+		if err != nil {
+			return fmt.Errorf("Invalid rate for QoS class %q: %w", class.name, err)
+		}
+
+		ceil, err := units.ParseBitSizeString(class.ceil)
+		if err != nil {
+			return fmt.Errorf("Invalid ceil for QoS class %q: %w", class.name, err)
+		}
+
+		if ceil < rate {
+			return fmt.Errorf("The ceil of QoS class %q can't be lower than its rate", class.name)
+		}
+
+		totalRate += rate
+
+		otherClass, found := minors[qosClassMinor(class.name)]
+		if found {
+			return fmt.Errorf("QoS classes %q and %q conflict, please rename one of them", otherClass, class.name)
+		}
+
+		minors[qosClassMinor(class.name)] = class.name
+	}
+
+	if config["qos.rate"] != "" {
+		if len(classes) == 0 {
+			return errors.New(`"qos.rate" requires QoS classes to be defined`)
+		}
+
+		rate, err := units.ParseBitSizeString(config["qos.rate"])
+		if err != nil {
+			return fmt.Errorf(`Invalid "qos.rate": %w`, err)
+		}
+
+		if rate < totalRate {
+			return errors.New(`"qos.rate" can't be lower than the sum of the rates of the QoS classes`)
+		}
+	}
+
+	return nil
+}
+
+// qosConfigChanged returns whether any of the QoS settings differs between the two network configurations.
+func qosConfigChanged(oldConfig map[string]string, newConfig map[string]string) bool {
+	for _, config := range []map[string]string{oldConfig, newConfig} {
+		for k := range config {
+			if strings.HasPrefix(k, "qos.") && oldConfig[k] != newConfig[k] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/lxc/incus/v6/internal/iprange"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 )
 
 func Example_parseIPRange() {
@@ -180,3 +181,126 @@ func Example_complementRanges() {
 	// Range2: 10.1.1.1-10.1.1.9, 10.1.1.101-10.1.1.199, 10.1.1.231-10.1.1.254
 	// Range3: 10.1.1.1-10.1.1.9, 10.1.1.26-10.1.1.254
 }
+
+func Example_prefixDelegationSubnet() {
+	for _, delegated := range []string{"2001:db8:1200::/56", "2001:db8:1234:5678::/64", "2001:db8:1234:5678::/80"} {
+		_, prefix, _ := net.ParseCIDR(delegated)
+
+		for _, index := range []uint64{0, 1, 255, 256} {
+			subnet, err := prefixDelegationSubnet(prefix, index)
+			if err != nil {
+				fmt.Printf("%s #%d: %v\n", delegated, index, err)
+				continue
+			}
+
+			fmt.Printf("%s #%d: %s\n", delegated, index, subnet.String())
+		}
+	}
+
+	// Output: 2001:db8:1200::/56 #0: 2001:db8:1200::/64
+	// 2001:db8:1200::/56 #1: 2001:db8:1200:1::/64
+	// 2001:db8:1200::/56 #255: 2001:db8:1200:ff::/64
+	// 2001:db8:1200::/56 #256: Subnet index 256 is out of the range of delegated prefix "2001:db8:1200::/56"
+	// 2001:db8:1234:5678::/64 #0: 2001:db8:1234:5678::/64
+	// 2001:db8:1234:5678::/64 #1: Subnet index 1 is out of the range of delegated prefix "2001:db8:1234:5678::/64"
+	// 2001:db8:1234:5678::/64 #255: Subnet index 255 is out of the range of delegated prefix "2001:db8:1234:5678::/64"
+	// 2001:db8:1234:5678::/64 #256: Subnet index 256 is out of the range of delegated prefix "2001:db8:1234:5678::/64"
+	// 2001:db8:1234:5678::/80 #0: Delegated prefix "2001:db8:1234:5678::/80" is too small to hold /64 subnets
+	// 2001:db8:1234:5678::/80 #1: Delegated prefix "2001:db8:1234:5678::/80" is too small to hold /64 subnets
+	// 2001:db8:1234:5678::/80 #255: Delegated prefix "2001:db8:1234:5678::/80" is too small to hold /64 subnets
+	// 2001:db8:1234:5678::/80 #256: Delegated prefix "2001:db8:1234:5678::/80" is too small to hold /64 subnets
+}
+
+func Example_prefixDelegationRenumberList() {
+	_, oldPrefix, _ := net.ParseCIDR("2001:db8:1200::/56")
+	_, newPrefix, _ := net.ParseCIDR("2001:db8:ab00::/56")
+	_, smallerPrefix, _ := net.ParseCIDR("2001:db8:ab00::/60")
+
+	fmt.Println(prefixDelegationRenumberList("2001:db8:1200::10", oldPrefix, newPrefix))
+	fmt.Println(prefixDelegationRenumberList("2001:db8:1200:3::10, 2001:db8:ffff::10", oldPrefix, newPrefix))
+	fmt.Println(prefixDelegationRenumberList("2001:db8:1200:1:1::/80,192.0.2.0/24", oldPrefix, newPrefix))
+	fmt.Println(prefixDelegationRenumberList("2001:db8:ffff::10, 2001:db8:ffff::11", oldPrefix, newPrefix))
+	fmt.Println(prefixDelegationRenumberList("2001:db8:1200::10", oldPrefix, smallerPrefix))
+
+	// Output: 2001:db8:ab00::10
+	// 2001:db8:ab00:3::10,2001:db8:ffff::10
+	// 2001:db8:ab00:1:1::/80,192.0.2.0/24
+	// 2001:db8:ffff::10, 2001:db8:ffff::11
+	// 2001:db8:1200::10
+}
+
+func Example_prefixDelegationRenumberDevices() {
+	_, oldPrefix, _ := net.ParseCIDR("2001:db8:1200::/56")
+	_, newPrefix, _ := net.ParseCIDR("2001:db8:ab00::/56")
+
+	devices := deviceConfig.Devices{
+		"eth0": {"type": "nic", "network": "incusbr0", "ipv6.address": "2001:db8:1200::10"},
+		"eth1": {"type": "nic", "nictype": "routed", "parent": "eth0", "ipv6.address": "2001:db8:1200:ff::10", "ipv6.routes": "2001:db8:1200:fe::/64"},
+		"eth2": {"type": "nic", "network": "incusbr1", "ipv6.address": "2001:db8:ffff::10"},
+		"root": {"type": "disk", "path": "/", "pool": "default"},
+	}
+
+	fmt.Println(prefixDelegationRenumberDevices(devices, oldPrefix, newPrefix))
+	fmt.Println(devices["eth0"]["ipv6.address"])
+	fmt.Println(devices["eth1"]["ipv6.address"], devices["eth1"]["ipv6.routes"])
+	fmt.Println(devices["eth2"]["ipv6.address"])
+	fmt.Println(prefixDelegationRenumberDevices(devices, oldPrefix, newPrefix))
+
+	// Output: true
+	// 2001:db8:ab00::10
+	// 2001:db8:ab00:ff::10 2001:db8:ab00:fe::/64
+	// 2001:db8:ffff::10
+	// false
+}
+
+func Example_qosValidate() {
+	configs := []map[string]string{
+		{"qos.classes.gold.rate": "50Mbit", "qos.classes.gold.ceil": "100Mbit", "qos.classes.bronze.rate": "10Mbit"},
+		{"qos.classes.gold.rate": "50Mbit", "qos.classes.gold.ceil": "10Mbit"},
+		{"qos.classes.gold.dscp": "EF"},
+		{"qos.classes.gold.rate": "50Mbit", "qos.rate": "40Mbit"},
+		{"qos.rate": "40Mbit"},
+	}
+
+	for _, config := range configs {
+		fmt.Println(qosValidate("incusbr0", config))
+	}
+
+	fmt.Println(qosValidate("incusbr0123456", configs[0]))
+
+	// Output: <nil>
+	// The ceil of QoS class "gold" can't be lower than its rate
+	// QoS class "gold" requires "qos.classes.gold.rate" to be set
+	// "qos.rate" can't be lower than the sum of the rates of the QoS classes
+	// "qos.rate" requires QoS classes to be defined
+	// Network name too long for QoS interface: incusbr0123456-qos
+}
+
+func Example_qosConfigChanged() {
+	oldConfig := map[string]string{"qos.classes.gold.rate": "50Mbit", "ipv4.address": "10.0.0.1/24"}
+
+	fmt.Println(qosConfigChanged(oldConfig, map[string]string{"qos.classes.gold.rate": "50Mbit", "ipv4.address": "10.0.1.1/24"}))
+	fmt.Println(qosConfigChanged(oldConfig, map[string]string{"qos.classes.gold.rate": "60Mbit", "ipv4.address": "10.0.0.1/24"}))
+	fmt.Println(qosConfigChanged(oldConfig, map[string]string{"ipv4.address": "10.0.0.1/24"}))
+	fmt.Println(qosConfigChanged(oldConfig, map[string]string{"qos.classes.gold.rate": "50Mbit", "qos.rate": "1Gbit", "ipv4.address": "10.0.0.1/24"}))
+
+	// Output: false
+	// true
+	// true
+	// true
+}
+
+func ExampleParseDSCP() {
+	for _, value := range []string{"EF", "af41", "cs1", "0", "63", "64", "BE"} {
+		dscp, err := ParseDSCP(value)
+		fmt.Println(value, dscp, err)
+	}
+
+	// Output: EF 46 <nil>
+	// af41 34 <nil>
+	// cs1 8 <nil>
+	// 0 0 <nil>
+	// 63 63 <nil>
+	// 64 0 Invalid DSCP value "64"
+	// BE 0 Invalid DSCP value "BE"
+}
@@ -82,6 +82,8 @@ const (
 	ovnExtIDIncusProjectID  = "incus_project_id"
 	ovnExtIDIncusPortGroup  = "incus_port_group"
 	ovnExtIDIncusLocation   = "incus_location"
+	ovnExtIDIncusACLRule    = "incus_acl_rule"
+	ovnExtIDIncusLB         = "incus_load_balancer"
 )
 
 // OVNIPv6RAOpts IPv6 router advertisements options that can be applied to a router.
@@ -145,6 +147,8 @@ type OVNACLRule struct {
 	Priority  int    // Priority (between 0 and 32767, inclusive). Higher values take precedence.
 	Log       bool   // Whether or not to log matched packets.
 	LogName   string // Log label name (requires Log be true).
+
+	CounterName string // Optional, name identifying the rule when retrieving its hit counters.
 }
 
 // OVNLoadBalancerTarget represents an OVN load balancer Virtual IP target.
@@ -173,6 +177,14 @@ type OVNLoadBalancerVIP struct {
 	Targets       []OVNLoadBalancerTarget
 }
 
+// OVNLoadBalancerConnectionLimit represents a limit of new TCP connections to OVN load balancer Virtual IP ports.
+type OVNLoadBalancerConnectionLimit struct {
+	ListenAddress     net.IP
+	ListenPorts       []uint64
+	Targets           []OVNLoadBalancerTarget
+	MaxConnectionRate int64 // New connections per second.
+}
+
 // OVNRouterRoute represents a static route added to a logical router.
 type OVNRouterRoute struct {
 	Prefix  net.IPNet
@@ -1674,6 +1686,26 @@ func (o *NB) logicalSwitchPortACLRules(ctx context.Context, portName OVNSwitchPo
 	return ruleUUIDs, nil
 }
 
+// GetACLRuleCounterUUIDs returns the UUIDs of the ACL rules carrying a counter name, indexed by counter name.
+func (o *NB) GetACLRuleCounterUUIDs(ctx context.Context) (map[string][]string, error) {
+	acls := []ovnNB.ACL{}
+
+	err := o.client.WhereCache(func(acl *ovnNB.ACL) bool {
+		return acl.ExternalIDs != nil && acl.ExternalIDs[ovnExtIDIncusACLRule] != ""
+	}).List(ctx, &acls)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleUUIDs := map[string][]string{}
+	for _, acl := range acls {
+		counterName := acl.ExternalIDs[ovnExtIDIncusACLRule]
+		ruleUUIDs[counterName] = append(ruleUUIDs[counterName], acl.UUID)
+	}
+
+	return ruleUUIDs, nil
+}
+
 // GetLogicalSwitchPorts returns a map of logical switch ports (name and UUID) for a switch.
 // Includes non-instance ports, such as the router port.
 func (o *NB) GetLogicalSwitchPorts(ctx context.Context, switchName OVNSwitch) (map[OVNSwitchPort]OVNSwitchPortUUID, error) {
@@ -2445,6 +2477,12 @@ func (o *NB) UpdateLogicalSwitchPortLinkRouter(ctx context.Context, switchPortNa
 
 // UpdateLogicalSwitchPortLinkProviderNetwork links a logical switch port to a provider network.
 func (o *NB) UpdateLogicalSwitchPortLinkProviderNetwork(ctx context.Context, switchPortName OVNSwitchPort, extNetworkName string) error {
+	return o.UpdateLogicalSwitchPortLinkProviderNetworkVLAN(ctx, switchPortName, extNetworkName, 0)
+}
+
+// UpdateLogicalSwitchPortLinkProviderNetworkVLAN links a logical switch port to a VLAN of a provider network.
+// A VLAN of 0 links the port to the untagged provider network.
+func (o *NB) UpdateLogicalSwitchPortLinkProviderNetworkVLAN(ctx context.Context, switchPortName OVNSwitchPort, extNetworkName string, vlan uint16) error {
 	// Get the logical switch port.
 	lsp := ovnNB.LogicalSwitchPort{
 		Name: string(switchPortName),
@@ -2464,8 +2502,15 @@ func (o *NB) UpdateLogicalSwitchPortLinkProviderNetwork(ctx context.Context, swi
 
 	lsp.Options["network_name"] = extNetworkName
 
-	// Update the record.
-	operations, err := o.client.Where(&lsp).Update(&lsp)
+	if vlan > 0 {
+		tag := int(vlan)
+		lsp.Tag = &tag
+	} else {
+		lsp.Tag = nil
+	}
+
+	// Update the record, listing the fields as a nil tag would otherwise be skipped.
+	operations, err := o.client.Where(&lsp).Update(&lsp, &lsp.Type, &lsp.Addresses, &lsp.Options, &lsp.Tag)
 	if err != nil {
 		return err
 	}
@@ -2957,6 +3002,10 @@ func (o *NB) aclRuleAddOperations(ctx context.Context, entityTable string, entit
 
 		maps.Copy(acl.ExternalIDs, externalIDs)
 
+		if rule.CounterName != "" {
+			acl.ExternalIDs[ovnExtIDIncusACLRule] = rule.CounterName
+		}
+
 		createOps, err := o.client.Create(&acl)
 		if err != nil {
 			return nil, err
@@ -3444,6 +3493,149 @@ func (o *NB) CreateLoadBalancer(ctx context.Context, loadBalancerName OVNLoadBal
 	return nil
 }
 
+// UpdateLoadBalancerConnectionLimits replaces the connection limits of the load balancer on the specified switch.
+// The limits are applied as QoS meters on the TCP SYN packets, both to the listen address (for traffic coming
+// from the switch) and to the targets (for traffic which went through the router, identified by its switch port).
+// As OVN can't count connections, the rate is only approximate as it assumes the usual size of a SYN packet.
+// Providing an empty set of limits will remove them.
+func (o *NB) UpdateLoadBalancerConnectionLimits(ctx context.Context, loadBalancerName OVNLoadBalancer, switchName OVNSwitch, routerPortName OVNSwitchPort, limits ...OVNLoadBalancerConnectionLimit) error {
+	operations := []ovsdb.Operation{}
+
+	// Get the logical switch.
+	ls, err := o.GetLogicalSwitch(ctx, switchName)
+	if err != nil {
+		return err
+	}
+
+	// Remove the existing limits.
+	qosRules := []ovnNB.QoS{}
+	err = o.client.WhereCache(func(qos *ovnNB.QoS) bool {
+		return qos.ExternalIDs != nil && qos.ExternalIDs[ovnExtIDIncusLB] == string(loadBalancerName)
+	}).List(ctx, &qosRules)
+	if err != nil {
+		return err
+	}
+
+	for _, qos := range qosRules {
+		if !slices.Contains(ls.QOSRules, qos.UUID) {
+			continue
+		}
+
+		updateOps, err := o.client.Where(ls).Mutate(ls, ovsModel.Mutation{
+			Field:   &ls.QOSRules,
+			Mutator: ovsdb.MutateOperationDelete,
+			Value:   []string{qos.UUID},
+		})
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, updateOps...)
+	}
+
+	// portSet returns the OVN match set of the ports.
+	portSet := func(ports []uint64) string {
+		values := make([]string, 0, len(ports))
+		for _, port := range ports {
+			values = append(values, fmt.Sprintf("%d", port))
+		}
+
+		return fmt.Sprintf("{%s}", strings.Join(values, ", "))
+	}
+
+	// Add the new limits.
+	for i, limit := range limits {
+		if limit.ListenAddress == nil || len(limit.ListenPorts) == 0 {
+			return errors.New("Missing connection limit listen address or port(s)")
+		}
+
+		if limit.MaxConnectionRate <= 0 {
+			continue
+		}
+
+		ipVersion := "ip4"
+		synSize := 74 // Ethernet, IPv4 and TCP headers, including the usual SYN options.
+		if limit.ListenAddress.To4() == nil {
+			ipVersion = "ip6"
+			synSize = 94
+		}
+
+		match := fmt.Sprintf("tcp.flags == 0x2 && ((%s.dst == %s && tcp.dst == %s)", ipVersion, limit.ListenAddress.String(), portSet(limit.ListenPorts))
+
+		targetAddresses := []string{}
+		targetPorts := []uint64{}
+		for _, target := range limit.Targets {
+			if !slices.Contains(targetAddresses, target.Address.String()) {
+				targetAddresses = append(targetAddresses, target.Address.String())
+			}
+
+			if !slices.Contains(targetPorts, target.Port) {
+				targetPorts = append(targetPorts, target.Port)
+			}
+		}
+
+		if len(targetAddresses) > 0 {
+			match += fmt.Sprintf(" || (inport == %q && %s.dst == {%s} && tcp.dst == %s)", routerPortName, ipVersion, strings.Join(targetAddresses, ", "), portSet(targetPorts))
+		}
+
+		match += ")"
+
+		// The meters are in kbps so convert the connection rate using the size of a SYN packet.
+		rate := int((limit.MaxConnectionRate*int64(synSize)*8 + 999) / 1000)
+
+		qos := &ovnNB.QoS{
+			UUID:      fmt.Sprintf("qos%d", i),
+			Direction: ovnNB.QoSDirectionFromLport,
+			Priority:  1000,
+			Match:     match,
+			Bandwidth: map[string]int{
+				"rate":  rate,
+				"burst": rate,
+			},
+			ExternalIDs: map[string]string{
+				ovnExtIDIncusSwitch: string(switchName),
+				ovnExtIDIncusLB:     string(loadBalancerName),
+			},
+		}
+
+		createOps, err := o.client.Create(qos)
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, createOps...)
+
+		updateOps, err := o.client.Where(ls).Mutate(ls, ovsModel.Mutation{
+			Field:   &ls.QOSRules,
+			Mutator: ovsdb.MutateOperationInsert,
+			Value:   []string{qos.UUID},
+		})
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, updateOps...)
+	}
+
+	// Check if anything to change.
+	if len(operations) == 0 {
+		return nil
+	}
+
+	// Apply the changes.
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // DeleteLoadBalancer deletes the specified load balancer(s).
 func (o *NB) DeleteLoadBalancer(ctx context.Context, loadBalancerNames ...OVNLoadBalancer) error {
 	operations := []ovsdb.Operation{}
@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ovn-kubernetes/libovsdb/ovsdb"
+
 	ovnNB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-nb"
 	ovnSB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-sb"
 )
@@ -96,3 +98,63 @@ func (o *SB) CheckLoadBalancerOnline(ctx context.Context, lb ovnNB.LoadBalancer)
 
 	return false, nil
 }
+
+// GetACLFlowCookies returns the OpenFlow cookies of the logical flows generated for each of the provided ACL rules.
+// ovn-northd tags the logical flows with the first 32 bits of the ACL rule UUID (stage-hint) and ovn-controller
+// uses the first 32 bits of the logical flow UUID as the cookie of the resulting OpenFlow flows.
+func (o *SB) GetACLFlowCookies(ctx context.Context, aclUUIDs []string) (map[string][]uint64, error) {
+	cookies := map[string][]uint64{}
+	if len(aclUUIDs) == 0 {
+		return cookies, nil
+	}
+
+	// The logical flows aren't cached, so query them directly.
+	operations := make([]ovsdb.Operation, 0, len(aclUUIDs))
+	for _, aclUUID := range aclUUIDs {
+		operations = append(operations, ovsdb.Operation{
+			Op:      ovsdb.OperationSelect,
+			Table:   ovnSB.LogicalFlowTable,
+			Columns: []string{"_uuid"},
+			Where: []ovsdb.Condition{
+				ovsdb.NewCondition("external_ids", ovsdb.ConditionIncludes, ovsdb.OvsMap{GoMap: map[any]any{"stage-hint": uuidCookie(aclUUID)}}),
+			},
+		})
+	}
+
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range resp[:len(aclUUIDs)] {
+		for _, row := range result.Rows {
+			flowUUID, ok := row["_uuid"].(ovsdb.UUID)
+			if !ok {
+				continue
+			}
+
+			cookie, err := strconv.ParseUint(uuidCookie(flowUUID.GoUUID), 16, 64)
+			if err != nil {
+				continue
+			}
+
+			cookies[aclUUIDs[i]] = append(cookies[aclUUIDs[i]], cookie)
+		}
+	}
+
+	return cookies, nil
+}
+
+// uuidCookie returns the first 32 bits of an UUID, in hexadecimal form.
+func uuidCookie(uuid string) string {
+	if len(uuid) < 8 {
+		return uuid
+	}
+
+	return uuid[:8]
+}
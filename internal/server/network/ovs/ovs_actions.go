@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/lxc/incus/v6/internal/server/ip"
 	ovsSwitch "github.com/lxc/incus/v6/internal/server/network/ovs/schema/ovs"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -531,6 +533,78 @@ func (o *VSwitch) GetOVNEncapIP(ctx context.Context) (net.IP, error) {
 	return encapIP, nil
 }
 
+// GetOVNIntegrationBridge returns the name of the bridge used by OVN for its logical flows.
+func (o *VSwitch) GetOVNIntegrationBridge(ctx context.Context) (string, error) {
+	// Get the root switch.
+	vSwitch := &ovsSwitch.OpenvSwitch{
+		UUID: o.rootUUID,
+	}
+
+	err := o.client.Get(ctx, vSwitch)
+	if err != nil {
+		return "", err
+	}
+
+	// Return the integration bridge.
+	bridgeName := vSwitch.ExternalIDs["ovn-bridge"]
+	if bridgeName == "" {
+		return "br-int", nil
+	}
+
+	return bridgeName, nil
+}
+
+// OVSFlowStatistics represents the hit counters of a set of OpenFlow flows.
+type OVSFlowStatistics struct {
+	Packets int64
+	Bytes   int64
+}
+
+// GetFlowStatistics returns the hit counters of the OpenFlow flows of a bridge, added up by flow cookie.
+func (o *VSwitch) GetFlowStatistics(ctx context.Context, bridgeName string) (map[uint64]OVSFlowStatistics, error) {
+	// The flows aren't stored in the database, so retrieve them from the switch itself.
+	output, err := subprocess.RunCommandContext(ctx, "ovs-ofctl", "dump-flows", bridgeName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting flows of bridge %q: %w", bridgeName, err)
+	}
+
+	statistics := map[uint64]OVSFlowStatistics{}
+	for _, line := range strings.Split(output, "\n") {
+		var cookie uint64
+		var flowStatistics OVSFlowStatistics
+		var hasCookie bool
+
+		// Each flow looks like: "cookie=0x1a2b3c4d, duration=1.2s, table=44, n_packets=1, n_bytes=98, ...".
+		for _, field := range strings.Fields(line) {
+			key, value, found := strings.Cut(strings.TrimSuffix(field, ","), "=")
+			if !found {
+				continue
+			}
+
+			switch key {
+			case "cookie":
+				cookie, err = strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 64)
+				hasCookie = err == nil
+			case "n_packets":
+				flowStatistics.Packets, _ = strconv.ParseInt(value, 10, 64)
+			case "n_bytes":
+				flowStatistics.Bytes, _ = strconv.ParseInt(value, 10, 64)
+			}
+		}
+
+		if !hasCookie {
+			continue
+		}
+
+		total := statistics[cookie]
+		total.Packets += flowStatistics.Packets
+		total.Bytes += flowStatistics.Bytes
+		statistics[cookie] = total
+	}
+
+	return statistics, nil
+}
+
 // GetOVNBridgeMappings gets the current OVN bridge mappings.
 func (o *VSwitch) GetOVNBridgeMappings(ctx context.Context, bridgeName string) ([]string, error) {
 	// Get the root switch.
@@ -0,0 +1,354 @@
+package zone
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/dns"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// DNSSEC key roles.
+const (
+	dnssecRoleKSK = "ksk"
+	dnssecRoleZSK = "zsk"
+)
+
+// DNSSEC key states.
+// Published zone signing keys are only listed in the zone while published key signing keys also sign it.
+// Retired keys remain listed in the zone until caches and secondaries stopped relying on them.
+const (
+	dnssecStatePublished = "published"
+	dnssecStateActive    = "active"
+	dnssecStateRetired   = "retired"
+)
+
+// dnssecDefaultAlgorithm is the algorithm used when dnssec.algorithm isn't set.
+const dnssecDefaultAlgorithm = "ECDSAP256SHA256"
+
+// dnssecDefaultZSKLifetime is the lifetime of zone signing keys when dnssec.zsk.lifetime isn't set.
+const dnssecDefaultZSKLifetime = "30d"
+
+// dnssecPropagationDelay is how long a new zone signing key is published before being used
+// and how long retired keys remain published. It covers the DNSKEY TTL and the zone refresh of secondaries.
+const dnssecPropagationDelay = 2 * time.Hour
+
+// dnssecKSKRolloverDelay is how long both key signing keys sign the zone during a rollover,
+// leaving time to replace the DS record in the parent zone.
+const dnssecKSKRolloverDelay = 7 * 24 * time.Hour
+
+// dnssecResignInterval is how long a signed zone is reused while its content and keys don't change.
+// It is well below the validity of the signatures so that resolvers never see expired ones.
+const dnssecResignInterval = 24 * time.Hour
+
+// dnssecSignedZone is the signed content of a zone along with the hash of what it was signed from.
+type dnssecSignedZone struct {
+	hash     string
+	content  string
+	signedAt time.Time
+}
+
+// dnssecSignedZones holds the last signed content of each zone, keyed by zone ID.
+var (
+	dnssecSignedZones   = map[int64]*dnssecSignedZone{}
+	dnssecSignedZonesMu sync.Mutex
+)
+
+// dnssecSOASerial matches the serial of the SOA records, which changes on every render of the zone.
+var dnssecSOASerial = regexp.MustCompile(`(?m)( IN SOA \S+ \S+ )\d+ `)
+
+// dnssecSignedHash returns the hash identifying the signed content of the zone, ignoring the SOA serial.
+func dnssecSignedHash(content string, keys []dns.Key, nsec3 bool) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(dnssecSOASerial.ReplaceAllString(content, "${1}0 ")))
+
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(h, "\n%s %t", key.Public, key.Signing)
+	}
+
+	_, _ = fmt.Fprintf(h, "\n%t", nsec3)
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// dnssecKeys returns the DNSSEC keys of the zone.
+func (d *zone) dnssecKeys() ([]dbCluster.NetworkZoneKeyPair, error) {
+	var keys []dbCluster.NetworkZoneKeyPair
+
+	err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		zoneID := int(d.id)
+		keys, err = dbCluster.GetNetworkZoneKeyPairs(ctx, tx.Tx(), dbCluster.NetworkZoneKeyPairFilter{NetworkZoneID: &zoneID})
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading DNSSEC keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// sign signs the zone content when DNSSEC is enabled.
+func (d *zone) sign(content *strings.Builder) (*strings.Builder, error) {
+	if util.IsFalseOrEmpty(d.info.Config["dnssec.enabled"]) {
+		return content, nil
+	}
+
+	keys, err := d.dnssecKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	signingKeys := make([]dns.Key, 0, len(keys))
+	for _, key := range keys {
+		signingKeys = append(signingKeys, dns.Key{
+			Public:  key.PublicKey,
+			Private: key.PrivateKey,
+			Signing: key.State == dnssecStateActive || (key.Role == dnssecRoleKSK && key.State == dnssecStatePublished),
+		})
+	}
+
+	// Reuse the signed zone if nothing but the SOA serial changed since it was signed.
+	now := time.Now()
+	nsec3 := util.IsTrue(d.info.Config["dnssec.nsec3"])
+	hash := dnssecSignedHash(content.String(), signingKeys, nsec3)
+
+	dnssecSignedZonesMu.Lock()
+	cached := dnssecSignedZones[d.id]
+	dnssecSignedZonesMu.Unlock()
+
+	sb := &strings.Builder{}
+	if cached != nil && cached.hash == hash && now.Sub(cached.signedAt) < dnssecResignInterval {
+		sb.WriteString(cached.content)
+		return sb, nil
+	}
+
+	signed, err := dns.SignZone(d.info.Name, content.String(), signingKeys, nsec3, now)
+	if err != nil {
+		return nil, fmt.Errorf("Failed signing zone %q: %w", d.info.Name, err)
+	}
+
+	dnssecSignedZonesMu.Lock()
+	dnssecSignedZones[d.id] = &dnssecSignedZone{hash: hash, content: signed, signedAt: now}
+	dnssecSignedZonesMu.Unlock()
+
+	sb.WriteString(signed)
+
+	return sb, nil
+}
+
+// DNSSEC returns the DNSSEC keys of the zone along with the DS records for the parent zone.
+// It returns nil when DNSSEC isn't enabled on the zone.
+func (d *zone) DNSSEC() (*api.NetworkZoneDNSSEC, error) {
+	if util.IsFalseOrEmpty(d.info.Config["dnssec.enabled"]) {
+		return nil, nil
+	}
+
+	keys, err := d.dnssecKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &api.NetworkZoneDNSSEC{
+		DS:   []string{},
+		Keys: []api.NetworkZoneDNSSECKey{},
+	}
+
+	for _, key := range keys {
+		tag, algorithm, err := dns.KeyInfo(key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		info.Keys = append(info.Keys, api.NetworkZoneDNSSECKey{
+			Tag:       int(tag),
+			Role:      key.Role,
+			State:     key.State,
+			Algorithm: algorithm,
+			CreatedAt: key.CreatedAt,
+			UpdatedAt: key.UpdatedAt,
+		})
+
+		// The parent zone should point to all key signing keys which sign the zone.
+		if key.Role != dnssecRoleKSK || key.State == dnssecStateRetired {
+			continue
+		}
+
+		ds, err := dns.DSRecord(key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		info.DS = append(info.DS, ds)
+	}
+
+	return info, nil
+}
+
+// RolloverDNSSECKeys generates, rolls over and removes the DNSSEC keys of the zone as needed.
+// A new key is created when the active one reached its lifetime. Zone signing keys are published
+// for a while before being used while key signing keys are used alongside the previous key to
+// allow the DS record in the parent zone to be replaced.
+func (d *zone) RolloverDNSSECKeys() error {
+	now := time.Now().UTC()
+
+	algorithm := d.info.Config["dnssec.algorithm"]
+	if algorithm == "" {
+		algorithm = dnssecDefaultAlgorithm
+	}
+
+	return d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		zoneID := int(d.id)
+
+		// Remove all keys once DNSSEC is disabled.
+		if util.IsFalseOrEmpty(d.info.Config["dnssec.enabled"]) {
+			return dbCluster.DeleteNetworkZoneKeyPairs(ctx, tx.Tx(), zoneID)
+		}
+
+		keys, err := dbCluster.GetNetworkZoneKeyPairs(ctx, tx.Tx(), dbCluster.NetworkZoneKeyPairFilter{NetworkZoneID: &zoneID})
+		if err != nil {
+			return err
+		}
+
+		createKey := func(role string, state string) error {
+			public, private, err := dns.GenerateKey(d.info.Name, algorithm, role == dnssecRoleKSK)
+			if err != nil {
+				return err
+			}
+
+			_, err = dbCluster.CreateNetworkZoneKeyPair(ctx, tx.Tx(), dbCluster.NetworkZoneKeyPair{
+				NetworkZoneID: zoneID,
+				Role:          role,
+				State:         state,
+				PublicKey:     public,
+				PrivateKey:    private,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			})
+			if err != nil {
+				return err
+			}
+
+			d.logger.Info("Created DNSSEC key", logger.Ctx{"role": role, "state": state})
+
+			return nil
+		}
+
+		for _, role := range []string{dnssecRoleKSK, dnssecRoleZSK} {
+			var active *dbCluster.NetworkZoneKeyPair
+			var published *dbCluster.NetworkZoneKeyPair
+
+			for i, key := range keys {
+				if key.Role != role {
+					continue
+				}
+
+				// Replace all keys when the algorithm changed.
+				_, keyAlgorithm, err := dns.KeyInfo(key.PublicKey)
+				if err != nil {
+					return err
+				}
+
+				if keyAlgorithm != algorithm {
+					err = dbCluster.DeleteNetworkZoneKeyPair(ctx, tx.Tx(), key.ID)
+					if err != nil {
+						return err
+					}
+
+					continue
+				}
+
+				switch key.State {
+				case dnssecStateActive:
+					active = &keys[i]
+				case dnssecStatePublished:
+					published = &keys[i]
+				case dnssecStateRetired:
+					// Remove retired keys once they're no longer relied upon.
+					if now.Sub(key.UpdatedAt) < dnssecPropagationDelay {
+						continue
+					}
+
+					err = dbCluster.DeleteNetworkZoneKeyPair(ctx, tx.Tx(), key.ID)
+					if err != nil {
+						return err
+					}
+
+					d.logger.Info("Removed retired DNSSEC key", logger.Ctx{"role": role})
+				}
+			}
+
+			// Create the initial key.
+			if active == nil && published == nil {
+				err = createKey(role, dnssecStateActive)
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			// Activate the new key once it's been published for long enough.
+			if published != nil {
+				delay := dnssecPropagationDelay
+				if role == dnssecRoleKSK {
+					delay = dnssecKSKRolloverDelay
+				}
+
+				if now.Sub(published.UpdatedAt) < delay && active != nil {
+					continue
+				}
+
+				err = dbCluster.UpdateNetworkZoneKeyPairState(ctx, tx.Tx(), published.ID, dnssecStateActive, now)
+				if err != nil {
+					return err
+				}
+
+				if active != nil {
+					err = dbCluster.UpdateNetworkZoneKeyPairState(ctx, tx.Tx(), active.ID, dnssecStateRetired, now)
+					if err != nil {
+						return err
+					}
+				}
+
+				d.logger.Info("Rolled over DNSSEC key", logger.Ctx{"role": role})
+
+				continue
+			}
+
+			// Start a rollover once the active key reached its lifetime.
+			lifetime := d.info.Config[fmt.Sprintf("dnssec.%s.lifetime", role)]
+			if lifetime == "" && role == dnssecRoleZSK {
+				lifetime = dnssecDefaultZSKLifetime
+			}
+
+			expiry, err := internalInstance.GetExpiry(active.UpdatedAt, lifetime)
+			if err != nil {
+				return err
+			}
+
+			if expiry.IsZero() || now.Before(expiry) {
+				continue
+			}
+
+			err = createKey(role, dnssecStatePublished)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
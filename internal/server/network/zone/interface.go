@@ -21,6 +21,7 @@ type NetworkZone interface {
 	UsedBy() ([]string, error)
 	Content() (*strings.Builder, error)
 	SOA() (*strings.Builder, error)
+	DNSSEC() (*api.NetworkZoneDNSSEC, error)
 
 	// Records.
 	AddRecord(req api.NetworkZoneRecordsPost) error
@@ -36,4 +37,7 @@ type NetworkZone interface {
 	// Modifications.
 	Update(config *api.NetworkZonePut, clientType request.ClientType) error
 	Delete() error
+
+	// DNSSEC.
+	RolloverDNSSECKeys() error
 }
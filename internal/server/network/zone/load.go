@@ -158,12 +158,28 @@ func Create(s *state.State, projectName string, zoneInfo *api.NetworkZonesPost)
 		return err
 	}
 
+	// Generate the DNSSEC keys.
+	if util.IsTrue(zoneInfo.Config["dnssec.enabled"]) {
+		zone, err := LoadByNameAndProject(s, projectName, zoneInfo.Name)
+		if err != nil {
+			return err
+		}
+
+		err = zone.RolloverDNSSECKeys()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Trigger a refresh of the TSIG entries.
 	err = s.DNS.UpdateTSIG()
 	if err != nil {
 		return err
 	}
 
+	// Drop the zones served to queries.
+	s.DNS.InvalidateZones()
+
 	return nil
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/dns"
 	"github.com/lxc/incus/v6/internal/server/network"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -168,6 +169,68 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 	//  shortdesc: Comma-separated list of DNS server FQDNs (for NS records)
 	rules["dns.nameservers"] = validate.IsListOf(validate.IsAny)
 
+	// gendoc:generate(entity=network_zone, group=common, key=dns.queries)
+	// Regular DNS queries (`A`, `AAAA`, `CNAME`, `DNSKEY`, `MX`, `NS`, `PTR`, `SRV` and `TXT`) are answered directly by the built-in DNS server when set to `any` (any client) or `peers` (only the peers configured on the zone, using their TSIG key if set).
+	// ---
+	//  type: string
+	//  required: no
+	//  defaultdesc: `none`
+	//  shortdesc: Which clients may query records of the zone
+	rules["dns.queries"] = validate.Optional(validate.IsOneOf("none", "peers", "any"))
+
+	// gendoc:generate(entity=network_zone, group=dnssec, key=dnssec.enabled)
+	// When enabled, keys are generated for the zone and its content gets signed on every zone transfer.
+	// ---
+	//  type: bool
+	//  required: no
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to sign the zone using DNSSEC
+	rules["dnssec.enabled"] = validate.Optional(validate.IsBool)
+
+	// gendoc:generate(entity=network_zone, group=dnssec, key=dnssec.algorithm)
+	// Possible values are `ECDSAP256SHA256`, `ECDSAP384SHA384` and `ED25519`.
+	// Changing the algorithm replaces all keys right away, so the DS record in the parent zone must be updated.
+	// ---
+	//  type: string
+	//  required: no
+	//  defaultdesc: `ECDSAP256SHA256`
+	//  shortdesc: Algorithm of the DNSSEC keys
+	rules["dnssec.algorithm"] = validate.Optional(validate.IsOneOf(dns.DNSSECAlgorithms()...))
+
+	// gendoc:generate(entity=network_zone, group=dnssec, key=dnssec.nsec3)
+	// NSEC3 records (without salt nor additional iterations) prevent walking the zone to list all its records.
+	// ---
+	//  type: bool
+	//  required: no
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to use NSEC3 instead of NSEC records
+	rules["dnssec.nsec3"] = validate.Optional(validate.IsBool)
+
+	// gendoc:generate(entity=network_zone, group=dnssec, key=dnssec.ksk.lifetime)
+	// Specify an expression like `1y` (one year) or `6m` (six months).
+	// During a rollover, both key signing keys are used for a week, during which the DS record in the parent zone must be replaced.
+	// ---
+	//  type: string
+	//  required: no
+	//  defaultdesc: empty (no automatic rollover)
+	//  shortdesc: How long key signing keys are used before being rolled over
+	rules["dnssec.ksk.lifetime"] = func(value string) error {
+		_, err := internalInstance.GetExpiry(time.Time{}, value)
+		return err
+	}
+
+	// gendoc:generate(entity=network_zone, group=dnssec, key=dnssec.zsk.lifetime)
+	// Specify an expression like `30d` (thirty days) or `2w` (two weeks).
+	// ---
+	//  type: string
+	//  required: no
+	//  defaultdesc: `30d`
+	//  shortdesc: How long zone signing keys are used before being rolled over
+	rules["dnssec.zsk.lifetime"] = func(value string) error {
+		_, err := internalInstance.GetExpiry(time.Time{}, value)
+		return err
+	}
+
 	// gendoc:generate(entity=network_zone, group=common, key=network.nat)
 	//
 	// ---
@@ -337,6 +400,12 @@ func (d *zone) Update(config *api.NetworkZonePut, clientType request.ClientType)
 		if err != nil {
 			return err
 		}
+
+		// Generate or remove the DNSSEC keys.
+		err = d.RolloverDNSSECKeys()
+		if err != nil {
+			return err
+		}
 	}
 
 	// Trigger a refresh of the TSIG entries.
@@ -345,6 +414,9 @@ func (d *zone) Update(config *api.NetworkZonePut, clientType request.ClientType)
 		return err
 	}
 
+	// Drop the zones served to queries.
+	d.state.DNS.InvalidateZones()
+
 	reverter.Success()
 	return nil
 }
@@ -370,15 +442,36 @@ func (d *zone) Delete() error {
 		return err
 	}
 
+	dnssecSignedZonesMu.Lock()
+	delete(dnssecSignedZones, d.id)
+	dnssecSignedZonesMu.Unlock()
+
 	// Trigger a refresh of the TSIG entries.
 	err = d.state.DNS.UpdateTSIG()
 	if err != nil {
 		return err
 	}
 
+	// Drop the zones served to queries.
+	d.state.DNS.InvalidateZones()
+
 	return nil
 }
 
+// notify sends a DNS NOTIFY to the zone peers if the zone records changed.
+func (d *zone) notify() {
+	content, err := d.Content()
+	if err != nil {
+		d.logger.Warn("Failed rendering zone for DNS NOTIFY", logger.Ctx{"err": err})
+		return
+	}
+
+	err = d.state.DNS.NotifyIfChanged(*d.info, content.String())
+	if err != nil {
+		d.logger.Warn("Failed sending DNS NOTIFY", logger.Ctx{"err": err})
+	}
+}
+
 // Content returns the DNS zone content.
 func (d *zone) Content() (*strings.Builder, error) {
 	var err error
@@ -584,7 +677,7 @@ func (d *zone) Content() (*strings.Builder, error) {
 		return nil, err
 	}
 
-	return sb, nil
+	return d.sign(sb)
 }
 
 // SOA returns just the DNS zone SOA record.
@@ -96,6 +96,11 @@ func (c *Config) NetworkOVSConnection() string {
 	return c.m.GetString("network.ovs.connection")
 }
 
+// NetworkVhostUserSocketsPath returns the directory holding the vhost-user sockets usable by NICs.
+func (c *Config) NetworkVhostUserSocketsPath() string {
+	return c.m.GetString("network.vhost_user.sockets_path")
+}
+
 // StorageBucketsAddress returns the address and port to setup the storage buckets listener on.
 func (c *Config) StorageBucketsAddress() string {
 	objectAddress := c.m.GetString("core.storage_buckets_address")
@@ -111,6 +116,11 @@ func (c *Config) StorageBackupsVolume() string {
 	return c.m.GetString("storage.backups_volume")
 }
 
+// StorageBackupsTargetPath returns the directory in which backups can be uploaded using the local target.
+func (c *Config) StorageBackupsTargetPath() string {
+	return c.m.GetString("storage.backups_target_path")
+}
+
 // StorageImagesVolume returns the name of the pool/volume to use for storing image tarballs.
 func (c *Config) StorageImagesVolume() string {
 	return c.m.GetString("storage.images_volume")
@@ -261,6 +271,15 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: OVS socket path
 	"network.ovs.connection": {Default: "unix:/run/openvswitch/db.sock"},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=network.vhost_user.sockets_path)
+	// The `socket` option of `vhost-user` NICs is relative to this directory.
+	// When unset, `vhost-user` NICs are disabled.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Directory holding the vhost-user sockets of userspace switches
+	"network.vhost_user.sockets_path": {Validator: validate.Optional(validate.IsAbsFilePath)},
+
 	// Storage volumes to store backups/images on
 
 	// gendoc:generate(entity=server, group=miscellaneous, key=storage.backups_volume)
@@ -271,6 +290,15 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Volume to use to store backup tarballs
 	"storage.backups_volume": {},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=storage.backups_target_path)
+	// Backups uploaded using the `local` target protocol are written inside of a sub-directory of this directory named after their project.
+	// When unset, the `local` target protocol is disabled.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Directory to upload backups to using the `local` target
+	"storage.backups_target_path": {Validator: validate.Optional(validate.IsAbsFilePath)},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=storage.images_volume)
 	// Specify the volume using the syntax `POOL/VOLUME`.
 	// ---
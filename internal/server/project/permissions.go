@@ -265,6 +265,16 @@ func AllowVolumeCreation(tx *db.ClusterTx, projectName string, poolName string,
 		return nil
 	}
 
+	err = AllowReplicationTarget(&info.Project, req.Config["replication.target"])
+	if err != nil {
+		return err
+	}
+
+	err = AllowBackupTarget(&info.Project, req.Config["backups.target"])
+	if err != nil {
+		return err
+	}
+
 	// If "limits.disk" is not set, there's nothing to do.
 	if info.Project.Config["limits.disk"] == "" {
 		return nil
@@ -484,6 +494,7 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 	allowContainerLowLevel := false
 	allowVMLowLevel := false
 	var allowedIDMapHostUIDs, allowedIDMapHostGIDs []idmap.Entry
+	var allowedReplicationTargets, allowedBackupTargets []string
 
 	for i := range allRestrictions {
 		// Check if this particular restriction is defined explicitly in the project config.
@@ -693,6 +704,12 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 			if err != nil {
 				return fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
 			}
+
+		case "restricted.replication.targets":
+			allowedReplicationTargets = util.SplitNTrimSpace(restrictionValue, ",", -1, true)
+
+		case "restricted.backups.targets":
+			allowedBackupTargets = util.SplitNTrimSpace(restrictionValue, ",", -1, true)
 		}
 	}
 
@@ -733,6 +750,14 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 				return fmt.Errorf("Use of low-level config %q on %s %q of project %q is forbidden", key, entityTypeLabel, entityName, project.Name)
 			}
 
+			if key == "replication.target" && value != "" && !slices.Contains(allowedReplicationTargets, value) {
+				return fmt.Errorf("Use of replication target %q on %s %q of project %q is forbidden", value, entityTypeLabel, entityName, project.Name)
+			}
+
+			if key == "backups.target" && value != "" && !slices.Contains(allowedBackupTargets, value) {
+				return fmt.Errorf("Use of backup target %q on %s %q of project %q is forbidden", value, entityTypeLabel, entityName, project.Name)
+			}
+
 			var checker func(value string) error
 			if isContainerOrProfile {
 				checker = containerConfigChecks[key]
@@ -837,6 +862,7 @@ var allAggregateLimits = []string{
 // allRestrictions lists all available 'restrict.*' config keys along with their default setting.
 var allRestrictions = map[string]string{
 	"restricted.backups":                   "block",
+	"restricted.backups.targets":           "",
 	"restricted.cluster.groups":            "",
 	"restricted.cluster.target":            "block",
 	"restricted.containers.nesting":        "block",
@@ -858,6 +884,7 @@ var allRestrictions = map[string]string{
 	"restricted.idmap.uid":                 "",
 	"restricted.idmap.gid":                 "",
 	"restricted.networks.access":           "",
+	"restricted.replication.targets":       "",
 	"restricted.snapshots":                 "block",
 }
 
@@ -980,6 +1007,20 @@ func AllowVolumeUpdate(tx *db.ClusterTx, projectName, volumeName string, req api
 		return nil
 	}
 
+	if req.Config["replication.target"] != currentConfig["replication.target"] {
+		err = AllowReplicationTarget(&info.Project, req.Config["replication.target"])
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.Config["backups.target"] != currentConfig["backups.target"] {
+		err = AllowBackupTarget(&info.Project, req.Config["backups.target"])
+		if err != nil {
+			return err
+		}
+	}
+
 	// If "limits.disk" is not set, there's nothing to do.
 	if info.Project.Config["limits.disk"] == "" {
 		return nil
@@ -1654,6 +1695,34 @@ func AllowSnapshotCreation(p *api.Project) error {
 	return nil
 }
 
+// AllowReplicationTarget returns an error if the project doesn't allow replicating to the given target.
+func AllowReplicationTarget(p *api.Project, targetName string) error {
+	if targetName == "" || util.IsFalseOrEmpty(p.Config["restricted"]) {
+		return nil
+	}
+
+	allowedTargets := util.SplitNTrimSpace(p.Config["restricted.replication.targets"], ",", -1, true)
+	if !slices.Contains(allowedTargets, targetName) {
+		return fmt.Errorf("Project %q doesn't allow replicating to target %q", p.Name, targetName)
+	}
+
+	return nil
+}
+
+// AllowBackupTarget returns an error if the project doesn't allow uploading backups to the given target.
+func AllowBackupTarget(p *api.Project, targetName string) error {
+	if targetName == "" || util.IsFalseOrEmpty(p.Config["restricted"]) {
+		return nil
+	}
+
+	allowedTargets := util.SplitNTrimSpace(p.Config["restricted.backups.targets"], ",", -1, true)
+	if !slices.Contains(allowedTargets, targetName) {
+		return fmt.Errorf("Project %q doesn't allow uploading backups to target %q", p.Name, targetName)
+	}
+
+	return nil
+}
+
 // GetRestrictedClusterGroups returns a slice of restricted cluster groups for the given project.
 func GetRestrictedClusterGroups(p *api.Project) []string {
 	return util.SplitNTrimSpace(p.Config["restricted.cluster.groups"], ",", -1, true)
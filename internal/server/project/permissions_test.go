@@ -249,3 +249,128 @@ func TestCheckClusterTargetRestriction_RestrictedFalse(t *testing.T) {
 	err = project.CheckClusterTargetRestriction(authorizer, req, p, "n1")
 	assert.NoError(t, err)
 }
+
+func TestAllowReplicationTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]string
+		target  string
+		wantErr bool
+	}{
+		{
+			name:   "Unrestricted project",
+			config: map[string]string{},
+			target: "backup-site",
+		},
+		{
+			name:   "No target",
+			config: map[string]string{"restricted": "true"},
+			target: "",
+		},
+		{
+			name:    "Restricted project without allowed targets",
+			config:  map[string]string{"restricted": "true"},
+			target:  "backup-site",
+			wantErr: true,
+		},
+		{
+			name:   "Allowed target",
+			config: map[string]string{"restricted": "true", "restricted.replication.targets": "other-site, backup-site"},
+			target: "backup-site",
+		},
+		{
+			name:    "Target not allowed",
+			config:  map[string]string{"restricted": "true", "restricted.replication.targets": "other-site"},
+			target:  "backup-site",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := project.AllowReplicationTarget(&api.Project{Name: "p1", ProjectPut: api.ProjectPut{Config: tt.config}}, tt.target)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAllowBackupTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]string
+		target  string
+		wantErr bool
+	}{
+		{
+			name:   "Unrestricted project",
+			config: map[string]string{},
+			target: "offsite",
+		},
+		{
+			name:   "No target",
+			config: map[string]string{"restricted": "true"},
+			target: "",
+		},
+		{
+			name:    "Restricted project without allowed targets",
+			config:  map[string]string{"restricted": "true"},
+			target:  "offsite",
+			wantErr: true,
+		},
+		{
+			name:   "Allowed target",
+			config: map[string]string{"restricted": "true", "restricted.backups.targets": "nas, offsite"},
+			target: "offsite",
+		},
+		{
+			name:    "Target not allowed",
+			config:  map[string]string{"restricted": "true", "restricted.backups.targets": "nas"},
+			target:  "offsite",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := project.AllowBackupTarget(&api.Project{Name: "p1", ProjectPut: api.ProjectPut{Config: tt.config}}, tt.target)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// If a restricted project doesn't allow the replication target, creating a volume replicated to it fails.
+func TestAllowVolumeCreation_ReplicationTarget(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"restricted": "true", "restricted.replication.targets": "backup-site"})
+	require.NoError(t, err)
+
+	req := api.StorageVolumesPost{
+		Name: "v1",
+		StorageVolumePut: api.StorageVolumePut{
+			Config: map[string]string{"replication.target": "backup-site"},
+		},
+	}
+
+	err = project.AllowVolumeCreation(tx, "p1", "default", req)
+	assert.NoError(t, err)
+
+	req.Config["replication.target"] = "other-site"
+	err = project.AllowVolumeCreation(tx, "p1", "default", req)
+	assert.EqualError(t, err, `Project "p1" doesn't allow replicating to target "other-site"`)
+}
@@ -7,13 +7,17 @@ import (
 
 	"go.starlark.net/starlark"
 
+	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	internalInstance "github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/network"
+	"github.com/lxc/incus/v6/internal/server/project"
 	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
 	"github.com/lxc/incus/v6/internal/server/scriptlet/log"
 	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	"github.com/lxc/incus/v6/shared/api"
 	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -153,6 +157,206 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 		return rv, nil
 	}
 
+	// connectCandidateMember connects to one of the candidate members.
+	connectCandidateMember := func(memberName string) (incus.InstanceServer, error) {
+		var targetMember *db.NodeInfo
+		for i := range candidateMembers {
+			if candidateMembers[i].Name == memberName {
+				targetMember = &candidateMembers[i]
+				break
+			}
+		}
+
+		if targetMember == nil {
+			return nil, fmt.Errorf("Invalid member name: %s", memberName)
+		}
+
+		return cluster.Connect(targetMember.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
+	}
+
+	getStoragePoolResourcesFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var memberName string
+		var poolName string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "member_name", &memberName, "pool_name", &poolName)
+		if err != nil {
+			return nil, err
+		}
+
+		var res *api.ResourcesStoragePool
+
+		// Get the local storage pool usage.
+		if memberName == s.ServerName {
+			pool, err := storagePools.LoadByName(s, poolName)
+			if err != nil {
+				return nil, err
+			}
+
+			res, err = pool.GetResources()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// Get remote member storage pool usage.
+			client, err := connectCandidateMember(memberName)
+			if err != nil {
+				return nil, err
+			}
+
+			res, err = client.GetStoragePoolResources(poolName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rv, err := scriptlet.StarlarkMarshal(res)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling storage pool resources for %q on %q failed: %w", poolName, memberName, err)
+		}
+
+		return rv, nil
+	}
+
+	getNetworkStateFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var memberName string
+		var networkName string
+		var projectName string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "member_name", &memberName, "network_name", &networkName, "project??", &projectName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Default to the project of the instance being placed.
+		if projectName == "" {
+			projectName = req.Project
+		}
+
+		if projectName == "" {
+			projectName = api.ProjectDefaultName
+		}
+
+		var networkState *api.NetworkState
+
+		// Get the local network state.
+		if memberName == s.ServerName {
+			networkProjectName, _, err := project.NetworkProject(s.DB.Cluster, projectName)
+			if err != nil {
+				return nil, err
+			}
+
+			n, err := network.LoadByName(s, networkProjectName, networkName)
+			if err != nil {
+				return nil, err
+			}
+
+			networkState, err = n.State()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// Get remote member network state.
+			client, err := connectCandidateMember(memberName)
+			if err != nil {
+				return nil, err
+			}
+
+			networkState, err = client.UseProject(projectName).GetNetworkState(networkName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rv, err := scriptlet.StarlarkMarshal(networkState)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling network state for %q on %q failed: %w", networkName, memberName, err)
+		}
+
+		return rv, nil
+	}
+
+	getClusterGroupsFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		err := starlark.UnpackArgs(b.Name(), args, kwargs)
+		if err != nil {
+			return nil, err
+		}
+
+		groups := []api.ClusterGroup{}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			dbGroups, err := dbCluster.GetClusterGroups(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			for _, dbGroup := range dbGroups {
+				group, err := dbGroup.ToAPI(ctx, tx.Tx())
+				if err != nil {
+					return err
+				}
+
+				group.Members, err = tx.GetClusterGroupNodes(ctx, dbGroup.Name)
+				if err != nil {
+					return err
+				}
+
+				groups = append(groups, *group)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		rv, err := scriptlet.StarlarkMarshal(groups)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling cluster groups failed: %w", err)
+		}
+
+		return rv, nil
+	}
+
+	getAntiAffinityMembersFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key string
+		var value string
+		var projectName string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key, "value", &value, "project??", &projectName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Count the instances having the configuration key set to the value (directly or through their profiles)
+		// on each member.
+		members := map[string]int{}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			filter := dbCluster.InstanceFilter{}
+			if projectName != "" {
+				filter.Project = &projectName
+			}
+
+			return tx.InstanceList(ctx, func(inst db.InstanceArgs, _ api.Project) error {
+				if db.ExpandInstanceConfig(inst.Config, inst.Profiles)[key] == value {
+					members[inst.Node]++
+				}
+
+				return nil
+			}, filter)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		rv, err := scriptlet.StarlarkMarshal(members)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling anti-affinity members failed: %w", err)
+		}
+
+		return rv, nil
+	}
+
 	getInstanceResourcesFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 		var err error
 		var res apiScriptlet.InstanceResources
@@ -462,6 +666,10 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 		"get_instances_count":          starlark.NewBuiltin("get_instances_count", getInstancesCountFunc),
 		"get_cluster_members":          starlark.NewBuiltin("get_cluster_members", getClusterMembersFunc),
 		"get_project":                  starlark.NewBuiltin("get_project", getProjectFunc),
+		"get_storage_pool_resources":   starlark.NewBuiltin("get_storage_pool_resources", getStoragePoolResourcesFunc),
+		"get_network_state":            starlark.NewBuiltin("get_network_state", getNetworkStateFunc),
+		"get_cluster_groups":           starlark.NewBuiltin("get_cluster_groups", getClusterGroupsFunc),
+		"get_anti_affinity_members":    starlark.NewBuiltin("get_anti_affinity_members", getAntiAffinityMembersFunc),
 	}
 
 	prog, thread, err := scriptletLoad.InstancePlacementProgram()
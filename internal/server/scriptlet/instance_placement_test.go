@@ -0,0 +1,165 @@
+package scriptlet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clusterConfig "github.com/lxc/incus/v6/internal/server/cluster/config"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// runInstancePlacement runs the scriptlet against all cluster members and returns the chosen member.
+func runInstancePlacement(t *testing.T, s *state.State, src string) (*db.NodeInfo, error) {
+	t.Helper()
+
+	err := scriptletLoad.InstancePlacementSet(src)
+	require.NoError(t, err)
+
+	var members []db.NodeInfo
+	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		s.GlobalConfig, err = clusterConfig.Load(ctx, tx)
+
+		return err
+	})
+	require.NoError(t, err)
+
+	req := &apiScriptlet.InstancePlacement{
+		InstancesPost: api.InstancesPost{Name: "c1", Type: api.InstanceTypeContainer},
+		Project:       api.ProjectDefaultName,
+		Reason:        apiScriptlet.InstancePlacementReasonNew,
+	}
+
+	return InstancePlacementRun(context.TODO(), logger.Log, s, req, members, "")
+}
+
+func TestInstancePlacement_GetClusterGroups(t *testing.T) {
+	s, cleanup := state.NewTestState(t)
+	defer cleanup()
+
+	s.ServerName = "none"
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, err := dbCluster.CreateClusterGroup(ctx, tx.Tx(), dbCluster.ClusterGroup{Name: "gpu", Description: "GPU members"})
+		if err != nil {
+			return err
+		}
+
+		return tx.AddNodeToClusterGroup(ctx, "gpu", "none")
+	})
+	require.NoError(t, err)
+
+	target, err := runInstancePlacement(t, s, `
+def instance_placement(request, candidate_members):
+	for group in get_cluster_groups():
+		if group.name == "gpu" and group.description == "GPU members" and group.members == ["none"]:
+			set_target(group.members[0])
+`)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, "none", target.Name)
+}
+
+func TestInstancePlacement_GetAntiAffinityMembers(t *testing.T) {
+	s, cleanup := state.NewTestState(t)
+	defer cleanup()
+
+	s.ServerName = "none"
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		for name, role := range map[string]string{"db1": "database", "db2": "database", "web1": "web"} {
+			id, err := dbCluster.CreateInstance(ctx, tx.Tx(), dbCluster.Instance{
+				Project:      api.ProjectDefaultName,
+				Name:         name,
+				Node:         "none",
+				Type:         instancetype.Container,
+				Architecture: 1,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = dbCluster.CreateInstanceConfig(ctx, tx.Tx(), id, map[string]string{"user.role": role})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	target, err := runInstancePlacement(t, s, `
+def instance_placement(request, candidate_members):
+	if get_anti_affinity_members("user.role", "database") != {"none": 2}:
+		fail("Unexpected database count")
+
+	if get_anti_affinity_members("user.role", "web", "default") != {"none": 1}:
+		fail("Unexpected web count")
+
+	if get_anti_affinity_members("user.role", "cache") != {}:
+		fail("Unexpected cache count")
+
+	if get_anti_affinity_members("user.role", "database", "other") != {}:
+		fail("Unexpected count in other project")
+
+	set_target("none")
+`)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, "none", target.Name)
+}
+
+func TestInstancePlacement_InvalidMember(t *testing.T) {
+	s, cleanup := state.NewTestState(t)
+	defer cleanup()
+
+	s.ServerName = "none"
+
+	for _, src := range []string{
+		`
+def instance_placement(request, candidate_members):
+	get_storage_pool_resources("missing", "default")
+`,
+		`
+def instance_placement(request, candidate_members):
+	get_network_state("missing", "incusbr0")
+`,
+	} {
+		_, err := runInstancePlacement(t, s, src)
+		assert.ErrorContains(t, err, "Invalid member name: missing")
+	}
+}
+
+func TestInstancePlacement_Validate(t *testing.T) {
+	// All builtins must be known at compile time.
+	err := scriptletLoad.InstancePlacementValidate(`
+def instance_placement(request, candidate_members):
+	get_storage_pool_resources("member1", "default")
+	get_network_state("member1", "incusbr0")
+	get_network_state("member1", "incusbr0", "default")
+	get_cluster_groups()
+	get_anti_affinity_members("user.role", "database")
+`)
+	assert.NoError(t, err)
+
+	err = scriptletLoad.InstancePlacementValidate(`
+def instance_placement(request, candidate_members):
+	get_unknown()
+`)
+	assert.Error(t, err)
+}
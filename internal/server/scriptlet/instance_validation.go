@@ -0,0 +1,187 @@
+package scriptlet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.starlark.net/starlark"
+
+	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/internal/server/scriptlet/log"
+	"github.com/lxc/incus/v6/shared/api"
+	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/scriptlet"
+)
+
+// InstanceValidationRun runs the instance validation scriptlet.
+// An error is returned when the scriptlet rejects the change. Changes made by the scriptlet are applied to the
+// local configuration and devices of the request.
+func InstanceValidationRun(l logger.Logger, req *apiScriptlet.InstanceValidation) error {
+	logFunc := log.CreateLogger(l, "Instance validation scriptlet")
+
+	var rejection string
+	rejected := false
+
+	rejectFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var reason string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "reason", &reason)
+		if err != nil {
+			return nil, err
+		}
+
+		rejected = true
+		rejection = reason
+
+		return starlark.None, nil
+	}
+
+	// Changes only make sense when the instance itself is being created or updated.
+	checkModifiable := func() error {
+		if req.Reason == apiScriptlet.InstanceValidationReasonProfile {
+			return errors.New("Instances can't be modified on profile changes")
+		}
+
+		return nil
+	}
+
+	setConfigFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key string
+		var value string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key, "value", &value)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkModifiable()
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Config == nil {
+			req.Config = map[string]string{}
+		}
+
+		// Unset the key when given an empty value.
+		if value == "" {
+			delete(req.Config, key)
+		} else {
+			req.Config[key] = value
+		}
+
+		l.Info("Instance validation scriptlet set configuration key", logger.Ctx{"key": key, "value": value})
+
+		return starlark.None, nil
+	}
+
+	setDeviceFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		var device starlark.Value
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "device", &device)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkModifiable()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := scriptlet.StarlarkUnmarshal(device)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Devices == nil {
+			req.Devices = map[string]map[string]string{}
+		}
+
+		// Remove the device when given None.
+		if value == nil {
+			delete(req.Devices, name)
+			l.Info("Instance validation scriptlet removed device", logger.Ctx{"device": name})
+
+			return starlark.None, nil
+		}
+
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("Invalid device %q: Expected a dictionary", name)
+		}
+
+		config := make(map[string]string, len(fields))
+		for key, field := range fields {
+			fieldValue, ok := field.(string)
+			if !ok {
+				return nil, fmt.Errorf("Invalid device %q: Value of %q isn't a string", name, key)
+			}
+
+			config[key] = fieldValue
+		}
+
+		req.Devices[name] = config
+		l.Info("Instance validation scriptlet set device", logger.Ctx{"device": name})
+
+		return starlark.None, nil
+	}
+
+	// Remember to match the entries in scriptletLoad.InstanceValidationCompile() with this list so Starlark can
+	// perform compile time validation of functions used.
+	env := starlark.StringDict{
+		"log_info":   starlark.NewBuiltin("log_info", logFunc),
+		"log_warn":   starlark.NewBuiltin("log_warn", logFunc),
+		"log_error":  starlark.NewBuiltin("log_error", logFunc),
+		"reject":     starlark.NewBuiltin("reject", rejectFunc),
+		"set_config": starlark.NewBuiltin("set_config", setConfigFunc),
+		"set_device": starlark.NewBuiltin("set_device", setDeviceFunc),
+	}
+
+	prog, thread, err := scriptletLoad.InstanceValidationProgram()
+	if err != nil {
+		return err
+	}
+
+	globals, err := prog.Init(thread, env)
+	if err != nil {
+		return fmt.Errorf("Failed initializing: %w", err)
+	}
+
+	globals.Freeze()
+
+	// Retrieve a global variable from starlark environment.
+	instanceValidation := globals["instance_validation"]
+	if instanceValidation == nil {
+		return errors.New("Scriptlet missing instance_validation function")
+	}
+
+	rv, err := scriptlet.StarlarkMarshal(req)
+	if err != nil {
+		return fmt.Errorf("Marshalling request failed: %w", err)
+	}
+
+	// Call starlark function from Go.
+	v, err := starlark.Call(thread, instanceValidation, nil, []starlark.Tuple{
+		{
+			starlark.String("request"),
+			rv,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to run: %w", err)
+	}
+
+	if v.Type() != "NoneType" {
+		return fmt.Errorf("Failed with unexpected return value: %v", v)
+	}
+
+	if rejected {
+		l.Info("Instance validation scriptlet rejected change", logger.Ctx{"reason": rejection})
+		return api.StatusErrorf(http.StatusBadRequest, "Rejected by instance validation scriptlet: %s", rejection)
+	}
+
+	return nil
+}
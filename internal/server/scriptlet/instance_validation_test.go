@@ -0,0 +1,138 @@
+package scriptlet
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/shared/api"
+	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+const testInstanceValidationScriptlet = `
+def instance_validation(request):
+	if request.project != "default" and request.expanded_config.get("security.privileged") == "true":
+		reject("Privileged containers are only allowed in the default project")
+		return
+
+	if "user.owner" not in request.config:
+		set_config("user.owner", "unknown")
+
+	if request.type == "virtual-machine" and "agent" not in request.expanded_devices:
+		set_device("agent", {"type": "disk", "source": "agent:config"})
+
+	if "usb" in request.devices:
+		set_device("usb", None)
+`
+
+func TestInstanceValidationRun(t *testing.T) {
+	err := scriptletLoad.InstanceValidationSet(testInstanceValidationScriptlet)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = scriptletLoad.InstanceValidationSet("") })
+
+	tests := []struct {
+		name            string
+		req             apiScriptlet.InstanceValidation
+		expectedConfig  map[string]string
+		expectedDevices map[string]map[string]string
+		expectedErr     string
+		expectedStatus  int
+	}{
+		{
+			name: "Unchanged",
+			req: apiScriptlet.InstanceValidation{
+				Reason:          apiScriptlet.InstanceValidationReasonCreate,
+				Project:         "default",
+				Type:            "container",
+				Config:          map[string]string{"user.owner": "alice"},
+				Devices:         map[string]map[string]string{},
+				ExpandedConfig:  map[string]string{"user.owner": "alice", "security.privileged": "true"},
+				ExpandedDevices: map[string]map[string]string{},
+			},
+			expectedConfig:  map[string]string{"user.owner": "alice"},
+			expectedDevices: map[string]map[string]string{},
+		},
+		{
+			name: "Rejected",
+			req: apiScriptlet.InstanceValidation{
+				Reason:          apiScriptlet.InstanceValidationReasonUpdate,
+				Project:         "foo",
+				Type:            "container",
+				Config:          map[string]string{},
+				ExpandedConfig:  map[string]string{"security.privileged": "true"},
+				ExpandedDevices: map[string]map[string]string{},
+			},
+			expectedErr:    "Rejected by instance validation scriptlet: Privileged containers are only allowed in the default project",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Modified",
+			req: apiScriptlet.InstanceValidation{
+				Reason:          apiScriptlet.InstanceValidationReasonCreate,
+				Project:         "foo",
+				Type:            "virtual-machine",
+				Config:          map[string]string{},
+				Devices:         map[string]map[string]string{"usb": {"type": "usb"}},
+				ExpandedConfig:  map[string]string{},
+				ExpandedDevices: map[string]map[string]string{"usb": {"type": "usb"}},
+			},
+			expectedConfig:  map[string]string{"user.owner": "unknown"},
+			expectedDevices: map[string]map[string]string{"agent": {"type": "disk", "source": "agent:config"}},
+		},
+		{
+			name: "Profile change",
+			req: apiScriptlet.InstanceValidation{
+				Reason:          apiScriptlet.InstanceValidationReasonProfile,
+				Project:         "foo",
+				Type:            "container",
+				Config:          map[string]string{},
+				ExpandedConfig:  map[string]string{},
+				ExpandedDevices: map[string]map[string]string{},
+			},
+			expectedErr: "Instances can't be modified on profile changes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := InstanceValidationRun(logger.Log, &tt.req)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+
+				if tt.expectedStatus != 0 {
+					assert.True(t, api.StatusErrorCheck(err, tt.expectedStatus))
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedConfig, tt.req.Config)
+			assert.Equal(t, tt.expectedDevices, tt.req.Devices)
+		})
+	}
+}
+
+func TestInstanceValidationValidate(t *testing.T) {
+	err := scriptletLoad.InstanceValidationValidate(testInstanceValidationScriptlet)
+	assert.NoError(t, err)
+
+	// The function must take the request.
+	err = scriptletLoad.InstanceValidationValidate(`
+def instance_validation():
+	pass
+`)
+	assert.Error(t, err)
+
+	// Only the validation builtins are available.
+	err = scriptletLoad.InstanceValidationValidate(`
+def instance_validation(request):
+	set_target("foo")
+`)
+	assert.Error(t, err)
+}
@@ -15,6 +15,9 @@ const prefixQEMU = "qemu"
 // nameAuthorization is the name used in Starlark for the Authorization scriptlet.
 const nameAuthorization = "authorization"
 
+// nameInstanceValidation is the name used in Starlark for the instance validation scriptlet.
+const nameInstanceValidation = "instance_validation"
+
 var loader = scriptlet.NewLoader()
 
 // InstancePlacementCompile compiles the instance placement scriptlet.
@@ -31,6 +34,10 @@ func InstancePlacementCompile(name string, src string) (*starlark.Program, error
 		"get_instances_count",
 		"get_cluster_members",
 		"get_project",
+		"get_storage_pool_resources",
+		"get_network_state",
+		"get_cluster_groups",
+		"get_anti_affinity_members",
 	})
 }
 
@@ -129,3 +136,33 @@ func AuthorizationSet(src string) error {
 func AuthorizationProgram() (*starlark.Program, *starlark.Thread, error) {
 	return loader.Program("Authorization", nameAuthorization)
 }
+
+// InstanceValidationCompile compiles the instance validation scriptlet.
+func InstanceValidationCompile(name string, src string) (*starlark.Program, error) {
+	return scriptlet.Compile(name, src, []string{
+		"log_info",
+		"log_warn",
+		"log_error",
+		"reject",
+		"set_config",
+		"set_device",
+	})
+}
+
+// InstanceValidationValidate validates the instance validation scriptlet.
+func InstanceValidationValidate(src string) error {
+	return scriptlet.Validate(InstanceValidationCompile, nameInstanceValidation, src, scriptlet.Declaration{
+		scriptlet.Required("instance_validation"): {"request"},
+	})
+}
+
+// InstanceValidationSet compiles the instance validation scriptlet into memory for use with InstanceValidationRun.
+// If empty src is provided the current program is deleted.
+func InstanceValidationSet(src string) error {
+	return loader.Set(InstanceValidationCompile, nameInstanceValidation, src)
+}
+
+// InstanceValidationProgram returns the precompiled instance validation scriptlet program.
+func InstanceValidationProgram() (*starlark.Program, *starlark.Thread, error) {
+	return loader.Program("Instance validation", nameInstanceValidation)
+}
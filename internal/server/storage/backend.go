@@ -250,6 +250,50 @@ func (b *backend) GetResources() (*api.ResourcesStoragePool, error) {
 	return b.driver.GetResources()
 }
 
+// GetState returns the utilisation information and the health of the pool.
+// The health is left empty if the storage driver doesn't report it.
+func (b *backend) GetState() (*api.StoragePoolState, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("GetState started")
+	defer l.Debug("GetState finished")
+
+	res, err := b.GetResources()
+	if err != nil {
+		return nil, err
+	}
+
+	poolState := &api.StoragePoolState{ResourcesStoragePool: *res}
+
+	poolState.Health, err = b.driver.GetHealth()
+	if err != nil && !errors.Is(err, drivers.ErrNotSupported) {
+		return nil, err
+	}
+
+	return poolState, nil
+}
+
+// Scrub starts a background scrub of the pool.
+func (b *backend) Scrub() error {
+	l := b.logger.AddContext(nil)
+	l.Debug("Scrub started")
+	defer l.Debug("Scrub finished")
+
+	if b.Status() == api.StoragePoolStatusPending {
+		return errors.New("The pool is in pending state")
+	}
+
+	err := b.driver.Scrub()
+	if err != nil {
+		if errors.Is(err, drivers.ErrNotSupported) {
+			return api.StatusErrorf(http.StatusBadRequest, "Storage pool driver %q doesn't support scrubbing", b.driver.Info().Name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 // IsUsed returns whether the storage pool is used by any volumes or profiles (excluding image volumes).
 func (b *backend) IsUsed() (bool, error) {
 	usedBy, err := UsedBy(context.TODO(), b.state, b, true, true, db.StoragePoolVolumeTypeNameImage)
@@ -718,6 +762,89 @@ func (b *backend) CreateInstance(inst instance.Instance, op *operations.Operatio
 	return nil
 }
 
+// createVolumeFromBackupChain unpacks a backup onto the storage device through the storage driver. For
+// incremental backups, the chain of parent backups is replayed first, oldest first, after which only the
+// wanted snapshots are kept. It returns the names of the restored snapshots along with the driver's post hook
+// (which runs the post hooks of every replayed backup) and a revert hook.
+func (b *backend) createVolumeFromBackupChain(vol drivers.Volume, srcBackup backup.Info, srcData io.ReadSeeker, wanted []string, op *operations.Operation) (drivers.VolumePostHook, revert.Hook, []string, error) {
+	chain, cleanup, err := backup.LoadChain(b.state.OS, &srcBackup, srcData)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	defer cleanup()
+
+	// Work out the snapshots that should exist once the chain is replayed.
+	snapshots := srcBackup.Snapshots
+	var unwanted []string
+	if len(chain) > 1 {
+		snapshots = nil
+		for _, snapName := range backup.ChainSnapshots(chain) {
+			if slices.Contains(wanted, snapName) {
+				snapshots = append(snapshots, snapName)
+			} else {
+				unwanted = append(unwanted, snapName)
+			}
+		}
+
+		for _, snapName := range wanted {
+			if !slices.Contains(snapshots, snapName) {
+				return nil, nil, nil, fmt.Errorf("Snapshot %q isn't part of the backup chain", snapName)
+			}
+		}
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	var postHooks []drivers.VolumePostHook
+	for i, link := range chain {
+		if len(chain) > 1 {
+			b.logger.Debug("Replaying backup chain", logger.Ctx{"link": i, "parentSnapshot": link.Info.ParentSnapshot, "snapshots": link.Info.Snapshots})
+		}
+
+		postHook, revertHook, err := b.driver.CreateVolumeFromBackup(vol, *link.Info, link.Data, op)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if revertHook != nil {
+			reverter.Add(revertHook)
+		}
+
+		if postHook != nil {
+			postHooks = append(postHooks, postHook)
+		}
+	}
+
+	// Remove the snapshots which only existed to replay the chain.
+	for _, snapName := range unwanted {
+		snapVol := b.GetVolume(vol.Type(), vol.ContentType(), drivers.GetSnapshotVolumeName(vol.Name(), snapName), vol.Config())
+		err = b.driver.DeleteVolumeSnapshot(snapVol, op)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("Failed removing snapshot %q: %w", snapName, err)
+		}
+	}
+
+	var volPostHook drivers.VolumePostHook
+	if len(postHooks) > 0 {
+		volPostHook = func(vol drivers.Volume) error {
+			for _, postHook := range postHooks {
+				err := postHook(vol)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	revertHook := reverter.Clone().Fail
+	reverter.Success()
+	return volPostHook, revertHook, snapshots, nil
+}
+
 // CreateInstanceFromBackup restores a backup file onto the storage device. Because the backup file
 // is unpacked and restored onto the storage device before the instance is created in the database
 // it is necessary to return two functions; a post hook that can be run once the instance has been
@@ -771,12 +898,22 @@ func (b *backend) CreateInstanceFromBackup(srcBackup backup.Info, srcData io.Rea
 	importRevert := revert.New()
 	defer importRevert.Fail()
 
-	// Unpack the backup into the new storage volume(s).
-	volPostHook, revertHook, err := b.driver.CreateVolumeFromBackup(vol, srcBackup, srcData, op)
+	// Only keep the instance snapshots which existed when the backup was taken.
+	var wantedSnapshots []string
+	if srcBackup.Config != nil {
+		for _, snap := range srcBackup.Config.Snapshots {
+			wantedSnapshots = append(wantedSnapshots, snap.Name)
+		}
+	}
+
+	// Unpack the backup (and for incremental backups its parents) into the new storage volume(s).
+	volPostHook, revertHook, snapshots, err := b.createVolumeFromBackupChain(vol, srcBackup, srcData, wantedSnapshots, op)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	srcBackup.Snapshots = snapshots
+
 	if revertHook != nil {
 		importRevert.Add(revertHook)
 	}
@@ -1257,6 +1394,10 @@ func (b *backend) RefreshCustomVolume(projectName string, srcProjectName string,
 	// Use the source volume's config if not supplied.
 	if config == nil {
 		config = srcConfig.Volume.Config
+	} else {
+		// Keep the secrets of the source, which aren't returned through the API.
+		config = util.CloneMap(config)
+		VolumeKeepWriteOnly(config, srcConfig.Volume.Config)
 	}
 
 	// Use the source volume's description if not supplied.
@@ -1915,6 +2056,10 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 		return err
 	}
 
+	if args.InfoReceived != nil {
+		args.InfoReceived(srcInfo)
+	}
+
 	// Now that we got the source details, validate against the instance limits.
 	_, rootDiskConf, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
 	if err != nil {
@@ -2441,6 +2586,15 @@ func (b *backend) UpdateInstance(inst instance.Instance, newDesc string, newConf
 			return errors.New(`Instance volume "block.filesystem" property cannot be changed`)
 		}
 
+		// Check that the volume's encryption settings aren't being changed.
+		// The key can still be set when missing, as on volumes imported from a backup which doesn't include it.
+		for _, key := range []string{"block.encryption", "block.encryption.key"} {
+			_, changed := changedConfig[key]
+			if changed && (key != "block.encryption.key" || curVol.Config[key] != "") {
+				return fmt.Errorf(`Instance volume %q property cannot be changed`, key)
+			}
+		}
+
 		// Load storage volume from database.
 		dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
 		if err != nil {
@@ -2662,7 +2816,7 @@ func (b *backend) CleanupInstancePaths(inst instance.Instance, op *operations.Op
 }
 
 // BackupInstance creates an instance backup.
-func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, op *operations.Operation) error {
+func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, parent string, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "optimized": optimized, "snapshots": snapshots})
 	l.Debug("BackupInstance started")
 	defer l.Debug("BackupInstance finished")
@@ -2695,7 +2849,7 @@ func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewrit
 	}
 
 	var snapNames []string
-	if snapshots {
+	if snapshots || parent != "" {
 		// Get snapshots in age order, oldest first, and pass names to storage driver.
 		instSnapshots, err := inst.Snapshots()
 		if err != nil {
@@ -2709,7 +2863,12 @@ func (b *backend) BackupInstance(inst instance.Instance, tarWriter *instancewrit
 		}
 	}
 
-	err = b.driver.BackupVolume(vol, tarWriter, optimized, snapNames, op)
+	snapNames, err = backupSnapshotNames(snapNames, snapshots, parent)
+	if err != nil {
+		return err
+	}
+
+	err = b.driver.BackupVolume(vol, tarWriter, optimized, snapNames, parent, op)
 	if err != nil {
 		return err
 	}
@@ -2954,13 +3113,17 @@ func (b *backend) getInstanceDisk(inst instance.Instance) (string, error) {
 		return "", err
 	}
 
+	// Load storage volume from database, its config is needed to locate the device of encrypted volumes.
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return "", err
+	}
+
 	contentType := InstanceContentType(inst)
 	volStorageName := project.Instance(inst.Project().Name, inst.Name())
 
 	// Get the volume.
-	// There's no need to pass config as it's not needed when getting the
-	// location of the disk block device.
-	vol := b.GetVolume(volType, contentType, volStorageName, nil)
+	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
 
 	// Get the location of the disk block device.
 	diskPath, err := b.driver.GetVolumeDiskPath(vol)
@@ -2968,7 +3131,8 @@ func (b *backend) getInstanceDisk(inst instance.Instance) (string, error) {
 		return "", err
 	}
 
-	return diskPath, nil
+	// Encrypted volumes are used through their opened device.
+	return drivers.LUKSDevicePath(vol, diskPath)
 }
 
 // CacheInstanceSnapshots instructs the driver to pre-fetch and cache details on all snapshots.
@@ -3647,6 +3811,11 @@ func (b *backend) EnsureImage(fingerprint string, op *operations.Operation) erro
 func (b *backend) shouldUseOptimizedImage(fingerprint string, contentType drivers.ContentType, volConfig map[string]string, op *operations.Operation) (bool, error) {
 	canOptimizeImage := b.driver.Info().OptimizedImages
 
+	// Encrypted volumes each have their own key, so they can't be created from an optimized image.
+	if volConfig["block.encryption"] != "" || (contentType == drivers.ContentTypeBlock && b.db.Config["volume.block.encryption"] != "") {
+		return false, nil
+	}
+
 	// If the volume config is empty, the default pool configuration is used, making the driver's support
 	// for optimized images the determining factor. However, an optimized image cannot be utilized if the
 	// driver lacks support for it.
@@ -4730,6 +4899,10 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 	// Use the source volume's config if not supplied.
 	if config == nil {
 		config = srcConfig.Volume.Config
+	} else {
+		// Keep the secrets of the source, which aren't returned through the API.
+		config = util.CloneMap(config)
+		VolumeKeepWriteOnly(config, srcConfig.Volume.Config)
 	}
 
 	// Use the source volume's description if not supplied.
@@ -5158,6 +5331,11 @@ func (b *backend) CreateCustomVolumeFromMigration(projectName string, conn io.Re
 		return err
 	}
 
+	// Keep the secrets of the source, which aren't returned through the API the migration was requested with.
+	if dbVol == nil && srcInfo.Config != nil && srcInfo.Config.Volume != nil {
+		VolumeKeepWriteOnly(vol.Config(), srcInfo.Config.Volume.Config)
+	}
+
 	reverter := revert.New()
 	defer reverter.Fail()
 
@@ -5433,6 +5611,15 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 			return errors.New(`Custom volume "block.filesystem" property cannot be changed`)
 		}
 
+		// Check that the volume's encryption settings aren't being changed.
+		// The key can still be set when missing, as on volumes imported from a backup which doesn't include it.
+		for _, key := range []string{"block.encryption", "block.encryption.key"} {
+			_, changed := changedConfig[key]
+			if changed && (key != "block.encryption.key" || curVol.Config[key] != "") {
+				return fmt.Errorf(`Custom volume %q property cannot be changed`, key)
+			}
+		}
+
 		// Check for config changing that is not allowed when running instances are using it.
 		if changedConfig["security.shifted"] != "" {
 			err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
@@ -5746,10 +5933,15 @@ func (b *backend) GetCustomVolumeDisk(projectName, volName string) (string, erro
 	// Get the volume name on storage.
 	volStorageName := project.StorageVolume(projectName, volName)
 
-	// There's no need to pass config as it's not needed when getting the volume usage.
-	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, nil)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
 
-	return b.driver.GetVolumeDiskPath(vol)
+	diskPath, err := b.driver.GetVolumeDiskPath(vol)
+	if err != nil {
+		return "", err
+	}
+
+	// Encrypted volumes are used through their opened device.
+	return drivers.LUKSDevicePath(vol, diskPath)
 }
 
 // GetCustomVolumeUsage returns the disk space used by the custom volume.
@@ -6206,6 +6398,80 @@ func (b *backend) RestoreCustomVolume(projectName, volName string, snapshotName
 	return nil
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a volume snapshot and another snapshot of the
+// volume, or the volume itself if otherSnapshotName is empty.
+func (b *backend) DiffVolumeSnapshot(projectName string, volType drivers.VolumeType, volName string, snapshotName string, otherSnapshotName string, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volType": volType, "volName": volName, "snapshotName": snapshotName, "otherSnapshotName": otherSnapshotName})
+	l.Debug("DiffVolumeSnapshot started")
+	defer l.Debug("DiffVolumeSnapshot finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	// Quick checks.
+	if internalInstance.IsSnapshot(volName) {
+		return nil, errors.New("Volume cannot be snapshot")
+	}
+
+	if internalInstance.IsSnapshot(snapshotName) || internalInstance.IsSnapshot(otherSnapshotName) {
+		return nil, errors.New("Invalid snapshot name")
+	}
+
+	dbVol, err := VolumeDBGet(b, projectName, volName, volType)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbVol.ContentType != db.StoragePoolVolumeContentTypeNameFS {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Only filesystem volumes can be compared")
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+	if volType != drivers.VolumeTypeCustom {
+		volStorageName = project.Instance(projectName, volName)
+	}
+
+	vol := b.GetVolume(volType, drivers.ContentTypeFS, volStorageName, dbVol.Config)
+
+	// Check that the snapshots exist.
+	_, err = VolumeDBGet(b, projectName, drivers.GetSnapshotVolumeName(volName, snapshotName), volType)
+	if err != nil {
+		return nil, err
+	}
+
+	snapVol, err := vol.NewSnapshot(snapshotName)
+	if err != nil {
+		return nil, err
+	}
+
+	otherVol := vol
+	if otherSnapshotName != "" {
+		_, err = VolumeDBGet(b, projectName, drivers.GetSnapshotVolumeName(volName, otherSnapshotName), volType)
+		if err != nil {
+			return nil, err
+		}
+
+		otherVol, err = vol.NewSnapshot(otherSnapshotName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diff, err := b.driver.DiffVolumeSnapshot(snapVol, otherVol, op)
+	if err != nil {
+		if errors.Is(err, drivers.ErrNotSupported) {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Storage pool driver %q doesn't support comparing snapshots", b.driver.Info().Name)
+		}
+
+		return nil, err
+	}
+
+	return diff, nil
+}
+
 func (b *backend) createStorageStructure(path string) error {
 	for _, volType := range b.driver.Info().VolumeTypes {
 		for _, name := range drivers.BaseDirectories[volType] {
@@ -6397,11 +6663,6 @@ func (b *backend) UpdateInstanceBackupFile(inst instance.Instance, snapshots boo
 		return err
 	}
 
-	data, err := yaml.Marshal(config)
-	if err != nil {
-		return err
-	}
-
 	// Get the volume name on storage.
 	volStorageName := project.Instance(inst.Project().Name, inst.Name())
 	volType, err := InstanceTypeToVolumeType(inst.Type())
@@ -6412,6 +6673,14 @@ func (b *backend) UpdateInstanceBackupFile(inst instance.Instance, snapshots boo
 	contentType := InstanceContentType(inst)
 	vol := b.GetVolume(volType, contentType, volStorageName, config.Volume.Config)
 
+	// Don't store secrets such as encryption keys alongside the volume.
+	BackupConfigStripWriteOnly(config)
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
 	// Only need to activate and mount the VM's config volume.
 	if inst.Type() == instancetype.VM {
 		vol = vol.NewVMBlockFilesystemVolume()
@@ -7073,7 +7342,7 @@ func (b *backend) ImportInstance(inst instance.Instance, poolVol *backupConfig.C
 }
 
 // BackupCustomVolume creates a custom volume backup.
-func (b *backend) BackupCustomVolume(projectName string, volName string, writer instancewriter.InstanceWriter, optimized bool, snapshots bool, op *operations.Operation) error {
+func (b *backend) BackupCustomVolume(projectName string, volName string, writer instancewriter.InstanceWriter, optimized bool, snapshots bool, parent string, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volume": volName, "optimized": optimized, "snapshots": snapshots})
 	l.Debug("BackupCustomVolume started")
 	defer l.Debug("BackupCustomVolume finished")
@@ -7101,7 +7370,7 @@ func (b *backend) BackupCustomVolume(projectName string, volName string, writer
 	}
 
 	var snapNames []string
-	if snapshots {
+	if snapshots || parent != "" {
 		// Get snapshots in age order, oldest first, and pass names to storage driver.
 		volSnaps, err := VolumeDBSnapshotsGet(b, projectName, volName, drivers.VolumeTypeCustom)
 		if err != nil {
@@ -7115,9 +7384,14 @@ func (b *backend) BackupCustomVolume(projectName string, volName string, writer
 		}
 	}
 
+	snapNames, err = backupSnapshotNames(snapNames, snapshots, parent)
+	if err != nil {
+		return err
+	}
+
 	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
 
-	err = b.driver.BackupVolume(vol, writer, optimized, snapNames, op)
+	err = b.driver.BackupVolume(vol, writer, optimized, snapNames, parent, op)
 	if err != nil {
 		return err
 	}
@@ -7218,7 +7492,8 @@ func (b *backend) CreateCustomVolumeFromBackup(srcBackup backup.Info, srcData io
 		return errors.New("Valid volume config not found in index")
 	}
 
-	if len(srcBackup.Snapshots) != len(srcBackup.Config.VolumeSnapshots) {
+	// Incremental backups only hold the snapshots taken since their parent.
+	if srcBackup.ParentSnapshot == "" && len(srcBackup.Snapshots) != len(srcBackup.Config.VolumeSnapshots) {
 		return errors.New("Valid volume snapshot config not found in index")
 	}
 
@@ -7265,6 +7540,7 @@ func (b *backend) CreateCustomVolumeFromBackup(srcBackup backup.Info, srcData io
 	reverter.Add(func() { _ = VolumeDBDelete(b, srcBackup.Project, srcBackup.Name, vol.Type()) })
 
 	// Create database entries for new storage volume snapshots.
+	wantedSnapshots := make([]string, 0, len(srcBackup.Config.VolumeSnapshots))
 	for _, s := range srcBackup.Config.VolumeSnapshots {
 		snapshot := s // Local var for revert.
 		snapName := snapshot.Name
@@ -7275,6 +7551,8 @@ func (b *backend) CreateCustomVolumeFromBackup(srcBackup backup.Info, srcData io
 			_, snapName, _ = api.GetParentAndSnapshotName(snapshot.Name)
 		}
 
+		wantedSnapshots = append(wantedSnapshots, snapName)
+
 		fullSnapName := drivers.GetSnapshotVolumeName(srcBackup.Name, snapName)
 		snapVolStorageName := project.StorageVolume(srcBackup.Project, fullSnapName)
 		snapVol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(srcBackup.Config.Volume.ContentType), snapVolStorageName, snapshot.Config)
@@ -7289,8 +7567,8 @@ func (b *backend) CreateCustomVolumeFromBackup(srcBackup backup.Info, srcData io
 		reverter.Add(func() { _ = VolumeDBDelete(b, srcBackup.Project, fullSnapName, snapVol.Type()) })
 	}
 
-	// Unpack the backup into the new storage volume(s).
-	volPostHook, revertHook, err := b.driver.CreateVolumeFromBackup(vol, srcBackup, srcData, op)
+	// Unpack the backup (and for incremental backups its parents) into the new storage volume(s).
+	volPostHook, revertHook, _, err := b.createVolumeFromBackupChain(vol, srcBackup, srcData, wantedSnapshots, op)
 	if err != nil {
 		return err
 	}
@@ -73,6 +73,14 @@ func (b *mockBackend) GetResources() (*api.ResourcesStoragePool, error) {
 	return nil, nil
 }
 
+func (b *mockBackend) GetState() (*api.StoragePoolState, error) {
+	return nil, nil
+}
+
+func (b *mockBackend) Scrub() error {
+	return nil
+}
+
 func (b *mockBackend) IsUsed() (bool, error) {
 	return false, nil
 }
@@ -178,7 +186,7 @@ func (b *mockBackend) RefreshInstance(inst instance.Instance, src instance.Insta
 	return nil
 }
 
-func (b *mockBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, op *operations.Operation) error {
+func (b *mockBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, parent string, op *operations.Operation) error {
 	return nil
 }
 
@@ -347,8 +355,12 @@ func (b *mockBackend) RestoreCustomVolume(projectName string, volName string, sn
 	return nil
 }
 
+func (b *mockBackend) DiffVolumeSnapshot(projectName string, volType drivers.VolumeType, volName string, snapshotName string, otherSnapshotName string, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	return nil, nil
+}
+
 // BackupCustomVolume creates a custom volume backup.
-func (b *mockBackend) BackupCustomVolume(projectName string, volName string, writer instancewriter.InstanceWriter, optimized bool, snapshots bool, op *operations.Operation) error {
+func (b *mockBackend) BackupCustomVolume(projectName string, volName string, writer instancewriter.InstanceWriter, optimized bool, snapshots bool, parent string, op *operations.Operation) error {
 	return nil
 }
 
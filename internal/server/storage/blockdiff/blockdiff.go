@@ -0,0 +1,192 @@
+package blockdiff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultBlockSize is the default granularity at which block devices are compared.
+const DefaultBlockSize = 64 * 1024
+
+// magic identifies an encoded block diff.
+var magic = []byte("INCUSBD1")
+
+// headerSize is the size of the encoded header (magic, target size, block size and block count).
+const headerSize = 8 + 8 + 4 + 8
+
+// Diff describes the blocks of a target device which differ from a base device.
+type Diff struct {
+	// Size is the size of the target device in bytes.
+	Size int64
+
+	// BlockSize is the size of the compared blocks in bytes.
+	BlockSize int64
+
+	// Blocks holds the indexes of the changed blocks in ascending order.
+	Blocks []int64
+}
+
+// Compare reads the base and target devices block by block and returns the blocks of target which differ
+// from base. Any block past the end of the base device is considered changed.
+func Compare(base io.ReaderAt, baseSize int64, target io.ReaderAt, targetSize int64, blockSize int64) (*Diff, error) {
+	if blockSize <= 0 {
+		return nil, errors.New("Block size must be greater than zero")
+	}
+
+	d := &Diff{
+		Size:      targetSize,
+		BlockSize: blockSize,
+	}
+
+	baseBuf := make([]byte, blockSize)
+	targetBuf := make([]byte, blockSize)
+
+	for index := int64(0); index*blockSize < targetSize; index++ {
+		offset := index * blockSize
+		length := min(blockSize, targetSize-offset)
+
+		_, err := target.ReadAt(targetBuf[:length], offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("Failed reading target block at offset %d: %w", offset, err)
+		}
+
+		if offset+length > baseSize {
+			d.Blocks = append(d.Blocks, index)
+			continue
+		}
+
+		_, err = base.ReadAt(baseBuf[:length], offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("Failed reading base block at offset %d: %w", offset, err)
+		}
+
+		if !bytes.Equal(baseBuf[:length], targetBuf[:length]) {
+			d.Blocks = append(d.Blocks, index)
+		}
+	}
+
+	return d, nil
+}
+
+// blockLength returns the length of the block at the given index.
+func (d *Diff) blockLength(index int64) int64 {
+	return min(d.BlockSize, d.Size-index*d.BlockSize)
+}
+
+// EncodedSize returns the number of bytes Encode will write.
+func (d *Diff) EncodedSize() int64 {
+	size := int64(headerSize)
+	for _, index := range d.Blocks {
+		size += 8 + d.blockLength(index)
+	}
+
+	return size
+}
+
+// Encode writes the diff to w, reading the content of the changed blocks from target.
+func (d *Diff) Encode(w io.Writer, target io.ReaderAt) error {
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	binary.BigEndian.PutUint64(header[8:], uint64(d.Size))
+	binary.BigEndian.PutUint32(header[16:], uint32(d.BlockSize))
+	binary.BigEndian.PutUint64(header[20:], uint64(len(d.Blocks)))
+
+	_, err := w.Write(header)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 8+d.BlockSize)
+	for _, index := range d.Blocks {
+		offset := index * d.BlockSize
+		length := d.blockLength(index)
+
+		binary.BigEndian.PutUint64(buf, uint64(index))
+		_, err = target.ReadAt(buf[8:8+length], offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("Failed reading target block at offset %d: %w", offset, err)
+		}
+
+		_, err = w.Write(buf[:8+length])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reader decodes a diff written by Encode.
+type Reader struct {
+	r         io.Reader
+	size      int64
+	blockSize int64
+	count     uint64
+}
+
+// NewReader reads the diff header from r and returns a Reader for the rest of the diff.
+func NewReader(r io.Reader) (*Reader, error) {
+	header := make([]byte, headerSize)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading block diff header: %w", err)
+	}
+
+	if !bytes.Equal(header[:8], magic) {
+		return nil, errors.New("Invalid block diff header")
+	}
+
+	blockSize := int64(binary.BigEndian.Uint32(header[16:]))
+	if blockSize == 0 {
+		return nil, errors.New("Invalid block diff block size")
+	}
+
+	return &Reader{
+		r:         r,
+		size:      int64(binary.BigEndian.Uint64(header[8:])),
+		blockSize: blockSize,
+		count:     binary.BigEndian.Uint64(header[20:]),
+	}, nil
+}
+
+// Size returns the size of the target device in bytes.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// ApplyTo writes the changed blocks onto dst. The destination is expected to already hold the content of
+// the base device and to be at least Size bytes long.
+func (r *Reader) ApplyTo(dst io.WriterAt) error {
+	indexBuf := make([]byte, 8)
+	buf := make([]byte, r.blockSize)
+
+	for range r.count {
+		_, err := io.ReadFull(r.r, indexBuf)
+		if err != nil {
+			return fmt.Errorf("Failed reading block diff entry: %w", err)
+		}
+
+		index := int64(binary.BigEndian.Uint64(indexBuf))
+		offset := index * r.blockSize
+		if offset < 0 || offset >= r.size {
+			return fmt.Errorf("Invalid block index %d in block diff", index)
+		}
+
+		length := min(r.blockSize, r.size-offset)
+
+		_, err = io.ReadFull(r.r, buf[:length])
+		if err != nil {
+			return fmt.Errorf("Failed reading block diff data: %w", err)
+		}
+
+		_, err = dst.WriteAt(buf[:length], offset)
+		if err != nil {
+			return fmt.Errorf("Failed writing block at offset %d: %w", offset, err)
+		}
+	}
+
+	return nil
+}
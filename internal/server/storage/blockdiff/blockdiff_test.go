@@ -0,0 +1,92 @@
+package blockdiff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writerAt is an in-memory io.WriterAt.
+type writerAt struct {
+	buf []byte
+}
+
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+	if int(off)+len(p) > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, int(off)+len(p)-len(w.buf))...)
+	}
+
+	return copy(w.buf[off:], p), nil
+}
+
+func TestCompareAndApply(t *testing.T) {
+	cases := []struct {
+		name   string
+		base   []byte
+		target []byte
+		blocks []int64
+	}{
+		{
+			name:   "identical",
+			base:   []byte("aaaabbbbcccc"),
+			target: []byte("aaaabbbbcccc"),
+			blocks: nil,
+		},
+		{
+			name:   "changed middle block",
+			base:   []byte("aaaabbbbcccc"),
+			target: []byte("aaaaBbbbcccc"),
+			blocks: []int64{1},
+		},
+		{
+			name:   "partial last block",
+			base:   []byte("aaaabbbbcc"),
+			target: []byte("aaaabbbbcd"),
+			blocks: []int64{2},
+		},
+		{
+			name:   "grown target",
+			base:   []byte("aaaabbbb"),
+			target: []byte("aaaabbbbccccdd"),
+			blocks: []int64{2, 3},
+		},
+		{
+			name:   "shrunk target",
+			base:   []byte("aaaabbbbcccc"),
+			target: []byte("xaaabb"),
+			blocks: []int64{0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Compare(bytes.NewReader(tc.base), int64(len(tc.base)), bytes.NewReader(tc.target), int64(len(tc.target)), 4)
+			require.NoError(t, err)
+			assert.Equal(t, tc.blocks, d.Blocks)
+
+			var encoded bytes.Buffer
+			err = d.Encode(&encoded, bytes.NewReader(tc.target))
+			require.NoError(t, err)
+			assert.Equal(t, d.EncodedSize(), int64(encoded.Len()))
+
+			r, err := NewReader(&encoded)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tc.target)), r.Size())
+
+			dst := &writerAt{buf: append([]byte{}, tc.base...)}
+			err = r.ApplyTo(dst)
+			require.NoError(t, err)
+			assert.Equal(t, tc.target, dst.buf[:r.Size()])
+		})
+	}
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("not a block diff header")))
+	assert.Error(t, err)
+
+	_, err = NewReader(bytes.NewReader([]byte("short")))
+	assert.Error(t, err)
+}
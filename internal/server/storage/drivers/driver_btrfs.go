@@ -518,6 +518,51 @@ func (d *btrfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return genericVFSGetResources(d)
 }
 
+// GetHealth returns the health of the filesystem, including the status of the last scrub.
+func (d *btrfs) GetHealth() (*api.StoragePoolStateHealth, error) {
+	mountPath := GetPoolMountPath(d.name)
+	health := &api.StoragePoolStateHealth{Status: api.StoragePoolHealthHealthy}
+
+	out, err := subprocess.RunCommand("btrfs", "device", "stats", mountPath)
+	if err != nil {
+		return nil, err
+	}
+
+	health.Messages = d.parseDeviceStats(out)
+	if len(health.Messages) > 0 {
+		health.Status = api.StoragePoolHealthDegraded
+	}
+
+	out, err = subprocess.RunCommand("btrfs", "filesystem", "show", mountPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(out, "Some devices missing") {
+		health.Status = api.StoragePoolHealthDegraded
+		health.Messages = append(health.Messages, "Some devices are missing")
+	}
+
+	out, err = subprocess.RunCommand("btrfs", "scrub", "status", mountPath)
+	if err != nil {
+		return nil, err
+	}
+
+	health.Scrub = d.parseScrubStatus(out)
+
+	return health, nil
+}
+
+// Scrub starts a background scrub of the filesystem.
+func (d *btrfs) Scrub() error {
+	_, err := subprocess.RunCommand("btrfs", "scrub", "start", GetPoolMountPath(d.name))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MigrationType returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *btrfs) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
 	var rsyncFeatures []string
@@ -659,3 +659,122 @@ func (d *btrfs) receiveSubVolume(r io.Reader, receivePath string, tracker *iopro
 
 	return subVolPath, nil
 }
+
+// parseDeviceStats parses the output of `btrfs device stats` and returns a message for each non-zero error counter.
+func (d *btrfs) parseDeviceStats(output string) []string {
+	messages := []string{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[1] == "0" {
+			continue
+		}
+
+		// Lines are in the form "[/dev/sda].write_io_errs   0".
+		device, counter, found := strings.Cut(fields[0], "].")
+		if !found {
+			continue
+		}
+
+		messages = append(messages, fmt.Sprintf("Device %q has %s %s", strings.TrimPrefix(device, "["), fields[1], counter))
+	}
+
+	return messages
+}
+
+// parseScrubStatus parses the output of `btrfs scrub status` into the status of the last scrub.
+func (d *btrfs) parseScrubStatus(output string) *api.StoragePoolStateScrub {
+	scrub := &api.StoragePoolStateScrub{Status: "none"}
+
+	for _, line := range strings.Split(output, "\n") {
+		name, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch name {
+		case "Status":
+			switch value {
+			case "running", "finished":
+				scrub.Status = value
+			case "aborted", "interrupted":
+				scrub.Status = "canceled"
+			}
+
+		case "Error summary":
+			// Either "no errors found" or a list of counters such as "csum=2 read=1".
+			for _, counter := range strings.Fields(value) {
+				_, count, found := strings.Cut(counter, "=")
+				if !found {
+					continue
+				}
+
+				errCount, err := strconv.ParseUint(count, 10, 64)
+				if err == nil {
+					scrub.Errors += errCount
+				}
+			}
+		}
+	}
+
+	return scrub
+}
+
+// getChangedFiles returns the files of the subvolume whose data changed since the given generation
+// (using `btrfs subvolume find-new`) along with the current generation of the subvolume.
+func (d *btrfs) getChangedFiles(path string, generation uint64) ([]string, uint64, error) {
+	output, err := subprocess.RunCommand("btrfs", "subvolume", "find-new", path, strconv.FormatUint(generation, 10))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return d.parseFindNew(output)
+}
+
+// parseFindNew parses the output of `btrfs subvolume find-new` into the list of changed files (relative to
+// the root of the subvolume) and the current generation of the subvolume.
+func (d *btrfs) parseFindNew(output string) ([]string, uint64, error) {
+	files := []string{}
+	seen := map[string]bool{}
+	var generation uint64
+
+	for _, line := range strings.Split(output, "\n") {
+		value, found := strings.CutPrefix(line, "transid marker was ")
+		if found {
+			var err error
+
+			generation, err = strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("Failed parsing subvolume generation %q: %w", value, err)
+			}
+
+			continue
+		}
+
+		// Lines are in the form "inode 257 file offset 0 len 4096 disk start 0 offset 0 gen 8 flags NONE path".
+		if !strings.HasPrefix(line, "inode ") {
+			continue
+		}
+
+		_, flagsAndPath, found := strings.Cut(line, " flags ")
+		if !found {
+			return nil, 0, fmt.Errorf("Unexpected btrfs find-new output line %q", line)
+		}
+
+		_, path, found := strings.Cut(flagsAndPath, " ")
+		if !found || path == "" {
+			return nil, 0, fmt.Errorf("Unexpected btrfs find-new output line %q", line)
+		}
+
+		// Files with several extents are listed once per extent.
+		path = "/" + path
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	return files, generation, nil
+}
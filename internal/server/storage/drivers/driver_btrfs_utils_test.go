@@ -0,0 +1,192 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func Test_btrfs_parseDeviceStats(t *testing.T) {
+	d := &btrfs{}
+
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			"No errors",
+			`[/dev/sda].write_io_errs    0
+[/dev/sda].read_io_errs     0
+[/dev/sda].flush_io_errs    0
+[/dev/sda].corruption_errs  0
+[/dev/sda].generation_errs  0
+`,
+			[]string{},
+		},
+		{
+			"Errors on several devices",
+			`[/dev/sda].write_io_errs    0
+[/dev/sda].read_io_errs     3
+[/dev/sda].flush_io_errs    0
+[/dev/sda].corruption_errs  0
+[/dev/sda].generation_errs  0
+[/dev/sdb].write_io_errs    1
+[/dev/sdb].read_io_errs     0
+[/dev/sdb].flush_io_errs    0
+[/dev/sdb].corruption_errs  12
+[/dev/sdb].generation_errs  0
+`,
+			[]string{
+				`Device "/dev/sda" has 3 read_io_errs`,
+				`Device "/dev/sdb" has 1 write_io_errs`,
+				`Device "/dev/sdb" has 12 corruption_errs`,
+			},
+		},
+		{
+			"Unexpected lines",
+			`ERROR: something went wrong
+devid 1 missing   5
+`,
+			[]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, d.parseDeviceStats(tt.output))
+		})
+	}
+}
+
+func Test_btrfs_parseScrubStatus(t *testing.T) {
+	d := &btrfs{}
+
+	tests := []struct {
+		name   string
+		output string
+		want   *api.StoragePoolStateScrub
+	}{
+		{
+			"Never scrubbed",
+			`UUID:             2f1c6d7e-3a0b-4a8e-9b55-0f5c9c2f1d3e
+	no stats available
+Total to scrub:   1.00GiB
+Rate:             0.00B/s
+Error summary:    no errors found
+`,
+			&api.StoragePoolStateScrub{Status: "none"},
+		},
+		{
+			"Finished without errors",
+			`UUID:             2f1c6d7e-3a0b-4a8e-9b55-0f5c9c2f1d3e
+Scrub started:    Sun Oct 11 00:24:01 2026
+Status:           finished
+Duration:         0:00:10
+Total to scrub:   4.50GiB
+Rate:             460.80MiB/s
+Error summary:    no errors found
+`,
+			&api.StoragePoolStateScrub{Status: "finished"},
+		},
+		{
+			"Running",
+			`UUID:             2f1c6d7e-3a0b-4a8e-9b55-0f5c9c2f1d3e
+Scrub started:    Sun Oct 11 00:24:01 2026
+Status:           running
+Duration:         0:00:02
+Time left:        0:00:08
+Bytes scrubbed:   1.20GiB  (26.67%)
+Rate:             614.40MiB/s
+Error summary:    no errors found
+`,
+			&api.StoragePoolStateScrub{Status: "running"},
+		},
+		{
+			"Aborted with errors",
+			`UUID:             2f1c6d7e-3a0b-4a8e-9b55-0f5c9c2f1d3e
+Scrub started:    Sun Oct 11 00:24:01 2026
+Status:           aborted
+Duration:         0:00:05
+Error summary:    csum=2 read=1
+  Corrected:      1
+  Uncorrectable:  2
+  Unverified:     0
+`,
+			&api.StoragePoolStateScrub{Status: "canceled", Errors: 3},
+		},
+		{
+			"Interrupted",
+			`Status:           interrupted
+Error summary:    verify=4
+`,
+			&api.StoragePoolStateScrub{Status: "canceled", Errors: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, d.parseScrubStatus(tt.output))
+		})
+	}
+}
+
+func Test_btrfs_parseFindNew(t *testing.T) {
+	d := &btrfs{}
+
+	tests := []struct {
+		name           string
+		output         string
+		wantFiles      []string
+		wantGeneration uint64
+		wantErr        bool
+	}{
+		{
+			name:           "No changes",
+			output:         "transid marker was 12\n",
+			wantFiles:      []string{},
+			wantGeneration: 12,
+		},
+		{
+			name: "Changed files",
+			output: `inode 257 file offset 0 len 4096 disk start 13631488 offset 0 gen 8 flags NONE dir/file
+inode 257 file offset 4096 len 4096 disk start 13635584 offset 0 gen 9 flags NONE dir/file
+inode 258 file offset 0 len 12 disk start 0 offset 0 gen 9 flags INLINE name with spaces
+transid marker was 10
+`,
+			wantFiles:      []string{"/dir/file", "/name with spaces"},
+			wantGeneration: 10,
+		},
+		{
+			name:    "Invalid generation",
+			output:  "transid marker was foo\n",
+			wantErr: true,
+		},
+		{
+			name:    "Line without flags",
+			output:  "inode 257 file offset 0 len 4096 disk start 0 offset 0 gen 8\n",
+			wantErr: true,
+		},
+		{
+			name:    "Line without path",
+			output:  "inode 257 file offset 0 len 4096 disk start 0 offset 0 gen 8 flags NONE\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, generation, err := d.parseFindNew(tt.output)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFiles, files)
+			assert.Equal(t, tt.wantGeneration, generation)
+		})
+	}
+}
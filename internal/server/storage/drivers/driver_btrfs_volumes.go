@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -156,7 +157,11 @@ func (d *btrfs) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Op
 func (d *btrfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
 	// Handle the non-optimized tarballs through the generic unpacker.
 	if !*srcBackup.OptimizedStorage {
-		return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcData, op)
+		return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcBackup.ParentSnapshot, srcData, op)
+	}
+
+	if srcBackup.ParentSnapshot != "" {
+		return nil, nil, errors.New("Optimized incremental backups aren't supported by this driver")
 	}
 
 	volExists, err := d.HasVolume(vol)
@@ -1054,6 +1059,76 @@ func (d *btrfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage volume
 
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=backups.compression_algorithm)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.compression_algorithm`
+	//  shortdesc: Compression algorithm to use for scheduled backups
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=backups.expiry)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.expiry`
+	//  shortdesc: {{backup_expiry_format}}
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=backups.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.schedule`
+	//  shortdesc: {{backup_schedule_format}}
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=backups.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.target`
+	//  shortdesc: {{backup_target_format}}
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=replication.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or empty to only replicate on request (the default)
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=replication.snapshots.retention)
+	//
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: `1`
+	//  shortdesc: Number of replication snapshots to keep on both servers
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=replication.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Name of the replication target (`replication.NAME.*` server configuration) that the volume is replicated to
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume pool
+	//  shortdesc: Storage pool of the replica on the target server
+
+	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume project
+	//  shortdesc: Project of the replica on the target server
+
 	// gendoc:generate(entity=storage_volume_btrfs, group=common, key=snapshots.expiry)
 	//
 	// ---
@@ -1623,7 +1698,7 @@ func (d *btrfs) migrateVolumeOptimized(vol Volume, conn io.ReadWriteCloser, volS
 
 // BackupVolume copies a volume (and optionally its snapshots) to a specified target path.
 // This driver does not support optimized backups.
-func (d *btrfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, op *operations.Operation) error {
+func (d *btrfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, parent string, op *operations.Operation) error {
 	// Handle the non-optimized tarballs through the generic packer.
 	if !optimized {
 		// Because the generic backup method will not take a consistent backup if files are being modified
@@ -1642,11 +1717,15 @@ func (d *btrfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, o
 			vol.mountCustomPath = snapshotPath
 		}
 
-		return genericVFSBackupVolume(d, vol, writer, snapshots, op)
+		return genericVFSBackupVolume(d, vol, writer, snapshots, parent, op)
 	}
 
 	// Optimized backup.
 
+	if parent != "" {
+		return errors.New("Optimized incremental backups aren't supported by this driver")
+	}
+
 	if len(snapshots) > 0 {
 		// Check requested snapshot match those in storage.
 		err := vol.SnapshotsMatch(snapshots, op)
@@ -2091,6 +2170,83 @@ func (d *btrfs) RestoreVolume(vol Volume, snapshotName string, op *operations.Op
 	return d.deleteSubvolume(backupSubvolume, true)
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+func (d *btrfs) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	if snapVol.contentType != ContentTypeFS {
+		return nil, ErrNotSupported
+	}
+
+	var diff []api.StorageVolumeSnapshotDiff
+	err := snapVol.MountTask(func(snapPath string, op *operations.Operation) error {
+		return otherVol.MountTask(func(otherPath string, op *operations.Operation) error {
+			var err error
+
+			// Subvolume snapshots keep the inode numbers so renames can be detected.
+			diff, err = genericVFSDiffPaths(snapPath, otherPath, true)
+			if err != nil {
+				return err
+			}
+
+			// Files whose data was rewritten without changing their size or timestamps are only
+			// found through the generation of their extents.
+			_, snapGeneration, err := d.getChangedFiles(snapPath, math.MaxUint64)
+			if err != nil {
+				return err
+			}
+
+			_, otherGeneration, err := d.getChangedFiles(otherPath, math.MaxUint64)
+			if err != nil {
+				return err
+			}
+
+			newerPath, olderGeneration := otherPath, snapGeneration
+			if otherGeneration < snapGeneration {
+				newerPath, olderGeneration = snapPath, otherGeneration
+			}
+
+			changed, _, err := d.getChangedFiles(newerPath, olderGeneration+1)
+			if err != nil {
+				return err
+			}
+
+			reported := make(map[string]bool, len(diff))
+			for _, entry := range diff {
+				reported[entry.Path] = true
+				if entry.NewPath != "" {
+					reported[entry.NewPath] = true
+				}
+			}
+
+			for _, relPath := range changed {
+				if reported[relPath] {
+					continue
+				}
+
+				_, err := os.Lstat(filepath.Join(snapPath, relPath))
+				if err != nil {
+					continue
+				}
+
+				_, err = os.Lstat(filepath.Join(otherPath, relPath))
+				if err != nil {
+					continue
+				}
+
+				diff = append(diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffModified, Path: relPath})
+			}
+
+			sortSnapshotDiff(diff)
+
+			return nil
+		}, op)
+	}, op)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
 // RenameVolumeSnapshot renames a volume snapshot.
 func (d *btrfs) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	return genericVFSRenameVolumeSnapshot(d, snapVol, newSnapshotName, op)
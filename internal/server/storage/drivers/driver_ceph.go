@@ -435,6 +435,11 @@ func (d *ceph) Unmount() (bool, error) {
 	return true, nil
 }
 
+// GetHealth returns the health of the Ceph cluster.
+func (d *ceph) GetHealth() (*api.StoragePoolStateHealth, error) {
+	return cephHealth(d.config["ceph.cluster_name"], d.config["ceph.user.name"])
+}
+
 // GetResources returns the pool resource usage information.
 func (d *ceph) GetResources() (*api.ResourcesStoragePool, error) {
 	var stdout bytes.Buffer
@@ -113,6 +113,8 @@ func (d *ceph) rbdCreateVolume(vol Volume, size string) error {
 		return err
 	}
 
+	sizeBytes = luksDiskSizeBytes(vol, sizeBytes)
+
 	cmd := []string{
 		"--id", d.config["ceph.user.name"],
 		"--cluster", d.config["ceph.cluster_name"],
@@ -166,6 +166,14 @@ func (d *ceph) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Ope
 		}
 	}
 
+	// Initialize the encryption of the volume.
+	if luksEncrypted(vol) {
+		err = luksFormat(vol, devPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	// For VMs, also create the filesystem volume.
 	if vol.IsVMBlock() {
 		fsVol := vol.NewVMBlockFilesystemVolume()
@@ -190,6 +198,12 @@ func (d *ceph) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Ope
 				if err != nil {
 					return err
 				}
+
+				// Fill encrypted volumes through their opened device.
+				devPath, err = LUKSDevicePath(vol, devPath)
+				if err != nil {
+					return err
+				}
 			}
 
 			allowUnsafeResize := false
@@ -319,7 +333,7 @@ func (d *ceph) getVolumeSize(volumeName string) (int64, error) {
 
 // CreateVolumeFromBackup re-creates a volume from its exported state.
 func (d *ceph) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
-	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcData, op)
+	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcBackup.ParentSnapshot, srcData, op)
 }
 
 // CreateVolumeFromCopy provides same-pool volume copying functionality.
@@ -837,6 +851,15 @@ func (d *ceph) FillVolumeConfig(vol Volume) error {
 // commonVolumeRules returns validation rules which are common for pool and volume.
 func (d *ceph) commonVolumeRules() map[string]func(value string) error {
 	return map[string]func(value string) error{
+		// gendoc:generate(entity=storage_volume_ceph, group=common, key=block.encryption)
+		//
+		// ---
+		//  type: string
+		//  condition: instance or custom volume with content type `block`
+		//  default: same as `volume.block.encryption`
+		//  shortdesc: Encryption of the volume (`luks2`), can only be set on creation
+		"block.encryption": validate.Optional(validate.IsOneOf("luks2")),
+
 		// gendoc:generate(entity=storage_volume_ceph, group=common, key=block.filesystem)
 		//
 		// ---
@@ -859,6 +882,14 @@ func (d *ceph) commonVolumeRules() map[string]func(value string) error {
 
 // ValidateVolume validates the supplied volume config.
 func (d *ceph) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=block.encryption.key)
+	//
+	// ---
+	//  type: string
+	//  condition: encrypted volume
+	//  default: randomly generated
+	//  shortdesc: Key used to unlock the encrypted volume, never returned once set
+
 	// gendoc:generate(entity=storage_volume_ceph, group=common, key=initial.gid)
 	//
 	// ---
@@ -915,6 +946,76 @@ func (d *ceph) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage volume
 
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=backups.compression_algorithm)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.compression_algorithm`
+	//  shortdesc: Compression algorithm to use for scheduled backups
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=backups.expiry)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.expiry`
+	//  shortdesc: {{backup_expiry_format}}
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=backups.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.schedule`
+	//  shortdesc: {{backup_schedule_format}}
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=backups.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.target`
+	//  shortdesc: {{backup_target_format}}
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=replication.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or empty to only replicate on request (the default)
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=replication.snapshots.retention)
+	//
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: `1`
+	//  shortdesc: Number of replication snapshots to keep on both servers
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=replication.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Name of the replication target (`replication.NAME.*` server configuration) that the volume is replicated to
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume pool
+	//  shortdesc: Storage pool of the replica on the target server
+
+	// gendoc:generate(entity=storage_volume_ceph, group=common, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume project
+	//  shortdesc: Project of the replica on the target server
+
 	// gendoc:generate(entity=storage_volume_ceph, group=common, key=snapshots.expiry)
 	//
 	// ---
@@ -948,6 +1049,7 @@ func (d *ceph) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  shortdesc: {{snapshot_schedule_format}}
 
 	commonRules := d.commonVolumeRules()
+	luksValidateVolume(vol, commonRules)
 
 	// Disallow block.* settings for regular custom block volumes. These settings only make sense
 	// when using custom filesystem volumes. Incus will create the filesystem
@@ -1073,6 +1175,8 @@ func (d *ceph) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, o
 		return nil
 	}
 
+	sizeBytes = luksDiskSizeBytes(vol, sizeBytes)
+
 	ourMap, devPath, err := d.getRBDMappedDevPath(vol, true)
 	if err != nil {
 		return err
@@ -1158,10 +1262,16 @@ func (d *ceph) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, o
 			return err
 		}
 
+		// Resize the opened device of encrypted volumes.
+		err = luksResize(vol)
+		if err != nil {
+			return err
+		}
+
 		// Move the VM GPT alt header to end of disk if needed (not needed in unsafe resize mode as it is
 		// expected the caller will do all necessary post resize actions themselves).
 		if vol.IsVMBlock() && !allowUnsafeResize {
-			err = d.moveGPTAltHeader(devPath)
+			err = luksWithDevice(vol, devPath, d.moveGPTAltHeader)
 			if err != nil {
 				return err
 			}
@@ -1340,6 +1450,14 @@ func (d *ceph) MountVolume(vol Volume, op *operations.Operation) error {
 				return err
 			}
 		}
+
+		// Open encrypted volumes.
+		if luksEncrypted(vol) {
+			_, err = luksOpen(vol, volDevPath)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	vol.MountRefCountIncrement() // From here on it is up to caller to call UnmountVolume() when done.
@@ -1404,8 +1522,13 @@ func (d *ceph) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Opera
 					return false, ErrInUse
 				}
 
+				err = luksClose(vol)
+				if err != nil {
+					return false, err
+				}
+
 				// Attempt to unmap.
-				err := d.rbdUnmapVolume(vol, true)
+				err = d.rbdUnmapVolume(vol, true)
 				if err != nil {
 					return false, err
 				}
@@ -1574,8 +1697,8 @@ func (d *ceph) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs *lo
 }
 
 // BackupVolume creates an exported version of a volume.
-func (d *ceph) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, op *operations.Operation) error {
-	return genericVFSBackupVolume(d, vol, writer, snapshots, op)
+func (d *ceph) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, parent string, op *operations.Operation) error {
+	return genericVFSBackupVolume(d, vol, writer, snapshots, parent, op)
 }
 
 // CreateVolumeSnapshot creates a snapshot of a volume.
@@ -1938,6 +2061,12 @@ func (d *ceph) RestoreVolume(vol Volume, snapshotName string, op *operations.Ope
 	return nil
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+// RBD snapshots are taken at the block level so inode numbers are kept and renames can be detected.
+func (d *ceph) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	return genericVFSDiffVolumeSnapshot(d, snapVol, otherVol, true, op)
+}
+
 // RenameVolumeSnapshot renames a volume snapshot.
 func (d *ceph) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	reverter := revert.New()
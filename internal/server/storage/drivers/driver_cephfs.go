@@ -567,6 +567,11 @@ func (d *cephfs) Unmount() (bool, error) {
 	return forceUnmount(GetPoolMountPath(d.name))
 }
 
+// GetHealth returns the health of the Ceph cluster.
+func (d *cephfs) GetHealth() (*api.StoragePoolStateHealth, error) {
+	return cephHealth(d.config["cephfs.cluster_name"], d.config["cephfs.user.name"])
+}
+
 // GetResources returns the pool resource usage information.
 func (d *cephfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return genericVFSGetResources(d)
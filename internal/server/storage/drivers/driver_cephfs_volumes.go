@@ -68,7 +68,7 @@ func (d *cephfs) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.O
 
 // CreateVolumeFromBackup re-creates a volume from its exported state.
 func (d *cephfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
-	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcData, op)
+	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcBackup.ParentSnapshot, srcData, op)
 }
 
 // CreateVolumeFromCopy copies an existing storage volume (with or without snapshots) into a new volume.
@@ -342,6 +342,76 @@ func (d *cephfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage volume
 
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=backups.compression_algorithm)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.compression_algorithm`
+	//  shortdesc: Compression algorithm to use for scheduled backups
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=backups.expiry)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.expiry`
+	//  shortdesc: {{backup_expiry_format}}
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=backups.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.schedule`
+	//  shortdesc: {{backup_schedule_format}}
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=backups.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.target`
+	//  shortdesc: {{backup_target_format}}
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=replication.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or empty to only replicate on request (the default)
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=replication.snapshots.retention)
+	//
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: `1`
+	//  shortdesc: Number of replication snapshots to keep on both servers
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=replication.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Name of the replication target (`replication.NAME.*` server configuration) that the volume is replicated to
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume pool
+	//  shortdesc: Storage pool of the replica on the target server
+
+	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume project
+	//  shortdesc: Project of the replica on the target server
+
 	// gendoc:generate(entity=storage_volume_cephfs, group=common, key=snapshots.expiry)
 	//
 	// ---
@@ -570,8 +640,8 @@ func (d *cephfs) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs *
 }
 
 // BackupVolume creates an exported version of a volume.
-func (d *cephfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, op *operations.Operation) error {
-	return genericVFSBackupVolume(d, vol, writer, snapshots, op)
+func (d *cephfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, parent string, op *operations.Operation) error {
+	return genericVFSBackupVolume(d, vol, writer, snapshots, parent, op)
 }
 
 // CreateVolumeSnapshot creates a new snapshot.
@@ -677,6 +747,11 @@ func (d *cephfs) RestoreVolume(vol Volume, snapshotName string, op *operations.O
 	return nil
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+func (d *cephfs) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	return genericVFSDiffVolumeSnapshot(d, snapVol, otherVol, false, op)
+}
+
 // RenameVolumeSnapshot renames a snapshot.
 func (d *cephfs) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	parentName, snapName, _ := api.GetParentAndSnapshotName(snapVol.name)
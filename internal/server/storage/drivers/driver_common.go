@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/subprocess"
@@ -125,12 +126,17 @@ func (d *common) fillVolumeConfig(vol *Volume, excludedKeys ...string) error {
 			continue
 		}
 
+		// block.encryption is only relevant for instance and custom block volumes.
+		if (vol.ContentType() != ContentTypeBlock || vol.Type() == VolumeTypeImage) && volKey == "block.encryption" {
+			continue
+		}
+
 		if vol.config[volKey] == "" {
 			vol.config[volKey] = d.config[k]
 		}
 	}
 
-	return nil
+	return luksFillVolumeConfig(vol)
 }
 
 // FillVolumeConfig populate volume with default config.
@@ -310,6 +316,16 @@ func (d *common) moveGPTAltHeader(devPath string) error {
 	return err
 }
 
+// GetHealth returns the health of the storage pool.
+func (d *common) GetHealth() (*api.StoragePoolStateHealth, error) {
+	return nil, ErrNotSupported
+}
+
+// Scrub starts a background scrub of the storage pool.
+func (d *common) Scrub() error {
+	return ErrNotSupported
+}
+
 // CreateVolume creates a new storage volume on disk.
 func (d *common) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Operation) error {
 	return ErrNotSupported
@@ -407,7 +423,7 @@ func (d *common) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs *
 }
 
 // BackupVolume creates an exported version of a volume.
-func (d *common) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, op *operations.Operation) error {
+func (d *common) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, parent string, op *operations.Operation) error {
 	return ErrNotSupported
 }
 
@@ -441,6 +457,11 @@ func (d *common) RestoreVolume(vol Volume, snapshotName string, op *operations.O
 	return ErrNotSupported
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+func (d *common) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	return nil, ErrNotSupported
+}
+
 // RenameVolumeSnapshot renames a snapshot.
 func (d *common) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	return ErrNotSupported
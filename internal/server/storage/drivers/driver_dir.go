@@ -1,8 +1,10 @@
 package drivers
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"golang.org/x/sys/unix"
@@ -12,6 +14,7 @@ import (
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
 )
 
 type dir struct {
@@ -38,7 +41,7 @@ func (d *dir) Info() Info {
 		Name:                         "dir",
 		Version:                      "1",
 		DefaultVMBlockFilesystemSize: deviceConfig.DefaultVMBlockFilesystemSize,
-		OptimizedImages:              false,
+		OptimizedImages:              d.reflinkEnabled(), // Images are only worth keeping when they can be reflinked.
 		PreservesInodes:              false,
 		Remote:                       d.isRemote(),
 		VolumeTypes:                  []VolumeType{VolumeTypeBucket, VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
@@ -93,6 +96,9 @@ func (d *dir) Create() error {
 		return fmt.Errorf("Source path '%s' isn't empty", sourcePath)
 	}
 
+	// Detect whether volumes can be copied using reflinks, ignoring any value provided by the user.
+	d.config["volatile.reflink"] = strconv.FormatBool(reflinkSupported(sourcePath))
+
 	return nil
 }
 
@@ -139,11 +145,27 @@ func (d *dir) Validate(config map[string]string) error {
 	//  default: -
 	//  shortdesc: Path to an existing directory
 
-	return d.validatePool(config, nil, nil)
+	rules := map[string]func(value string) error{
+		// gendoc:generate(entity=storage_dir, group=common, key=volatile.reflink)
+		//
+		// ---
+		//  type: bool
+		//  scope: local
+		//  default: detected on creation
+		//  shortdesc: Whether the filesystem supports reflinks, which are then used to snapshot and copy volumes
+		"volatile.reflink": validate.Optional(validate.IsBool),
+	}
+
+	return d.validatePool(config, rules, d.commonVolumeRules())
 }
 
 // Update applies any driver changes required from a configuration change.
 func (d *dir) Update(changedConfig map[string]string) error {
+	_, changed := changedConfig["volatile.reflink"]
+	if changed {
+		return errors.New("volatile.reflink cannot be changed")
+	}
+
 	return nil
 }
 
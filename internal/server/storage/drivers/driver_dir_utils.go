@@ -2,11 +2,17 @@ package drivers
 
 import (
 	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus/v6/internal/server/storage/quota"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/units"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // withoutGetVolID returns a copy of this struct but with a volIDFunc which will cause quotas to be skipped.
@@ -134,3 +140,54 @@ func (d *dir) setQuota(path string, volID int64, sizeBytes int64) error {
 	// Set the project quota size.
 	return quota.SetProjectQuota(path, projectID, sizeBytes)
 }
+
+// reflinkSupported returns whether the filesystem of path supports reflinks (such as XFS or btrfs).
+func reflinkSupported(path string) bool {
+	src, err := os.CreateTemp(path, ".incus-reflink-")
+	if err != nil {
+		return false
+	}
+
+	defer func() {
+		_ = src.Close()
+		_ = os.Remove(src.Name())
+	}()
+
+	_, err = src.WriteString("incus")
+	if err != nil {
+		return false
+	}
+
+	dst, err := os.CreateTemp(path, ".incus-reflink-")
+	if err != nil {
+		return false
+	}
+
+	defer func() {
+		_ = dst.Close()
+		_ = os.Remove(dst.Name())
+	}()
+
+	return unix.IoctlFileClone(int(dst.Fd()), int(src.Fd())) == nil
+}
+
+// reflinkEnabled returns whether the pool uses reflinks to copy volumes.
+func (d *dir) reflinkEnabled() bool {
+	return util.IsTrue(d.config["volatile.reflink"])
+}
+
+// reflinkCopy copies the content of srcPath (including the disk file of block volumes) into targetPath,
+// sharing the data of the files between both through reflinks.
+// On failure the content of targetPath is wiped so that the caller can fall back to a regular copy.
+func (d *dir) reflinkCopy(srcPath string, targetPath string) error {
+	d.Logger().Debug("Reflinking volume", logger.Ctx{"sourcePath": srcPath, "targetPath": targetPath})
+
+	_, err := subprocess.RunCommand("cp", "-a", "--reflink=always", srcPath+"/.", targetPath)
+	if err != nil {
+		_ = wipeDirectory(targetPath)
+
+		return fmt.Errorf("Failed reflinking %q to %q: %w", srcPath, targetPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package drivers
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// newTestDir returns a dir driver for a pool stored in a temporary directory, using reflinks when supported.
+func newTestDir(t *testing.T) *dir {
+	t.Setenv("INCUS_DIR", t.TempDir())
+
+	poolPath := GetPoolMountPath("pool")
+	require.NoError(t, os.MkdirAll(poolPath, 0o711))
+
+	getVolID := func(volType VolumeType, volName string) (int64, error) { return volIDQuotaSkip, nil }
+
+	d := &dir{}
+	d.init(nil, "pool", map[string]string{"volatile.reflink": "true"}, logger.AddContext(nil), getVolID, nil)
+
+	return d
+}
+
+// writeReflinkTestTree writes a small tree of files into path.
+func writeReflinkTestTree(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "file"), []byte(content), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "dir", "nested"), []byte(content), 0o600))
+	require.NoError(t, os.Symlink("file", filepath.Join(path, "link")))
+}
+
+// assertReflinkTestTree checks the content of a tree written by writeReflinkTestTree.
+func assertReflinkTestTree(t *testing.T, path string, content string) {
+	for _, name := range []string{"file", "dir/nested", "link"} {
+		data, err := os.ReadFile(filepath.Join(path, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data), name)
+	}
+
+	info, err := os.Stat(filepath.Join(path, "dir", "nested"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+// assertEmptyDir checks that path is an empty directory.
+func assertEmptyDir(t *testing.T, path string) {
+	entries, err := os.ReadDir(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func Test_reflinkSupported(t *testing.T) {
+	path := t.TempDir()
+
+	// Compare with an actual reflink copy on the same filesystem.
+	require.NoError(t, os.WriteFile(filepath.Join(path, "src"), []byte("incus"), 0o600))
+	expected := exec.Command("cp", "--reflink=always", filepath.Join(path, "src"), filepath.Join(path, "dst")).Run() == nil
+	require.NoError(t, os.Remove(filepath.Join(path, "src")))
+	_ = os.Remove(filepath.Join(path, "dst"))
+
+	assert.Equal(t, expected, reflinkSupported(path))
+
+	// The probe files are removed.
+	assertEmptyDir(t, path)
+
+	assert.False(t, reflinkSupported(filepath.Join(path, "missing")))
+}
+
+func Test_dir_reflinkCopy(t *testing.T) {
+	d := newTestDir(t)
+
+	srcPath := filepath.Join(t.TempDir(), "src")
+	writeReflinkTestTree(t, srcPath, "source")
+
+	targetPath := GetVolumeMountPath("pool", VolumeTypeCustom, "vol")
+	require.NoError(t, os.MkdirAll(targetPath, 0o711))
+
+	err := d.reflinkCopy(srcPath, targetPath)
+	if !reflinkSupported(targetPath) {
+		// The target is left empty for the caller to fall back to a regular copy.
+		assert.Error(t, err)
+		assertEmptyDir(t, targetPath)
+		return
+	}
+
+	require.NoError(t, err)
+	assertReflinkTestTree(t, targetPath, "source")
+}
+
+func Test_dir_reflinkRestore(t *testing.T) {
+	d := newTestDir(t)
+
+	vol := NewVolume(d, "pool", VolumeTypeCustom, ContentTypeFS, "vol", map[string]string{}, d.config)
+	writeReflinkTestTree(t, vol.MountPath(), "current")
+
+	snapVol, err := vol.NewSnapshot("snap0")
+	require.NoError(t, err)
+	writeReflinkTestTree(t, snapVol.MountPath(), "snapshot")
+
+	err = d.reflinkRestore(vol, snapVol.MountPath())
+	if !reflinkSupported(vol.MountPath()) {
+		// The volume is untouched when the snapshot can't be reflinked.
+		assert.Error(t, err)
+		assertReflinkTestTree(t, vol.MountPath(), "current")
+	} else {
+		require.NoError(t, err)
+		assertReflinkTestTree(t, vol.MountPath(), "snapshot")
+	}
+
+	// The snapshot is untouched and the temporary restore directory is removed.
+	assertReflinkTestTree(t, snapVol.MountPath(), "snapshot")
+
+	entries, err := os.ReadDir(filepath.Dir(vol.MountPath()))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "vol", entries[0].Name())
+}
+
+func Test_dir_CreateVolumeSnapshot_reflink(t *testing.T) {
+	d := newTestDir(t)
+
+	if !reflinkSupported(GetPoolMountPath("pool")) {
+		t.Skip("Reflinks aren't supported on the test filesystem")
+	}
+
+	vol := NewVolume(d, "pool", VolumeTypeCustom, ContentTypeFS, "vol", map[string]string{}, d.config)
+	writeReflinkTestTree(t, vol.MountPath(), "current")
+
+	snapVol, err := vol.NewSnapshot("snap0")
+	require.NoError(t, err)
+
+	require.NoError(t, d.CreateVolumeSnapshot(snapVol, nil))
+	assertReflinkTestTree(t, snapVol.MountPath(), "current")
+
+	// Changing the volume doesn't affect the snapshot sharing its data.
+	require.NoError(t, os.WriteFile(filepath.Join(vol.MountPath(), "file"), []byte("changed"), 0o644))
+	assertReflinkTestTree(t, snapVol.MountPath(), "current")
+}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"golang.org/x/sys/unix"
+
 	"github.com/lxc/incus/v6/internal/instancewriter"
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/rsync"
@@ -20,6 +22,7 @@ import (
 	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
 )
 
 // CreateVolume creates an empty volume and can optionally fill it by executing the supplied
@@ -62,8 +65,34 @@ func (d *dir) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 		}
 	}
 
+	// Encrypted volumes are initialized upfront and filled through their opened device.
+	devPath := rootBlockPath
+	if luksEncrypted(vol) {
+		sizeBytes, err := units.ParseByteSizeString(vol.ConfigSize())
+		if err != nil {
+			return err
+		}
+
+		_, err = ensureVolumeBlockFile(vol, rootBlockPath, luksDiskSizeBytes(vol, sizeBytes), false)
+		if err != nil {
+			return err
+		}
+
+		err = luksFormat(vol, rootBlockPath)
+		if err != nil {
+			return err
+		}
+
+		devPath, err = luksOpen(vol, rootBlockPath)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = luksClose(vol) }()
+	}
+
 	// Run the volume filler function if supplied.
-	err = genericRunFiller(d, vol, rootBlockPath, filler, false)
+	err = genericRunFiller(d, vol, devPath, filler, false)
 	if err != nil {
 		return err
 	}
@@ -80,14 +109,14 @@ func (d *dir) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 
 		// Ignore ErrCannotBeShrunk when setting size this just means the filler run above has needed to
 		// increase the volume size beyond the default block volume size.
-		_, err = ensureVolumeBlockFile(vol, rootBlockPath, sizeBytes, false)
+		_, err = ensureVolumeBlockFile(vol, rootBlockPath, luksDiskSizeBytes(vol, sizeBytes), false)
 		if err != nil && !errors.Is(err, ErrCannotBeShrunk) {
 			return err
 		}
 
 		// Move the GPT alt header to end of disk if needed and if filler specified.
 		if vol.IsVMBlock() && filler != nil && filler.Fill != nil {
-			err = d.moveGPTAltHeader(rootBlockPath)
+			err = d.moveGPTAltHeader(devPath)
 			if err != nil {
 				return err
 			}
@@ -101,7 +130,7 @@ func (d *dir) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 // CreateVolumeFromBackup restores a backup tarball onto the storage device.
 func (d *dir) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
 	// Run the generic backup unpacker
-	postHook, revertHook, err := genericVFSBackupUnpack(d.withoutGetVolID(), d.state.OS, vol, srcBackup.Snapshots, srcData, op)
+	postHook, revertHook, err := genericVFSBackupUnpack(d.withoutGetVolID(), d.state.OS, vol, srcBackup.Snapshots, srcBackup.ParentSnapshot, srcData, op)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -150,10 +179,96 @@ func (d *dir) CreateVolumeFromCopy(vol Volume, srcVol Volume, copySnapshots bool
 		}
 	}
 
+	// Use reflinks to share the data of the source volume when supported.
+	if d.reflinkEnabled() {
+		err = d.reflinkCopyVolume(vol, srcVol, srcSnapshots, op)
+		if err == nil {
+			return nil
+		}
+
+		d.logger.Warn("Failed reflinking volume, falling back to regular copy", logger.Ctx{"volName": vol.name, "err": err})
+	}
+
 	// Run the generic copy.
 	return genericVFSCopyVolume(d, d.setupInitialQuota, vol, srcVol, srcSnapshots, false, allowInconsistent, op)
 }
 
+// reflinkCopyVolume copies a volume and its snapshots using reflinks.
+func (d *dir) reflinkCopyVolume(vol Volume, srcVol Volume, srcSnapshots []Volume, op *operations.Operation) error {
+	if vol.contentType != srcVol.contentType {
+		return errors.New("Content type of source and target must be the same")
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	err := d.CreateVolume(vol, nil, op)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = d.DeleteVolume(vol, op) })
+
+	// Snapshots are plain directories, so they can be reflinked directly into the target snapshots.
+	for _, srcSnapshot := range srcSnapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(srcSnapshot.name)
+		snapVol := NewVolume(d, d.name, vol.volType, vol.contentType, GetSnapshotVolumeName(vol.name, snapName), vol.config, vol.poolConfig)
+
+		err := snapVol.EnsureMountPath(false)
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = d.DeleteVolumeSnapshot(snapVol, op) })
+
+		err = d.reflinkCopy(srcSnapshot.MountPath(), snapVol.MountPath())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = srcVol.MountTask(func(srcMountPath string, op *operations.Operation) error {
+		return d.reflinkCopy(srcMountPath, vol.MountPath())
+	}, op)
+	if err != nil {
+		return err
+	}
+
+	// The disk file now has the size of the source one, grow it to the requested size if needed.
+	if IsContentBlock(vol.contentType) {
+		sizeBytes, err := units.ParseByteSizeString(vol.ConfigSize())
+		if err != nil {
+			return err
+		}
+
+		rootBlockPath, err := d.GetVolumeDiskPath(vol)
+		if err != nil {
+			return err
+		}
+
+		resized, err := ensureVolumeBlockFile(vol, rootBlockPath, luksDiskSizeBytes(vol, sizeBytes), false)
+		if err != nil && !errors.Is(err, ErrCannotBeShrunk) {
+			return err
+		}
+
+		if vol.IsVMBlock() && resized {
+			err = luksWithDevice(vol, rootBlockPath, d.moveGPTAltHeader)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Ensure the copied directory has the correct permissions set.
+	err = vol.EnsureMountPath(false)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+	return nil
+}
+
 // CreateVolumeFromMigration creates a volume being sent via a migration.
 func (d *dir) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser, volTargetArgs migration.VolumeTargetArgs, preFiller *VolumeFiller, op *operations.Operation) error {
 	return genericVFSCreateVolumeFromMigration(d, d.setupInitialQuota, vol, conn, volTargetArgs, preFiller, op)
@@ -236,8 +351,30 @@ func (d *dir) FillVolumeConfig(vol Volume) error {
 	return nil
 }
 
+// commonVolumeRules returns validation rules which are common for pool and volume.
+func (d *dir) commonVolumeRules() map[string]func(value string) error {
+	return map[string]func(value string) error{
+		// gendoc:generate(entity=storage_volume_dir, group=common, key=block.encryption)
+		//
+		// ---
+		//  type: string
+		//  condition: instance or custom volume with content type `block`
+		//  default: same as `volume.block.encryption`
+		//  shortdesc: Encryption of the volume (`luks2`), can only be set on creation
+		"block.encryption": validate.Optional(validate.IsOneOf("luks2")),
+	}
+}
+
 // ValidateVolume validates the supplied volume config. Optionally removes invalid keys from the volume's config.
 func (d *dir) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=block.encryption.key)
+	//
+	// ---
+	//  type: string
+	//  condition: encrypted volume
+	//  default: randomly generated
+	//  shortdesc: Key used to unlock the encrypted volume, never returned once set
+
 	// gendoc:generate(entity=storage_volume_dir, group=common, key=initial.gid)
 	//
 	// ---
@@ -294,6 +431,76 @@ func (d *dir) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage volume
 
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=backups.compression_algorithm)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.compression_algorithm`
+	//  shortdesc: Compression algorithm to use for scheduled backups
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=backups.expiry)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.expiry`
+	//  shortdesc: {{backup_expiry_format}}
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=backups.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.schedule`
+	//  shortdesc: {{backup_schedule_format}}
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=backups.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.target`
+	//  shortdesc: {{backup_target_format}}
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=replication.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or empty to only replicate on request (the default)
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=replication.snapshots.retention)
+	//
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: `1`
+	//  shortdesc: Number of replication snapshots to keep on both servers
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=replication.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Name of the replication target (`replication.NAME.*` server configuration) that the volume is replicated to
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume pool
+	//  shortdesc: Storage pool of the replica on the target server
+
+	// gendoc:generate(entity=storage_volume_dir, group=common, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume project
+	//  shortdesc: Project of the replica on the target server
+
 	// gendoc:generate(entity=storage_volume_dir, group=common, key=snapshots.expiry)
 	//
 	// ---
@@ -326,7 +533,10 @@ func (d *dir) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.snapshot.schedule`
 	//  shortdesc: {{snapshot_schedule_format}}
 
-	err := d.validateVolume(vol, nil, removeUnknownKeys)
+	commonRules := d.commonVolumeRules()
+	luksValidateVolume(vol, commonRules)
+
+	err := d.validateVolume(vol, commonRules, removeUnknownKeys)
 	if err != nil {
 		return err
 	}
@@ -402,16 +612,24 @@ func (d *dir) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 			return err
 		}
 
-		resized, err := ensureVolumeBlockFile(vol, rootBlockPath, sizeBytes, allowUnsafeResize)
+		resized, err := ensureVolumeBlockFile(vol, rootBlockPath, luksDiskSizeBytes(vol, sizeBytes), allowUnsafeResize)
 		if err != nil {
 			return err
 		}
 
+		// Resize the opened device of encrypted volumes.
+		if resized {
+			err = luksResize(vol)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Move the GPT alt header to end of disk if needed and resize has taken place (not needed in
 		// unsafe resize mode as it is expected the caller will do all necessary post resize actions
 		// themselves).
 		if vol.IsVMBlock() && resized && !allowUnsafeResize {
-			err = d.moveGPTAltHeader(rootBlockPath)
+			err = luksWithDevice(vol, rootBlockPath, d.moveGPTAltHeader)
 			if err != nil {
 				return err
 			}
@@ -473,6 +691,19 @@ func (d *dir) MountVolume(vol Volume, op *operations.Operation) error {
 		}
 	}
 
+	// Open encrypted volumes.
+	if luksEncrypted(vol) {
+		rootBlockPath, err := d.GetVolumeDiskPath(vol)
+		if err != nil {
+			return err
+		}
+
+		_, err = luksOpen(vol, rootBlockPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	vol.MountRefCountIncrement() // From here on it is up to caller to call UnmountVolume() when done.
 	return nil
 }
@@ -493,6 +724,14 @@ func (d *dir) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Operat
 		return false, ErrInUse
 	}
 
+	// Close encrypted volumes.
+	if luksEncrypted(vol) && !keepBlockDev {
+		err = luksClose(vol)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	return false, nil
 }
 
@@ -508,8 +747,8 @@ func (d *dir) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs *mig
 
 // BackupVolume copies a volume (and optionally its snapshots) to a specified target path.
 // This driver does not support optimized backups.
-func (d *dir) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, op *operations.Operation) error {
-	return genericVFSBackupVolume(d, vol, writer, snapshots, op)
+func (d *dir) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, parent string, op *operations.Operation) error {
+	return genericVFSBackupVolume(d, vol, writer, snapshots, parent, op)
 }
 
 // CreateVolumeSnapshot creates a snapshot of a volume.
@@ -528,6 +767,17 @@ func (d *dir) CreateVolumeSnapshot(snapVol Volume, op *operations.Operation) err
 	snapPath := snapVol.MountPath()
 	reverter.Add(func() { _ = os.RemoveAll(snapPath) })
 
+	// Use reflinks to share the data of the parent volume when supported.
+	if d.reflinkEnabled() {
+		err = d.reflinkCopy(GetVolumeMountPath(d.name, snapVol.volType, parentName), snapPath)
+		if err == nil {
+			reverter.Success()
+			return nil
+		}
+
+		d.logger.Warn("Failed reflinking volume, falling back to regular copy", logger.Ctx{"volName": snapVol.name, "err": err})
+	}
+
 	if snapVol.contentType != ContentTypeBlock || snapVol.volType != VolumeTypeCustom {
 		var rsyncArgs []string
 
@@ -671,6 +921,16 @@ func (d *dir) RestoreVolume(vol Volume, snapshotName string, op *operations.Oper
 
 	volPath := vol.MountPath()
 
+	// Use reflinks to share the data of the snapshot when supported.
+	if d.reflinkEnabled() {
+		err = d.reflinkRestore(vol, srcPath)
+		if err == nil {
+			return nil
+		}
+
+		d.logger.Warn("Failed reflinking volume, falling back to regular copy", logger.Ctx{"volName": vol.name, "err": err})
+	}
+
 	// Restore filesystem volume.
 	if vol.contentType != ContentTypeBlock || vol.volType != VolumeTypeCustom {
 		var rsyncArgs []string
@@ -714,6 +974,46 @@ func (d *dir) RestoreVolume(vol Volume, snapshotName string, op *operations.Oper
 	return nil
 }
 
+// reflinkRestore replaces the content of the volume with a reflinked copy of srcPath.
+// The copy is made into a temporary directory which is only swapped with the volume directory once complete,
+// leaving the volume untouched on failure.
+func (d *dir) reflinkRestore(vol Volume, srcPath string) error {
+	volPath := vol.MountPath()
+
+	tmpPath, err := os.MkdirTemp(filepath.Dir(volPath), ".incus-restore-")
+	if err != nil {
+		return fmt.Errorf("Failed creating temporary restore directory: %w", err)
+	}
+
+	// Once swapped, the temporary directory holds the previous content of the volume.
+	defer func() { _ = os.RemoveAll(tmpPath) }()
+
+	err = d.reflinkCopy(srcPath, tmpPath)
+	if err != nil {
+		return err
+	}
+
+	err = unix.Renameat2(unix.AT_FDCWD, tmpPath, unix.AT_FDCWD, volPath, unix.RENAME_EXCHANGE)
+	if err != nil {
+		return fmt.Errorf("Failed swapping restored content into %q: %w", volPath, err)
+	}
+
+	// Apply the project quota of the volume to the restored content.
+	_, err = d.setupInitialQuota(vol)
+	if err != nil {
+		_ = unix.Renameat2(unix.AT_FDCWD, tmpPath, unix.AT_FDCWD, volPath, unix.RENAME_EXCHANGE)
+
+		return fmt.Errorf("Failed setting quota on restored volume: %w", err)
+	}
+
+	return nil
+}
+
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+func (d *dir) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	return genericVFSDiffVolumeSnapshot(d, snapVol, otherVol, false, op)
+}
+
 // RenameVolumeSnapshot renames a volume snapshot.
 func (d *dir) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	return genericVFSRenameVolumeSnapshot(d, snapVol, newSnapshotName, op)
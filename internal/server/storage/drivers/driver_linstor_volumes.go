@@ -184,6 +184,76 @@ func (d *linstor) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage volume
 
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=backups.compression_algorithm)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.compression_algorithm`
+	//  shortdesc: Compression algorithm to use for scheduled backups
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=backups.expiry)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.expiry`
+	//  shortdesc: {{backup_expiry_format}}
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=backups.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.schedule`
+	//  shortdesc: {{backup_schedule_format}}
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=backups.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.target`
+	//  shortdesc: {{backup_target_format}}
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=replication.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or empty to only replicate on request (the default)
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=replication.snapshots.retention)
+	//
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: `1`
+	//  shortdesc: Number of replication snapshots to keep on both servers
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=replication.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Name of the replication target (`replication.NAME.*` server configuration) that the volume is replicated to
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume pool
+	//  shortdesc: Storage pool of the replica on the target server
+
+	// gendoc:generate(entity=storage_volume_linstor, group=common, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume project
+	//  shortdesc: Project of the replica on the target server
+
 	// gendoc:generate(entity=storage_volume_linstor, group=common, key=snapshots.expiry)
 	//
 	// ---
@@ -969,6 +1039,12 @@ func (d *linstor) RestoreVolume(vol Volume, snapshotName string, op *operations.
 	return nil
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+// LINSTOR snapshots are taken at the block level so inode numbers are kept and renames can be detected.
+func (d *linstor) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	return genericVFSDiffVolumeSnapshot(d, snapVol, otherVol, true, op)
+}
+
 // RenameVolumeSnapshot is a no-op.
 func (d *linstor) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.name)
@@ -1360,11 +1436,11 @@ func (d *linstor) VolumeSnapshots(vol Volume, op *operations.Operation) ([]strin
 
 // BackupVolume copies a volume (and optionally its snapshots) to a specified target path.
 // This driver does not support optimized backups.
-func (d *linstor) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, op *operations.Operation) error {
-	return genericVFSBackupVolume(d, vol, writer, snapshots, op)
+func (d *linstor) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, parent string, op *operations.Operation) error {
+	return genericVFSBackupVolume(d, vol, writer, snapshots, parent, op)
 }
 
 // CreateVolumeFromBackup restores a backup tarball onto the storage device.
 func (d *linstor) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
-	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcData, op)
+	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcBackup.ParentSnapshot, srcData, op)
 }
@@ -939,6 +939,58 @@ func (d *lvm) GetResources() (*api.ResourcesStoragePool, error) {
 	return &res, nil
 }
 
+// GetHealth returns the health of the volume group and of its thinpool (if used).
+func (d *lvm) GetHealth() (*api.StoragePoolStateHealth, error) {
+	health := &api.StoragePoolStateHealth{Status: api.StoragePoolHealthHealthy, Messages: []string{}}
+
+	out, err := subprocess.RunCommand("vgs", d.config["lvm.vg_name"], "--noheadings", "-o", "vg_attr")
+	if err != nil {
+		return nil, err
+	}
+
+	// The fourth volume group attribute is set to "p" when physical volumes are missing.
+	attr := strings.TrimSpace(out)
+	if len(attr) > 3 && attr[3] == 'p' {
+		health.Status = api.StoragePoolHealthDegraded
+		health.Messages = append(health.Messages, "One or more physical volumes are missing from the volume group")
+	}
+
+	if !d.usesThinpool() {
+		return health, nil
+	}
+
+	volDevPath := d.lvmPath(d.config["lvm.vg_name"], "", "", d.thinpoolName())
+	status, metadataSize, metadataPerc, err := d.thinPoolHealth(volDevPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case "":
+	case "failed":
+		health.Status = api.StoragePoolHealthFailed
+		health.Messages = append(health.Messages, "The thinpool has failed")
+	default:
+		health.Status = api.StoragePoolHealthDegraded
+		health.Messages = append(health.Messages, fmt.Sprintf("The thinpool health status is %q", status))
+	}
+
+	if metadataPerc >= lvmThinpoolMetadataWarnPercent {
+		if health.Status == api.StoragePoolHealthHealthy {
+			health.Status = api.StoragePoolHealthDegraded
+		}
+
+		health.Messages = append(health.Messages, fmt.Sprintf("The thinpool metadata is %.1f%% full", metadataPerc))
+	}
+
+	health.Metadata = &api.ResourcesStoragePoolSpace{
+		Total: metadataSize,
+		Used:  uint64(float64(metadataSize) * (metadataPerc / 100)),
+	}
+
+	return health, nil
+}
+
 // roundVolumeBlockSizeBytes returns sizeBytes rounded up to the next multiple
 // of the volume group extent size.
 func (d *lvm) roundVolumeBlockSizeBytes(vol Volume, sizeBytes int64) (int64, error) {
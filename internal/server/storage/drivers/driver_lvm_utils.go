@@ -39,6 +39,9 @@ const lvmEscapedHyphen = "--"
 // lvmThinpoolDefaultName is the default name for the thinpool volume.
 const lvmThinpoolDefaultName = "IncusThinPool"
 
+// lvmThinpoolMetadataWarnPercent is the thinpool metadata usage above which the pool is reported as degraded.
+const lvmThinpoolMetadataWarnPercent = 80
+
 type lvmSourceType int
 
 const (
@@ -354,6 +357,8 @@ func (d *lvm) createLogicalVolume(vgName, thinPoolName string, vol Volume, makeT
 		return err
 	}
 
+	lvSizeBytes = luksDiskSizeBytes(vol, lvSizeBytes)
+
 	lvFullName := d.lvmFullVolumeName(vol.volType, vol.contentType, vol.name)
 
 	args := []string{
@@ -832,6 +837,45 @@ func (d *lvm) thinPoolVolumeUsage(volDevPath string) (uint64, uint64, error) {
 	return totalSize, usedSize, nil
 }
 
+// thinPoolHealth returns the health status, metadata size and metadata usage percentage of a thinpool.
+func (d *lvm) thinPoolHealth(volDevPath string) (string, uint64, float64, error) {
+	args := []string{
+		volDevPath,
+		"--noheadings",
+		"--units", "b",
+		"--nosuffix",
+		"--separator", ",",
+		"-o", "lv_health_status,lv_metadata_size,metadata_percent",
+	}
+
+	out, err := subprocess.RunCommand("lvs", args...)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	parts := util.SplitNTrimSpace(out, ",", -1, false)
+	if len(parts) < 3 {
+		return "", 0, 0, errors.New("Unexpected output from lvs command")
+	}
+
+	metadataSize, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("Failed parsing thinpool metadata size (%q): %w", parts[1], err)
+	}
+
+	// Used percentage is not available if the thinpool isn't activated.
+	if parts[2] == "" {
+		return parts[0], metadataSize, 0, nil
+	}
+
+	metadataPerc, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("Failed parsing thinpool metadata used percentage (%q): %w", parts[2], err)
+	}
+
+	return parts[0], metadataSize, metadataPerc, nil
+}
+
 // parseLogicalVolumeSnapshot parses a raw logical volume name (from lvs command) and checks whether it is a
 // snapshot of the supplied parent volume. Returns unescaped parsed snapshot name if snapshot volume recognised,
 // empty string if not. The parent is required due to limitations in the naming scheme that Incus has historically
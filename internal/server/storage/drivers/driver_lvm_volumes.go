@@ -58,6 +58,27 @@ func (d *lvm) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 		reverter.Add(func() { _ = d.DeleteVolume(fsVol, op) })
 	}
 
+	// Initialize the encryption of the volume.
+	if luksEncrypted(vol) {
+		activated, err := d.activateVolume(vol)
+		if err != nil {
+			return err
+		}
+
+		devPath, err := d.GetVolumeDiskPath(vol)
+		if err == nil {
+			err = luksFormat(vol, devPath)
+		}
+
+		if activated {
+			_, _ = d.deactivateVolume(vol)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
 	err = vol.MountTask(func(mountPath string, op *operations.Operation) error {
 		// Run the volume filler function if supplied.
 		if filler != nil && filler.Fill != nil {
@@ -70,6 +91,12 @@ func (d *lvm) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 				if err != nil {
 					return err
 				}
+
+				// Fill encrypted volumes through their opened device.
+				devPath, err = LUKSDevicePath(vol, devPath)
+				if err != nil {
+					return err
+				}
 			}
 
 			allowUnsafeResize := false
@@ -123,7 +150,7 @@ func (d *lvm) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 
 // CreateVolumeFromBackup restores a backup tarball onto the storage device.
 func (d *lvm) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
-	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcData, op)
+	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcBackup.ParentSnapshot, srcData, op)
 }
 
 // CreateVolumeFromCopy provides same-pool volume copying functionality.
@@ -321,6 +348,15 @@ func (d *lvm) commonVolumeRules() map[string]func(value string) error {
 		//  shortdesc: Mount options for block-backed file system volumes
 		"block.mount_options": validate.IsAny,
 
+		// gendoc:generate(entity=storage_volume_lvm, group=common, key=block.encryption)
+		//
+		// ---
+		//  type: string
+		//  condition: instance or custom volume with content type `block`
+		//  default: same as `volume.block.encryption`
+		//  shortdesc: Encryption of the volume (`luks2`), can only be set on creation
+		"block.encryption": validate.Optional(validate.IsOneOf("luks2")),
+
 		// gendoc:generate(entity=storage_volume_lvm, group=common, key=block.filesystem)
 		//
 		// ---
@@ -352,6 +388,14 @@ func (d *lvm) commonVolumeRules() map[string]func(value string) error {
 
 // ValidateVolume validates the supplied volume config.
 func (d *lvm) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=block.encryption.key)
+	//
+	// ---
+	//  type: string
+	//  condition: encrypted volume
+	//  default: randomly generated
+	//  shortdesc: Key used to unlock the encrypted volume, never returned once set
+
 	// gendoc:generate(entity=storage_volume_lvm, group=common, key=initial.gid)
 	//
 	// ---
@@ -408,6 +452,76 @@ func (d *lvm) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage volume
 
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=backups.compression_algorithm)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.compression_algorithm`
+	//  shortdesc: Compression algorithm to use for scheduled backups
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=backups.expiry)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.expiry`
+	//  shortdesc: {{backup_expiry_format}}
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=backups.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.schedule`
+	//  shortdesc: {{backup_schedule_format}}
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=backups.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.target`
+	//  shortdesc: {{backup_target_format}}
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=replication.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or empty to only replicate on request (the default)
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=replication.snapshots.retention)
+	//
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: `1`
+	//  shortdesc: Number of replication snapshots to keep on both servers
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=replication.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Name of the replication target (`replication.NAME.*` server configuration) that the volume is replicated to
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume pool
+	//  shortdesc: Storage pool of the replica on the target server
+
+	// gendoc:generate(entity=storage_volume_lvm, group=common, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume project
+	//  shortdesc: Project of the replica on the target server
+
 	// gendoc:generate(entity=storage_volume_lvm, group=common, key=snapshots.expiry)
 	//
 	// ---
@@ -449,6 +563,7 @@ func (d *lvm) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  shortdesc: Size/quota of the storage bucket
 
 	commonRules := d.commonVolumeRules()
+	luksValidateVolume(vol, commonRules)
 
 	// Disallow block.* settings for regular custom block volumes. These settings only make sense
 	// when using custom filesystem volumes. Incus will create the filesystem
@@ -545,6 +660,8 @@ func (d *lvm) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 		return err
 	}
 
+	sizeBytes = luksDiskSizeBytes(vol, sizeBytes)
+
 	// Read actual size of current volume.
 	volPath := d.lvmPath(d.config["lvm.vg_name"], vol.volType, vol.contentType, vol.name)
 	oldSizeBytes, err := d.logicalVolumeSize(volPath)
@@ -717,6 +834,12 @@ func (d *lvm) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 			}
 		}
 
+		// Resize the opened device of encrypted volumes.
+		err = luksResize(vol)
+		if err != nil {
+			return err
+		}
+
 		// Move the VM GPT alt header to end of disk if needed (not needed in unsafe resize mode as it is
 		// expected the caller will do all necessary post resize actions themselves).
 		if vol.IsVMBlock() && !allowUnsafeResize {
@@ -738,7 +861,7 @@ func (d *lvm) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 				return err
 			}
 
-			err = d.moveGPTAltHeader(volDevPath)
+			err = luksWithDevice(vol, volDevPath, d.moveGPTAltHeader)
 			if err != nil {
 				return err
 			}
@@ -921,6 +1044,19 @@ func (d *lvm) MountVolume(vol Volume, op *operations.Operation) error {
 				return err
 			}
 		}
+
+		// Open encrypted volumes.
+		if luksEncrypted(vol) {
+			volDevPath, err := d.GetVolumeDiskPath(vol)
+			if err != nil {
+				return err
+			}
+
+			_, err = luksOpen(vol, volDevPath)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	vol.MountRefCountIncrement() // From here on it is up to caller to call UnmountVolume() when done.
@@ -988,6 +1124,11 @@ func (d *lvm) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Operat
 				return false, ErrInUse
 			}
 
+			err = luksClose(vol)
+			if err != nil {
+				return false, err
+			}
+
 			_, err = d.deactivateVolume(vol)
 			if err != nil {
 				return false, err
@@ -1125,8 +1266,8 @@ func (d *lvm) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs *mig
 
 // BackupVolume copies a volume (and optionally its snapshots) to a specified target path.
 // This driver does not support optimized backups.
-func (d *lvm) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, _ bool, snapshots []string, op *operations.Operation) error {
-	return genericVFSBackupVolume(d, vol, writer, snapshots, op)
+func (d *lvm) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, _ bool, snapshots []string, parent string, op *operations.Operation) error {
+	return genericVFSBackupVolume(d, vol, writer, snapshots, parent, op)
 }
 
 // CreateVolumeSnapshot creates a snapshot of a volume.
@@ -1648,6 +1789,12 @@ func (d *lvm) RestoreVolume(vol Volume, snapshotName string, op *operations.Oper
 	return nil
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+// LVM snapshots are taken at the block level so inode numbers are kept and renames can be detected.
+func (d *lvm) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	return genericVFSDiffVolumeSnapshot(d, snapVol, otherVol, true, op)
+}
+
 // RenameVolumeSnapshot renames a volume snapshot.
 func (d *lvm) RenameVolumeSnapshot(snapVol Volume, newSnapshotName string, op *operations.Operation) error {
 	volPath := d.lvmPath(d.config["lvm.vg_name"], snapVol.volType, snapVol.contentType, snapVol.name)
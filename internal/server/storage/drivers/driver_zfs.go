@@ -144,6 +144,7 @@ func (d *zfs) Info() Info {
 		DefaultVMBlockFilesystemSize: deviceConfig.DefaultVMBlockFilesystemSize,
 		OptimizedImages:              true,
 		OptimizedBackups:             true,
+		OptimizedIncrementalBackups:  true,
 		PreservesInodes:              true,
 		Remote:                       d.isRemote(),
 		VolumeTypes:                  []VolumeType{VolumeTypeBucket, VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
@@ -786,6 +787,30 @@ func (d *zfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return &res, nil
 }
 
+// GetHealth returns the health of the zpool, including the status of the last scrub.
+func (d *zfs) GetHealth() (*api.StoragePoolStateHealth, error) {
+	poolName := strings.Split(d.config["zfs.pool_name"], "/")[0]
+
+	out, err := subprocess.RunCommand("zpool", "status", "-p", poolName)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.parsePoolStatus(out), nil
+}
+
+// Scrub starts a background scrub of the zpool.
+func (d *zfs) Scrub() error {
+	poolName := strings.Split(d.config["zfs.pool_name"], "/")[0]
+
+	_, err := subprocess.RunCommand("zpool", "scrub", poolName)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MigrationType returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *zfs) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
 	var rsyncFeatures []string
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -478,3 +479,172 @@ func (d *zfs) delegateDataset(vol Volume, pid int) error {
 func ZFSSupportsDelegation() bool {
 	return zfsDelegate
 }
+
+// parsePoolStatus parses the output of `zpool status` into the health of the pool.
+func (d *zfs) parsePoolStatus(output string) *api.StoragePoolStateHealth {
+	// Fields of the status output, each of which can span multiple lines.
+	fieldNames := []string{"pool", "id", "state", "status", "action", "see", "scan", "remove", "checkpoint", "config", "errors"}
+	fields := map[string]string{}
+
+	var field string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if found && slices.Contains(fieldNames, name) {
+			field = name
+			fields[field] = strings.TrimSpace(value)
+			continue
+		}
+
+		// Skip the vdev listing.
+		if field == "" || field == "config" {
+			continue
+		}
+
+		fields[field] = strings.TrimSpace(fields[field] + " " + line)
+	}
+
+	health := &api.StoragePoolStateHealth{Messages: []string{}}
+
+	switch fields["state"] {
+	case "ONLINE":
+		health.Status = api.StoragePoolHealthHealthy
+	case "DEGRADED":
+		health.Status = api.StoragePoolHealthDegraded
+	default:
+		health.Status = api.StoragePoolHealthFailed
+	}
+
+	if fields["status"] != "" {
+		health.Messages = append(health.Messages, fields["status"])
+	}
+
+	if fields["errors"] != "" && fields["errors"] != "No known data errors" {
+		health.Messages = append(health.Messages, fields["errors"])
+	}
+
+	scan := fields["scan"]
+	health.Scrub = &api.StoragePoolStateScrub{Status: "none"}
+
+	switch {
+	case strings.HasPrefix(scan, "scrub in progress"):
+		health.Scrub.Status = "running"
+	case strings.HasPrefix(scan, "scrub canceled"):
+		health.Scrub.Status = "canceled"
+	case strings.HasPrefix(scan, "scrub repaired"):
+		health.Scrub.Status = "finished"
+
+		_, after, found := strings.Cut(scan, " with ")
+		if found {
+			errorsStr, _, _ := strings.Cut(after, " ")
+			health.Scrub.Errors, _ = strconv.ParseUint(errorsStr, 10, 64)
+		}
+	}
+
+	return health
+}
+
+// getSnapshotDiff returns the paths which differ between the snapshot and a later snapshot of the same
+// dataset (or the dataset itself). The filesystem must be mounted on mountPath.
+func (d *zfs) getSnapshotDiff(snapshotDataset string, otherDataset string, mountPath string) ([]api.StorageVolumeSnapshotDiff, error) {
+	output, err := subprocess.RunCommand("zfs", "diff", "-H", snapshotDataset, otherDataset)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.parseDiff(output, mountPath)
+}
+
+// parseDiff parses the output of `zfs diff -H` into the list of changed paths relative to mountPath.
+func (d *zfs) parseDiff(output string, mountPath string) ([]api.StorageVolumeSnapshotDiff, error) {
+	diff := []api.StorageVolumeSnapshotDiff{}
+
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("Unexpected zfs diff output line %q", line)
+		}
+
+		paths := make([]string, 0, len(fields)-1)
+		for _, field := range fields[1:] {
+			path, err := d.unescapeDiffPath(field)
+			if err != nil {
+				return nil, err
+			}
+
+			if path != mountPath && !strings.HasPrefix(path, mountPath+"/") {
+				return nil, fmt.Errorf("Unexpected path %q outside of %q in zfs diff output", path, mountPath)
+			}
+
+			paths = append(paths, strings.TrimPrefix(path, mountPath))
+		}
+
+		// Skip the root of the filesystem.
+		if paths[0] == "" || paths[0] == "/" {
+			continue
+		}
+
+		entry := api.StorageVolumeSnapshotDiff{Path: paths[0]}
+
+		switch fields[0] {
+		case "+":
+			entry.Type = api.StorageVolumeSnapshotDiffAdded
+		case "-":
+			entry.Type = api.StorageVolumeSnapshotDiffDeleted
+		case "M":
+			entry.Type = api.StorageVolumeSnapshotDiffModified
+		case "R":
+			if len(paths) != 2 {
+				return nil, fmt.Errorf("Unexpected zfs diff output line %q", line)
+			}
+
+			entry.Type = api.StorageVolumeSnapshotDiffRenamed
+			entry.NewPath = paths[1]
+		default:
+			return nil, fmt.Errorf("Unknown zfs diff change type %q", fields[0])
+		}
+
+		diff = append(diff, entry)
+	}
+
+	sortSnapshotDiff(diff)
+
+	return diff, nil
+}
+
+// unescapeDiffPath decodes the characters escaped by `zfs diff` as a backslash followed by four octal digits.
+func (d *zfs) unescapeDiffPath(value string) (string, error) {
+	if !strings.Contains(value, `\`) {
+		return value, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			sb.WriteByte(value[i])
+			continue
+		}
+
+		if i+5 > len(value) {
+			return "", fmt.Errorf("Invalid escape sequence in zfs diff path %q", value)
+		}
+
+		char, err := strconv.ParseUint(value[i+1:i+5], 8, 8)
+		if err != nil {
+			return "", fmt.Errorf("Invalid escape sequence in zfs diff path %q: %w", value, err)
+		}
+
+		sb.WriteByte(byte(char))
+		i += 4
+	}
+
+	return sb.String(), nil
+}
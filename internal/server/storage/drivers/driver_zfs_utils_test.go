@@ -0,0 +1,171 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func Test_zfs_parsePoolStatus(t *testing.T) {
+	d := &zfs{}
+
+	tests := []struct {
+		name   string
+		output string
+		want   *api.StoragePoolStateHealth
+	}{
+		{
+			"Healthy pool after scrub",
+			`  pool: tank
+ state: ONLINE
+  scan: scrub repaired 0B in 00:00:01 with 0 errors on Sun Oct 11 00:24:01 2026
+config:
+
+	NAME        STATE     READ WRITE CKSUM
+	tank        ONLINE       0     0     0
+	  sda       ONLINE       0     0     0
+
+errors: No known data errors
+`,
+			&api.StoragePoolStateHealth{
+				Status:   api.StoragePoolHealthHealthy,
+				Messages: []string{},
+				Scrub:    &api.StoragePoolStateScrub{Status: "finished"},
+			},
+		},
+		{
+			"Degraded pool with scrub in progress",
+			`  pool: tank
+ state: DEGRADED
+status: One or more devices could not be used because the label is missing or
+	invalid.  Sufficient replicas exist for the pool to continue
+	functioning in a degraded state.
+action: Replace the device using 'zpool replace'.
+   see: https://openzfs.github.io/openzfs-docs/msg/ZFS-8000-4J
+  scan: scrub in progress since Sun Oct 11 00:24:01 2026
+	1.20G scanned at 410M/s, 0B issued at 0B/s, 4.50G total
+	0B repaired, 0.00% done, no estimated completion time
+config:
+
+	NAME        STATE     READ WRITE CKSUM
+	tank        DEGRADED     0     0     0
+	  mirror-0  DEGRADED     0     0     0
+	    sda     ONLINE       0     0     0
+	    sdb     UNAVAIL      0     0     0
+
+errors: 2 data errors, use '-v' for a list
+`,
+			&api.StoragePoolStateHealth{
+				Status: api.StoragePoolHealthDegraded,
+				Messages: []string{
+					"One or more devices could not be used because the label is missing or invalid.  Sufficient replicas exist for the pool to continue functioning in a degraded state.",
+					"2 data errors, use '-v' for a list",
+				},
+				Scrub: &api.StoragePoolStateScrub{Status: "running"},
+			},
+		},
+		{
+			"Faulted pool never scrubbed",
+			`  pool: tank
+ state: FAULTED
+  scan: none requested
+config:
+
+	NAME        STATE     READ WRITE CKSUM
+	tank        FAULTED      0     0     0
+`,
+			&api.StoragePoolStateHealth{
+				Status:   api.StoragePoolHealthFailed,
+				Messages: []string{},
+				Scrub:    &api.StoragePoolStateScrub{Status: "none"},
+			},
+		},
+		{
+			"Scrub with errors",
+			`  pool: tank
+ state: ONLINE
+  scan: scrub repaired 12K in 00:10:00 with 3 errors on Sun Oct 11 00:24:01 2026
+`,
+			&api.StoragePoolStateHealth{
+				Status:   api.StoragePoolHealthHealthy,
+				Messages: []string{},
+				Scrub:    &api.StoragePoolStateScrub{Status: "finished", Errors: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, d.parsePoolStatus(tt.output))
+		})
+	}
+}
+
+func Test_zfs_parseDiff(t *testing.T) {
+	d := &zfs{}
+	mountPath := "/var/lib/incus/storage-pools/default/custom/default_data"
+
+	tests := []struct {
+		name    string
+		output  string
+		want    []api.StorageVolumeSnapshotDiff
+		wantErr bool
+	}{
+		{
+			"All change types",
+			"M\t" + mountPath + "/\n" +
+				"+\t" + mountPath + "/new\n" +
+				"-\t" + mountPath + "/dir/old\n" +
+				"M\t" + mountPath + "/dir\n" +
+				"R\t" + mountPath + "/a\t" + mountPath + "/b\n",
+			[]api.StorageVolumeSnapshotDiff{
+				{Type: api.StorageVolumeSnapshotDiffRenamed, Path: "/a", NewPath: "/b"},
+				{Type: api.StorageVolumeSnapshotDiffModified, Path: "/dir"},
+				{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/dir/old"},
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/new"},
+			},
+			false,
+		},
+		{
+			"Escaped characters",
+			"+\t" + mountPath + "/with\\0040space\n",
+			[]api.StorageVolumeSnapshotDiff{
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/with space"},
+			},
+			false,
+		},
+		{
+			"No changes",
+			"",
+			[]api.StorageVolumeSnapshotDiff{},
+			false,
+		},
+		{
+			"Path outside of the mount path",
+			"+\t/var/lib/incus/other/file\n",
+			nil,
+			true,
+		},
+		{
+			"Unknown change type",
+			"X\t" + mountPath + "/file\n",
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.parseDiff(tt.output, mountPath)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
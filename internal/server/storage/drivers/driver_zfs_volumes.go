@@ -227,7 +227,7 @@ func (d *zfs) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 		}
 
 		// Create the volume dataset.
-		err = d.createVolume(d.dataset(vol, false), sizeBytes, opts...)
+		err = d.createVolume(d.dataset(vol, false), luksDiskSizeBytes(vol, sizeBytes), opts...)
 		if err != nil {
 			return err
 		}
@@ -235,6 +235,27 @@ func (d *zfs) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 		// After this point we'll have a volume, so setup revert.
 		reverter.Add(func() { _ = d.DeleteVolume(vol, op) })
 
+		// Initialize the encryption of the volume.
+		if luksEncrypted(vol) {
+			activated, err := d.activateVolume(vol)
+			if err != nil {
+				return err
+			}
+
+			devPath, err := d.GetVolumeDiskPath(vol)
+			if err == nil {
+				err = luksFormat(vol, devPath)
+			}
+
+			if activated {
+				_, _ = d.deactivateVolume(vol)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
 		if vol.contentType == ContentTypeFS {
 			// Wait up to 30 seconds for the device to appear.
 			ctx, cancel := context.WithTimeout(d.state.ShutdownCtx, 30*time.Second)
@@ -282,6 +303,12 @@ func (d *zfs) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 				if err != nil {
 					return err
 				}
+
+				// Fill encrypted volumes through their opened device.
+				devPath, err = LUKSDevicePath(vol, devPath)
+				if err != nil {
+					return err
+				}
 			}
 
 			allowUnsafeResize := false
@@ -364,7 +391,7 @@ func (d *zfs) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Oper
 func (d *zfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
 	// Handle the non-optimized tarballs through the generic unpacker.
 	if !*srcBackup.OptimizedStorage {
-		return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcData, op)
+		return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcBackup.ParentSnapshot, srcData, op)
 	}
 
 	volExists, err := d.HasVolume(vol)
@@ -372,7 +399,11 @@ func (d *zfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData
 		return nil, nil, err
 	}
 
-	if volExists {
+	// Incremental backups are received on top of the existing volume.
+	incremental := srcBackup.ParentSnapshot != ""
+	if incremental && !volExists {
+		return nil, nil, errors.New("Cannot apply incremental backup, volume doesn't exist on target")
+	} else if !incremental && volExists {
 		return nil, nil, errors.New("Cannot restore volume, already exists on target")
 	}
 
@@ -388,8 +419,10 @@ func (d *zfs) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData
 			_ = d.DeleteVolumeSnapshot(snapVol, op)
 		}
 
-		// And lastly the main volume.
-		_ = d.DeleteVolume(vol, op)
+		// And lastly the main volume (unless it existed before).
+		if !incremental {
+			_ = d.DeleteVolume(vol, op)
+		}
 	}
 
 	// Only execute the revert function if we have had an error internally.
@@ -1580,6 +1613,15 @@ func (d *zfs) HasVolume(vol Volume) (bool, error) {
 // commonVolumeRules returns validation rules which are common for pool and volume.
 func (d *zfs) commonVolumeRules() map[string]func(value string) error {
 	return map[string]func(value string) error{
+		// gendoc:generate(entity=storage_volume_zfs, group=common, key=block.encryption)
+		//
+		// ---
+		//  type: string
+		//  condition: instance or custom volume with content type `block`
+		//  default: same as `volume.block.encryption`
+		//  shortdesc: Encryption of the volume (`luks2`), can only be set on creation
+		"block.encryption": validate.Optional(validate.IsOneOf("luks2")),
+
 		// gendoc:generate(entity=storage_volume_zfs, group=common, key=block.filesystem)
 		//
 		// ---
@@ -1656,6 +1698,14 @@ func (d *zfs) commonVolumeRules() map[string]func(value string) error {
 
 // ValidateVolume validates the supplied volume config.
 func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=block.encryption.key)
+	//
+	// ---
+	//  type: string
+	//  condition: encrypted volume
+	//  default: randomly generated
+	//  shortdesc: Key used to unlock the encrypted volume, never returned once set
+
 	// gendoc:generate(entity=storage_volume_zfs, group=common, key=initial.gid)
 	//
 	// ---
@@ -1712,6 +1762,76 @@ func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  default: same as `volume.size`
 	//  shortdesc: Size/quota of the storage volume
 
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=backups.compression_algorithm)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.compression_algorithm`
+	//  shortdesc: Compression algorithm to use for scheduled backups
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=backups.expiry)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.expiry`
+	//  shortdesc: {{backup_expiry_format}}
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=backups.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.schedule`
+	//  shortdesc: {{backup_schedule_format}}
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=backups.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as `volume.backups.target`
+	//  shortdesc: {{backup_target_format}}
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=replication.schedule)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or empty to only replicate on request (the default)
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=replication.snapshots.retention)
+	//
+	// ---
+	//  type: integer
+	//  condition: custom volume
+	//  default: `1`
+	//  shortdesc: Number of replication snapshots to keep on both servers
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=replication.target)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  shortdesc: Name of the replication target (`replication.NAME.*` server configuration) that the volume is replicated to
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=replication.target.pool)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume pool
+	//  shortdesc: Storage pool of the replica on the target server
+
+	// gendoc:generate(entity=storage_volume_zfs, group=common, key=replication.target.project)
+	//
+	// ---
+	//  type: string
+	//  condition: custom volume
+	//  default: same as the volume project
+	//  shortdesc: Project of the replica on the target server
+
 	// gendoc:generate(entity=storage_volume_zfs, group=common, key=snapshots.expiry)
 	//
 	// ---
@@ -1753,6 +1873,7 @@ func (d *zfs) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 	//  shortdesc: Size/quota of the storage bucket
 
 	commonRules := d.commonVolumeRules()
+	luksValidateVolume(vol, commonRules)
 
 	// Disallow block.* settings for regular custom block volumes. These settings only make sense
 	// when using custom filesystem volumes with block mode enabled. Incus will create the filesystem
@@ -1938,6 +2059,8 @@ func (d *zfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 			return err
 		}
 
+		sizeBytes = luksDiskSizeBytes(vol, sizeBytes)
+
 		oldSizeBytesStr, err := d.getDatasetProperty(d.dataset(vol, false), "volsize")
 		if err != nil {
 			return err
@@ -2036,6 +2159,12 @@ func (d *zfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 			if err != nil {
 				return err
 			}
+
+			// Resize the opened device of encrypted volumes.
+			err = luksResize(vol)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Move the VM GPT alt header to end of disk if needed (not needed in unsafe resize mode as
@@ -2047,7 +2176,7 @@ func (d *zfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op
 					return err
 				}
 
-				return d.moveGPTAltHeader(devPath)
+				return luksWithDevice(vol, devPath, d.moveGPTAltHeader)
 			}, op)
 			if err != nil {
 				return err
@@ -2487,6 +2616,19 @@ func (d *zfs) MountVolume(vol Volume, op *operations.Operation) error {
 				return err
 			}
 		}
+
+		// Open encrypted volumes.
+		if luksEncrypted(vol) {
+			volPath, err := d.GetVolumeDiskPath(vol)
+			if err != nil {
+				return err
+			}
+
+			_, err = luksOpen(vol, volPath)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	vol.MountRefCountIncrement() // From here on it is up to caller to call UnmountVolume() when done.
@@ -2561,6 +2703,11 @@ func (d *zfs) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Operat
 				return false, ErrInUse
 			}
 
+			err = luksClose(vol)
+			if err != nil {
+				return false, err
+			}
+
 			// For block devices, we make them disappear if active.
 			ourUnmount, err = d.deactivateVolume(vol)
 			if err != nil {
@@ -2945,7 +3092,7 @@ func (d *zfs) readonlySnapshot(vol Volume) (string, revert.Hook, error) {
 }
 
 // BackupVolume creates an exported version of a volume.
-func (d *zfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, op *operations.Operation) error {
+func (d *zfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, optimized bool, snapshots []string, parent string, op *operations.Operation) error {
 	// Handle the non-optimized tarballs through the generic packer.
 	if !optimized {
 		// Because the generic backup method will not take a consistent backup if files are being modified
@@ -2964,12 +3111,18 @@ func (d *zfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, opt
 			vol.mountCustomPath = snapshotPath
 		}
 
-		return genericVFSBackupVolume(d, vol, writer, snapshots, op)
+		return genericVFSBackupVolume(d, vol, writer, snapshots, parent, op)
 	}
 
 	// Optimized backup.
 
-	if len(snapshots) > 0 {
+	if parent != "" {
+		// Check the parent and requested snapshots exist in storage.
+		err := vol.SnapshotsPresent(append([]string{parent}, snapshots...), op)
+		if err != nil {
+			return err
+		}
+	} else if len(snapshots) > 0 {
 		// Check requested snapshot match those in storage.
 		err := vol.SnapshotsMatch(snapshots, op)
 		if err != nil {
@@ -2980,7 +3133,7 @@ func (d *zfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, opt
 	// Backup VM config volumes first.
 	if vol.IsVMBlock() {
 		fsVol := vol.NewVMBlockFilesystemVolume()
-		err := d.BackupVolume(fsVol, writer, optimized, snapshots, op)
+		err := d.BackupVolume(fsVol, writer, optimized, snapshots, parent, op)
 		if err != nil {
 			return err
 		}
@@ -3039,14 +3192,24 @@ func (d *zfs) BackupVolume(vol Volume, writer instancewriter.InstanceWriter, opt
 		return tmpFile.Close()
 	}
 
-	// Handle snapshots.
+	// For incremental backups, everything is sent relative to the parent snapshot.
 	finalParent := ""
+	if parent != "" {
+		parentSnapshot, err := vol.NewSnapshot(parent)
+		if err != nil {
+			return err
+		}
+
+		finalParent = d.dataset(parentSnapshot, false)
+	}
+
+	// Handle snapshots.
 	if len(snapshots) > 0 {
 		for i, snapName := range snapshots {
 			snapshot, _ := vol.NewSnapshot(snapName)
 
 			// Figure out parent and current subvolumes.
-			parent := ""
+			parent := finalParent
 			if i > 0 {
 				oldSnapshot, _ := vol.NewSnapshot(snapshots[i-1])
 				parent = d.dataset(oldSnapshot, false)
@@ -3537,6 +3700,71 @@ func (d *zfs) RestoreVolume(vol Volume, snapshotName string, op *operations.Oper
 	return d.restoreVolume(vol, snapshotName, false, op)
 }
 
+// DiffVolumeSnapshot lists the paths which differ between a snapshot and another snapshot or the volume.
+func (d *zfs) DiffVolumeSnapshot(snapVol Volume, otherVol Volume, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	if snapVol.contentType != ContentTypeFS {
+		return nil, ErrNotSupported
+	}
+
+	// Filesystems on top of a ZFS volume can't be compared by ZFS.
+	if d.isBlockBacked(snapVol) {
+		return genericVFSDiffVolumeSnapshot(d, snapVol, otherVol, true, op)
+	}
+
+	fromDataset := d.dataset(snapVol, false)
+	toDataset := d.dataset(otherVol, false)
+
+	// ZFS only compares a snapshot with a later one, so swap them if needed and invert the result.
+	inverted := false
+	if otherVol.IsSnapshot() {
+		fromTXG, err := d.getDatasetProperty(fromDataset, "createtxg")
+		if err != nil {
+			return nil, err
+		}
+
+		toTXG, err := d.getDatasetProperty(toDataset, "createtxg")
+		if err != nil {
+			return nil, err
+		}
+
+		fromTXGInt, err := strconv.ParseUint(fromTXG, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing creation transaction of %q: %w", fromDataset, err)
+		}
+
+		toTXGInt, err := strconv.ParseUint(toTXG, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing creation transaction of %q: %w", toDataset, err)
+		}
+
+		if toTXGInt < fromTXGInt {
+			fromDataset, toDataset = toDataset, fromDataset
+			inverted = true
+		}
+	}
+
+	// The parent filesystem must be mounted as ZFS reports paths under its mount point.
+	parentName, _, _ := api.GetParentAndSnapshotName(snapVol.Name())
+	parentVol := NewVolume(d, d.Name(), snapVol.volType, snapVol.contentType, parentName, snapVol.config, snapVol.poolConfig)
+
+	var diff []api.StorageVolumeSnapshotDiff
+	err := parentVol.MountTask(func(mountPath string, op *operations.Operation) error {
+		var err error
+
+		diff, err = d.getSnapshotDiff(fromDataset, toDataset, mountPath)
+		return err
+	}, op)
+	if err != nil {
+		return nil, err
+	}
+
+	if inverted {
+		return invertSnapshotDiff(diff), nil
+	}
+
+	return diff, nil
+}
+
 func (d *zfs) restoreVolume(vol Volume, snapshotName string, migration bool, op *operations.Operation) error {
 	// Get the list of snapshots.
 	entries, err := d.getDatasets(d.dataset(vol, false), "snapshot")
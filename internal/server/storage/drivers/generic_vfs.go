@@ -1,6 +1,7 @@
 package drivers
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,8 +11,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"golang.org/x/sys/unix"
+	"gopkg.in/yaml.v2"
+
 	"github.com/lxc/incus/v6/internal/instancewriter"
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/migration"
@@ -19,6 +24,7 @@ import (
 	localMigration "github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/storage/blockdiff"
 	"github.com/lxc/incus/v6/internal/server/sys"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
@@ -36,6 +42,12 @@ const genericVolumeBlockExtension = "img"
 // genericVolumeDiskFile used to indicate the file name used for block volume disk files.
 const genericVolumeDiskFile = "root.img"
 
+// genericVolumeBlockDiffExtension extension used for changed blocks of generic block volumes in incremental backups.
+const genericVolumeBlockDiffExtension = "img.diff"
+
+// genericVolumeDeletedExtension extension used for the list of removed files in incremental backups.
+const genericVolumeDeletedExtension = "deleted.yaml"
+
 // genericISOVolumeSuffix suffix used for generic iso content type volumes.
 const genericISOVolumeSuffix = ".iso"
 
@@ -522,8 +534,16 @@ func genericVFSGetVolumeDiskPath(vol Volume) (string, error) {
 }
 
 // genericVFSBackupVolume is a generic BackupVolume implementation for VFS-only drivers.
-func genericVFSBackupVolume(d Driver, vol Volume, writer instancewriter.InstanceWriter, snapshots []string, op *operations.Operation) error {
-	if len(snapshots) > 0 {
+// For incremental backups, filesystem volumes only include the files changed since the parent snapshot along
+// with a list of removed files, and block volumes only include the changed blocks.
+func genericVFSBackupVolume(d Driver, vol Volume, writer instancewriter.InstanceWriter, snapshots []string, parent string, op *operations.Operation) error {
+	if parent != "" {
+		// Check the parent and requested snapshots exist in storage.
+		err := vol.SnapshotsPresent(append([]string{parent}, snapshots...), op)
+		if err != nil {
+			return err
+		}
+	} else if len(snapshots) > 0 {
 		// Check requested snapshot match those in storage.
 		err := vol.SnapshotsMatch(snapshots, op)
 		if err != nil {
@@ -532,7 +552,16 @@ func genericVFSBackupVolume(d Driver, vol Volume, writer instancewriter.Instance
 	}
 
 	// Define a function that can copy a volume into the backup target location.
-	backupVolume := func(v Volume, prefix string) error {
+	// When base is set, only the differences from it are copied.
+	backupVolume := func(v Volume, base *Volume, prefix string) error {
+		if base != nil {
+			return base.MountTask(func(baseMountPath string, op *operations.Operation) error {
+				return v.MountTask(func(mountPath string, op *operations.Operation) error {
+					return genericVFSBackupVolumeDiff(d, vol, v, *base, writer, prefix, mountPath, baseMountPath)
+				}, op)
+			}, op)
+		}
+
 		return v.MountTask(func(mountPath string, op *operations.Operation) error {
 			// Reset hard link cache as we are copying a new volume (instance or snapshot).
 			writer.ResetHardLinkMap()
@@ -672,6 +701,17 @@ func genericVFSBackupVolume(d Driver, vol Volume, writer instancewriter.Instance
 		}, op)
 	}
 
+	// For incremental backups, each volume is diffed against the previous one starting from the parent.
+	var base *Volume
+	if parent != "" {
+		parentVol, err := vol.NewSnapshot(parent)
+		if err != nil {
+			return err
+		}
+
+		base = &parentVol
+	}
+
 	// Handle snapshots.
 	if len(snapshots) > 0 {
 		snapshotsPrefix := "backup/snapshots"
@@ -688,10 +728,14 @@ func genericVFSBackupVolume(d Driver, vol Volume, writer instancewriter.Instance
 				return err
 			}
 
-			err = backupVolume(snapVol, prefix)
+			err = backupVolume(snapVol, base, prefix)
 			if err != nil {
 				return err
 			}
+
+			if base != nil {
+				base = &snapVol
+			}
 		}
 	}
 
@@ -703,20 +747,504 @@ func genericVFSBackupVolume(d Driver, vol Volume, writer instancewriter.Instance
 		prefix = "backup/volume"
 	}
 
-	err := backupVolume(vol, prefix)
+	err := backupVolume(vol, base, prefix)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// genericVFSBackupVolumeDiff writes the differences between the mounted volume v and its mounted base
+// snapshot to the backup. Changed files are written under prefix and the list of removed files is written
+// to the prefix's deleted list file. For block volumes, the changed blocks are written to the prefix's block
+// diff file.
+func genericVFSBackupVolumeDiff(d Driver, vol Volume, v Volume, base Volume, writer instancewriter.InstanceWriter, prefix string, mountPath string, baseMountPath string) error {
+	// Reset hard link cache as we are copying a new volume (instance or snapshot).
+	writer.ResetHardLinkMap()
+
+	var exclude []string // Files to exclude from the filesystem diff, relative to the mount path.
+	var blockPath, baseBlockPath string
+	if v.contentType == ContentTypeBlock || v.contentType == ContentTypeISO {
+		var err error
+
+		blockPath, err = d.GetVolumeDiskPath(v)
+		if err != nil {
+			return fmt.Errorf("Error getting block volume disk path: %w", err)
+		}
+
+		baseBlockPath, err = d.GetVolumeDiskPath(base)
+		if err != nil {
+			return fmt.Errorf("Error getting parent block volume disk path: %w", err)
+		}
+
+		if !linux.IsBlockdevPath(blockPath) {
+			exclude = append(exclude, strings.TrimPrefix(blockPath, mountPath))
+		}
+	}
+
+	// Diff the filesystem parts of the volume (for VMs that is the config volume).
+	if !v.IsCustomBlock() && v.contentType != ContentTypeISO {
+		// Follow the target if mountPath is a symlink.
+		target, err := os.Readlink(mountPath)
+		if err == nil {
+			_, err = os.Stat(target)
+			if err == nil {
+				mountPath = target
+			}
+		}
+
+		d.Logger().Debug("Copying changed volume files", logger.Ctx{"sourcePath": mountPath, "parentPath": baseMountPath, "prefix": prefix})
+
+		root, err := os.OpenRoot(mountPath)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = root.Close() }()
+
+		baseRoot, err := os.OpenRoot(baseMountPath)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = baseRoot.Close() }()
+
+		var deleted []string
+		err = filepath.Walk(mountPath, func(srcPath string, fi os.FileInfo, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					logger.Warnf("File vanished during export: %q, skipping", srcPath)
+					return nil
+				}
+
+				return fmt.Errorf("Error walking file during export: %q: %w", srcPath, err)
+			}
+
+			relPath := strings.TrimPrefix(srcPath, mountPath)
+			if util.StringHasPrefix(relPath, exclude...) {
+				return nil
+			}
+
+			baseFi, err := baseRoot.Lstat(genericVFSRelPath(relPath))
+			if err == nil {
+				// Directories are always included so that their metadata gets restored.
+				if !fi.IsDir() && !genericVFSFileChanged(root, relPath, fi, baseRoot, relPath, baseFi) {
+					return nil
+				}
+
+				// Entries changing type must be removed before being unpacked.
+				if fi.Mode().Type() != baseFi.Mode().Type() {
+					deleted = append(deleted, relPath)
+				}
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			name := filepath.Join(prefix, relPath)
+			err = writer.WriteFile(name, srcPath, fi, true)
+			if err != nil {
+				return fmt.Errorf("Error adding %q as %q to tarball: %w", srcPath, name, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Record the entries which have been removed since the base.
+		err = filepath.Walk(baseMountPath, func(basePath string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath := strings.TrimPrefix(basePath, baseMountPath)
+			if relPath == "" || util.StringHasPrefix(relPath, exclude...) {
+				return nil
+			}
+
+			_, err = root.Lstat(genericVFSRelPath(relPath))
+			if err == nil {
+				return nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			deleted = append(deleted, relPath)
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		deletedYAML, err := yaml.Marshal(deleted)
+		if err != nil {
+			return err
+		}
+
+		fi := instancewriter.FileInfo{
+			FileName:    fmt.Sprintf("%s.%s", prefix, genericVolumeDeletedExtension),
+			FileSize:    int64(len(deletedYAML)),
+			FileMode:    0o600,
+			FileModTime: time.Now(),
+		}
+
+		err = writer.WriteFileFromReader(bytes.NewReader(deletedYAML), &fi)
+		if err != nil {
+			return fmt.Errorf("Error writing deleted files list: %w", err)
+		}
+	}
+
+	if blockPath == "" {
+		return nil
+	}
+
+	// Diff the block device.
+	from, err := os.Open(blockPath)
+	if err != nil {
+		return fmt.Errorf("Error opening file for reading %q: %w", blockPath, err)
+	}
+
+	defer func() { _ = from.Close() }()
+
+	baseFrom, err := os.Open(baseBlockPath)
+	if err != nil {
+		return fmt.Errorf("Error opening file for reading %q: %w", baseBlockPath, err)
+	}
+
+	defer func() { _ = baseFrom.Close() }()
+
+	blockDiskSize, err := BlockDiskSizeBytes(blockPath)
+	if err != nil {
+		return fmt.Errorf("Error getting block device size %q: %w", blockPath, err)
+	}
+
+	baseBlockDiskSize, err := BlockDiskSizeBytes(baseBlockPath)
+	if err != nil {
+		return fmt.Errorf("Error getting block device size %q: %w", baseBlockPath, err)
+	}
+
+	// Use the configured volume size if available as the disk file may be larger.
+	fileSize, err := strconv.ParseInt(vol.config["size"], 10, 64)
+	if err != nil {
+		fileSize = blockDiskSize
+	}
+
+	name := fmt.Sprintf("%s.%s", prefix, genericVolumeBlockDiffExtension)
+	d.Logger().Debug("Comparing block volume", logger.Ctx{"sourcePath": blockPath, "parentPath": baseBlockPath, "file": name, "size": fileSize})
+
+	diff, err := blockdiff.Compare(baseFrom, baseBlockDiskSize, from, fileSize, blockdiff.DefaultBlockSize)
 	if err != nil {
 		return err
 	}
 
+	fi := instancewriter.FileInfo{
+		FileName:    name,
+		FileSize:    diff.EncodedSize(),
+		FileMode:    0o600,
+		FileModTime: time.Now(),
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		_ = pipeWriter.CloseWithError(diff.Encode(pipeWriter, from))
+	}()
+
+	defer func() { _ = pipeReader.Close() }()
+
+	err = writer.WriteFileFromReader(pipeReader, &fi)
+	if err != nil {
+		return fmt.Errorf("Error copying changed blocks of %q as %q to tarball: %w", blockPath, name, err)
+	}
+
 	return nil
 }
 
+// genericVFSFileChanged returns whether the file at relPath within root differs from the file at baseRelPath
+// within baseRoot.
+func genericVFSFileChanged(root *os.Root, relPath string, fi os.FileInfo, baseRoot *os.Root, baseRelPath string, baseFi os.FileInfo) bool {
+	if fi.Mode() != baseFi.Mode() || fi.Size() != baseFi.Size() || !fi.ModTime().Equal(baseFi.ModTime()) {
+		return true
+	}
+
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	baseSt, baseOk := baseFi.Sys().(*syscall.Stat_t)
+	if ok && baseOk && (st.Uid != baseSt.Uid || st.Gid != baseSt.Gid) {
+		return true
+	}
+
+	if fi.Mode().Type() == os.ModeSymlink {
+		target, err := genericVFSReadlinkBeneath(root, relPath)
+		if err != nil {
+			return true
+		}
+
+		baseTarget, err := genericVFSReadlinkBeneath(baseRoot, baseRelPath)
+		if err != nil {
+			return true
+		}
+
+		return target != baseTarget
+	}
+
+	return false
+}
+
+// genericVFSRelPath converts a path relative to the root of a volume into a path usable with os.Root.
+func genericVFSRelPath(path string) string {
+	relPath := strings.TrimPrefix(filepath.Clean(string(filepath.Separator)+path), string(filepath.Separator))
+	if relPath == "" {
+		return "."
+	}
+
+	return relPath
+}
+
+// genericVFSReadlinkBeneath returns the target of the symlink at relPath, resolving its parent directories
+// within root.
+func genericVFSReadlinkBeneath(root *os.Root, relPath string) (string, error) {
+	relPath = genericVFSRelPath(relPath)
+
+	dir, err := root.Open(filepath.Dir(relPath))
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = dir.Close() }()
+
+	buf := make([]byte, unix.PathMax)
+	n, err := unix.Readlinkat(int(dir.Fd()), filepath.Base(relPath), buf)
+	if err != nil {
+		return "", &fs.PathError{Op: "readlinkat", Path: relPath, Err: err}
+	}
+
+	return string(buf[:n]), nil
+}
+
+// genericVFSDiffVolumeSnapshot mounts the snapshot and the snapshot or volume it is compared with and lists
+// the paths which differ between them. When stableInodes is set, the storage driver keeps the inode numbers
+// across snapshots which allows detecting renamed paths.
+func genericVFSDiffVolumeSnapshot(d Driver, snapVol Volume, otherVol Volume, stableInodes bool, op *operations.Operation) ([]api.StorageVolumeSnapshotDiff, error) {
+	if snapVol.contentType != ContentTypeFS {
+		return nil, ErrNotSupported
+	}
+
+	var diff []api.StorageVolumeSnapshotDiff
+	err := snapVol.MountTask(func(snapPath string, op *operations.Operation) error {
+		return otherVol.MountTask(func(otherPath string, op *operations.Operation) error {
+			var err error
+
+			d.Logger().Debug("Comparing volume files", logger.Ctx{"snapshotPath": snapPath, "otherPath": otherPath})
+
+			diff, err = genericVFSDiffPaths(snapPath, otherPath, stableInodes)
+			return err
+		}, op)
+	}, op)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// genericVFSDiffPaths walks both directory trees and lists the paths which differ between them, as changes
+// going from basePath to otherPath. Paths are relative to the root of the trees.
+func genericVFSDiffPaths(basePath string, otherPath string, stableInodes bool) ([]api.StorageVolumeSnapshotDiff, error) {
+	diff := []api.StorageVolumeSnapshotDiff{}
+	added := map[string]os.FileInfo{}
+	deleted := map[string]os.FileInfo{}
+	var addedPaths, deletedPaths []string
+
+	// Directories which don't exist as directories on the other side. Their entries are looked up through
+	// their parent rather than through symlinks which may have replaced the directory.
+	missingBaseDirs := map[string]bool{}
+	missingOtherDirs := map[string]bool{}
+
+	baseRoot, err := os.OpenRoot(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = baseRoot.Close() }()
+
+	otherRoot, err := os.OpenRoot(otherPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = otherRoot.Close() }()
+
+	// Record the entries which have been added or modified.
+	err = filepath.Walk(otherPath, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				logger.Warnf("File vanished during diff: %q, skipping", path)
+				return nil
+			}
+
+			return fmt.Errorf("Error walking file during diff: %q: %w", path, err)
+		}
+
+		relPath := strings.TrimPrefix(path, otherPath)
+		if relPath == "" {
+			return nil
+		}
+
+		var baseFi os.FileInfo
+		if !missingBaseDirs[filepath.Dir(relPath)] {
+			baseFi, err = baseRoot.Lstat(genericVFSRelPath(relPath))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+
+		if baseFi == nil {
+			if fi.IsDir() {
+				missingBaseDirs[relPath] = true
+			}
+
+			added[relPath] = fi
+			addedPaths = append(addedPaths, relPath)
+			return nil
+		}
+
+		if fi.IsDir() && !baseFi.IsDir() {
+			missingBaseDirs[relPath] = true
+		}
+
+		// Entries changing type are reported as modified.
+		if genericVFSFileChanged(otherRoot, relPath, fi, baseRoot, relPath, baseFi) {
+			diff = append(diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffModified, Path: relPath})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the entries which have been removed.
+	err = filepath.Walk(basePath, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("Error walking file during diff: %q: %w", path, err)
+		}
+
+		relPath := strings.TrimPrefix(path, basePath)
+		if relPath == "" {
+			return nil
+		}
+
+		if !missingOtherDirs[filepath.Dir(relPath)] {
+			otherFi, err := otherRoot.Lstat(genericVFSRelPath(relPath))
+			if err == nil {
+				if fi.IsDir() && !otherFi.IsDir() {
+					missingOtherDirs[relPath] = true
+				}
+
+				return nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+
+		if fi.IsDir() {
+			missingOtherDirs[relPath] = true
+		}
+
+		deleted[relPath] = fi
+		deletedPaths = append(deletedPaths, relPath)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Match the removed entries with the added entries using the same inode to find the renamed ones.
+	renames := map[string]string{}
+	renamedTo := map[string]bool{}
+	if stableInodes {
+		addedInodes := make(map[uint64]string, len(addedPaths))
+		for _, relPath := range addedPaths {
+			st, ok := added[relPath].Sys().(*syscall.Stat_t)
+			if !ok {
+				continue
+			}
+
+			_, found := addedInodes[st.Ino]
+			if !found {
+				addedInodes[st.Ino] = relPath
+			}
+		}
+
+		for _, relPath := range deletedPaths {
+			st, ok := deleted[relPath].Sys().(*syscall.Stat_t)
+			if !ok {
+				continue
+			}
+
+			newPath, found := addedInodes[st.Ino]
+			if !found || added[newPath].Mode().Type() != deleted[relPath].Mode().Type() {
+				continue
+			}
+
+			renames[relPath] = newPath
+			renamedTo[newPath] = true
+			delete(addedInodes, st.Ino)
+		}
+	}
+
+	for _, relPath := range addedPaths {
+		if !renamedTo[relPath] {
+			diff = append(diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffAdded, Path: relPath})
+		}
+	}
+
+	for _, relPath := range deletedPaths {
+		newPath, found := renames[relPath]
+		if !found {
+			diff = append(diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffDeleted, Path: relPath})
+			continue
+		}
+
+		// Entries moved along with their parent directory are only reported if they changed.
+		if !genericVFSRenameImplied(renames, relPath, newPath) {
+			diff = append(diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffRenamed, Path: relPath, NewPath: newPath})
+		} else if genericVFSFileChanged(otherRoot, newPath, added[newPath], baseRoot, relPath, deleted[relPath]) {
+			diff = append(diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffModified, Path: newPath})
+		}
+	}
+
+	sortSnapshotDiff(diff)
+
+	return diff, nil
+}
+
+// genericVFSRenameImplied returns whether the rename of oldPath to newPath results from the rename of one
+// of its parent directories.
+func genericVFSRenameImplied(renames map[string]string, oldPath string, newPath string) bool {
+	for parent := filepath.Dir(oldPath); parent != "/" && parent != "."; parent = filepath.Dir(parent) {
+		newParent, found := renames[parent]
+		if found && newPath == newParent+strings.TrimPrefix(oldPath, parent) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // genericVFSBackupUnpack unpacks a non-optimized backup tarball through a storage driver.
 // Returns a post hook function that should be called once the database entries for the restored backup have been
 // created and a revert function that can be used to undo the actions this function performs should something
 // subsequently fail. For VolumeTypeCustom volumes, a nil post hook is returned as it is expected that the DB
 // record be created before the volume is unpacked due to differences in the archive format that allows this.
-func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []string, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
+// When parent is set, the tarball is an incremental backup which is applied on top of the existing volume after
+// restoring it to the parent snapshot.
+func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []string, parent string, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
 	// Define function to unpack a volume from a backup tarball file.
 	unpackVolume := func(r io.ReadSeeker, tarArgs []string, unpacker []string, srcPrefix string, mountPath string) error {
 		volTypeName := "container"
@@ -726,10 +1254,18 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 			volTypeName = "custom"
 		}
 
-		// Clear the volume ready for unpack.
-		err := wipeDirectory(mountPath)
-		if err != nil {
-			return fmt.Errorf("Error clearing volume before unpack: %w", err)
+		if parent == "" {
+			// Clear the volume ready for unpack.
+			err := wipeDirectory(mountPath)
+			if err != nil {
+				return fmt.Errorf("Error clearing volume before unpack: %w", err)
+			}
+		} else if !vol.IsCustomBlock() {
+			// Remove the files which were removed since the previous backup.
+			err := genericVFSBackupApplyDeleted(r, unpacker, fmt.Sprintf("%s.%s", srcPrefix, genericVolumeDeletedExtension), mountPath)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Unpack the filesystem parts of the volume (for containers and custom filesystem volumes that is
@@ -795,6 +1331,9 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 			}
 
 			srcFile := fmt.Sprintf("%s.%s", srcPrefix, genericVolumeBlockExtension)
+			if parent != "" {
+				srcFile = fmt.Sprintf("%s.%s", srcPrefix, genericVolumeBlockDiffExtension)
+			}
 
 			tr, cancelFunc, err := archive.CompressedTarReader(context.Background(), r, unpacker, mountPath)
 			if err != nil {
@@ -813,6 +1352,10 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 					return err
 				}
 
+				if hdr.Name == srcFile && parent != "" {
+					return genericVFSBackupApplyBlockDiff(d, vol, tr, targetPath, op)
+				}
+
 				if hdr.Name == srcFile {
 					var allowUnsafeResize bool
 
@@ -888,17 +1431,29 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 		return nil, nil, err
 	}
 
-	if volExists {
-		return nil, nil, errors.New("Cannot restore volume, already exists on target")
-	}
+	if parent != "" {
+		if !volExists {
+			return nil, nil, errors.New("Cannot apply incremental backup, volume doesn't exist on target")
+		}
 
-	// Create new empty volume.
-	err = d.CreateVolume(vol, nil, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+		// Reset the volume to the state the incremental backup is relative to.
+		err = d.RestoreVolume(vol, parent, op)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed restoring volume to parent snapshot %q: %w", parent, err)
+		}
+	} else {
+		if volExists {
+			return nil, nil, errors.New("Cannot restore volume, already exists on target")
+		}
+
+		// Create new empty volume.
+		err = d.CreateVolume(vol, nil, nil)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	reverter.Add(func() { _ = d.DeleteVolume(vol, op) })
+		reverter.Add(func() { _ = d.DeleteVolume(vol, op) })
+	}
 
 	if len(snapshots) > 0 {
 		// Create new snapshots directory.
@@ -992,6 +1547,140 @@ func genericVFSBackupUnpack(d Driver, sysOS *sys.OS, vol Volume, snapshots []str
 	return postHook, cleanup, nil
 }
 
+// genericVFSBackupApplyDeleted removes the files listed in the named deleted list of an incremental backup.
+func genericVFSBackupApplyDeleted(r io.ReadSeeker, unpacker []string, name string, mountPath string) error {
+	tr, cancelFunc, err := archive.CompressedTarReader(context.Background(), r, unpacker, mountPath)
+	if err != nil {
+		return err
+	}
+
+	defer cancelFunc()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break // End of archive.
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if hdr.Name != name {
+			continue
+		}
+
+		var deleted []string
+		err = yaml.NewDecoder(tr).Decode(&deleted)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("Failed parsing deleted files list %q: %w", name, err)
+		}
+
+		root, err := os.OpenRoot(mountPath)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = root.Close() }()
+
+		for _, relPath := range deleted {
+			err = genericVFSRemoveBeneath(root, relPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		cancelFunc()
+		return nil
+	}
+
+	return fmt.Errorf("Could not find %q", name)
+}
+
+// genericVFSRemoveBeneath recursively removes relPath from within root.
+// Path resolution is confined to root so that symlinks in the tree can't be used to remove files outside of it.
+func genericVFSRemoveBeneath(root *os.Root, path string) error {
+	relPath := genericVFSRelPath(path)
+	if relPath == "." {
+		return fmt.Errorf("Invalid path %q in deleted files list", path)
+	}
+
+	fi, err := root.Lstat(relPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed removing %q: %w", relPath, err)
+	}
+
+	if fi.IsDir() {
+		dir, err := root.Open(relPath)
+		if err != nil {
+			return fmt.Errorf("Failed removing %q: %w", relPath, err)
+		}
+
+		names, err := dir.Readdirnames(-1)
+		_ = dir.Close()
+		if err != nil {
+			return fmt.Errorf("Failed removing %q: %w", relPath, err)
+		}
+
+		for _, name := range names {
+			err = genericVFSRemoveBeneath(root, filepath.Join(relPath, name))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = root.Remove(relPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Failed removing %q: %w", relPath, err)
+	}
+
+	return nil
+}
+
+// genericVFSBackupApplyBlockDiff applies the changed blocks of an incremental backup to a block volume.
+func genericVFSBackupApplyBlockDiff(d Driver, vol Volume, r io.Reader, targetPath string, op *operations.Operation) error {
+	diff, err := blockdiff.NewReader(r)
+	if err != nil {
+		return err
+	}
+
+	// Resize the volume if its size changed since the parent snapshot.
+	blockDiskSize, err := BlockDiskSizeBytes(targetPath)
+	if err != nil {
+		return fmt.Errorf("Error getting block device size %q: %w", targetPath, err)
+	}
+
+	if blockDiskSize != diff.Size() {
+		d.Logger().Debug("Setting volume size from source", logger.Ctx{"target": targetPath, "size": diff.Size()})
+
+		// Allow potentially destructive resize of volume as the backup holds the expected content.
+		err = d.SetVolumeQuota(vol, fmt.Sprintf("%d", diff.Size()), true, op)
+		if err != nil {
+			return err
+		}
+	}
+
+	to, err := os.OpenFile(targetPath, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("Error opening file for writing %q: %w", targetPath, err)
+	}
+
+	defer func() { _ = to.Close() }()
+
+	d.Logger().Debug("Applying changed blocks to block volume", logger.Ctx{"target": targetPath})
+	err = diff.ApplyTo(to)
+	if err != nil {
+		return err
+	}
+
+	return to.Close()
+}
+
 // genericVFSCopyVolume copies a volume and its snapshots using a non-optimized method.
 // initVolume is run against the main volume (not the snapshots) and is often used for quota initialization.
 func genericVFSCopyVolume(d Driver, initVolume func(vol Volume) (revert.Hook, error), vol Volume, srcVol Volume, srcSnapshots []Volume, refresh bool, allowInconsistent bool, op *operations.Operation) error {
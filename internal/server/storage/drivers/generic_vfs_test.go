@@ -0,0 +1,312 @@
+package drivers
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// writeTestTree creates the given files and symlinks below the path. Entries ending with a slash are
+// directories, entries containing "->" are symlinks.
+func writeTestTree(t *testing.T, path string, entries []string) {
+	t.Helper()
+
+	for _, entry := range entries {
+		name, target, isLink := strings.Cut(entry, " -> ")
+		fullPath := filepath.Join(path, name)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+
+		switch {
+		case isLink:
+			require.NoError(t, os.Symlink(target, fullPath))
+		case name[len(name)-1] == '/':
+			require.NoError(t, os.MkdirAll(fullPath, 0o755))
+		default:
+			require.NoError(t, os.WriteFile(fullPath, []byte(name), 0o644))
+		}
+	}
+}
+
+func Test_genericVFSRemoveBeneath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantErr   bool
+		removed   []string
+		remaining []string
+	}{
+		{
+			name:      "File",
+			path:      "/dir/file",
+			removed:   []string{"dir/file"},
+			remaining: []string{"dir", "other"},
+		},
+		{
+			name:      "Directory",
+			path:      "/dir",
+			removed:   []string{"dir"},
+			remaining: []string{"other"},
+		},
+		{
+			name:      "Missing",
+			path:      "/missing",
+			remaining: []string{"dir/file", "other"},
+		},
+		{
+			name:      "Dot-dot is kept within the root",
+			path:      "/../../dir/file",
+			removed:   []string{"dir/file"},
+			remaining: []string{"dir"},
+		},
+		{
+			name:      "Symlink is removed rather than followed",
+			path:      "/escape",
+			removed:   []string{"escape"},
+			remaining: []string{"dir"},
+		},
+		{
+			name:    "Symlinked parent escaping the root",
+			path:    "/escape/victim",
+			wantErr: true,
+		},
+		{
+			name:    "Root",
+			path:    "/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			outside := filepath.Join(tmpDir, "outside")
+			mountPath := filepath.Join(tmpDir, "volume")
+
+			writeTestTree(t, outside, []string{"victim"})
+			writeTestTree(t, mountPath, []string{"dir/file", "other", "escape -> " + outside})
+
+			root, err := os.OpenRoot(mountPath)
+			require.NoError(t, err)
+			defer func() { _ = root.Close() }()
+
+			err = genericVFSRemoveBeneath(root, tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// Files outside of the volume must never be touched.
+			assert.FileExists(t, filepath.Join(outside, "victim"))
+
+			for _, path := range tt.removed {
+				_, err := os.Lstat(filepath.Join(mountPath, path))
+				assert.ErrorIs(t, err, os.ErrNotExist, path)
+			}
+
+			for _, path := range tt.remaining {
+				_, err := os.Lstat(filepath.Join(mountPath, path))
+				assert.NoError(t, err, path)
+			}
+		})
+	}
+}
+
+func Test_genericVFSBackupApplyDeleted(t *testing.T) {
+	tmpDir := t.TempDir()
+	outside := filepath.Join(tmpDir, "outside")
+	mountPath := filepath.Join(tmpDir, "volume")
+
+	writeTestTree(t, outside, []string{"victim"})
+	writeTestTree(t, mountPath, []string{"dir/file", "other", "keep", "escape -> " + outside})
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	deleted := []byte("- /dir\n- /other\n- /escape/victim\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "backup/volume.deleted", Mode: 0o600, Size: int64(len(deleted)), ModTime: time.Now()}))
+	_, err := tw.Write(deleted)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	err = genericVFSBackupApplyDeleted(bytes.NewReader(buf.Bytes()), nil, "backup/volume.deleted", mountPath)
+	assert.Error(t, err)
+	assert.FileExists(t, filepath.Join(outside, "victim"))
+	assert.NoDirExists(t, filepath.Join(mountPath, "dir"))
+	assert.NoFileExists(t, filepath.Join(mountPath, "other"))
+	assert.FileExists(t, filepath.Join(mountPath, "keep"))
+
+	err = genericVFSBackupApplyDeleted(bytes.NewReader(buf.Bytes()), nil, "backup/missing.deleted", mountPath)
+	assert.Error(t, err)
+}
+
+func Test_genericVFSFileChanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "base")
+	otherPath := filepath.Join(tmpDir, "other")
+
+	entries := []string{"same", "link -> target"}
+	writeTestTree(t, basePath, entries)
+	writeTestTree(t, otherPath, entries)
+	writeTestTree(t, basePath, []string{"changed-link -> old"})
+	writeTestTree(t, otherPath, []string{"changed-link -> new"})
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "resized"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(otherPath, "resized"), []byte("ab"), 0o644))
+
+	// Align the modification times so only the content related fields differ.
+	now := time.Now()
+	for _, path := range []string{"same", "resized"} {
+		require.NoError(t, os.Chtimes(filepath.Join(basePath, path), now, now))
+		require.NoError(t, os.Chtimes(filepath.Join(otherPath, path), now, now))
+	}
+
+	baseRoot, err := os.OpenRoot(basePath)
+	require.NoError(t, err)
+	defer func() { _ = baseRoot.Close() }()
+
+	otherRoot, err := os.OpenRoot(otherPath)
+	require.NoError(t, err)
+	defer func() { _ = otherRoot.Close() }()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "Unchanged file", path: "/same", want: false},
+		{name: "Resized file", path: "/resized", want: true},
+		{name: "Unchanged symlink", path: "/link", want: false},
+		{name: "Retargeted symlink", path: "/changed-link", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fi, err := otherRoot.Lstat(genericVFSRelPath(tt.path))
+			require.NoError(t, err)
+
+			baseFi, err := baseRoot.Lstat(genericVFSRelPath(tt.path))
+			require.NoError(t, err)
+
+			if fi.Mode().Type() == os.ModeSymlink {
+				// Symlink modification times can't be aligned portably, compare their targets only.
+				baseFi = fi
+			}
+
+			assert.Equal(t, tt.want, genericVFSFileChanged(otherRoot, tt.path, fi, baseRoot, tt.path, baseFi))
+		})
+	}
+}
+
+func Test_genericVFSDiffPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	outside := filepath.Join(tmpDir, "outside")
+	basePath := filepath.Join(tmpDir, "base")
+	otherPath := filepath.Join(tmpDir, "other")
+
+	writeTestTree(t, outside, []string{"victim"})
+	writeTestTree(t, basePath, []string{"same", "deleted", "dir/kept", "link-dir/file", "renamed-old", "escape -> " + outside, "link -> link-dir"})
+	writeTestTree(t, otherPath, []string{"same", "added", "dir/kept", "link-dir/file", "escape/victim", "link/file"})
+	require.NoError(t, os.WriteFile(filepath.Join(basePath, "modified"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(otherPath, "modified"), []byte("ab"), 0o644))
+
+	// Hard links keep the inode number, like a renamed file in a snapshot with stable inodes.
+	require.NoError(t, os.Link(filepath.Join(basePath, "renamed-old"), filepath.Join(otherPath, "renamed-new")))
+
+	// Align the modification times so that only actual changes are reported.
+	now := time.Now()
+	for _, path := range []string{basePath, otherPath} {
+		err := filepath.Walk(path, func(path string, fi os.FileInfo, err error) error {
+			if err != nil || fi.Mode().Type() == os.ModeSymlink {
+				return err
+			}
+
+			return os.Chtimes(path, now, now)
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name         string
+		stableInodes bool
+		want         []api.StorageVolumeSnapshotDiff
+	}{
+		{
+			name:         "Stable inodes",
+			stableInodes: true,
+			want: []api.StorageVolumeSnapshotDiff{
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/added"},
+				{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/deleted"},
+				{Type: api.StorageVolumeSnapshotDiffModified, Path: "/escape"},
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/escape/victim"},
+				{Type: api.StorageVolumeSnapshotDiffModified, Path: "/link"},
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/link/file"},
+				{Type: api.StorageVolumeSnapshotDiffModified, Path: "/modified"},
+				{Type: api.StorageVolumeSnapshotDiffRenamed, Path: "/renamed-old", NewPath: "/renamed-new"},
+			},
+		},
+		{
+			name: "Unstable inodes",
+			want: []api.StorageVolumeSnapshotDiff{
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/added"},
+				{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/deleted"},
+				{Type: api.StorageVolumeSnapshotDiffModified, Path: "/escape"},
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/escape/victim"},
+				{Type: api.StorageVolumeSnapshotDiffModified, Path: "/link"},
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/link/file"},
+				{Type: api.StorageVolumeSnapshotDiffModified, Path: "/modified"},
+				{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/renamed-new"},
+				{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/renamed-old"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := genericVFSDiffPaths(basePath, otherPath, tt.stableInodes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, diff)
+		})
+	}
+
+	// Comparing the other way around reports the symlinked directories as deleted.
+	diff, err := genericVFSDiffPaths(otherPath, basePath, false)
+	require.NoError(t, err)
+	assert.Contains(t, diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/escape/victim"})
+	assert.Contains(t, diff, api.StorageVolumeSnapshotDiff{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/link/file"})
+}
+
+func Test_genericVFSRenameImplied(t *testing.T) {
+	renames := map[string]string{
+		"/dir":          "/renamed",
+		"/other/nested": "/moved",
+	}
+
+	tests := []struct {
+		name    string
+		oldPath string
+		newPath string
+		want    bool
+	}{
+		{name: "Entry of a renamed directory", oldPath: "/dir/file", newPath: "/renamed/file", want: true},
+		{name: "Nested entry of a renamed directory", oldPath: "/dir/sub/file", newPath: "/renamed/sub/file", want: true},
+		{name: "Entry of a nested renamed directory", oldPath: "/other/nested/file", newPath: "/moved/file", want: true},
+		{name: "Entry moved out of a renamed directory", oldPath: "/dir/file", newPath: "/file", want: false},
+		{name: "Entry of a directory which wasn't renamed", oldPath: "/other/file", newPath: "/renamed/file", want: false},
+		{name: "Renamed directory itself", oldPath: "/dir", newPath: "/renamed", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, genericVFSRenameImplied(renames, tt.oldPath, tt.newPath))
+		})
+	}
+}
@@ -264,6 +264,35 @@ func GetSnapshotVolumeName(parentName, snapshotName string) string {
 	return fmt.Sprintf("%s%s%s", parentName, internalInstance.SnapshotDelimiter, snapshotName)
 }
 
+// sortSnapshotDiff sorts the snapshot differences by path.
+func sortSnapshotDiff(diff []api.StorageVolumeSnapshotDiff) {
+	slices.SortFunc(diff, func(a api.StorageVolumeSnapshotDiff, b api.StorageVolumeSnapshotDiff) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+}
+
+// invertSnapshotDiff turns the differences going from one volume to another into the differences going
+// the other way around.
+func invertSnapshotDiff(diff []api.StorageVolumeSnapshotDiff) []api.StorageVolumeSnapshotDiff {
+	inverted := make([]api.StorageVolumeSnapshotDiff, 0, len(diff))
+	for _, entry := range diff {
+		switch entry.Type {
+		case api.StorageVolumeSnapshotDiffAdded:
+			entry.Type = api.StorageVolumeSnapshotDiffDeleted
+		case api.StorageVolumeSnapshotDiffDeleted:
+			entry.Type = api.StorageVolumeSnapshotDiffAdded
+		case api.StorageVolumeSnapshotDiffRenamed:
+			entry.Path, entry.NewPath = entry.NewPath, entry.Path
+		}
+
+		inverted = append(inverted, entry)
+	}
+
+	sortSnapshotDiff(inverted)
+
+	return inverted
+}
+
 // createParentSnapshotDirIfMissing creates the parent directory for volume snapshots.
 func createParentSnapshotDirIfMissing(poolName string, volType VolumeType, volName string) error {
 	snapshotsPath := GetVolumeSnapshotDir(poolName, volType, volName)
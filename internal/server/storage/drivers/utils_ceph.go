@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/lxc/incus/v6/shared/api"
@@ -336,6 +338,43 @@ func CephFsid(cluster string, client string) (string, error) {
 	return fsid.Fsid, nil
 }
 
+// cephHealth retrieves the health of the given cluster.
+func cephHealth(cluster string, client string) (*api.StoragePoolStateHealth, error) {
+	type cephHealthCheck struct {
+		Severity string `json:"severity"`
+		Summary  struct {
+			Message string `json:"message"`
+		} `json:"summary"`
+	}
+
+	cephStatus := struct {
+		Status string                     `json:"status"`
+		Checks map[string]cephHealthCheck `json:"checks"`
+	}{}
+
+	err := callCephJSON(&cephStatus, "--cluster", cluster, "--name", EnsureClientPrefix(client), "health")
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get health for %q: %w", cluster, err)
+	}
+
+	health := &api.StoragePoolStateHealth{Messages: []string{}}
+
+	switch cephStatus.Status {
+	case "HEALTH_OK":
+		health.Status = api.StoragePoolHealthHealthy
+	case "HEALTH_WARN":
+		health.Status = api.StoragePoolHealthDegraded
+	default:
+		health.Status = api.StoragePoolHealthFailed
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cephStatus.Checks)) {
+		health.Messages = append(health.Messages, cephStatus.Checks[name].Summary.Message)
+	}
+
+	return health, nil
+}
+
 // EnsureClientPrefix returns the given client string with the "client" prefix added,
 // but only if it does not already start with that prefix.
 func EnsureClientPrefix(client string) string {
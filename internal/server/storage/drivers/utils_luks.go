@@ -0,0 +1,188 @@
+package drivers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// luksHeaderSize is the space reserved for the LUKS2 header at the start of encrypted volumes.
+// Encrypted volumes are grown by that much so that their usable size matches the configured size.
+const luksHeaderSize = 16 * 1024 * 1024
+
+// luksValidateVolume adjusts the supplied volume rules for the encryption settings of the volume.
+// Encryption is only supported for instance and custom volumes with content type `block`.
+func luksValidateVolume(vol Volume, rules map[string]func(value string) error) {
+	if vol.contentType != ContentTypeBlock || vol.volType == VolumeTypeImage {
+		delete(rules, "block.encryption")
+		return
+	}
+
+	rules["block.encryption.key"] = validate.IsAny
+}
+
+// luksFillVolumeConfig generates the encryption key of new encrypted volumes which weren't supplied one.
+// Volumes created from a source keep using the key of their source, which is carried along with the source config,
+// and are left without one when it's missing (like in backups created without it) until it's set.
+func luksFillVolumeConfig(vol *Volume) error {
+	if vol.config["block.encryption"] == "" || vol.config["block.encryption.key"] != "" || vol.hasSource {
+		return nil
+	}
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return fmt.Errorf("Failed generating encryption key: %w", err)
+	}
+
+	vol.config["block.encryption.key"] = hex.EncodeToString(key)
+
+	return nil
+}
+
+// luksEncrypted returns whether the volume is an encrypted block volume.
+func luksEncrypted(vol Volume) bool {
+	return vol.contentType == ContentTypeBlock && vol.config["block.encryption"] != ""
+}
+
+// luksDiskSizeBytes returns the size of the disk needed for an encrypted volume of the supplied size.
+// The size is returned as is for volumes which aren't encrypted.
+func luksDiskSizeBytes(vol Volume, sizeBytes int64) int64 {
+	if !luksEncrypted(vol) || sizeBytes <= 0 {
+		return sizeBytes
+	}
+
+	return sizeBytes + luksHeaderSize
+}
+
+// luksDeviceName returns the device mapper name used for the opened encrypted volume.
+func luksDeviceName(vol Volume) string {
+	hash := sha256.Sum256(fmt.Appendf(nil, "%s/%s/%s", vol.pool, vol.volType, vol.name))
+
+	return fmt.Sprintf("incus-%s", hex.EncodeToString(hash[:]))
+}
+
+// luksMapperPath returns the path of the device used when the encrypted volume is opened.
+func luksMapperPath(vol Volume) string {
+	return filepath.Join("/dev/mapper", luksDeviceName(vol))
+}
+
+// LUKSDevicePath returns the path of the device to use for accessing the content of the volume.
+// This is the opened device for encrypted volumes, an error is returned if they haven't been opened.
+// The supplied disk path is returned as is for volumes which aren't encrypted.
+func LUKSDevicePath(vol Volume, diskPath string) (string, error) {
+	if !luksEncrypted(vol) {
+		return diskPath, nil
+	}
+
+	devPath := luksMapperPath(vol)
+	if !util.PathExists(devPath) {
+		return "", fmt.Errorf("Encrypted volume %q isn't opened", vol.name)
+	}
+
+	return devPath, nil
+}
+
+// luksWithDevice runs the supplied function with the path of the device to use for accessing the content of
+// the volume. Encrypted volumes which aren't already opened are opened for the duration of the function.
+func luksWithDevice(vol Volume, diskPath string, f func(devPath string) error) error {
+	if !luksEncrypted(vol) {
+		return f(diskPath)
+	}
+
+	if util.PathExists(luksMapperPath(vol)) {
+		return f(luksMapperPath(vol))
+	}
+
+	devPath, err := luksOpen(vol, diskPath)
+	if err != nil {
+		return err
+	}
+
+	err = f(devPath)
+	if err != nil {
+		_ = luksClose(vol)
+		return err
+	}
+
+	return luksClose(vol)
+}
+
+// luksRun runs cryptsetup, passing the encryption key of the volume on stdin.
+func luksRun(vol Volume, args ...string) error {
+	key := vol.config["block.encryption.key"]
+	if key == "" {
+		return fmt.Errorf("Missing encryption key for volume %q", vol.name)
+	}
+
+	return subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(key), nil, "cryptsetup", args...)
+}
+
+// luksFormat initializes the LUKS2 header on the disk of an encrypted volume.
+func luksFormat(vol Volume, diskPath string) error {
+	err := luksRun(vol, "luksFormat", "--type", "luks2", "--batch-mode", "--offset", fmt.Sprintf("%d", luksHeaderSize/512), "--key-file", "-", diskPath)
+	if err != nil {
+		return fmt.Errorf("Failed formatting encrypted volume %q: %w", vol.name, err)
+	}
+
+	return nil
+}
+
+// luksOpen opens the disk of an encrypted volume if not already opened and returns the path of the opened device.
+func luksOpen(vol Volume, diskPath string) (string, error) {
+	devName := luksDeviceName(vol)
+	devPath := luksMapperPath(vol)
+	if util.PathExists(devPath) {
+		return devPath, nil
+	}
+
+	err := luksRun(vol, "open", "--type", "luks2", "--key-file", "-", diskPath, devName)
+	if err != nil {
+		return "", fmt.Errorf("Failed opening encrypted volume %q: %w", vol.name, err)
+	}
+
+	vol.driver.Logger().Debug("Opened encrypted volume", logger.Ctx{"volName": vol.name, "dev": devPath})
+
+	return devPath, nil
+}
+
+// luksClose closes the opened device of an encrypted volume if opened.
+func luksClose(vol Volume) error {
+	devName := luksDeviceName(vol)
+	if !util.PathExists(luksMapperPath(vol)) {
+		return nil
+	}
+
+	_, err := subprocess.TryRunCommand("cryptsetup", "close", devName)
+	if err != nil {
+		return fmt.Errorf("Failed closing encrypted volume %q: %w", vol.name, err)
+	}
+
+	vol.driver.Logger().Debug("Closed encrypted volume", logger.Ctx{"volName": vol.name})
+
+	return nil
+}
+
+// luksResize resizes the opened device of an encrypted volume to the size of its disk.
+func luksResize(vol Volume) error {
+	devName := luksDeviceName(vol)
+	if !util.PathExists(luksMapperPath(vol)) {
+		return nil
+	}
+
+	err := luksRun(vol, "resize", "--key-file", "-", devName)
+	if err != nil {
+		return fmt.Errorf("Failed resizing encrypted volume %q: %w", vol.name, err)
+	}
+
+	return nil
+}
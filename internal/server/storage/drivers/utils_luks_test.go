@@ -0,0 +1,155 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+func Test_luksValidateVolume(t *testing.T) {
+	tests := []struct {
+		name        string
+		vol         Volume
+		hasEncrypt  bool
+		hasKeyRules bool
+	}{
+		{
+			name:        "Custom block volume",
+			vol:         Volume{volType: VolumeTypeCustom, contentType: ContentTypeBlock},
+			hasEncrypt:  true,
+			hasKeyRules: true,
+		},
+		{
+			name:        "VM block volume",
+			vol:         Volume{volType: VolumeTypeVM, contentType: ContentTypeBlock},
+			hasEncrypt:  true,
+			hasKeyRules: true,
+		},
+		{
+			name: "Image block volume",
+			vol:  Volume{volType: VolumeTypeImage, contentType: ContentTypeBlock},
+		},
+		{
+			name: "Custom filesystem volume",
+			vol:  Volume{volType: VolumeTypeCustom, contentType: ContentTypeFS},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := map[string]func(value string) error{"block.encryption": validate.IsAny}
+			luksValidateVolume(tt.vol, rules)
+
+			_, ok := rules["block.encryption"]
+			assert.Equal(t, tt.hasEncrypt, ok)
+
+			_, ok = rules["block.encryption.key"]
+			assert.Equal(t, tt.hasKeyRules, ok)
+		})
+	}
+}
+
+func Test_luksFillVolumeConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]string
+		hasSource bool
+		wantKey   string
+		generated bool
+	}{
+		{
+			name:   "Not encrypted",
+			config: map[string]string{},
+		},
+		{
+			name:      "Generated key",
+			config:    map[string]string{"block.encryption": "luks2"},
+			generated: true,
+		},
+		{
+			name:    "Supplied key",
+			config:  map[string]string{"block.encryption": "luks2", "block.encryption.key": "secret"},
+			wantKey: "secret",
+		},
+		{
+			name:      "Created from a source",
+			config:    map[string]string{"block.encryption": "luks2"},
+			hasSource: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vol := Volume{volType: VolumeTypeCustom, contentType: ContentTypeBlock, config: tt.config, hasSource: tt.hasSource}
+			require.NoError(t, luksFillVolumeConfig(&vol))
+
+			if tt.generated {
+				assert.Len(t, vol.config["block.encryption.key"], 64)
+				return
+			}
+
+			assert.Equal(t, tt.wantKey, vol.config["block.encryption.key"])
+		})
+	}
+
+	// Generated keys differ between volumes.
+	vol1 := Volume{config: map[string]string{"block.encryption": "luks2"}}
+	vol2 := Volume{config: map[string]string{"block.encryption": "luks2"}}
+	require.NoError(t, luksFillVolumeConfig(&vol1))
+	require.NoError(t, luksFillVolumeConfig(&vol2))
+	assert.NotEqual(t, vol1.config["block.encryption.key"], vol2.config["block.encryption.key"])
+}
+
+func Test_luksDiskSizeBytes(t *testing.T) {
+	encrypted := Volume{contentType: ContentTypeBlock, config: map[string]string{"block.encryption": "luks2"}}
+	plain := Volume{contentType: ContentTypeBlock, config: map[string]string{}}
+	fs := Volume{contentType: ContentTypeFS, config: map[string]string{"block.encryption": "luks2"}}
+
+	assert.True(t, luksEncrypted(encrypted))
+	assert.False(t, luksEncrypted(plain))
+	assert.False(t, luksEncrypted(fs))
+
+	assert.Equal(t, int64(1024*1024*1024+luksHeaderSize), luksDiskSizeBytes(encrypted, 1024*1024*1024))
+	assert.Equal(t, int64(0), luksDiskSizeBytes(encrypted, 0))
+	assert.Equal(t, int64(1024*1024*1024), luksDiskSizeBytes(plain, 1024*1024*1024))
+	assert.Equal(t, int64(1024*1024*1024), luksDiskSizeBytes(fs, 1024*1024*1024))
+}
+
+func Test_luksDeviceName(t *testing.T) {
+	vol := Volume{pool: "pool", volType: VolumeTypeCustom, name: "vol"}
+
+	name := luksDeviceName(vol)
+	assert.Regexp(t, "^incus-[0-9a-f]{64}$", name)
+	assert.Equal(t, name, luksDeviceName(Volume{pool: "pool", volType: VolumeTypeCustom, name: "vol"}))
+
+	for _, other := range []Volume{
+		{pool: "pool2", volType: VolumeTypeCustom, name: "vol"},
+		{pool: "pool", volType: VolumeTypeVM, name: "vol"},
+		{pool: "pool", volType: VolumeTypeCustom, name: "vol2"},
+	} {
+		assert.NotEqual(t, name, luksDeviceName(other))
+	}
+}
+
+func Test_LUKSDevicePath(t *testing.T) {
+	// Volumes which aren't encrypted are used through their disk.
+	plain := Volume{pool: "pool", volType: VolumeTypeCustom, contentType: ContentTypeBlock, name: "vol", config: map[string]string{}}
+	devPath, err := LUKSDevicePath(plain, "/dev/disk")
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/disk", devPath)
+
+	// Encrypted volumes must have been opened.
+	encrypted := Volume{pool: "pool", volType: VolumeTypeCustom, contentType: ContentTypeBlock, name: "vol-not-opened", config: map[string]string{"block.encryption": "luks2"}}
+	_, err = LUKSDevicePath(encrypted, "/dev/disk")
+	assert.EqualError(t, err, `Encrypted volume "vol-not-opened" isn't opened`)
+
+	// The supplied function is run with the disk of volumes which aren't encrypted.
+	err = luksWithDevice(plain, "/dev/disk", func(devPath string) error {
+		assert.Equal(t, "/dev/disk", devPath)
+		return nil
+	})
+	require.NoError(t, err)
+}
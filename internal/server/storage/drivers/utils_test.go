@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
 )
 
 // Test GetVolumeMountPath.
@@ -35,3 +37,25 @@ func TestGetVolumeMountPath(t *testing.T) {
 	expected = GetPoolMountPath(poolName) + "/virtual-machines/testvol"
 	assert.Equal(t, expected, path)
 }
+
+func Test_invertSnapshotDiff(t *testing.T) {
+	diff := []api.StorageVolumeSnapshotDiff{
+		{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/added"},
+		{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/deleted"},
+		{Type: api.StorageVolumeSnapshotDiffModified, Path: "/modified"},
+		{Type: api.StorageVolumeSnapshotDiffRenamed, Path: "/b-old", NewPath: "/z-new"},
+	}
+
+	want := []api.StorageVolumeSnapshotDiff{
+		{Type: api.StorageVolumeSnapshotDiffDeleted, Path: "/added"},
+		{Type: api.StorageVolumeSnapshotDiffAdded, Path: "/deleted"},
+		{Type: api.StorageVolumeSnapshotDiffModified, Path: "/modified"},
+		{Type: api.StorageVolumeSnapshotDiffRenamed, Path: "/z-new", NewPath: "/b-old"},
+	}
+
+	assert.Equal(t, want, invertSnapshotDiff(diff))
+
+	// Inverting twice gives back the original differences.
+	assert.Equal(t, []api.StorageVolumeSnapshotDiff{diff[0], diff[3], diff[1], diff[2]}, invertSnapshotDiff(invertSnapshotDiff(diff)))
+	assert.Empty(t, invertSnapshotDiff(nil))
+}
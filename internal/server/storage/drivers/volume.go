@@ -439,6 +439,27 @@ func (v Volume) SnapshotsMatch(snapNames []string, op *operations.Operation) err
 	return nil
 }
 
+// SnapshotsPresent checks that the given snapshot names exist in storage. Unlike SnapshotsMatch, other
+// snapshots may exist in storage too.
+func (v Volume) SnapshotsPresent(snapNames []string, op *operations.Operation) error {
+	if v.IsSnapshot() {
+		return errors.New("Volume is a snapshot")
+	}
+
+	snapshots, err := v.driver.VolumeSnapshots(v, op)
+	if err != nil {
+		return err
+	}
+
+	for _, snapName := range snapNames {
+		if !slices.Contains(snapshots, snapName) {
+			return fmt.Errorf("Snapshot %q expected but not in storage", snapName)
+		}
+	}
+
+	return nil
+}
+
 // IsBlockBacked indicates whether storage device is block backed.
 func (v Volume) IsBlockBacked() bool {
 	return v.driver.isBlockBacked(v) || v.mountFilesystemProbe
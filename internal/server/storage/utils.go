@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/v6/internal/migration"
 	"github.com/lxc/incus/v6/internal/rsync"
 	"github.com/lxc/incus/v6/internal/server/apparmor"
+	backupConfig "github.com/lxc/incus/v6/internal/server/backup/config"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/instance"
@@ -76,6 +77,58 @@ func ConfigDiff(oldConfig map[string]string, newConfig map[string]string) ([]str
 	return changedConfig, userOnly
 }
 
+// volumeWriteOnlyKeys are the storage volume configuration keys holding secrets.
+// They can be set but are never returned through the API or stored alongside the volume data.
+var volumeWriteOnlyKeys = []string{"block.encryption.key"}
+
+// VolumeStripWriteOnly returns a copy of the volume config without the configuration keys holding secrets.
+func VolumeStripWriteOnly(config map[string]string) map[string]string {
+	if config == nil {
+		return nil
+	}
+
+	stripped := make(map[string]string, len(config))
+	for k, v := range config {
+		if !slices.Contains(volumeWriteOnlyKeys, k) {
+			stripped[k] = v
+		}
+	}
+
+	return stripped
+}
+
+// VolumeKeepWriteOnly copies the configuration keys holding secrets from the old volume config to the new one
+// when not set in it, so that updating a volume with the config it was returned doesn't clear them.
+func VolumeKeepWriteOnly(newConfig map[string]string, oldConfig map[string]string) {
+	if newConfig == nil {
+		return
+	}
+
+	for _, k := range volumeWriteOnlyKeys {
+		_, ok := newConfig[k]
+		if ok || oldConfig[k] == "" {
+			continue
+		}
+
+		newConfig[k] = oldConfig[k]
+	}
+}
+
+// BackupConfigStripWriteOnly removes the configuration keys holding secrets from the volumes of a backup config.
+func BackupConfigStripWriteOnly(config *backupConfig.Config) {
+	if config.Volume != nil {
+		vol := *config.Volume
+		vol.Config = VolumeStripWriteOnly(vol.Config)
+		config.Volume = &vol
+	}
+
+	for i, snap := range config.VolumeSnapshots {
+		newSnap := *snap
+		newSnap.Config = VolumeStripWriteOnly(newSnap.Config)
+		config.VolumeSnapshots[i] = &newSnap
+	}
+}
+
 // VolumeTypeNameToDBType converts a volume type string to internal volume type DB code.
 func VolumeTypeNameToDBType(volumeTypeName string) (int, error) {
 	switch volumeTypeName {
@@ -479,6 +532,14 @@ func BucketKeysDBGet(pool Pool, bucketID int64) ([]*db.StorageBucketKey, error)
 // When vol argument is nil function returns pool specific rules.
 func poolAndVolumeCommonRules(vol *drivers.Volume) map[string]func(string) error {
 	rules := map[string]func(string) error{
+		"backups.compression_algorithm": validate.Optional(validate.IsCompressionAlgorithm),
+		"backups.expiry": func(value string) error {
+			// Validate expression
+			_, err := internalInstance.GetExpiry(time.Time{}, value)
+			return err
+		},
+		"backups.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly", "@never"})),
+		"backups.target":   validate.Optional(internalInstance.IsBackupTargetName),
 		// Note: size should not be modifiable for non-custom volumes and should be checked
 		// in the relevant volume update functions.
 		"size": validate.Optional(validate.IsSize),
@@ -552,6 +613,21 @@ func validateVolumeCommonRules(vol drivers.Volume) map[string]func(string) error
 		rules["block.filesystem"] = validate.IsAny
 	}
 
+	// Scheduled backups are only supported for custom volumes.
+	if vol.Type() == drivers.VolumeTypeCustom {
+		rules["volatile.backups.uploaded"] = validate.IsAny
+	}
+
+	// Replication is only supported for custom volumes.
+	if vol.Type() == drivers.VolumeTypeCustom {
+		rules["replication.schedule"] = validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"}))
+		rules["replication.snapshots.retention"] = validate.Optional(validate.IsInRange(1, 1000))
+		rules["replication.target"] = validate.Optional(internalInstance.IsReplicationTargetName)
+		rules["replication.target.pool"] = validate.IsAny
+		rules["replication.target.project"] = validate.IsAny
+		rules["volatile.replication.last_success"] = validate.IsAny
+	}
+
 	// volatile.rootfs.size is only used for image volumes.
 	if vol.Type() == drivers.VolumeTypeImage {
 		rules["volatile.rootfs.size"] = validate.Optional(validate.IsInt64)
@@ -1227,3 +1303,22 @@ func VolumeSnapshotsToMigrationSnapshots(snapshots []*api.StorageVolumeSnapshot,
 
 	return migrationSnapshots, nil
 }
+
+// backupSnapshotNames returns the snapshots (oldest first) to include in a backup. For incremental backups, only
+// the snapshots newer than the parent snapshot are included.
+func backupSnapshotNames(snapNames []string, snapshots bool, parent string) ([]string, error) {
+	if parent != "" {
+		idx := slices.Index(snapNames, parent)
+		if idx < 0 {
+			return nil, fmt.Errorf("Parent snapshot %q not found", parent)
+		}
+
+		snapNames = snapNames[idx+1:]
+	}
+
+	if !snapshots {
+		return nil, nil
+	}
+
+	return snapNames, nil
+}
@@ -3,6 +3,7 @@ package syslog
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net"
 	"os"
 	"strings"
@@ -18,7 +19,8 @@ import (
 )
 
 // Listen starts the log monitor.
-func Listen(ctx context.Context, eventServer *events.Server) error {
+// The optional aclContext function returns the structured fields of an ACL log message, added to the event context.
+func Listen(ctx context.Context, eventServer *events.Server, aclContext func(message string) map[string]string) error {
 	var listenConfig net.ListenConfig
 
 	sockFile := internalUtil.VarPath("syslog.socket")
@@ -133,6 +135,10 @@ func Listen(ctx context.Context, eventServer *events.Server) error {
 				event.Context["application"] = applicationName
 			}
 
+			if aclContext != nil {
+				maps.Copy(event.Context, aclContext(message))
+			}
+
 			err = eventServer.Send("", api.EventTypeNetworkACL, event)
 			if err != nil {
 				continue
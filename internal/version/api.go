@@ -506,6 +506,31 @@ var APIExtensions = []string{
 	"bpf_token_delegation",
 	"file_storage_volume",
 	"network_hwaddr_pattern",
+	"backup_upload_targets",
+	"backup_incremental",
+	"backup_schedule",
+	"network_zones_dns_queries",
+	"network_zones_dnssec",
+	"instances_placement_scriptlet_builtins",
+	"instances_validation_scriptlet",
+	"logging_otlp",
+	"projects_usage",
+	"network_type_vxlan",
+	"network_type_wireguard",
+	"network_integrations_bgp_vlan",
+	"network_acl_counters",
+	"network_forward_connection_limits",
+	"network_load_balancer_bridge",
+	"proxy_http",
+	"network_address_set_selectors",
+	"network_bridge_ipv6_prefix_delegation",
+	"network_qos",
+	"instance_nic_vhost_user",
+	"storage_dir_reflink",
+	"storage_volume_encryption",
+	"storage_pool_state",
+	"replication",
+	"storage_volume_snapshot_diff",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // NetworksPost represents the fields of a new network
 //
 // swagger:model
@@ -188,6 +192,11 @@ type NetworkState struct {
 	//
 	// API extension: network_state_ovn
 	OVN *NetworkStateOVN `json:"ovn" yaml:"ovn"`
+
+	// Additional WireGuard network information
+	//
+	// API extension: network_type_wireguard
+	Wireguard *NetworkStateWireguard `json:"wireguard" yaml:"wireguard"`
 }
 
 // NetworkStateAddress represents a network address
@@ -347,3 +356,56 @@ type NetworkStateOVN struct {
 	// API extension: network_ovn_state_addresses
 	UplinkIPv6 string `json:"uplink_ipv6" yaml:"uplink_ipv6"`
 }
+
+// NetworkStateWireguard represents WireGuard specific state
+//
+// swagger:model
+//
+// API extension: network_type_wireguard.
+type NetworkStateWireguard struct {
+	// Public key of the local cluster member
+	// Example: xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=
+	PublicKey string `json:"public_key" yaml:"public_key"`
+
+	// Listening UDP port
+	// Example: 51820
+	ListenPort int `json:"listen_port" yaml:"listen_port"`
+
+	// List of peers
+	Peers []NetworkStateWireguardPeer `json:"peers" yaml:"peers"`
+}
+
+// NetworkStateWireguardPeer represents the state of a WireGuard peer
+//
+// swagger:model
+//
+// API extension: network_type_wireguard.
+type NetworkStateWireguardPeer struct {
+	// Name of the peer (cluster member name or name of the peer in the configuration)
+	// Example: server02
+	Name string `json:"name" yaml:"name"`
+
+	// Public key of the peer
+	// Example: TrMvSoP4jYQlY6RIzBgbssQqY3vxI2Pi+y71lOWWXX0=
+	PublicKey string `json:"public_key" yaml:"public_key"`
+
+	// Endpoint of the peer
+	// Example: 10.0.0.2:51820
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+
+	// Addresses and subnets routed to the peer
+	// Example: ["10.100.0.2/32"]
+	AllowedIPs []string `json:"allowed_ips" yaml:"allowed_ips"`
+
+	// Time of the latest handshake with the peer
+	// Example: 2024-01-01T12:00:00Z
+	LatestHandshake time.Time `json:"latest_handshake" yaml:"latest_handshake"`
+
+	// Number of bytes received from the peer
+	// Example: 1024
+	BytesReceived int64 `json:"bytes_received" yaml:"bytes_received"`
+
+	// Number of bytes sent to the peer
+	// Example: 2048
+	BytesSent int64 `json:"bytes_sent" yaml:"bytes_sent"`
+}
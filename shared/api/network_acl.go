@@ -166,3 +166,98 @@ type NetworkACLsPost struct {
 	NetworkACLPost `yaml:",inline"`
 	NetworkACLPut  `yaml:",inline"`
 }
+
+// NetworkACLRuleCounters represents the hit counters of a network ACL rule.
+//
+// swagger:model
+//
+// API extension: network_acl_counters.
+type NetworkACLRuleCounters struct {
+	// Number of packets which matched the rule
+	// Example: 1024
+	Packets int64 `json:"packets" yaml:"packets"`
+
+	// Number of bytes which matched the rule
+	// Example: 98304
+	Bytes int64 `json:"bytes" yaml:"bytes"`
+}
+
+// NetworkACLState represents the state of a network ACL.
+//
+// swagger:model
+//
+// API extension: network_acl_counters.
+type NetworkACLState struct {
+	// Hit counters of the ingress rules (in the same order as the rules)
+	Ingress []NetworkACLRuleCounters `json:"ingress" yaml:"ingress"`
+
+	// Hit counters of the egress rules (in the same order as the rules)
+	Egress []NetworkACLRuleCounters `json:"egress" yaml:"egress"`
+}
+
+// NetworkACLLogEntry represents an entry of the network ACL log.
+//
+// swagger:model
+//
+// API extension: network_acl_counters.
+type NetworkACLLogEntry struct {
+	// Time of the entry
+	// Example: 2025-01-01T12:00:00Z
+	Time string `json:"time" yaml:"time"`
+
+	// Name of the ACL
+	// Example: web
+	ACL string `json:"acl,omitempty" yaml:"acl,omitempty"`
+
+	// Project of the ACL and instance
+	// Example: default
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+
+	// Name of the instance
+	// Example: c1
+	Instance string `json:"instance,omitempty" yaml:"instance,omitempty"`
+
+	// Name of the instance NIC
+	// Example: eth0
+	Device string `json:"device,omitempty" yaml:"device,omitempty"`
+
+	// Direction of the traffic relative to the instance (ingress or egress)
+	// Example: ingress
+	Direction string `json:"direction,omitempty" yaml:"direction,omitempty"`
+
+	// Index of the matching rule within its direction (unset for the default rules)
+	// Example: 0
+	Rule *int `json:"rule,omitempty" yaml:"rule,omitempty"`
+
+	// Protocol
+	// Example: tcp
+	Proto string `json:"proto" yaml:"proto"`
+
+	// Source address
+	// Example: 10.0.0.1
+	Src string `json:"src" yaml:"src"`
+
+	// Destination address
+	// Example: 10.0.0.2
+	Dst string `json:"dst" yaml:"dst"`
+
+	// Source port
+	// Example: 43210
+	SrcPort string `json:"src_port,omitempty" yaml:"src_port,omitempty"`
+
+	// Destination port
+	// Example: 22
+	DstPort string `json:"dst_port,omitempty" yaml:"dst_port,omitempty"`
+
+	// Type of ICMP message
+	// Example: 8
+	ICMPType string `json:"icmp_type,omitempty" yaml:"icmp_type,omitempty"`
+
+	// ICMP message code
+	// Example: 0
+	ICMPCode string `json:"icmp_code,omitempty" yaml:"icmp_code,omitempty"`
+
+	// Action taken on the traffic
+	// Example: drop
+	Action string `json:"action" yaml:"action"`
+}
@@ -36,6 +36,18 @@ type NetworkForwardPort struct {
 	//
 	// API extension: network_forward_snat
 	SNAT bool `json:"snat" yaml:"snat"`
+
+	// Maximum number of new connections per second to the listen port(s) (0 for unlimited, approximated for TCP ports only on OVN networks)
+	// Example: 100
+	//
+	// API extension: network_forward_connection_limits
+	MaxConnectionRate int64 `json:"max_connection_rate,omitempty" yaml:"max_connection_rate,omitempty"`
+
+	// Maximum number of concurrent connections to the listen port(s) per source address (0 for unlimited, bridge networks only)
+	// Example: 10
+	//
+	// API extension: network_forward_connection_limits
+	MaxSourceConnections int64 `json:"max_source_connections,omitempty" yaml:"max_source_connections,omitempty"`
 }
 
 // Normalise normalises the fields in the rule so that they are comparable with ones stored.
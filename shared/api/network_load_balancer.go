@@ -68,6 +68,18 @@ type NetworkLoadBalancerPort struct {
 	// TargetBackend backend names to load balance ListenPorts to
 	// Example: ["c1-http","c2-http"]
 	TargetBackend []string `json:"target_backend" yaml:"target_backend"`
+
+	// Maximum number of new connections per second to the listen port(s) (0 for unlimited, approximated for TCP ports only on OVN networks)
+	// Example: 100
+	//
+	// API extension: network_forward_connection_limits
+	MaxConnectionRate int64 `json:"max_connection_rate,omitempty" yaml:"max_connection_rate,omitempty"`
+
+	// Maximum number of concurrent connections to the listen port(s) per source address (0 for unlimited, bridge networks only)
+	// Example: 10
+	//
+	// API extension: network_forward_connection_limits
+	MaxSourceConnections int64 `json:"max_source_connections,omitempty" yaml:"max_source_connections,omitempty"`
 }
 
 // Normalise normalises the fields in the load balancer port so that they are comparable with ones stored.
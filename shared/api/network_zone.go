@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // NetworkZonesPost represents the fields of a new network zone
 //
 // swagger:model
@@ -50,6 +54,57 @@ type NetworkZone struct {
 	//
 	// API extension: network_zones_all_projects
 	Project string `json:"project" yaml:"project"`
+
+	// DNSSEC keys and DS records (only set when DNSSEC is enabled)
+	// Read only: true
+	//
+	// API extension: network_zones_dnssec
+	DNSSEC *NetworkZoneDNSSEC `json:"dnssec,omitempty" yaml:"dnssec,omitempty"`
+}
+
+// NetworkZoneDNSSEC represents the DNSSEC state of a network zone
+//
+// swagger:model
+//
+// API extension: network_zones_dnssec.
+type NetworkZoneDNSSEC struct {
+	// DS records to add to the parent zone
+	// Example: ["incus.example.net. 3600 IN DS 31589 13 2 5d6e5b0c..."]
+	DS []string `json:"ds" yaml:"ds"`
+
+	// List of DNSSEC keys of the zone
+	Keys []NetworkZoneDNSSECKey `json:"keys" yaml:"keys"`
+}
+
+// NetworkZoneDNSSECKey represents a DNSSEC key of a network zone
+//
+// swagger:model
+//
+// API extension: network_zones_dnssec.
+type NetworkZoneDNSSECKey struct {
+	// Key tag
+	// Example: 31589
+	Tag int `json:"tag" yaml:"tag"`
+
+	// Role of the key (ksk or zsk)
+	// Example: ksk
+	Role string `json:"role" yaml:"role"`
+
+	// State of the key (published, active or retired)
+	// Example: active
+	State string `json:"state" yaml:"state"`
+
+	// Signing algorithm
+	// Example: ECDSAP256SHA256
+	Algorithm string `json:"algorithm" yaml:"algorithm"`
+
+	// When the key was created
+	// Example: 2021-03-23T20:00:00-04:00
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+
+	// When the key last changed state
+	// Example: 2021-03-23T20:00:00-04:00
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
 }
 
 // Writable converts a full NetworkZone struct into a NetworkZonePut struct (filters read-only fields).
@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // ProjectDefaultName is the name of the default project that can never be deleted.
 const ProjectDefaultName = "default"
 
@@ -99,3 +103,92 @@ type ProjectStateResource struct {
 	// Example: 4
 	Usage int64
 }
+
+// ProjectUsageCounters represents the resources consumed over a period
+//
+// swagger:model
+//
+// API extension: projects_usage.
+type ProjectUsageCounters struct {
+	// CPU time in seconds
+	// Example: 3600.5
+	CPUSeconds float64 `json:"cpu_seconds" yaml:"cpu_seconds"`
+
+	// Memory usage over time in byte-seconds
+	// Example: 1932735283200
+	MemoryByteSeconds int64 `json:"memory_byte_seconds" yaml:"memory_byte_seconds"`
+
+	// Bytes read from disks
+	// Example: 104857600
+	DiskReadBytes int64 `json:"disk_read_bytes" yaml:"disk_read_bytes"`
+
+	// Bytes written to disks
+	// Example: 52428800
+	DiskWrittenBytes int64 `json:"disk_written_bytes" yaml:"disk_written_bytes"`
+
+	// Bytes received over the network
+	// Example: 10485760
+	NetworkReceivedBytes int64 `json:"network_received_bytes" yaml:"network_received_bytes"`
+
+	// Bytes sent over the network
+	// Example: 5242880
+	NetworkSentBytes int64 `json:"network_sent_bytes" yaml:"network_sent_bytes"`
+}
+
+// ProjectUsagePeriod represents the resources consumed over a single period
+//
+// swagger:model
+//
+// API extension: projects_usage.
+type ProjectUsagePeriod struct {
+	ProjectUsageCounters `yaml:",inline"`
+
+	// Start of the period
+	// Example: 2024-01-01T00:00:00Z
+	Start time.Time `json:"start" yaml:"start"`
+}
+
+// ProjectUsageInstance represents the resources consumed by an instance of a project
+//
+// swagger:model
+//
+// API extension: projects_usage.
+type ProjectUsageInstance struct {
+	// Name of the instance
+	// Example: c1
+	Name string `json:"name" yaml:"name"`
+
+	// Resources consumed over the whole range
+	Total ProjectUsageCounters `json:"total" yaml:"total"`
+
+	// Resources consumed per period
+	Periods []ProjectUsagePeriod `json:"periods" yaml:"periods"`
+}
+
+// ProjectUsage represents the historical resource consumption of a project
+//
+// swagger:model
+//
+// API extension: projects_usage.
+type ProjectUsage struct {
+	// Start of the range (included)
+	// Example: 2024-01-01T00:00:00Z
+	From time.Time `json:"from" yaml:"from"`
+
+	// End of the range (excluded)
+	// Example: 2024-01-02T00:00:00Z
+	To time.Time `json:"to" yaml:"to"`
+
+	// Length of the periods (hourly or daily)
+	// Example: hourly
+	Granularity string `json:"granularity" yaml:"granularity"`
+
+	// Resources consumed by the project over the whole range
+	Total ProjectUsageCounters `json:"total" yaml:"total"`
+
+	// Resources consumed by the project per period
+	Periods []ProjectUsagePeriod `json:"periods" yaml:"periods"`
+
+	// Resources consumed by each instance of the project
+	Instances []ProjectUsageInstance `json:"instances" yaml:"instances"`
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"time"
+)
+
+// ReplicationActionSync replicates the instance or volume to the target server.
+const ReplicationActionSync = "sync"
+
+// ReplicationActionFailover replicates the instance or volume one last time and then reverses
+// the direction of the replication, promoting the replica.
+const ReplicationActionFailover = "failover"
+
+// Replication represents the replication state of an instance or custom storage volume.
+//
+// swagger:model
+//
+// API extension: replication.
+type Replication struct {
+	// Name of the replication target the instance or volume is replicated to
+	// Example: backup-site
+	Target string `json:"target" yaml:"target"`
+
+	// Storage pool of the replica on the target server
+	// Example: default
+	Pool string `json:"pool" yaml:"pool"`
+
+	// Project of the replica on the target server
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Replication schedule
+	// Example: @hourly
+	Schedule string `json:"schedule" yaml:"schedule"`
+
+	// Whether a replication is currently running
+	// Example: false
+	Running bool `json:"running" yaml:"running"`
+
+	// When the last successful replication started
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	LastSuccess time.Time `json:"last_success" yaml:"last_success"`
+
+	// Replication lag in seconds (time since the last successful replication started, -1 if never replicated)
+	// Example: 3600
+	Lag int64 `json:"lag" yaml:"lag"`
+}
+
+// ReplicationPost represents the fields available for triggering a replication or a planned failover.
+//
+// swagger:model
+//
+// API extension: replication.
+type ReplicationPost struct {
+	// Action to perform (sync or failover)
+	// Example: sync
+	Action string `json:"action" yaml:"action"`
+
+	// Name of the replication target defined on the target server which points back to this server (failover only)
+	// Example: primary-site
+	Target string `json:"target" yaml:"target"`
+}
@@ -31,3 +31,31 @@ type InstancePlacement struct {
 	Reason  string `json:"reason" yaml:"reason"`
 	Project string `json:"project" yaml:"project"`
 }
+
+// InstanceValidationReasonCreate is when a new instance is being created.
+const InstanceValidationReasonCreate = "create"
+
+// InstanceValidationReasonUpdate is when the configuration of an existing instance is being updated.
+const InstanceValidationReasonUpdate = "update"
+
+// InstanceValidationReasonProfile is when a profile used by an existing instance is being updated.
+const InstanceValidationReasonProfile = "profile"
+
+// InstanceValidation represents the instance validation request.
+//
+// API extension: instances_validation_scriptlet.
+type InstanceValidation struct {
+	Reason   string   `json:"reason" yaml:"reason"`
+	Project  string   `json:"project" yaml:"project"`
+	Name     string   `json:"name" yaml:"name"`
+	Type     string   `json:"type" yaml:"type"`
+	Profiles []string `json:"profiles" yaml:"profiles"`
+
+	// Local configuration and devices of the instance.
+	Config  map[string]string            `json:"config" yaml:"config"`
+	Devices map[string]map[string]string `json:"devices" yaml:"devices"`
+
+	// Configuration and devices of the instance with its profiles applied.
+	ExpandedConfig  map[string]string            `json:"expanded_config" yaml:"expanded_config"`
+	ExpandedDevices map[string]map[string]string `json:"expanded_devices" yaml:"expanded_devices"`
+}
@@ -15,6 +15,15 @@ const StoragePoolStatusUnknown = "Unknown"
 // StoragePoolStatusUnvailable storage pool failed to initialize.
 const StoragePoolStatusUnvailable = "Unavailable"
 
+// StoragePoolHealthHealthy storage pool is healthy.
+const StoragePoolHealthHealthy = "healthy"
+
+// StoragePoolHealthDegraded storage pool is working but needs attention.
+const StoragePoolHealthDegraded = "degraded"
+
+// StoragePoolHealthFailed storage pool has failed.
+const StoragePoolHealthFailed = "failed"
+
 // StoragePoolsPost represents the fields of a new storage pool
 //
 // swagger:model
@@ -97,4 +106,45 @@ func (storagePool *StoragePool) Writable() StoragePoolPut {
 // API extension: cluster_member_state.
 type StoragePoolState struct {
 	ResourcesStoragePool `yaml:",inline"`
+
+	// Health of the storage pool (if reported by the storage driver)
+	//
+	// API extension: storage_pool_state
+	Health *StoragePoolStateHealth `json:"health,omitempty" yaml:"health,omitempty"`
+}
+
+// StoragePoolStateHealth represents the health of a storage pool
+//
+// swagger:model
+//
+// API extension: storage_pool_state.
+type StoragePoolStateHealth struct {
+	// Health status (healthy, degraded or failed)
+	// Example: healthy
+	Status string `json:"status" yaml:"status"`
+
+	// Messages explaining the health status
+	// Example: ["One or more devices has experienced an unrecoverable error"]
+	Messages []string `json:"messages" yaml:"messages"`
+
+	// Metadata space usage (for LVM thin pools)
+	Metadata *ResourcesStoragePoolSpace `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Scrub status (for drivers supporting scrubbing)
+	Scrub *StoragePoolStateScrub `json:"scrub,omitempty" yaml:"scrub,omitempty"`
+}
+
+// StoragePoolStateScrub represents the scrub status of a storage pool
+//
+// swagger:model
+//
+// API extension: storage_pool_state.
+type StoragePoolStateScrub struct {
+	// Scrub status (none, running, finished or canceled)
+	// Example: finished
+	Status string `json:"status" yaml:"status"`
+
+	// Number of errors found by the last scrub
+	// Example: 0
+	Errors uint64 `json:"errors" yaml:"errors"`
 }
@@ -40,6 +40,12 @@ type StorageBucketBackupsPost struct {
 	// What compression algorithm to use
 	// Example: gzip
 	CompressionAlgorithm string `json:"compression_algorithm" yaml:"compression_algorithm"`
+
+	// External upload target
+	// The backup will be uploaded and then deleted from local storage.
+	//
+	// API extension: backup_upload_targets
+	Target *BackupTarget `json:"target" yaml:"target"`
 }
 
 // StorageBucketBackupPost represents the fields available for the renaming of a bucket backup
@@ -62,6 +62,13 @@ type StorageVolumeBackupsPost struct {
 	//
 	// API extension: backup_s3_upload
 	Target *BackupTarget `json:"target" yaml:"target"`
+
+	// Name of an earlier backup to make this backup incremental against
+	// Only the changes since the most recent snapshot included in that backup are stored.
+	// Example: backup0
+	//
+	// API extension: backup_incremental
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
 }
 
 // StorageVolumeBackupPost represents the fields available for the renaming of a volume backup
@@ -71,6 +71,38 @@ type StorageVolumeSnapshot struct {
 	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
 }
 
+// StorageVolumeSnapshotDiffAdded the path only exists in the compared snapshot or volume.
+const StorageVolumeSnapshotDiffAdded = "added"
+
+// StorageVolumeSnapshotDiffModified the path exists in both but its content or metadata changed.
+const StorageVolumeSnapshotDiffModified = "modified"
+
+// StorageVolumeSnapshotDiffDeleted the path only exists in the snapshot.
+const StorageVolumeSnapshotDiffDeleted = "deleted"
+
+// StorageVolumeSnapshotDiffRenamed the path was moved to a new path.
+const StorageVolumeSnapshotDiffRenamed = "renamed"
+
+// StorageVolumeSnapshotDiff represents a path which differs between a storage volume snapshot
+// and another snapshot of the volume (or the volume itself).
+//
+// swagger:model
+//
+// API extension: storage_volume_snapshot_diff.
+type StorageVolumeSnapshotDiff struct {
+	// Type of change (added, modified, deleted or renamed)
+	// Example: modified
+	Type string `json:"type" yaml:"type"`
+
+	// Path relative to the root of the volume
+	// Example: /rootfs/etc/hosts
+	Path string `json:"path" yaml:"path"`
+
+	// New path relative to the root of the volume (renamed paths only)
+	// Example: /rootfs/etc/hosts.old
+	NewPath string `json:"new_path,omitempty" yaml:"new_path,omitempty"`
+}
+
 // StorageVolumeSnapshotPut represents the modifiable fields of a storage volume
 //
 // swagger:model